@@ -152,16 +152,12 @@ func (wg *WalletGUI) GetAppWidget() (a *gel.App) {
 			// ),
 			"mining": wg.Page(
 				"mining", gel.Widgets{
-					gel.WidgetSize{
-						Widget: a.Placeholder("mining"),
-					},
+					gel.WidgetSize{Widget: wg.MiningPage.Fn},
 				},
 			),
 			"explorer": wg.Page(
 				"explorer", gel.Widgets{
-					gel.WidgetSize{
-						Widget: a.Placeholder("explorer"),
-					},
+					gel.WidgetSize{Widget: wg.ExplorerPage.Fn},
 				},
 			),
 		},
@@ -173,8 +169,8 @@ func (wg *WalletGUI) GetAppWidget() (a *gel.App) {
 			wg.SideBarButton("send", "send", 1),
 			wg.SideBarButton("receive", "receive", 2),
 			wg.SideBarButton("history", "history", 3),
-			// wg.SideBarButton("explorer", "explorer", 6),
-			// wg.SideBarButton("mining", "mining", 7),
+			wg.SideBarButton("explorer", "explorer", 6),
+			wg.SideBarButton("mining", "mining", 7),
 			wg.SideBarButton("console", "console", 9),
 			wg.SideBarButton("settings", "settings", 5),
 			// wg.SideBarButton("log", "log", 10),
@@ -565,6 +561,7 @@ func (wg *WalletGUI) RunStatusPanel(gtx l.Context) l.Dimensions {
 								I.Ln(
 									"discover enabled:",
 									wg.cx.Config.Discovery.True(),
+									"(takes effect when the node is restarted)",
 								)
 							}()
 						},
@@ -576,7 +573,7 @@ func (wg *WalletGUI) RunStatusPanel(gtx l.Context) l.Dimensions {
 					0.33,
 					wg.Caption(
 						fmt.Sprintf(
-							"%d LAN %d", len(wg.otherNodes),
+							"%d LAN %d", wg.lanPeerCount.Load(),
 							wg.peerCount.Load(),
 						),
 					).
@@ -643,21 +640,7 @@ func (wg *WalletGUI) RunStatusPanel(gtx l.Context) l.Dimensions {
 					).
 					Background(wg.MainApp.StatusBarBackgroundGet()).
 					SetClick(
-						func() {
-							// wg.toggleMiner()
-							go func() {
-								if wg.cx.Config.GenThreads.V() != 0 {
-									if wg.miner.Running() {
-										wg.cx.Config.Generate.F()
-										wg.miner.Stop()
-									} else {
-										wg.miner.Start()
-										wg.cx.Config.Generate.T()
-									}
-									_ = wg.cx.Config.WriteToFile(wg.cx.Config.ConfigFile.V())
-								}
-							}()
-						},
+						wg.toggleMiner,
 					).
 					Fn,
 			).
@@ -99,6 +99,8 @@ func (wg *WalletGUI) cwfRestoreButton() l.Widget {
 					wg.restoring = true
 					wg.createVerifying = false
 				} else {
+					// the words entered to restore replaced the generated ones, so generate a new seed to show
+					wg.ShuffleSeed()
 					wg.createMatch = ""
 					wg.restoring = false
 					wg.createVerifying = false
@@ -144,6 +146,8 @@ func (wg *WalletGUI) cwfSetGenesis() l.Widget {
 						if wg.createSeed, e = hex.DecodeString(seedString); F.Chk(e) {
 							panic(e)
 						}
+						// the genesis wallet predates seeds derived from the words and uses this seed directly
+						wg.createLegacy = true
 						var wk string
 						if wk, e = bip39.NewMnemonic(wg.createSeed); E.Chk(e) {
 							panic(e)
@@ -316,33 +320,43 @@ func (wg *WalletGUI) cfwWordsVerify() (w l.Widget) {
 }
 
 func (wg *WalletGUI) cfwRestore() (w l.Widget) {
-	w = func(l.Context) l.Dimensions {
-		return l.Dimensions{}
-	}
-	if wg.restoring {
-		// restoreState := wg.Button(
-		// 	wg.clickables["createRestore"].SetClick(
-		// 		func() {
-		// 			wg.restoring = false
-		// 		},
-		// 	),
-		// ).Text("back").Fn
-		if wg.createWords == wg.createMatch {
-			w = wg.Flex().AlignMiddle().
-				Rigid(
-					wg.Inset(0.25, wg.H5("valid").Color("Success").Fn).Fn,
-				).Fn
+	if !wg.restoring {
+		return func(l.Context) l.Dimensions {
+			return l.Dimensions{}
 		}
-		return wg.Flex().
-			Rigid(
-				w,
-			).
-			Rigid(
-				wg.inputs["walletRestore"].Fn,
-			).
-			Fn
 	}
-	return
+	state := wg.Inset(
+		0.25,
+		wg.Body1("enter the words of the wallet to restore").Color("PanelText").Fn,
+	).Fn
+	if wg.createMatch != "" {
+		state = wg.Inset(0.25, wg.Body1("invalid mnemonic").Color("Danger").Fn).Fn
+	}
+	if wg.createWords == wg.createMatch {
+		state = wg.Inset(0.25, wg.H5("valid").Color("Success").Fn).Fn
+	}
+	return wg.VFlex().
+		Rigid(
+			wg.Flex().AlignMiddle().
+				Rigid(
+					state,
+				).
+				Rigid(
+					wg.inputs["walletRestore"].Fn,
+				).
+				Fn,
+		).
+		Rigid(
+			wg.passwords["restorePassEditor"].Fn,
+		).
+		Rigid(
+			wg.CheckBox(wg.bools["legacySeed"]).
+				IconColor("Primary").
+				TextColor("DocText").
+				Text("the words were shown when the wallet was created by an earlier version of this app").
+				Fn,
+		).
+		Fn
 }
 
 func (wg *WalletGUI) cwfTestnetSettings() (out l.Widget) {
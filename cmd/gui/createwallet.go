@@ -13,8 +13,8 @@ import (
 	"github.com/cybriq/p9/cmd/wallet"
 	"github.com/cybriq/p9/pkg/chaincfg"
 	"github.com/cybriq/p9/pkg/constant"
-	"github.com/cybriq/p9/pkg/fork"
 	"github.com/cybriq/p9/pkg/gel"
+	"github.com/cybriq/p9/pkg/util/hdkeychain"
 
 	l "github.com/cybriq/p9/pkg/gel/gio/layout"
 )
@@ -207,7 +207,34 @@ func (wg *WalletGUI) createWalletAction() {
 	dbDir := wg.cx.Config.WalletFile.V()
 	loader := wallet.NewLoader(wg.cx.ActiveNet, dbDir, 250)
 	// seed, _ := hex.DecodeString(wg.inputs["walletSeed"].GetText())
-	seed := wg.createSeed
+	var seed []byte
+	var e error
+	bday := time.Now()
+	switch {
+	case wg.restoring && wg.bools["legacySeed"].GetValue():
+		// Wallets created by earlier versions of this app used the entropy behind the words as the seed and had no
+		// mnemonic passphrase.
+		if seed, e = hdkeychain.LegacySeedFromMnemonic(wg.createWords); E.Chk(e) {
+			return
+		}
+	case wg.restoring:
+		if seed, e = hdkeychain.SeedFromMnemonic(
+			wg.createWords, wg.passwords["restorePassEditor"].GetPassword(),
+		); E.Chk(e) {
+			return
+		}
+	case wg.createLegacy:
+		seed = wg.createSeed
+	default:
+		if seed, e = hdkeychain.SeedFromMnemonic(wg.createWords, ""); E.Chk(e) {
+			return
+		}
+	}
+	if wg.restoring {
+		// The restored wallet may have been used at any time, so its birthday is the genesis block so that the first
+		// sync searches the whole chain for its addresses.
+		bday = wg.cx.ActiveNet.GenesisBlock.Header.Timestamp
+	}
 	pass := wg.passwords["passEditor"].GetPassword()
 	wg.cx.Config.WalletPass.Set(pass)
 	D.Ln("password", pass)
@@ -216,7 +243,7 @@ func (wg *WalletGUI) createWalletAction() {
 		[]byte(pass),
 		[]byte(pass),
 		seed,
-		time.Now(),
+		bday,
 		false,
 		wg.cx.Config,
 		qu.T(),
@@ -276,10 +303,8 @@ func (wg *WalletGUI) createWalletTestnetToggle(b bool) {
 	}
 	if b {
 		wg.cx.ActiveNet = &chaincfg.TestNet3Params
-		fork.IsTestnet = true
 	} else {
 		wg.cx.ActiveNet = &chaincfg.MainNetParams
-		fork.IsTestnet = false
 	}
 	I.Ln("activenet:", wg.cx.ActiveNet.Name)
 	D.Ln("setting ports to match network")
@@ -6,8 +6,6 @@ import (
 	"time"
 
 	"github.com/cybriq/p9/pkg/amt"
-	"github.com/cybriq/p9/pkg/chainrpc/p2padvt"
-	"github.com/cybriq/p9/pkg/transport"
 	"github.com/cybriq/p9/pkg/wire"
 
 	"github.com/cybriq/p9/pkg/btcjson"
@@ -22,21 +20,16 @@ func (wg *WalletGUI) WalletAndClientRunning() bool {
 	return running
 }
 
-func (wg *WalletGUI) Advertise() (e error) {
-	if wg.node.Running() && wg.cx.Config.Discovery.True() {
-		// I.Ln("sending out p2p advertisment")
-		if e = wg.multiConn.SendMany(
-			p2padvt.Magic,
-			transport.GetShards(
-				p2padvt.Get(
-					uint64(wg.cx.Config.UUID.V()),
-					(wg.cx.Config.P2PListeners.S())[0],
-				),
-			),
-		); E.Chk(e) {
-		}
+// countLANPeers returns the number of nodes on the local network the node found, when LAN peer discovery is enabled.
+func (wg *WalletGUI) countLANPeers() int32 {
+	if wg.cx.Config.Discovery.False() && wg.cx.Config.LAN.False() {
+		return 0
 	}
-	return
+	peers, e := wg.ChainClient.GetLANPeers()
+	if D.Chk(e) {
+		return 0
+	}
+	return int32(len(peers))
 }
 
 // func (wg *WalletGUI) Tickers() {
@@ -255,6 +248,7 @@ func (wg *WalletGUI) processWalletBlockNotification() bool {
 	wg.txMx.Unlock()
 	wg.RecentTransactions(10, "recent")
 	wg.RecentTransactions(-1, "history")
+	wg.refreshLabels()
 	return true
 }
 
@@ -317,7 +311,7 @@ func (wg *WalletGUI) ChainNotifications() *rpcclient.NotificationHandlers {
 			D.Ln(
 				"(((NOTIFICATION))) OnFilteredBlockConnected hash", hash,
 				"POW hash:",
-				header.BlockHashWithAlgos(height), "height", height,
+				header.BlockHashWithAlgos(wg.cx.ActiveNet.Forks, height), "height", height,
 			)
 			// D.S(txs)
 			if wg.processWalletBlockNotification() {
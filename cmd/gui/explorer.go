@@ -0,0 +1,629 @@
+package gui
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	icons2 "golang.org/x/exp/shiny/materialdesign/icons"
+
+	l "github.com/cybriq/p9/pkg/gel/gio/layout"
+
+	"github.com/cybriq/p9/pkg/btcaddr"
+	"github.com/cybriq/p9/pkg/btcjson"
+	"github.com/cybriq/p9/pkg/chainhash"
+	"github.com/cybriq/p9/pkg/gel"
+	"github.com/cybriq/p9/pkg/rpcclient"
+)
+
+// explorerBlocks is the number of latest blocks listed on the front of the explorer
+const explorerBlocks = 20
+
+// explorerAddressTxs is the maximum number of transactions shown for an address
+const explorerAddressTxs = 100
+
+// algoColors are the colors blocks are marked with by their version, which determines the algorithm they were mined
+// with
+var algoColors = map[int32]string{
+	2:   "light-orange", // sha256d before the hard fork
+	514: "light-blue",   // scrypt before the hard fork
+	5:   "red",
+	6:   "orange",
+	7:   "yellow",
+	8:   "green",
+	9:   "lite-blue",
+	10:  "blue",
+	11:  "purple",
+	12:  "light-red",
+	13:  "light-green",
+}
+
+func algoColor(version int32) string {
+	if c, ok := algoColors[version]; ok {
+		return c
+	}
+	return "gray"
+}
+
+// explorerView is what the explorer is showing, with only one of the fields set. The zero value is the list of latest
+// blocks.
+type explorerView struct {
+	block      *btcjson.GetBlockVerboseResult
+	tx         *btcjson.TxRawResult
+	address    string
+	addressTxs []*btcjson.SearchRawTransactionsResult
+}
+
+// front returns whether the view is the list of latest blocks
+func (v explorerView) front() bool {
+	return v.block == nil && v.tx == nil && v.address == ""
+}
+
+// ExplorerPage is a block explorer that looks up blocks, transactions and addresses through the RPC connection to the
+// node
+type ExplorerPage struct {
+	wg *WalletGUI
+	sync.Mutex
+	blocks     []*btcjson.GetBlockVerboseResult
+	tip        int32
+	refreshing bool
+	loading    bool
+	status     string
+	view       explorerView
+	history    []explorerView
+	clickables []*gel.Clickable
+	next       int
+	row        int
+	back       *gel.Clickable
+	search     *gel.Clickable
+}
+
+func (wg *WalletGUI) GetExplorerPage() (ep *ExplorerPage) {
+	ep = &ExplorerPage{
+		wg:     wg,
+		tip:    -1,
+		back:   wg.Clickable(),
+		search: wg.Clickable(),
+	}
+	return
+}
+
+func (ep *ExplorerPage) Fn(gtx l.Context) l.Dimensions {
+	wg := ep.wg
+	ep.Lock()
+	if height := wg.State.BestBlockHeight(); height != ep.tip && !ep.refreshing {
+		ep.refreshing = true
+		go ep.refresh(height)
+	}
+	view, status, blocks := ep.view, ep.status, ep.blocks
+	ep.Unlock()
+	// the clickables are handed out in the order the widgets are created, which is the same on every frame for the
+	// same view
+	ep.next, ep.row = 0, 0
+	var out []l.Widget
+	switch {
+	case view.block != nil:
+		out = ep.blockDetail(view.block)
+	case view.tx != nil:
+		out = ep.txDetail(view.tx)
+	case view.address != "":
+		out = ep.addressDetail(view.address, view.addressTxs)
+	default:
+		for i := range blocks {
+			out = append(out, ep.blockCard(blocks[i]))
+		}
+	}
+	le := func(gtx l.Context, index int) l.Dimensions {
+		return wg.Inset(0.25, out[index]).Fn(gtx)
+	}
+	return wg.VFlex().AlignStart().
+		Rigid(ep.searchBar(view)).
+		Rigid(
+			gel.If(
+				status != "",
+				wg.Inset(0.25, wg.Body2(status).Color("PanelText").Fn).Fn,
+				gel.EmptySpace(0, 0),
+			),
+		).
+		Flexed(
+			1,
+			wg.lists["explorer"].
+				Vertical().
+				Length(len(out)).
+				ListElement(le).
+				Fn,
+		).
+		Fn(gtx)
+}
+
+// clickable returns the next clickable for the widgets of the current frame
+func (ep *ExplorerPage) clickable() (c *gel.Clickable) {
+	if ep.next >= len(ep.clickables) {
+		ep.clickables = append(ep.clickables, ep.wg.Clickable())
+	}
+	c = ep.clickables[ep.next]
+	ep.next++
+	return
+}
+
+func (ep *ExplorerPage) searchBar(view explorerView) l.Widget {
+	wg := ep.wg
+	return wg.Flex().AlignMiddle().
+		Rigid(
+			gel.If(
+				!view.front(),
+				wg.ButtonLayout(ep.back.SetClick(ep.goBack)).
+					Background("Transparent").
+					Embed(
+						wg.Inset(
+							0.25,
+							wg.Icon().Color("PanelText").Scale(gel.Scales["H5"]).Src(&icons2.NavigationArrowBack).Fn,
+						).Fn,
+					).Fn,
+				gel.EmptySpace(0, 0),
+			),
+		).
+		Flexed(1, wg.Inset(0.25, wg.inputs["explorerSearch"].Fn).Fn).
+		Rigid(
+			wg.ButtonLayout(
+				ep.search.SetClick(
+					func() {
+						ep.Search(wg.inputs["explorerSearch"].GetText())
+					},
+				),
+			).
+				Background("Primary").
+				Embed(
+					wg.Inset(
+						0.5,
+						wg.H6("search").Color("Light").Fn,
+					).Fn,
+				).Fn,
+		).
+		Fn
+}
+
+// field is a line of the detail views, with the background alternating between lines. If open is not nil the line
+// opens what it shows when clicked.
+func (ep *ExplorerPage) field(name, detail string, open func()) l.Widget {
+	wg := ep.wg
+	bg := "DocBg"
+	if ep.row%2 == 1 {
+		bg = "DocBgDim"
+	}
+	ep.row++
+	color := "PanelText"
+	if open != nil {
+		color = "Primary"
+	}
+	line := wg.Fill(
+		bg, l.Center, wg.TextSize.V, 0,
+		wg.Flex().AlignBaseline().
+			Flexed(
+				0.25,
+				wg.Inset(
+					0.25,
+					wg.Body1(name).Color("PanelText").Font("bariol bold").Fn,
+				).Fn,
+			).
+			Flexed(
+				0.75,
+				wg.Inset(
+					0.25,
+					wg.Caption(detail).Font("go regular").Color(color).Fn,
+				).Fn,
+			).Fn,
+	).Fn
+	if open == nil {
+		return line
+	}
+	return wg.ButtonLayout(ep.clickable().SetClick(open)).Background(bg).Embed(line).Fn
+}
+
+// heading is a title between the sections of a detail view
+func (ep *ExplorerPage) heading(title string) l.Widget {
+	ep.row = 0
+	return ep.wg.Inset(0.25, ep.wg.H6(title).Color("PanelText").Fn).Fn
+}
+
+func (ep *ExplorerPage) blockCard(b *btcjson.GetBlockVerboseResult) l.Widget {
+	wg := ep.wg
+	hash := b.Hash
+	return wg.ButtonLayout(
+		ep.clickable().SetClick(
+			func() {
+				ep.OpenBlock(hash)
+			},
+		),
+	).
+		Background("DocBg").
+		Embed(
+			wg.Flex().AlignMiddle().
+				Rigid(
+					wg.Fill(
+						algoColor(b.Version), l.Center, 0, 0,
+						gel.EmptySpace(int(wg.TextSize.V/2), int(wg.TextSize.V*3)),
+					).Fn,
+				).
+				Flexed(
+					1,
+					wg.Inset(
+						0.25,
+						wg.VFlex().
+							Rigid(
+								wg.Flex().AlignBaseline().
+									Rigid(wg.H6(fmt.Sprint(b.Height)).Color("PanelText").Fn).
+									Rigid(wg.Inset(0.25, gel.EmptySpace(0, 0)).Fn).
+									Rigid(wg.Body1(b.PowAlgo).Color(algoColor(b.Version)).Fn).
+									Flexed(1, gel.EmptyMaxWidth()).
+									Rigid(
+										wg.Caption(
+											fmt.Sprintf(
+												"%s  %d transactions", formatTime(b.Time), b.TxNum,
+											),
+										).Color("PanelText").Fn,
+									).
+									Fn,
+							).
+							Rigid(wg.Caption(b.Hash).Font("go regular").Color("PanelText").Fn).
+							Fn,
+					).Fn,
+				).
+				Fn,
+		).Fn
+}
+
+func (ep *ExplorerPage) blockDetail(b *btcjson.GetBlockVerboseResult) (out []l.Widget) {
+	out = []l.Widget{
+		ep.heading(fmt.Sprintf("Block %d", b.Height)),
+		ep.field("Hash", b.Hash, nil),
+		ep.field("Confirmations", fmt.Sprint(b.Confirmations), nil),
+		ep.field("Time", formatTime(b.Time), nil),
+		ep.field("Algorithm", fmt.Sprintf("%s (version %d)", b.PowAlgo, b.Version), nil),
+		ep.field("PoW Hash", b.PowHash, nil),
+		ep.field("Bits", b.Bits, nil),
+		ep.field("Difficulty", fmt.Sprint(b.Difficulty), nil),
+		ep.field("Nonce", fmt.Sprint(b.Nonce), nil),
+		ep.field("Merkle Root", b.MerkleRoot, nil),
+		ep.field("Size", fmt.Sprint(b.Size), nil),
+		ep.field("Weight", fmt.Sprint(b.Weight), nil),
+	}
+	if prev := b.PreviousHash; prev != "" && prev != (chainhash.Hash{}).String() {
+		out = append(out, ep.field("Previous Block", prev, func() { ep.OpenBlock(prev) }))
+	}
+	if next := b.NextHash; next != "" {
+		out = append(out, ep.field("Next Block", next, func() { ep.OpenBlock(next) }))
+	}
+	out = append(out, ep.heading(fmt.Sprintf("%d Transactions", len(b.RawTx))))
+	for i := range b.RawTx {
+		tx := &b.RawTx[i]
+		out = append(
+			out, ep.field(
+				fmt.Sprintf("%0.8f", totalOut(tx.Vout)), tx.Txid, func() { ep.show(explorerView{tx: tx}) },
+			),
+		)
+	}
+	return
+}
+
+func (ep *ExplorerPage) txDetail(tx *btcjson.TxRawResult) (out []l.Widget) {
+	out = []l.Widget{
+		ep.heading("Transaction"),
+		ep.field("TxID", tx.Txid, nil),
+	}
+	if hash := tx.BlockHash; hash != "" {
+		out = append(
+			out,
+			ep.field("Block", hash, func() { ep.OpenBlock(hash) }),
+			ep.field("Confirmations", fmt.Sprint(tx.Confirmations), nil),
+			ep.field("Time", formatTime(tx.Blocktime), nil),
+		)
+	} else {
+		out = append(out, ep.field("Block", "unconfirmed", nil))
+	}
+	out = append(
+		out,
+		ep.field("Size", fmt.Sprint(tx.Size), nil),
+		ep.field("Version", fmt.Sprint(tx.Version), nil),
+		ep.field("Lock Time", fmt.Sprint(tx.LockTime), nil),
+		ep.heading(fmt.Sprintf("%d Inputs", len(tx.Vin))),
+	)
+	for i := range tx.Vin {
+		in := tx.Vin[i]
+		if in.Coinbase != "" {
+			out = append(out, ep.field(fmt.Sprint(i), "coinbase "+in.Coinbase, nil))
+			continue
+		}
+		txid := in.Txid
+		out = append(
+			out, ep.field(fmt.Sprint(i), fmt.Sprintf("%s:%d", in.Txid, in.Vout), func() { ep.OpenTx(txid) }),
+		)
+	}
+	out = append(out, ep.heading(fmt.Sprintf("%d Outputs, %0.8f total", len(tx.Vout), totalOut(tx.Vout))))
+	for i := range tx.Vout {
+		vout := &tx.Vout[i]
+		name := fmt.Sprintf("%d: %0.8f", vout.N, vout.Value)
+		if len(vout.ScriptPubKey.Addresses) != 1 {
+			out = append(out, ep.field(name, vout.ScriptPubKey.Type+" "+vout.ScriptPubKey.Asm, nil))
+			continue
+		}
+		addr := vout.ScriptPubKey.Addresses[0]
+		out = append(out, ep.field(name, addr, func() { ep.OpenAddress(addr) }))
+	}
+	return
+}
+
+func (ep *ExplorerPage) addressDetail(
+	addr string, txs []*btcjson.SearchRawTransactionsResult,
+) (out []l.Widget) {
+	var received, sent float64
+	var rows []l.Widget
+	for _, tx := range txs {
+		var change float64
+		for i := range tx.VOut {
+			for _, a := range tx.VOut[i].ScriptPubKey.Addresses {
+				if a == addr {
+					change += tx.VOut[i].Value
+				}
+			}
+		}
+		received += change
+		for i := range tx.Vin {
+			if prevOut := tx.Vin[i].PrevOut; prevOut != nil {
+				for _, a := range prevOut.Addresses {
+					if a == addr {
+						change -= prevOut.Value
+						sent += prevOut.Value
+					}
+				}
+			}
+		}
+		when := "unconfirmed"
+		if tx.Blocktime != 0 {
+			when = formatTime(tx.Blocktime)
+		}
+		txid := tx.TxID
+		rows = append(
+			rows, ep.field(
+				fmt.Sprintf("%+0.8f", change), fmt.Sprintf("%s %s", when, txid), func() { ep.OpenTx(txid) },
+			),
+		)
+	}
+	out = []l.Widget{
+		ep.heading("Address"),
+		ep.field("Address", addr, nil),
+		ep.field("Received", fmt.Sprintf("%0.8f", received), nil),
+		ep.field("Sent", fmt.Sprintf("%0.8f", sent), nil),
+		ep.field("Balance", fmt.Sprintf("%0.8f", received-sent), nil),
+		ep.heading(fmt.Sprintf("%d Transactions", len(txs))),
+	}
+	return append(out, rows...)
+}
+
+func totalOut(vout []btcjson.Vout) (total float64) {
+	for i := range vout {
+		total += vout[i].Value
+	}
+	return
+}
+
+func formatTime(t int64) string {
+	return time.Unix(t, 0).Format("2006-01-02 15:04:05")
+}
+
+// refresh loads the latest blocks up to the passed height for the front of the explorer
+func (ep *ExplorerPage) refresh(height int32) {
+	var e error
+	var blocks []*btcjson.GetBlockVerboseResult
+	defer func() {
+		ep.Lock()
+		if e == nil {
+			ep.blocks, ep.tip = blocks, height
+		}
+		ep.refreshing = false
+		ep.Unlock()
+		if e == nil {
+			ep.wg.Invalidate()
+		}
+	}()
+	var c *rpcclient.Client
+	if c, e = ep.client(); e != nil {
+		return
+	}
+	for h := int64(height); h >= 0 && h > int64(height)-explorerBlocks; h-- {
+		var hash *chainhash.Hash
+		if hash, e = c.GetBlockHash(h); E.Chk(e) {
+			return
+		}
+		var b *btcjson.GetBlockVerboseResult
+		if b, e = c.GetBlockVerbose(hash); E.Chk(e) {
+			return
+		}
+		blocks = append(blocks, b)
+	}
+}
+
+func (ep *ExplorerPage) client() (c *rpcclient.Client, e error) {
+	if c = ep.wg.ChainClient; c == nil || c.Disconnected() {
+		return nil, errors.New("not connected to the node")
+	}
+	return
+}
+
+// load runs a lookup on the node in the background, showing its error if it fails
+func (ep *ExplorerPage) load(fn func(c *rpcclient.Client) error) {
+	ep.Lock()
+	if ep.loading {
+		ep.Unlock()
+		return
+	}
+	ep.loading, ep.status = true, "loading..."
+	ep.Unlock()
+	ep.wg.Invalidate()
+	go func() {
+		c, e := ep.client()
+		if e == nil {
+			e = fn(c)
+		}
+		ep.Lock()
+		ep.loading, ep.status = false, ""
+		if e != nil {
+			D.Ln(e)
+			ep.status = e.Error()
+		}
+		ep.Unlock()
+		ep.wg.Invalidate()
+	}()
+}
+
+// show opens a view, remembering the current one to go back to
+func (ep *ExplorerPage) show(view explorerView) {
+	ep.Lock()
+	ep.history = append(ep.history, ep.view)
+	ep.view, ep.status = view, ""
+	ep.Unlock()
+	ep.wg.Invalidate()
+}
+
+func (ep *ExplorerPage) goBack() {
+	ep.Lock()
+	ep.view, ep.status = explorerView{}, ""
+	if n := len(ep.history); n > 0 {
+		ep.view, ep.history = ep.history[n-1], ep.history[:n-1]
+	}
+	ep.Unlock()
+	ep.wg.Invalidate()
+}
+
+// OpenBlock shows the block with the passed hash with its transactions
+func (ep *ExplorerPage) OpenBlock(hash string) {
+	ep.load(
+		func(c *rpcclient.Client) (e error) {
+			var h *chainhash.Hash
+			if h, e = chainhash.NewHashFromStr(hash); e != nil {
+				return
+			}
+			var b *btcjson.GetBlockVerboseResult
+			if b, e = c.GetBlockVerboseTx(h); e != nil {
+				return
+			}
+			ep.show(explorerView{block: b})
+			return
+		},
+	)
+}
+
+// OpenHeight shows the block at the passed height of the best chain
+func (ep *ExplorerPage) OpenHeight(height int64) {
+	ep.load(
+		func(c *rpcclient.Client) (e error) {
+			var h *chainhash.Hash
+			if h, e = c.GetBlockHash(height); e != nil {
+				return
+			}
+			var b *btcjson.GetBlockVerboseResult
+			if b, e = c.GetBlockVerboseTx(h); e != nil {
+				return
+			}
+			ep.show(explorerView{block: b})
+			return
+		},
+	)
+}
+
+// OpenTx shows the transaction with the passed id. Transactions that are not in the mempool can only be found if the
+// node has the transaction index enabled.
+func (ep *ExplorerPage) OpenTx(txid string) {
+	ep.load(
+		func(c *rpcclient.Client) (e error) {
+			var h *chainhash.Hash
+			if h, e = chainhash.NewHashFromStr(txid); e != nil {
+				return
+			}
+			var tx *btcjson.TxRawResult
+			if tx, e = c.GetRawTransactionVerbose(h); e != nil {
+				if !ep.wg.cx.Config.TxIndex.True() {
+					e = fmt.Errorf("%v - transactions are found only with txindex enabled on the node", e)
+				}
+				return
+			}
+			ep.show(explorerView{tx: tx})
+			return
+		},
+	)
+}
+
+// OpenAddress shows the transactions paying to and spending from the passed address, which requires the node to have
+// the address index enabled
+func (ep *ExplorerPage) OpenAddress(address string) {
+	if !ep.wg.cx.Config.AddrIndex.True() {
+		ep.Lock()
+		ep.status = "address lookups need addrindex enabled on the node"
+		ep.Unlock()
+		ep.wg.Invalidate()
+		return
+	}
+	ep.load(
+		func(c *rpcclient.Client) (e error) {
+			var addr btcaddr.Address
+			if addr, e = btcaddr.Decode(address, ep.wg.cx.ActiveNet); e != nil {
+				return
+			}
+			var txs []*btcjson.SearchRawTransactionsResult
+			if txs, e = c.SearchRawTransactionsVerbose(
+				addr, 0, explorerAddressTxs, true, true, nil,
+			); e != nil {
+				if jerr, ok := e.(*btcjson.RPCError); !ok || jerr.Code != btcjson.ErrRPCNoTxInfo {
+					return
+				}
+				// an address that has never been used has no transactions
+				e = nil
+			}
+			ep.show(explorerView{address: address, addressTxs: txs})
+			return
+		},
+	)
+}
+
+// Search opens the block, transaction or address the passed text refers to, which can be a block height, a block hash,
+// a transaction id or an address
+func (ep *ExplorerPage) Search(s string) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return
+	}
+	if height, e := strconv.ParseInt(s, 10, 32); e == nil {
+		ep.OpenHeight(height)
+		return
+	}
+	if _, e := chainhash.NewHashFromStr(s); e == nil && len(s) == chainhash.MaxHashStringSize {
+		ep.load(
+			func(c *rpcclient.Client) (e error) {
+				h, _ := chainhash.NewHashFromStr(s)
+				var b *btcjson.GetBlockVerboseResult
+				if b, e = c.GetBlockVerboseTx(h); e == nil {
+					ep.show(explorerView{block: b})
+					return
+				}
+				var tx *btcjson.TxRawResult
+				if tx, e = c.GetRawTransactionVerbose(h); e != nil {
+					return fmt.Errorf("no block or transaction found with hash %s", s)
+				}
+				ep.show(explorerView{tx: tx})
+				return
+			},
+		)
+		return
+	}
+	if _, e := btcaddr.Decode(s, ep.wg.cx.ActiveNet); e == nil {
+		ep.OpenAddress(s)
+		return
+	}
+	ep.Lock()
+	ep.status = "not a block height, block hash, transaction id or address: " + s
+	ep.Unlock()
+	ep.wg.Invalidate()
+}
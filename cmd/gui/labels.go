@@ -0,0 +1,112 @@
+package gui
+
+import (
+	"sync"
+
+	"github.com/cybriq/p9/pkg/btcjson"
+)
+
+// labelBook caches the address labels stored in the wallet database so the address books can show them without
+// querying the wallet every frame. The labels are shared with every other front-end of the wallet.
+type labelBook struct {
+	sync.Mutex
+	addresses map[string]string
+	// migrated is set once the labels of address book entries saved by older versions in the GUI state have been
+	// moved to the wallet
+	migrated bool
+}
+
+func newLabelBook() *labelBook {
+	return &labelBook{addresses: make(map[string]string)}
+}
+
+// addressLabel returns the label the wallet stores for an address.
+func (wg *WalletGUI) addressLabel(addr string) string {
+	wg.labels.Lock()
+	defer wg.labels.Unlock()
+	return wg.labels.addresses[addr]
+}
+
+// setAddressLabel stores the label of an address in the wallet.
+func (wg *WalletGUI) setAddressLabel(addr, label string) {
+	if !wg.WalletAndClientRunning() {
+		return
+	}
+	var e error
+	if e = wg.WalletClient.SetLabel(addr, label); E.Chk(e) {
+		return
+	}
+	wg.labels.Lock()
+	wg.labels.addresses[addr] = label
+	wg.labels.Unlock()
+	wg.Invalidate()
+}
+
+// setTxLabel stores the label of a wallet transaction in the wallet.
+func (wg *WalletGUI) setTxLabel(txid, label string) {
+	if !wg.WalletAndClientRunning() {
+		return
+	}
+	if e := wg.WalletClient.SetLabel(txid, label); E.Chk(e) {
+	}
+}
+
+// refreshLabels reloads the address labels from the wallet, first moving the labels of address book entries that older
+// versions kept only in the GUI state into the wallet.
+func (wg *WalletGUI) refreshLabels() {
+	if !wg.WalletAndClientRunning() {
+		return
+	}
+	var labelled map[string]btcjson.AddressLabelResult
+	var e error
+	if labelled, e = wg.WalletClient.ListAddressLabels(); E.Chk(e) {
+		return
+	}
+	addresses := make(map[string]string, len(labelled))
+	for addr, label := range labelled {
+		addresses[addr] = label.Label
+	}
+	wg.labels.Lock()
+	migrated := wg.labels.migrated
+	wg.labels.migrated = true
+	wg.labels.Unlock()
+	if !migrated && !wg.migrateLabels(addresses) {
+		// the entries that could not be moved keep their labels in the GUI state and are tried again on the next refresh
+		wg.labels.Lock()
+		wg.labels.migrated = false
+		wg.labels.Unlock()
+	}
+	wg.labels.Lock()
+	wg.labels.addresses = addresses
+	wg.labels.Unlock()
+	wg.Invalidate()
+}
+
+// migrateLabels moves the labels of send address book entries saved in the GUI state into the wallet, unless the
+// wallet already has a label for the address. Transactions sent to an entry are labelled the same. It returns whether
+// every entry was moved.
+func (wg *WalletGUI) migrateLabels(addresses map[string]string) (done bool) {
+	done = true
+	for i := range wg.State.sendAddresses {
+		entry := &wg.State.sendAddresses[i]
+		if entry.Label == "" {
+			continue
+		}
+		if _, ok := addresses[entry.Address]; !ok {
+			if e := wg.WalletClient.SetLabel(entry.Address, entry.Label); E.Chk(e) {
+				done = false
+				continue
+			}
+			addresses[entry.Address] = entry.Label
+		}
+		if entry.TxID != "" {
+			if e := wg.WalletClient.SetLabel(entry.TxID, entry.Label); E.Chk(e) {
+				done = false
+				continue
+			}
+		}
+		D.Ln("moved label of", entry.Address, "to the wallet")
+		entry.Label = ""
+	}
+	return
+}
@@ -2,8 +2,6 @@ package gui
 
 import (
 	"crypto/rand"
-	"fmt"
-	"net"
 	"os"
 	"runtime"
 	"strings"
@@ -12,10 +10,12 @@ import (
 
 	"github.com/cybriq/p9/pkg/helpers"
 	"github.com/cybriq/p9/pkg/proc"
+	"github.com/cybriq/p9/pkg/util/hdkeychain"
 	"github.com/tyler-smith/go-bip39"
 
-	"github.com/cybriq/gotiny"
-	"github.com/cybriq/p9/pkg/chainrpc/p2padvt"
+	"github.com/cybriq/p9/pkg/chainrpc/hashrate"
+	"github.com/cybriq/p9/pkg/chainrpc/job"
+	"github.com/cybriq/p9/pkg/constant"
 	"github.com/cybriq/p9/pkg/opts/meta"
 	"github.com/cybriq/p9/pkg/opts/text"
 	"github.com/cybriq/p9/pkg/transport"
@@ -47,7 +47,7 @@ func Main(cx *state.State) (e error) {
 		quit:       cx.KillAll,
 		Size:       size,
 		noWallet:   &noWallet,
-		otherNodes: make(map[uint64]*nodeSpec),
+		labels:     newLabelBook(),
 		certs:      cx.Config.ReadCAFile(),
 	}
 	return wg.Run()
@@ -116,27 +116,26 @@ type WalletGUI struct {
 	preRendering  bool
 	// ReceiveAddressbook l.Widget
 	// SendAddressbook    l.Widget
-	ReceivePage *ReceivePage
-	SendPage    *SendPage
+	ReceivePage  *ReceivePage
+	SendPage     *SendPage
+	ExplorerPage *ExplorerPage
+	MiningPage   *MiningPage
+	labels       *labelBook
 	// toasts                    *toast.Toasts
 	// dialog                    *dialog.Dialog
 	createSeed                          []byte
 	createWords, showWords, createMatch string
 	createVerifying                     bool
+	createLegacy                        bool
 	restoring                           bool
 	lastUpdated                         uberatomic.Int64
 	multiConn                           *transport.Channel
-	otherNodes                          map[uint64]*nodeSpec
 	uuid                                uint64
 	peerCount                           *uberatomic.Int32
+	lanPeerCount                        *uberatomic.Int32
 	certs                               []byte
 }
 
-type nodeSpec struct {
-	time.Time
-	addr string
-}
-
 func (wg *WalletGUI) Run() (e error) {
 	wg.openTxID = uberatomic.NewString("")
 	var mc *transport.Channel
@@ -148,7 +147,7 @@ func (wg *WalletGUI) Run() (e error) {
 		wg,
 		wg.cx.Config.MulticastPass.Bytes(),
 		transport.DefaultPort,
-		16384,
+		constant.MaxDatagramSize,
 		handlersMulticast,
 		quit,
 	); E.Chk(e) {
@@ -156,6 +155,7 @@ func (wg *WalletGUI) Run() (e error) {
 	}
 	wg.multiConn = mc
 	wg.peerCount = uberatomic.NewInt32(0)
+	wg.lanPeerCount = uberatomic.NewInt32(0)
 	wg.prevOpenTxID = uberatomic.NewString("")
 	wg.stateLoaded = uberatomic.NewBool(false)
 	wg.currentReceiveRegenerate = uberatomic.NewBool(true)
@@ -205,6 +205,8 @@ func (wg *WalletGUI) Run() (e error) {
 	}
 	wg.ReceivePage = wg.GetReceivePage()
 	wg.SendPage = wg.GetSendPage()
+	wg.ExplorerPage = wg.GetExplorerPage()
+	wg.MiningPage = wg.GetMiningPage()
 	wg.MainApp = wg.GetAppWidget()
 	wg.State = GetNewState(wg.cx.ActiveNet,
 		wg.MainApp.ActivePageGetAtomic())
@@ -245,8 +247,6 @@ func (wg *WalletGUI) Run() (e error) {
 			select {
 			case <-ticker.C:
 				go func() {
-					if e = wg.Advertise(); E.Chk(e) {
-					}
 					if wg.node.Running() {
 						if wg.ChainClient != nil {
 							if !wg.ChainClient.Disconnected() {
@@ -255,6 +255,7 @@ func (wg *WalletGUI) Run() (e error) {
 									return
 								}
 								wg.peerCount.Store(int32(len(pi)))
+								wg.lanPeerCount.Store(wg.countLANPeers())
 								wg.Invalidate()
 							}
 						}
@@ -328,6 +329,7 @@ func (wg *WalletGUI) GetButtons() {
 func (wg *WalletGUI) ShuffleSeed() {
 	wg.createSeed = make([]byte, 32)
 	_, _ = rand.Read(wg.createSeed)
+	wg.createLegacy = false
 	var e error
 	var wk string
 	if wk, e = bip39.NewMnemonic(wg.createSeed); E.Chk(e) {
@@ -386,6 +388,16 @@ func (wg *WalletGUI) GetInputs() Inputs {
 			func(string) {},
 		),
 
+		"explorerSearch": wg.Input(
+			"",
+			"block height, block hash, transaction id or address",
+			"DocText",
+			"PanelBg",
+			"DocBg",
+			func(string) {},
+			func(string) {},
+		),
+
 		"console": wg.Input(
 			"",
 			"enter rpc command",
@@ -409,12 +421,15 @@ func (wg *WalletGUI) GetInputs() Inputs {
 			"DocText", "DocBg",
 			"PanelBg", func(string) {},
 			func(seedWords string) {
-				var e error
-				wg.createMatch = seedWords
-				if wg.createSeed, e = bip39.EntropyFromMnemonic(seedWords); E.Chk(e) {
+				wg.createMatch = hdkeychain.NormalizeMnemonic(seedWords)
+				// The seed is only derived when the wallet is created, as it also depends on the optional mnemonic
+				// passphrase, so here the words and their checksum are only validated.
+				if e := hdkeychain.ValidateMnemonic(wg.createMatch); e != nil {
+					D.Ln("invalid mnemonic:", e)
+					wg.Invalidate()
 					return
 				}
-				wg.createWords = seedWords
+				wg.createWords = wg.createMatch
 				wg.Invalidate()
 			},
 		),
@@ -459,6 +474,14 @@ func (wg *WalletGUI) GetPasswords() (passwords Passwords) {
 			"PanelBg",
 			func(pass string) {},
 		),
+		"restorePassEditor": wg.Password(
+			"mnemonic passphrase (optional)",
+			text.New(meta.Data{}, ""),
+			"DocText",
+			"DocBg",
+			"PanelBg",
+			func(pass string) {},
+		),
 		"publicPassEditor": wg.Password(
 			"public password (optional)",
 			wg.cx.Config.WalletPass,
@@ -546,6 +569,8 @@ func (wg *WalletGUI) GetLists() (o ListMap) {
 		"received":         wg.List(),
 		"history":          wg.List(),
 		"txdetail":         wg.List(),
+		"explorer":         wg.List(),
+		"mining":           wg.List(),
 	}
 }
 
@@ -590,6 +615,7 @@ func (wg *WalletGUI) GetBools() BoolMap {
 		"lan":          wg.Bool(false),
 		"solo":         wg.Bool(false),
 		"ihaveread":    wg.Bool(false),
+		"legacySeed":   wg.Bool(false),
 		"showGenerate": wg.Bool(true),
 		"showSent":     wg.Bool(true),
 		"showReceived": wg.Bool(true),
@@ -645,97 +671,6 @@ func (wg *WalletGUI) gracefulShutdown() {
 
 var handlersMulticast = transport.Handlers{
 	// string(sol.Magic):      processSolMsg,
-	string(p2padvt.Magic): processAdvtMsg,
-	// string(hashrate.Magic): processHashrateMsg,
-}
-
-func processAdvtMsg(
-	ctx interface{}, src net.Addr, dst string, b []byte,
-) (e error) {
-	wg := ctx.(*WalletGUI)
-	if wg.cx.Config.Discovery.False() {
-		return
-	}
-	if wg.ChainClient == nil {
-		T.Ln("no chain client to process advertisment")
-		return
-	}
-	var j p2padvt.Advertisment
-	gotiny.Unmarshal(b, &j)
-	// I.S(j)
-	var peerUUID uint64
-	peerUUID = j.UUID
-	// I.Ln("peerUUID of advertisment", peerUUID, wg.otherNodes)
-	if int(peerUUID) == wg.cx.Config.UUID.V() {
-		D.Ln("ignoring own advertisment message")
-		return
-	}
-	if _, ok := wg.otherNodes[peerUUID]; !ok {
-		var pi []btcjson.GetPeerInfoResult
-		if pi, e = wg.ChainClient.GetPeerInfo(); E.Chk(e) {
-		}
-		for i := range pi {
-			for k := range j.IPs {
-				jpa := net.JoinHostPort(k, fmt.Sprint(j.P2P))
-				if jpa == pi[i].Addr {
-					I.Ln("not connecting to node already connected outbound")
-					return
-				}
-				if jpa == pi[i].AddrLocal {
-					I.Ln("not connecting to node already connected inbound")
-					return
-				}
-			}
-		}
-		// if we haven't already added it to the permanent peer list, we can add it now
-		I.Ln("connecting to lan peer with same PSK", j.IPs, peerUUID)
-		wg.otherNodes[peerUUID] = &nodeSpec{}
-		wg.otherNodes[peerUUID].Time = time.Now()
-		for i := range j.IPs {
-			addy := net.JoinHostPort(i, fmt.Sprint(j.P2P))
-			for j := range pi {
-				if addy == pi[j].Addr || addy == pi[j].AddrLocal {
-					// not connecting to peer we already have connected to
-					return
-				}
-			}
-		}
-		// try all IPs
-		for addr := range j.IPs {
-			peerIP := net.JoinHostPort(addr, fmt.Sprint(j.P2P))
-			if e = wg.ChainClient.AddNode(peerIP, "add"); E.Chk(e) {
-				continue
-			}
-			D.Ln("connected to peer via address", peerIP)
-			wg.otherNodes[peerUUID].addr = peerIP
-			break
-		}
-		I.Ln(peerUUID, "added", "otherNodes", wg.otherNodes)
-	} else {
-		// update last seen time for peerUUID for garbage collection of stale disconnected
-		// nodes
-		D.Ln("other node seen again", peerUUID,
-			wg.otherNodes[peerUUID].addr)
-		wg.otherNodes[peerUUID].Time = time.Now()
-	}
-	// I.S(wg.otherNodes)
-	// If we lose connection for more than 9 seconds we delete and if the node
-	// reappears it can be reconnected
-	for i := range wg.otherNodes {
-		D.Ln(i, wg.otherNodes[i])
-		tn := time.Now()
-		if tn.Sub(wg.otherNodes[i].Time) > time.Second*6 {
-			// also remove from connection manager
-			if e = wg.ChainClient.AddNode(
-				wg.otherNodes[i].addr,
-				"remove",
-			); E.Chk(e) {
-			}
-			D.Ln("deleting", tn, wg.otherNodes[i], i)
-			delete(wg.otherNodes, i)
-		}
-	}
-	// on := int32(len(wg.otherNodes))
-	// wg.otherNodeCount.Store(on)
-	return
+	string(hashrate.Magic): processHashrateMsg,
+	string(job.Magic):      processJobMsg,
 }
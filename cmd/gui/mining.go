@@ -0,0 +1,441 @@
+package gui
+
+import (
+	"fmt"
+	"net"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/cybriq/gotiny"
+
+	l "github.com/cybriq/p9/pkg/gel/gio/layout"
+
+	"github.com/cybriq/p9/pkg/btcjson"
+	"github.com/cybriq/p9/pkg/chainhash"
+	"github.com/cybriq/p9/pkg/chainrpc/hashrate"
+	"github.com/cybriq/p9/pkg/chainrpc/templates"
+	"github.com/cybriq/p9/pkg/gel"
+)
+
+const (
+	// hashrateWindow is the period hashrate reports are averaged over, after which workers that stopped reporting
+	// are no longer shown
+	hashrateWindow = time.Second * 10
+	// controllerTimeout is how long after its last job a controller is still shown
+	controllerTimeout = time.Second * 10
+	// miningRefresh is the interval the difficulties and found blocks are updated from the node
+	miningRefresh = time.Second * 5
+	// foundBlocksShown is the number of recently found blocks listed
+	foundBlocksShown = 20
+)
+
+// hashReport is a hashrate report received from a worker
+type hashReport struct {
+	time  time.Time
+	count int
+	nonce int32
+}
+
+// minerWorker is a kopach instance on the LAN that sends hashrate reports
+type minerWorker struct {
+	id      string
+	ip      net.IP
+	reports []hashReport
+}
+
+// hashrate returns the average hashes per second over the hashrate window
+func (w *minerWorker) hashrate() float64 {
+	var count int
+	for i := range w.reports {
+		count += w.reports[i].count
+	}
+	return float64(count) / hashrateWindow.Seconds()
+}
+
+// lanController is a controller on the LAN that was seen sending out work
+type lanController struct {
+	uuid     uint64
+	addr     string
+	height   int32
+	lastSeen time.Time
+}
+
+// foundBlock is a block that paid a coinbase to the wallet
+type foundBlock struct {
+	hash          string
+	height        int64
+	time          int64
+	amount        float64
+	confirmations int64
+	orphan        bool
+}
+
+// MiningPage shows the local miner controls and the mining activity on the LAN
+type MiningPage struct {
+	wg *WalletGUI
+	sync.Mutex
+	workers     map[string]*minerWorker
+	controllers map[uint64]*lanController
+	algos       []btcjson.AlgoStatsResult
+	found       map[string]*foundBlock
+	updated     time.Time
+	refreshing  bool
+	clickables  []*gel.Clickable
+	toggle      *gel.Clickable
+}
+
+func (wg *WalletGUI) GetMiningPage() (mp *MiningPage) {
+	mp = &MiningPage{
+		wg:          wg,
+		workers:     make(map[string]*minerWorker),
+		controllers: make(map[uint64]*lanController),
+		found:       make(map[string]*foundBlock),
+		toggle:      wg.Clickable(),
+	}
+	return
+}
+
+// toggleMiner starts the local miner if it is stopped and stops it if it is running
+func (wg *WalletGUI) toggleMiner() {
+	go func() {
+		if wg.cx.Config.GenThreads.V() != 0 {
+			if wg.miner.Running() {
+				wg.cx.Config.Generate.F()
+				wg.miner.Stop()
+			} else {
+				wg.miner.Start()
+				wg.cx.Config.Generate.T()
+			}
+			_ = wg.cx.Config.WriteToFile(wg.cx.Config.ConfigFile.V())
+		}
+	}()
+}
+
+func (mp *MiningPage) Fn(gtx l.Context) l.Dimensions {
+	wg := mp.wg
+	mp.Lock()
+	if time.Since(mp.updated) > miningRefresh && !mp.refreshing {
+		mp.refreshing = true
+		go mp.refresh()
+	}
+	mp.expire()
+	workers := make([]minerWorker, 0, len(mp.workers))
+	for _, w := range mp.workers {
+		workers = append(workers, *w)
+	}
+	controllers := make([]lanController, 0, len(mp.controllers))
+	for _, c := range mp.controllers {
+		controllers = append(controllers, *c)
+	}
+	found := make([]foundBlock, 0, len(mp.found))
+	for _, b := range mp.found {
+		found = append(found, *b)
+	}
+	algos := mp.algos
+	mp.Unlock()
+	sort.Slice(workers, func(i, j int) bool { return workers[i].id < workers[j].id })
+	sort.Slice(controllers, func(i, j int) bool { return controllers[i].uuid < controllers[j].uuid })
+	sort.Slice(found, func(i, j int) bool { return found[i].height > found[j].height })
+	if len(found) > foundBlocksShown {
+		found = found[:foundBlocksShown]
+	}
+	var total float64
+	for i := range workers {
+		total += workers[i].hashrate()
+	}
+	out := []l.Widget{
+		mp.controls(),
+		mp.heading(fmt.Sprintf("Hashrate %s", formatHashrate(total))),
+	}
+	row := 0
+	entry := func(name, detail string) l.Widget {
+		bg := "DocBg"
+		if row%2 == 1 {
+			bg = "DocBgDim"
+		}
+		row++
+		return wg.txDetailEntry(name, detail, bg, true)
+	}
+	if len(workers) == 0 {
+		out = append(out, entry("Workers", "no hashrate reports received"))
+	}
+	for i := range workers {
+		out = append(
+			out, entry(workers[i].id, fmt.Sprintf("%s  %s", workers[i].ip, formatHashrate(workers[i].hashrate()))),
+		)
+	}
+	out = append(out, mp.heading("Next Block Difficulty"))
+	row = 0
+	for i := range algos {
+		a := algos[i]
+		out = append(
+			out, wg.Flex().AlignMiddle().
+				Rigid(
+					wg.Fill(
+						algoColor(a.Version), l.Center, 0, 0,
+						gel.EmptySpace(int(wg.TextSize.V/2), int(wg.TextSize.V*1.5)),
+					).Fn,
+				).
+				Flexed(
+					1, entry(
+						a.Name, fmt.Sprintf(
+							"%0.8f  %0.1f%% of recent blocks", a.Difficulty, a.Share*100,
+						),
+					),
+				).Fn,
+		)
+	}
+	out = append(out, mp.heading("Found Blocks"))
+	row = 0
+	if len(found) == 0 {
+		out = append(out, entry("Blocks", "no blocks found by this wallet"))
+	}
+	for i := range found {
+		b := found[i]
+		status := fmt.Sprintf("%d confirmations", b.confirmations)
+		if b.orphan {
+			status = "orphaned"
+		}
+		bg := "DocBg"
+		if row%2 == 1 {
+			bg = "DocBgDim"
+		}
+		row++
+		if i >= len(mp.clickables) {
+			mp.clickables = append(mp.clickables, wg.Clickable())
+		}
+		hash := b.hash
+		out = append(
+			out, wg.ButtonLayout(
+				mp.clickables[i].SetClick(
+					func() {
+						wg.ExplorerPage.OpenBlock(hash)
+						wg.MainApp.ActivePage("explorer")
+					},
+				),
+			).
+				Background(bg).
+				Embed(
+					wg.txDetailEntry(
+						fmt.Sprint(b.height),
+						fmt.Sprintf("%s  %0.8f  %s", formatTime(b.time), b.amount, status),
+						bg, true,
+					),
+				).Fn,
+		)
+	}
+	out = append(out, mp.heading("LAN Controllers"))
+	row = 0
+	if len(controllers) == 0 {
+		out = append(out, entry("Controllers", "no controllers found on the LAN"))
+	}
+	for i := range controllers {
+		c := controllers[i]
+		name := fmt.Sprint(c.uuid)
+		if c.uuid == uint64(wg.cx.Config.UUID.V()) {
+			name += " (this node)"
+		}
+		out = append(
+			out, entry(
+				name, fmt.Sprintf(
+					"%s  working on block %d  seen %s ago", c.addr, c.height,
+					time.Since(c.lastSeen).Round(time.Second),
+				),
+			),
+		)
+	}
+	le := func(gtx l.Context, index int) l.Dimensions {
+		return wg.Inset(0.25, out[index]).Fn(gtx)
+	}
+	return wg.lists["mining"].
+		Vertical().
+		Length(len(out)).
+		ListElement(le).
+		Fn(gtx)
+}
+
+func (mp *MiningPage) heading(title string) l.Widget {
+	return mp.wg.Inset(0.25, mp.wg.H6(title).Color("PanelText").Fn).Fn
+}
+
+func (mp *MiningPage) controls() l.Widget {
+	wg := mp.wg
+	label, bg := "start mining", "Primary"
+	status := "miner stopped"
+	if wg.miner.Running() {
+		label, bg = "stop mining", "Danger"
+		status = fmt.Sprintf("mining with %d threads", wg.cx.Config.GenThreads.V())
+	}
+	if wg.cx.Config.GenThreads.V() == 0 {
+		status = "set the number of threads to mine with"
+	}
+	if wg.cx.Config.Controller.False() {
+		status += ", the node controller is off"
+	}
+	return wg.Flex().AlignMiddle().
+		Rigid(
+			wg.ButtonLayout(mp.toggle.SetClick(wg.toggleMiner)).
+				Background(bg).
+				Embed(
+					wg.Inset(
+						0.5,
+						wg.H6(label).Color("Light").Fn,
+					).Fn,
+				).Fn,
+		).
+		Rigid(wg.Inset(0.5, wg.Body1("threads").Color("PanelText").Fn).Fn).
+		Rigid(
+			func(gtx l.Context) l.Dimensions {
+				return wg.incdecs["generatethreads"].Fn(gtx)
+			},
+		).
+		Rigid(wg.Inset(0.5, wg.Body2(status).Color("PanelText").Fn).Fn).
+		Fn
+}
+
+// expire removes the hashrate reports and controllers that are too old to be shown
+func (mp *MiningPage) expire() {
+	now := time.Now()
+	for id, w := range mp.workers {
+		var i int
+		for i < len(w.reports) && now.Sub(w.reports[i].time) > hashrateWindow {
+			i++
+		}
+		if w.reports = w.reports[i:]; len(w.reports) == 0 {
+			delete(mp.workers, id)
+		}
+	}
+	for uuid, c := range mp.controllers {
+		if now.Sub(c.lastSeen) > controllerTimeout {
+			delete(mp.controllers, uuid)
+		}
+	}
+}
+
+// refresh updates the difficulties of the next block and the status of the blocks found by the wallet
+func (mp *MiningPage) refresh() {
+	wg := mp.wg
+	defer func() {
+		mp.Lock()
+		mp.refreshing = false
+		mp.updated = time.Now()
+		mp.Unlock()
+		wg.Invalidate()
+	}()
+	if wg.ChainClient == nil || wg.ChainClient.Disconnected() {
+		return
+	}
+	var e error
+	var stats *btcjson.GetAlgoStatsResult
+	if stats, e = wg.ChainClient.GetAlgoStats(100); !E.Chk(e) {
+		mp.Lock()
+		mp.algos = stats.Algos
+		mp.Unlock()
+	}
+	// the wallet forgets coinbases of blocks that were orphaned, so the blocks are remembered here to show them
+	height := int64(wg.State.BestBlockHeight())
+	mp.Lock()
+	for _, tx := range wg.State.allTxs.Load() {
+		if tx.Category != "generate" && tx.Category != "immature" || tx.BlockHash == "" {
+			continue
+		}
+		if _, ok := mp.found[tx.BlockHash]; !ok {
+			mp.found[tx.BlockHash] = &foundBlock{
+				hash:   tx.BlockHash,
+				height: height - tx.Confirmations + 1,
+				time:   tx.BlockTime,
+				amount: tx.Amount,
+			}
+		}
+	}
+	hashes := make([]string, 0, len(mp.found))
+	for hash := range mp.found {
+		hashes = append(hashes, hash)
+	}
+	mp.Unlock()
+	for _, hash := range hashes {
+		var h *chainhash.Hash
+		if h, e = chainhash.NewHashFromStr(hash); E.Chk(e) {
+			continue
+		}
+		var header *btcjson.GetBlockHeaderVerboseResult
+		header, e = wg.ChainClient.GetBlockHeaderVerbose(h)
+		if _, ok := e.(*btcjson.RPCError); e != nil && !ok {
+			// the connection failed so the status of the block is unknown
+			E.Ln(e)
+			continue
+		}
+		mp.Lock()
+		if b := mp.found[hash]; b != nil {
+			// blocks that are not in the main chain are not found by height
+			if b.orphan = e != nil; !b.orphan {
+				b.height, b.confirmations = int64(header.Height), header.Confirmations
+			}
+		}
+		mp.Unlock()
+	}
+}
+
+func formatHashrate(h float64) string {
+	units := []string{"H/s", "kH/s", "MH/s", "GH/s", "TH/s"}
+	var i int
+	for h >= 1000 && i < len(units)-1 {
+		h /= 1000
+		i++
+	}
+	return fmt.Sprintf("%0.2f %s", h, units[i])
+}
+
+func processHashrateMsg(
+	ctx interface{}, src net.Addr, dst string, b []byte,
+) (e error) {
+	wg := ctx.(*WalletGUI)
+	if wg.MiningPage == nil {
+		return
+	}
+	var hr hashrate.Hashrate
+	gotiny.Unmarshal(b, &hr)
+	mp := wg.MiningPage
+	mp.Lock()
+	defer mp.Unlock()
+	w, ok := mp.workers[hr.ID]
+	if !ok {
+		w = &minerWorker{id: hr.ID}
+		mp.workers[hr.ID] = w
+	}
+	// a report can arrive more than once
+	for i := range w.reports {
+		if w.reports[i].nonce == hr.Nonce {
+			return
+		}
+	}
+	w.ip = hr.IP
+	w.reports = append(w.reports, hashReport{time: time.Now(), count: hr.Count, nonce: hr.Nonce})
+	return
+}
+
+func processJobMsg(
+	ctx interface{}, src net.Addr, dst string, b []byte,
+) (e error) {
+	wg := ctx.(*WalletGUI)
+	if wg.MiningPage == nil {
+		return
+	}
+	var j templates.Message
+	gotiny.Unmarshal(b, &j)
+	mp := wg.MiningPage
+	mp.Lock()
+	defer mp.Unlock()
+	c, ok := mp.controllers[j.UUID]
+	if !ok {
+		c = &lanController{uuid: j.UUID}
+		mp.controllers[j.UUID] = c
+	}
+	c.addr = src.String()
+	if host, _, e := net.SplitHostPort(c.addr); e == nil {
+		c.addr = host
+	}
+	c.height, c.lastSeen = j.Height, time.Now()
+	return
+}
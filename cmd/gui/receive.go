@@ -318,6 +318,7 @@ func (rp *ReceivePage) RegenerateButton() l.Widget {
 							// not be intentional or used addresses so we don't generate a new entry for this case
 							wg.State.receiveAddresses[len(wg.State.receiveAddresses)-1].Amount = am
 							wg.State.receiveAddresses[len(wg.State.receiveAddresses)-1].Message = msg
+							wg.setAddressLabel(wg.State.receiveAddresses[len(wg.State.receiveAddresses)-1].Address, msg)
 						} else {
 							// go func() {
 							wg.GetNewReceivingAddress()
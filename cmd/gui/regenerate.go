@@ -41,6 +41,9 @@ func (wg *WalletGUI) GetNewReceivingAddress() {
 			msg = msg[:64]
 		}
 		ae.Message = msg
+		if msg != "" {
+			wg.setAddressLabel(ae.Address, msg)
+		}
 		ae.Created = time.Now()
 		if wg.State.IsReceivingAddress() {
 			wg.State.receiveAddresses = append(wg.State.receiveAddresses, ae)
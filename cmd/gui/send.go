@@ -308,10 +308,14 @@ func (sp *SendPage) saveForm(txid string) {
 	if ad, e = btcaddr.Decode(addr, wg.cx.ActiveNet); E.Chk(e) {
 		return
 	}
+	// the label is stored in the wallet so it is available to every front-end of the wallet
+	wg.setAddressLabel(ad.EncodeAddress(), msg)
+	if txid != "" {
+		wg.setTxLabel(txid, msg)
+	}
 	wg.State.sendAddresses = append(
 		wg.State.sendAddresses, AddressEntry{
 			Address: ad.EncodeAddress(),
-			Label:   msg,
 			Amount:  ua,
 			Created: time.Now(),
 			TxID:    txid,
@@ -442,6 +446,11 @@ func (sp *SendPage) GetAddressbookHistoryCards(bg string) (widgets []l.Widget) {
 	for x := range wg.State.sendAddresses {
 		j := x
 		i := len(wg.State.sendAddresses) - 1 - x
+		label := wg.addressLabel(wg.State.sendAddresses[i].Address)
+		if label == "" {
+			// entries of older versions keep their label in the state until it is moved to the wallet
+			label = wg.State.sendAddresses[i].Label
+		}
 		widgets = append(
 			widgets, func(gtx l.Context) l.Dimensions {
 				return wg.ButtonLayout(
@@ -451,7 +460,7 @@ func (sp *SendPage) GetAddressbookHistoryCards(bg string) (widgets []l.Widget) {
 								"parallelcoin:%s?amount=%8.8f&message=%s",
 								wg.State.sendAddresses[i].Address,
 								wg.State.sendAddresses[i].Amount.ToDUO(),
-								wg.State.sendAddresses[i].Label,
+								label,
 							)
 							D.Ln("clicked send address list item", j)
 							if e := clipboard.WriteAll(sendText); E.Chk(e) {
@@ -480,7 +489,7 @@ func (sp *SendPage) GetAddressbookHistoryCards(bg string) (widgets []l.Widget) {
 								Rigid(
 									wg.Inset(
 										0.25,
-										wg.Body1(wg.State.sendAddresses[i].Label).MaxLines(1).Fn,
+										wg.Body1(label).MaxLines(1).Fn,
 									).Fn,
 								).
 								Rigid(
@@ -72,8 +72,6 @@ func (wg *WalletGUI) Watcher() qu.C {
 				D.Ln("top of watcher loop")
 				select {
 				case <-watchTick.C:
-					if e = wg.Advertise(); E.Chk(e) {
-					}
 					if !wg.node.Running() {
 						D.Ln("watcher starting node")
 						wg.node.Start()
@@ -81,3 +81,41 @@ func (c *Client) SendPass(pass []byte) (e error) {
 	}
 	return
 }
+
+// SendAddress sends the payout address the workers identify their shares with
+// when mining for a pool
+func (c *Client) SendAddress(address string) (e error) {
+	D.Ln("sending payout address")
+	var reply bool
+	e = c.Call("Worker.SendAddress", address, &reply)
+	if e != nil {
+		return
+	}
+	if reply != true {
+		e = errors.New("send address command not acknowledged")
+	}
+	return
+}
+
+// SendRelay sends the loopback address of the relay the workers dispatch their
+// solutions to when kopach is connected to its controller by stream. It must be
+// sent before the password.
+func (c *Client) SendRelay(address string) (e error) {
+	D.Ln("sending relay address")
+	var reply bool
+	e = c.Call("Worker.SendRelay", address, &reply)
+	if e != nil {
+		return
+	}
+	if reply != true {
+		e = errors.New("send relay command not acknowledged")
+	}
+	return
+}
+
+// HashCount returns the number of hashes the worker has computed since it
+// started
+func (c *Client) HashCount() (count uint64, e error) {
+	e = c.Call("Worker.HashCount", 1, &count)
+	return
+}
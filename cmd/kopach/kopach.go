@@ -7,10 +7,12 @@ import (
 	"net"
 	"os"
 	"runtime"
+	"sync"
 	"time"
 
 	"github.com/cybriq/gotiny"
 	"github.com/cybriq/p9/pkg/log"
+	"github.com/cybriq/p9/pkg/metrics"
 	"github.com/cybriq/p9/pkg/proc"
 
 	"github.com/cybriq/p9/pkg/chainrpc/p2padvt"
@@ -28,6 +30,7 @@ import (
 	"github.com/cybriq/p9/pkg/chainrpc/hashrate"
 	"github.com/cybriq/p9/pkg/chainrpc/job"
 	"github.com/cybriq/p9/pkg/chainrpc/pause"
+	"github.com/cybriq/p9/pkg/chainrpc/sol"
 	rav "github.com/cybriq/p9/pkg/ring"
 	"github.com/cybriq/p9/pkg/transport"
 )
@@ -59,9 +62,12 @@ type Worker struct {
 	height              int32
 	active              atomic.Bool
 	conn                *transport.Channel
+	stream              *transport.StreamClient
+	relay               *transport.Channel
 	ctx                 context.Context
 	quit                qu.C
 	sendAddresses       []*net.UDPAddr
+	clientsMx           sync.Mutex
 	clients             []*client.Client
 	workers             []*proc.Worker
 	FirstSender         atomic.Uint64
@@ -77,12 +83,14 @@ type Worker struct {
 	Update        qu.C
 	hashCount     atomic.Uint64
 	hashSampleBuf *rav.BufferUint64
-	hashrate      float64
+	hashrate      atomic.Float64
 	lastNonce     uint64
 }
 
 func (w *Worker) Start() {
 	D.Ln("starting up kopach workers")
+	w.clientsMx.Lock()
+	defer w.clientsMx.Unlock()
 	w.workers = []*proc.Worker{}
 	w.clients = []*client.Client{}
 	for i := 0; i < w.cx.Config.GenThreads.V(); i++ {
@@ -95,10 +103,21 @@ func (w *Worker) Start() {
 		w.clients = append(w.clients, client.New(cmd.StdConn))
 	}
 	for i := range w.clients {
+		var e error
+		if w.relay != nil {
+			T.Ln("sending relay address to worker", i)
+			if e = w.clients[i].SendRelay(w.relay.Receiver.LocalAddr().String()); E.Chk(e) {
+			}
+		}
 		T.Ln("sending pass to worker", i)
-		e := w.clients[i].SendPass(w.cx.Config.MulticastPass.Bytes())
+		e = w.clients[i].SendPass(w.cx.Config.MulticastPass.Bytes())
 		if e != nil {
 		}
+		if address := w.cx.Config.PayoutAddress.V(); address != "" {
+			T.Ln("sending payout address to worker", i)
+			if e = w.clients[i].SendAddress(address); E.Chk(e) {
+			}
+		}
 	}
 	D.Ln("setting workers to active")
 	w.active.Store(true)
@@ -107,6 +126,8 @@ func (w *Worker) Start() {
 
 func (w *Worker) Stop() {
 	var e error
+	w.clientsMx.Lock()
+	defer w.clientsMx.Unlock()
 	for i := range w.clients {
 		if e = w.clients[i].Pause(); E.Chk(e) {
 		}
@@ -146,14 +167,40 @@ func Run(cx *state.State) (e error) {
 	}
 	w.lastSent.Store(time.Now().UnixNano())
 	w.active.Store(false)
-	D.Ln("opening broadcast channel listener")
-	w.conn, e = transport.NewBroadcastChannel(
-		"kopachmain", w, cx.Config.MulticastPass.Bytes(),
-		transport.DefaultPort, constant.MaxDatagramSize, handlers,
-		w.quit,
-	)
-	if e != nil {
-		return
+	if listeners := cx.Config.KopachMetricsListeners.V(); len(listeners) > 0 {
+		registry := metrics.NewRegistry()
+		registry.Register(w.Metrics()...)
+		if e = metrics.Serve(registry, listeners, w.quit); E.Chk(e) {
+			return
+		}
+	}
+	if controllers := cx.Config.KopachControllers.V(); len(controllers) > 0 {
+		// the workers send to a relay on the loopback which forwards to the
+		// controller kopach is connected to
+		D.Ln("opening stream connection to controllers", controllers)
+		if w.stream, e = transport.NewStreamClient(
+			"kopachmain", w, cx.Config.MulticastPass.Bytes(), controllers,
+			handlers, w.quit,
+		); E.Chk(e) {
+			return
+		}
+		D.Ln("opening relay for workers")
+		if w.relay, e = transport.NewUnicastChannel(
+			"kopachrelay", w, cx.Config.MulticastPass.Bytes(), "",
+			"127.0.0.1:0", constant.MaxDatagramSize, relayHandlers, w.quit,
+		); E.Chk(e) {
+			return
+		}
+	} else {
+		D.Ln("opening broadcast channel listener")
+		w.conn, e = transport.NewBroadcastChannel(
+			"kopachmain", w, cx.Config.MulticastPass.Bytes(),
+			transport.DefaultPort, constant.MaxDatagramSize, handlers,
+			w.quit,
+		)
+		if e != nil {
+			return
+		}
 	}
 	// start up the workers
 	// if cx.Config.Generate.True() {
@@ -200,7 +247,7 @@ func Run(cx *state.State) (e error) {
 				// }
 			case <-logger.C:
 				W.Ln("hash report ticker")
-				w.hashrate = w.HashReport()
+				w.hashrate.Store(w.HashReport())
 				// if interrupt.Requested() {
 				// 	w.StopChan <- struct{}{}
 				// 	w.quit.Q()
@@ -249,7 +296,9 @@ func Run(cx *state.State) (e error) {
 		D.Ln("finished kopach miner work loop")
 		log.LogChanDisabled.Store(true)
 	}()
-	D.Ln("listening on", transport.MulticastAddress)
+	if w.stream == nil {
+		D.Ln("listening on", transport.MulticastAddress)
+	}
 	<-w.quit
 	I.Ln("kopach shutting down") // , interrupt.GoroutineDump())
 	// <-interrupt.HandlersDone
@@ -257,6 +306,24 @@ func Run(cx *state.State) (e error) {
 	return
 }
 
+// relayHandlers forward the messages of the workers to the controller when
+// connected by stream, counting the hashrate reports as they pass
+var relayHandlers = transport.Handlers{
+	string(hashrate.Magic): func(
+		ctx interface{}, src net.Addr, dst string, b []byte,
+	) (e error) {
+		if e = handlers[string(hashrate.Magic)](ctx, src, dst, b); E.Chk(e) {
+		}
+		return ctx.(*Worker).stream.Send(hashrate.Magic, b)
+	},
+	string(sol.Magic): func(
+		ctx interface{}, src net.Addr, dst string, b []byte,
+	) (e error) {
+		D.Ln("relaying solution to", ctx.(*Worker).stream.Address())
+		return ctx.(*Worker).stream.Send(sol.Magic, b)
+	},
+}
+
 // these are the handlers for specific message types.
 var handlers = transport.Handlers{
 	string(hashrate.Magic): func(
@@ -419,3 +486,39 @@ func (w *Worker) HashReport() float64 {
 	// panic("aaargh")
 	return average
 }
+
+// Metrics returns the metrics of the miner for exposition
+func (w *Worker) Metrics() []metrics.Collector {
+	return []metrics.Collector{
+		metrics.NewCounterFunc(
+			"kopach_hashes_total", "number of hashes reported by the worker threads", func() float64 {
+				return float64(w.hashCount.Load())
+			},
+		),
+		metrics.NewGaugeFunc(
+			"kopach_hashrate", "moving average of the hashes per second of all of the worker threads", func() float64 {
+				return w.hashrate.Load()
+			},
+		),
+		metrics.NewCounterVecFunc(
+			"kopach_thread_hashes_total", "number of hashes computed by each worker thread, the rate of which is its hashrate",
+			"thread", w.threadHashes,
+		),
+	}
+}
+
+// threadHashes asks each of the running worker threads how many hashes it has computed
+func (w *Worker) threadHashes() (counts map[string]float64) {
+	w.clientsMx.Lock()
+	clients := append([]*client.Client(nil), w.clients...)
+	w.clientsMx.Unlock()
+	counts = make(map[string]float64, len(clients))
+	for i := range clients {
+		count, e := clients[i].HashCount()
+		if D.Chk(e) {
+			continue
+		}
+		counts[fmt.Sprint(i)] = float64(count)
+	}
+	return
+}
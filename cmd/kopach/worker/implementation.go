@@ -45,9 +45,13 @@ type Worker struct {
 	running          atomic.Bool
 	hashCount        atomic.Uint64
 	hashSampleBuf    *ring.BufferUint64
+	forks            *fork.Schedule
+	address          atomic.String
+	relay            string
 }
 
 type Counter struct {
+	forks         *fork.Schedule
 	rpa           int32
 	C             atomic.Int32
 	Algos         atomic.Value // []int32
@@ -56,12 +60,12 @@ type Counter struct {
 
 // NewCounter returns an initialized algorithm rolling counter that ensures each
 // miner does equal amounts of every algorithm
-func NewCounter(countPerRound int32) (c *Counter) {
+func NewCounter(countPerRound int32, forks *fork.Schedule) (c *Counter) {
 	// these will be populated when work arrives
 	var algos []int32
 	// Start the counter at a random position
 	rand.Seed(time.Now().UnixNano())
-	c = &Counter{}
+	c = &Counter{forks: forks}
 	c.C.Store(int32(rand.Intn(int(countPerRound)+1) + 1))
 	c.Algos.Store(algos)
 	c.RoundsPerAlgo.Store(countPerRound)
@@ -72,7 +76,7 @@ func NewCounter(countPerRound int32) (c *Counter) {
 // GetAlgoVer returns the next algo version based on the current configuration
 func (c *Counter) GetAlgoVer(height int32) (ver int32) {
 	// the formula below rolls through versions with blocks roundsPerAlgo long for each algorithm by its index
-	algs := fork.GetAlgoVerSlice(height)
+	algs := c.forks.GetAlgoVerSlice(height)
 	// D.Ln(algs)
 	if c.RoundsPerAlgo.Load() < 1 {
 		D.Ln("CountPerRound is", c.RoundsPerAlgo.Load(), len(algs))
@@ -116,7 +120,7 @@ func (c *Counter) GetAlgoVer(height int32) (ver int32) {
 // allow a worker to be configured to run on a bare metal system with a different launcher main
 func NewWithConnAndSemaphore(
 	id string, conn *proc.StdConn, quit qu.C,
-	uuid uint64,
+	uuid uint64, forks *fork.Schedule,
 ) *Worker {
 	T.Ln("creating new worker")
 	// msgBlock := wire.WireBlock{Header: wire.BlockHeader{}}
@@ -124,10 +128,11 @@ func NewWithConnAndSemaphore(
 		id:            id,
 		pipeConn:      conn,
 		quit:          quit,
-		roller:        NewCounter(CountPerRound),
+		roller:        NewCounter(CountPerRound, forks),
 		startChan:     qu.T(),
 		stopChan:      qu.T(),
 		hashSampleBuf: ring.NewBufferUint64(1000),
+		forks:         forks,
 	}
 	w.uuid.Store(uuid)
 	w.dispatchReady.Store(false)
@@ -232,16 +237,26 @@ out:
 					blockHeader.Nonce = nonce
 					// D.S(w.templatesMessage)
 					// D.S(blockHeader)
-					hash := blockHeader.BlockHashWithAlgos(newHeight)
+					hash := blockHeader.BlockHashWithAlgos(w.forks, newHeight)
 					bigHash := blockchain.HashToBig(&hash)
-					if bigHash.Cmp(bits.CompactToBig(blockHeader.Bits)) <= 0 {
+					isBlock := bigHash.Cmp(bits.CompactToBig(blockHeader.Bits)) <= 0
+					// when mining for a pool every hash under the share target is sent, and the work continues unless
+					// it is also a block
+					address := w.address.Load()
+					shareBits, isPool := w.templatesMessage.ShareBits[vers]
+					isPool = isPool && address != ""
+					if isBlock || isPool && bigHash.Cmp(bits.CompactToBig(shareBits)) <= 0 {
 						D.Ln(
 							"found solution", newHeight,
 							w.templatesMessage.Nonce, w.templatesMessage.UUID,
+							"block", isBlock,
 						)
+						if !isPool {
+							address = ""
+						}
 						srs := sol.Encode(
 							w.templatesMessage.Nonce,
-							w.templatesMessage.UUID, blockHeader,
+							w.templatesMessage.UUID, address, blockHeader,
 						)
 						e := w.dispatchConn.SendMany(
 							sol.Magic,
@@ -250,6 +265,9 @@ out:
 						if e != nil {
 						}
 						D.Ln("sent solution")
+						if !isBlock {
+							continue
+						}
 						w.templatesMessage = nil
 						select {
 						case <-w.quit.Wait():
@@ -269,12 +287,12 @@ out:
 }
 
 // New initialises the state for a worker, loading the work function handler that runs a round of processing between
-// checking quit signal and work semaphore
-func New(id string, quit qu.C, uuid uint64) (w *Worker, conn net.Conn) {
+// checking quit signal and work semaphore. The hard fork schedule is that of the network the worker is mining on.
+func New(id string, quit qu.C, uuid uint64, forks *fork.Schedule) (w *Worker, conn net.Conn) {
 	// log.L.SetLevel("trace", true)
 	sc := proc.New(os.Stdin, os.Stdout, quit)
 
-	return NewWithConnAndSemaphore(id, sc, quit, uuid), sc
+	return NewWithConnAndSemaphore(id, sc, quit, uuid, forks), sc
 }
 
 // NewJob is a delivery of a new job for the worker, this makes the miner start
@@ -338,15 +356,29 @@ func (w *Worker) SendPass(pass []byte, reply *bool) (e error) {
 	// sp := fmt.Sprint(rand.Intn(32767) + 1025)
 	// rp := fmt.Sprint(rand.Intn(32767) + 1025)
 	var conn *transport.Channel
-	conn, e = transport.NewBroadcastChannel(
-		"kopachworker",
-		w,
-		pass,
-		transport.DefaultPort,
-		constant.MaxDatagramSize,
-		transport.Handlers{},
-		w.quit,
-	)
+	if w.relay != "" {
+		// kopach is connected to the controller by stream and relays what is sent to it
+		conn, e = transport.NewUnicastChannel(
+			"kopachworker",
+			w,
+			pass,
+			w.relay,
+			"",
+			constant.MaxDatagramSize,
+			transport.Handlers{},
+			w.quit,
+		)
+	} else {
+		conn, e = transport.NewBroadcastChannel(
+			"kopachworker",
+			w,
+			pass,
+			transport.DefaultPort,
+			constant.MaxDatagramSize,
+			transport.Handlers{},
+			w.quit,
+		)
+	}
 	if e != nil {
 	}
 	w.dispatchConn = conn
@@ -354,3 +386,27 @@ func (w *Worker) SendPass(pass []byte, reply *bool) (e error) {
 	*reply = true
 	return
 }
+
+// SendAddress gives the payout address configured for kopach, which makes the workers submit shares when the
+// controller is running in pool mode
+func (w *Worker) SendAddress(address string, reply *bool) (e error) {
+	D.Ln("receiving payout address", address)
+	w.address.Store(address)
+	*reply = true
+	return
+}
+
+// HashCount returns the number of hashes the worker has computed since it started
+func (w *Worker) HashCount(_ int, reply *uint64) (e error) {
+	*reply = w.hashCount.Load()
+	return
+}
+
+// SendRelay gives the loopback address of the relay of the kopach controller, which the worker dispatches its
+// solutions to instead of the multicast when the controller is connected to by stream
+func (w *Worker) SendRelay(address string, reply *bool) (e error) {
+	D.Ln("receiving relay address", address)
+	w.relay = address
+	*reply = true
+	return
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/cybriq/p9/pkg/connmgr"
 
 	"github.com/cybriq/p9/pkg/chaincfg"
+	"github.com/cybriq/p9/pkg/chainhash"
 )
 
 // Config stores current state of the node
@@ -17,6 +18,7 @@ type Config struct {
 	Oniondial           func(string, string, time.Duration) (net.Conn, error)
 	Dial                func(string, string, time.Duration) (net.Conn, error)
 	AddedCheckpoints    []chaincfg.Checkpoint
+	AssumeValid         *chainhash.Hash
 	ActiveMiningAddrs   []btcaddr.Address
 	ActiveMinerKey      []byte
 	ActiveMinRelayTxFee amt.Amount
@@ -0,0 +1,112 @@
+package node
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/cybriq/p9/pkg/apputil"
+	"github.com/cybriq/p9/pkg/database"
+	"github.com/cybriq/p9/pkg/database/blockdb"
+	"github.com/cybriq/p9/pkg/indexers"
+	"github.com/cybriq/p9/pkg/proc"
+	"github.com/cybriq/p9/pkg/qu"
+	"github.com/cybriq/p9/pod/state"
+)
+
+// dropIndexes drops the indexes selected in the state configuration from the block database.
+//
+// NOTE: The order is important here because dropping the tx index also drops the address index since it relies on it
+func dropIndexes(cx *state.State, db database.DB, interrupt qu.C) (e error) {
+	if cx.StateCfg.DropAddrIndex {
+		W.Ln("dropping address index")
+		if e = indexers.DropAddrIndex(db, interrupt); E.Chk(e) {
+			return
+		}
+	}
+	if cx.StateCfg.DropTxIndex {
+		W.Ln("dropping transaction index")
+		if e = indexers.DropTxIndex(db, interrupt); E.Chk(e) {
+			return
+		}
+	}
+	if cx.StateCfg.DropCfIndex {
+		W.Ln("dropping cfilter index")
+		if e = indexers.DropCfIndex(db, interrupt); E.Chk(e) {
+			return
+		}
+	}
+	return
+}
+
+// openBlockDB opens the existing block database without creating it, for the maintenance commands. This fails if the
+// node is running, as the database can only be opened by one process at a time.
+func openBlockDB(cx *state.State) (db database.DB, dbPath string, e error) {
+	if cx.Config.DbType.V() == "memdb" {
+		e = errors.New("the block database is kept in memory, there is nothing to change")
+		return
+	}
+	dbPath = state.BlockDb(cx, cx.Config.DbType.V(), blockdb.NamePrefix)
+	if !apputil.FileExists(dbPath) {
+		e = fmt.Errorf("there is no block database at '%s'", dbPath)
+		return
+	}
+	if db, e = database.Open(cx.Config.DbType.V(), dbPath, cx.ActiveNet.Net); E.Chk(e) {
+		e = fmt.Errorf("unable to open the block database at '%s', is the node still running? %v", dbPath, e)
+	}
+	return
+}
+
+// DropIndexes drops the indexes selected in the state configuration from the block database of a stopped node. If it
+// is interrupted the drop is resumed the next time the node starts, before the index is used again.
+func DropIndexes(cx *state.State) (e error) {
+	var db database.DB
+	if db, _, e = openBlockDB(cx); e != nil {
+		return
+	}
+	defer func() {
+		if e := db.Close(); E.Chk(e) {
+		}
+	}()
+	interrupt := qu.T()
+	proc.AddHandler(
+		func() {
+			W.Ln("interrupted, the drop will be finished the next time the node starts")
+			interrupt.Q()
+		},
+	)
+	if e = dropIndexes(cx, db, interrupt); E.Chk(e) {
+		return
+	}
+	if cx.Config.TxIndex.True() || cx.Config.AddrIndex.True() || cx.Config.NoCFilters.False() {
+		W.Ln("indexes that are enabled in the configuration will be rebuilt the next time the node starts")
+	}
+	return
+}
+
+// ResetChain deletes the block database of a stopped node so the chain is downloaded again the next time it starts.
+// The database is renamed before it is deleted so an interrupted reset never leaves behind a partial database.
+func ResetChain(cx *state.State) (e error) {
+	var db database.DB
+	var dbPath string
+	if db, dbPath, e = openBlockDB(cx); e != nil {
+		return
+	}
+	if e = db.Close(); E.Chk(e) {
+		return
+	}
+	deleting := dbPath + ".deleting"
+	// a previous reset may have been interrupted after the rename
+	if e = os.RemoveAll(deleting); E.Chk(e) {
+		return
+	}
+	if e = os.Rename(dbPath, deleting); E.Chk(e) {
+		return
+	}
+	I.F("deleting block database '%s'", dbPath)
+	if e = os.RemoveAll(deleting); E.Chk(e) {
+		return
+	}
+	I.Ln("block database deleted, the chain will be downloaded again when the node starts")
+	return
+}
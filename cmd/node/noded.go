@@ -20,8 +20,10 @@ VERSION:
 
 COMMANDS:
      dropaddrindex  drop the address search index
-     droptxindex    drop the address search index
-     dropcfindex    drop the address search index
+     droptxindex    drop the transaction index
+     dropcfindex    drop the cfilter index
+     dropindexes    drop all of the indexes
+     resetchain     delete the block database to download the chain again
 
 GLOBAL OPTIONS:
    --help, -h  show help
@@ -37,6 +39,7 @@ import (
 	"runtime/pprof"
 
 	"github.com/cybriq/p9/pkg/log"
+	"github.com/cybriq/p9/pkg/metrics"
 	"github.com/cybriq/p9/pkg/proc"
 	"github.com/cybriq/p9/pkg/qu"
 
@@ -46,7 +49,7 @@ import (
 	"github.com/cybriq/p9/pkg/ctrl"
 	"github.com/cybriq/p9/pkg/database"
 	"github.com/cybriq/p9/pkg/database/blockdb"
-	"github.com/cybriq/p9/pkg/indexers"
+	"github.com/cybriq/p9/pkg/discovery"
 	"github.com/cybriq/p9/pod/state"
 )
 
@@ -136,36 +139,9 @@ func NodeMain(cx *state.State) (e error) {
 	if proc.Requested() {
 		return nil
 	}
-	// drop indexes and exit if requested.
-	//
-	// NOTE: The order is important here because dropping the tx index also drops the address index since it relies on
-	// it
-	if cx.StateCfg.DropAddrIndex {
-		W.Ln("dropping address index")
-		if e = indexers.DropAddrIndex(
-			db,
-			proc.ShutdownRequestChan,
-		); E.Chk(e) {
-			return
-		}
-	}
-	if cx.StateCfg.DropTxIndex {
-		W.Ln("dropping transaction index")
-		if e = indexers.DropTxIndex(
-			db,
-			proc.ShutdownRequestChan,
-		); E.Chk(e) {
-			return
-		}
-	}
-	if cx.StateCfg.DropCfIndex {
-		W.Ln("dropping cfilter index")
-		if e = indexers.DropCfIndex(
-			db,
-			proc.ShutdownRequestChan,
-		); E.Chk(e) {
-			return
-		}
+	// drop indexes if requested
+	if e = dropIndexes(cx, db, proc.ShutdownRequestChan); E.Chk(e) {
+		return
 	}
 	// return now if an interrupt signal was triggered
 	if proc.Requested() {
@@ -203,7 +179,7 @@ func NodeMain(cx *state.State) (e error) {
 	}
 	if cx.Config.Controller.True() {
 		D.Ln("starting controller", cx.Config.Controller.True())
-		cx.Controller, e = ctrl.New(
+		if cx.Controller, e = ctrl.New(
 			cx.Syncing,
 			cx.Config,
 			cx.StateCfg,
@@ -213,11 +189,47 @@ func NodeMain(cx *state.State) (e error) {
 			uint64(cx.Config.UUID.V()),
 			cx.KillAll,
 			cx.RealNode.StartController, cx.RealNode.StopController,
-		)
+		); E.Chk(e) {
+			return
+		}
+		for i := range server.RPCServers {
+			server.RPCServers[i].Cfg.PoolInfo = cx.Controller.PoolInfo
+		}
 		go cx.Controller.Run()
 		cx.Controller.Start()
 		D.Ln("controller started")
 	}
+	if cx.Config.Discovery.True() || cx.Config.LAN.True() {
+		if p2pListeners := cx.Config.P2PListeners.S(); len(p2pListeners) == 0 {
+			W.Ln("not starting LAN peer discovery as there is no P2P listener to advertise")
+		} else {
+			D.Ln("starting LAN peer discovery")
+			var d *discovery.Discovery
+			if d, e = discovery.New(
+				uint64(cx.Config.UUID.V()),
+				p2pListeners[0],
+				cx.Config.MulticastPass.Bytes(),
+				&chainrpc.ConnManager{Server: server},
+				cx.NodeKill,
+			); E.Chk(e) {
+				return
+			}
+			for i := range server.RPCServers {
+				server.RPCServers[i].Cfg.LANPeers = d.Peers
+			}
+			go d.Run()
+		}
+	}
+	if listeners := cx.Config.MetricsListeners.V(); len(listeners) > 0 {
+		registry := metrics.NewRegistry()
+		registry.Register(server.Metrics()...)
+		if cx.Controller != nil {
+			registry.Register(cx.Controller.Metrics()...)
+		}
+		if e = metrics.Serve(registry, listeners, cx.NodeKill); E.Chk(e) {
+			return
+		}
+	}
 	once := true
 	gracefulShutdown := func() {
 		if !once {
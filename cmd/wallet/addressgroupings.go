@@ -0,0 +1,152 @@
+package wallet
+
+import (
+	"sort"
+
+	"github.com/cybriq/p9/pkg/amt"
+	"github.com/cybriq/p9/pkg/btcaddr"
+	"github.com/cybriq/p9/pkg/btcjson"
+	"github.com/cybriq/p9/pkg/txscript"
+	"github.com/cybriq/p9/pkg/waddrmgr"
+	"github.com/cybriq/p9/pkg/walletdb"
+	"github.com/cybriq/p9/pkg/wtxmgr"
+)
+
+// addressGroups is a disjoint set of addresses used to merge addresses that are known to be controlled by the same
+// owner.
+type addressGroups map[string]string
+
+// find returns the address that represents the group the passed address belongs to, adding the address as a group of
+// its own if it has not been seen before.
+func (g addressGroups) find(addr string) string {
+	parent, ok := g[addr]
+	if !ok {
+		g[addr] = addr
+		return addr
+	}
+	if parent == addr {
+		return addr
+	}
+	root := g.find(parent)
+	g[addr] = root
+	return root
+}
+
+// union merges the groups of the two passed addresses.
+func (g addressGroups) union(a, b string) {
+	ra, rb := g.find(a), g.find(b)
+	if ra == rb {
+		return
+	}
+	// Keep the lowest address as the representative so the result does not depend on map iteration order.
+	if rb < ra {
+		ra, rb = rb, ra
+	}
+	g[rb] = ra
+}
+
+// groups returns the addresses of each group sorted, with the groups ordered by their first address.
+func (g addressGroups) groups() (groups [][]string) {
+	members := make(map[string][]string)
+	for addr := range g {
+		root := g.find(addr)
+		members[root] = append(members[root], addr)
+	}
+	for _, group := range members {
+		sort.Strings(group)
+		groups = append(groups, group)
+	}
+	sort.Slice(
+		groups, func(i, j int) bool {
+			return groups[i][0] < groups[j][0]
+		},
+	)
+	return groups
+}
+
+// AddressGroupings returns the wallet addresses that have been involved in transactions grouped by common ownership as
+// it is visible on the chain. All addresses whose outputs were spent as inputs of the same transaction are placed in
+// one group, together with the change addresses of that transaction, since anyone observing the chain can assume they
+// are controlled by the same wallet. Each address is reported with its unspent balance and the account it belongs to.
+func (w *Wallet) AddressGroupings() (result [][]btcjson.AddressGroupingResult, e error) {
+	e = walletdb.View(
+		w.db, func(tx walletdb.ReadTx) (e error) {
+			addrmgrNs := tx.ReadBucket(waddrmgrNamespaceKey)
+			txmgrNs := tx.ReadBucket(wtxmgrNamespaceKey)
+			pkScriptAddress := func(pkScript []byte) string {
+				_, addrs, _, e := txscript.ExtractPkScriptAddrs(pkScript, w.chainParams)
+				if e != nil || len(addrs) != 1 {
+					return ""
+				}
+				return addrs[0].EncodeAddress()
+			}
+			groups := make(addressGroups)
+			rangeFn := func(details []wtxmgr.TxDetails) (bool, error) {
+				for i := range details {
+					detail := &details[i]
+					var inputs []string
+					for _, debit := range detail.Debits {
+						prevOut := &detail.MsgTx.TxIn[debit.Index].PreviousOutPoint
+						prev, e := w.TxStore.TxDetails(txmgrNs, &prevOut.Hash)
+						if e != nil || prev == nil || int(prevOut.Index) >= len(prev.MsgTx.TxOut) {
+							continue
+						}
+						if addr := pkScriptAddress(prev.MsgTx.TxOut[prevOut.Index].PkScript); addr != "" {
+							inputs = append(inputs, addr)
+						}
+					}
+					for _, addr := range inputs {
+						groups.union(inputs[0], addr)
+					}
+					for _, cred := range detail.Credits {
+						addr := pkScriptAddress(detail.MsgTx.TxOut[cred.Index].PkScript)
+						if addr == "" {
+							continue
+						}
+						groups.find(addr)
+						if cred.Change && len(inputs) > 0 {
+							groups.union(inputs[0], addr)
+						}
+					}
+				}
+				return false, nil
+			}
+			if e = w.TxStore.RangeTransactions(txmgrNs, 0, -1, rangeFn); E.Chk(e) {
+				return e
+			}
+			var unspent []wtxmgr.Credit
+			if unspent, e = w.TxStore.UnspentOutputs(txmgrNs); E.Chk(e) {
+				return e
+			}
+			balances := make(map[string]amt.Amount)
+			for i := range unspent {
+				if addr := pkScriptAddress(unspent[i].PkScript); addr != "" {
+					balances[addr] += unspent[i].Amount
+				}
+			}
+			for _, group := range groups.groups() {
+				entries := make([]btcjson.AddressGroupingResult, len(group))
+				for i, addrStr := range group {
+					entries[i] = btcjson.AddressGroupingResult{
+						Address: addrStr,
+						Amount:  balances[addrStr].ToDUO(),
+					}
+					addr, e := btcaddr.Decode(addrStr, w.chainParams)
+					if e != nil {
+						continue
+					}
+					var scopedMgr *waddrmgr.ScopedKeyManager
+					var account uint32
+					if scopedMgr, account, e = w.Manager.AddrAccount(addrmgrNs, addr); e != nil {
+						continue
+					}
+					if entries[i].Account, e = scopedMgr.AccountName(addrmgrNs, account); E.Chk(e) {
+					}
+				}
+				result = append(result, entries)
+			}
+			return nil
+		},
+	)
+	return result, e
+}
@@ -0,0 +1,49 @@
+package wallet
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+// TestAddressGroups ensures addresses merged by common input ownership end up in the same group regardless of the
+// order they are merged in, and that the groups are returned sorted.
+func TestAddressGroups(t *testing.T) {
+	groups := make(addressGroups)
+	groups.find("e")
+	groups.union("d", "c")
+	groups.union("a", "b")
+	groups.union("c", "b")
+	groups.find("f")
+	groups.union("f", "f")
+	want := [][]string{{"a", "b", "c", "d"}, {"e"}, {"f"}}
+	if got := groups.groups(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("unexpected groups: got %v, want %v", got, want)
+	}
+}
+
+// TestDumpLine ensures the lines written to a wallet dump are read back as the key they were written for, and that
+// comments and blank lines are skipped.
+func TestDumpLine(t *testing.T) {
+	entry := dumpEntry{
+		wif:     "cVrXkBNnKakVuy5zyTPZNTEQGVWhUb1UYaD6Vr2aPtfYUUaWZz1L",
+		address: "SNQSMpXvVUKmfz4z7KSpwpLMpWwS9G4xe2",
+		account: "my account",
+		change:  true,
+		path:    "m/44'/0'/0'/1/3",
+	}
+	line := entry.line(time.Unix(1600000000, 0))
+	want := "cVrXkBNnKakVuy5zyTPZNTEQGVWhUb1UYaD6Vr2aPtfYUUaWZz1L 2020-09-13T12:26:40Z label=my%20account change=1 " +
+		"# addr=SNQSMpXvVUKmfz4z7KSpwpLMpWwS9G4xe2 hdkeypath=m/44'/0'/0'/1/3"
+	if line != want {
+		t.Fatalf("unexpected dump line: got %q, want %q", line, want)
+	}
+	if key := parseDumpLine(line); key != entry.wif {
+		t.Fatalf("unexpected key parsed from dump line: got %q, want %q", key, entry.wif)
+	}
+	for _, line := range []string{"", "   ", "# End of dump", "  # addr=SNQSMpXvVUKmfz4z7KSpwpLMpWwS9G4xe2"} {
+		if key := parseDumpLine(line); key != "" {
+			t.Fatalf("parsed key %q from line %q", key, line)
+		}
+	}
+}
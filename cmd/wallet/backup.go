@@ -0,0 +1,246 @@
+package wallet
+
+import (
+	"bufio"
+	"fmt"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/cybriq/p9/pkg/btcaddr"
+	"github.com/cybriq/p9/pkg/util"
+	"github.com/cybriq/p9/pkg/waddrmgr"
+	"github.com/cybriq/p9/pkg/walletdb"
+	"github.com/cybriq/p9/version"
+)
+
+// BackupWallet writes a consistent copy of the wallet database to destination while the wallet remains in use. If
+// destination is a directory the copy is named wallet.db inside it. The copy is first written to a temporary file
+// next to the destination and only renamed into place once it is complete, so an interrupted backup never leaves a
+// truncated database behind.
+func (w *Wallet) BackupWallet(destination string) (e error) {
+	if destination, e = filepath.Abs(destination); E.Chk(e) {
+		return e
+	}
+	var fi os.FileInfo
+	if fi, e = os.Stat(destination); e == nil && fi.IsDir() {
+		destination = filepath.Join(destination, "wallet.db")
+	}
+	if w.PodConfig != nil {
+		var live string
+		if live, e = filepath.Abs(w.PodConfig.WalletFile.V()); !E.Chk(e) && live == destination {
+			return fmt.Errorf("cannot back up the wallet over its own database file %s", destination)
+		}
+	}
+	var f *os.File
+	if f, e = ioutil.TempFile(filepath.Dir(destination), ".wallet.db.backup"); E.Chk(e) {
+		return e
+	}
+	tmpName := f.Name()
+	if e = w.db.Copy(f); E.Chk(e) {
+		_ = f.Close()
+		_ = os.Remove(tmpName)
+		return e
+	}
+	if e = f.Sync(); E.Chk(e) {
+		_ = f.Close()
+		_ = os.Remove(tmpName)
+		return e
+	}
+	if e = f.Close(); E.Chk(e) {
+		_ = os.Remove(tmpName)
+		return e
+	}
+	if e = os.Rename(tmpName, destination); E.Chk(e) {
+		_ = os.Remove(tmpName)
+		return e
+	}
+	I.Ln("wallet database backed up to", destination)
+	return nil
+}
+
+// dumpEntry is a private key of the wallet along with the metadata recorded next to it in a wallet dump.
+type dumpEntry struct {
+	wif      string
+	address  string
+	account  string
+	change   bool
+	imported bool
+	path     string
+}
+
+// DumpWallet writes every private key of the wallet to a new file in a human readable format compatible with the
+// dumpwallet output of the reference implementation. Each key is written on its own line together with the account
+// it belongs to, whether it is a change key, its address and, for keys that were derived from the HD seed, the
+// derivation path. The wallet must be unlocked, and an existing file is never overwritten.
+func (w *Wallet) DumpWallet(filename string) (e error) {
+	if filename, e = filepath.Abs(filename); E.Chk(e) {
+		return e
+	}
+	var entries []dumpEntry
+	if e = walletdb.View(
+		w.db, func(tx walletdb.ReadTx) (e error) {
+			addrmgrNs := tx.ReadBucket(waddrmgrNamespaceKey)
+			return w.Manager.ForEachActiveAddress(
+				addrmgrNs, func(addr btcaddr.Address) (e error) {
+					var ma waddrmgr.ManagedAddress
+					if ma, e = w.Manager.Address(addrmgrNs, addr); E.Chk(e) {
+						return e
+					}
+					pka, ok := ma.(waddrmgr.ManagedPubKeyAddress)
+					if !ok {
+						return nil
+					}
+					var wif *util.WIF
+					if wif, e = pka.ExportPrivKey(); e != nil {
+						return e
+					}
+					entry := dumpEntry{
+						wif:      wif.String(),
+						address:  addr.EncodeAddress(),
+						change:   pka.Internal(),
+						imported: pka.Imported(),
+					}
+					var scopedMgr *waddrmgr.ScopedKeyManager
+					if scopedMgr, _, e = w.Manager.AddrAccount(addrmgrNs, addr); E.Chk(e) {
+						return e
+					}
+					if entry.account, e = scopedMgr.AccountName(addrmgrNs, pka.Account()); E.Chk(e) {
+						return e
+					}
+					if scope, path, ok := pka.DerivationInfo(); ok {
+						entry.path = fmt.Sprintf(
+							"m/%d'/%d'/%d'/%d/%d", scope.Purpose, scope.Coin,
+							path.Account, path.Branch, path.Index,
+						)
+					}
+					entries = append(entries, entry)
+					return nil
+				},
+			)
+		},
+	); e != nil {
+		return e
+	}
+	var f *os.File
+	if f, e = os.OpenFile(filename, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600); E.Chk(e) {
+		return e
+	}
+	synced := w.Manager.SyncedTo()
+	birthday := w.Manager.Birthday()
+	bw := bufio.NewWriter(f)
+	_, _ = fmt.Fprintf(bw, "# Wallet dump created by %s %s\n", version.PathBase, version.Get())
+	_, _ = fmt.Fprintf(bw, "# * Created on %s\n", time.Now().UTC().Format(time.RFC3339))
+	_, _ = fmt.Fprintf(bw, "# * Best block at time of backup was %d (%v)\n", synced.Height, synced.Hash)
+	_, _ = fmt.Fprintf(bw, "# * Wallet birthday %s\n", birthday.UTC().Format(time.RFC3339))
+	_, _ = fmt.Fprintln(bw, "#")
+	_, _ = fmt.Fprintln(bw, "# <key> <time> label=<account> [change=1] # addr=<address> [hdkeypath=<path>]")
+	_, _ = fmt.Fprintln(bw)
+	for i := range entries {
+		_, _ = fmt.Fprintln(bw, entries[i].line(birthday))
+	}
+	_, _ = fmt.Fprintln(bw)
+	_, _ = fmt.Fprintln(bw, "# End of dump")
+	if e = bw.Flush(); E.Chk(e) {
+		_ = f.Close()
+		return e
+	}
+	if e = f.Close(); E.Chk(e) {
+		return e
+	}
+	I.F("dumped %d keys to %s", len(entries), filename)
+	return nil
+}
+
+// line formats the entry as a line of a wallet dump.
+func (d *dumpEntry) line(birthday time.Time) string {
+	s := fmt.Sprintf(
+		"%s %s label=%s", d.wif, birthday.UTC().Format(time.RFC3339), url.PathEscape(d.account),
+	)
+	if d.change {
+		s += " change=1"
+	}
+	s += " # addr=" + d.address
+	if d.path != "" {
+		s += " hdkeypath=" + d.path
+	}
+	return s
+}
+
+// parseDumpLine returns the WIF encoded private key of a line of a wallet dump, or an empty string for comments and
+// blank lines.
+func parseDumpLine(line string) string {
+	line = strings.TrimSpace(line)
+	if line == "" || strings.HasPrefix(line, "#") {
+		return ""
+	}
+	return strings.Fields(line)[0]
+}
+
+// ImportWallet imports every private key found in a wallet dump written by DumpWallet, or by the dumpwallet command of
+// the reference implementation, into the imported account, and then rescans the chain from the genesis block for
+// transactions involving them. Keys the wallet already holds are skipped. The number of keys that were imported is
+// returned.
+func (w *Wallet) ImportWallet(filename string) (imported int, e error) {
+	var f *os.File
+	if f, e = os.Open(filename); E.Chk(e) {
+		return 0, e
+	}
+	defer func() {
+		if e := f.Close(); E.Chk(e) {
+		}
+	}()
+	var wifs []*util.WIF
+	scanner := bufio.NewScanner(f)
+	for lineNum := 1; scanner.Scan(); lineNum++ {
+		key := parseDumpLine(scanner.Text())
+		if key == "" {
+			continue
+		}
+		var wif *util.WIF
+		if wif, e = util.DecodeWIF(key); e != nil {
+			return 0, fmt.Errorf("line %d of %s: %v", lineNum, filename, e)
+		}
+		if !wif.IsForNet(w.chainParams) {
+			return 0, fmt.Errorf("line %d of %s: key is not intended for %s", lineNum, filename, w.chainParams.Name)
+		}
+		wifs = append(wifs, wif)
+	}
+	if e = scanner.Err(); E.Chk(e) {
+		return 0, e
+	}
+	var addrs []btcaddr.Address
+	for _, wif := range wifs {
+		var addrStr string
+		addrStr, e = w.ImportPrivateKey(waddrmgr.KeyScopeBIP0044, wif, nil, false)
+		switch {
+		case waddrmgr.IsError(e, waddrmgr.ErrDuplicateAddress):
+			continue
+		case e != nil:
+			return imported, e
+		}
+		var addr btcaddr.Address
+		if addr, e = btcaddr.Decode(addrStr, w.chainParams); E.Chk(e) {
+			return imported, e
+		}
+		addrs = append(addrs, addr)
+		imported++
+	}
+	if len(addrs) > 0 {
+		// The rescan is not waited on, its progress and result are logged by the rescan handler.
+		_ = w.SubmitRescan(
+			&RescanJob{
+				Addrs: addrs,
+				BlockStamp: waddrmgr.BlockStamp{
+					Hash:   *w.chainParams.GenesisHash,
+					Height: 0,
+				},
+			},
+		)
+	}
+	I.F("imported %d keys from %s", imported, filename)
+	return imported, nil
+}
@@ -0,0 +1,148 @@
+package wallet
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/cybriq/p9/pkg/amt"
+	"github.com/cybriq/p9/pkg/btcaddr"
+	"github.com/cybriq/p9/pkg/chaincfg"
+	"github.com/cybriq/p9/pkg/chainclient"
+	"github.com/cybriq/p9/pkg/chainhash"
+	"github.com/cybriq/p9/pkg/qu"
+	"github.com/cybriq/p9/pkg/snacl"
+	"github.com/cybriq/p9/pkg/txscript"
+	"github.com/cybriq/p9/pkg/waddrmgr"
+	"github.com/cybriq/p9/pkg/walletdb"
+	"github.com/cybriq/p9/pkg/wire"
+	"github.com/cybriq/p9/pkg/wtxmgr"
+)
+
+// testChainHeight is the height of the best block reported by the test chain client
+const testChainHeight = 100
+
+var testPrivPass = []byte("private")
+
+// testChainClient is a chain client that reports a fixed best block and keeps the transactions sent to it, or fails to
+// send them with sendErr if it is set. The methods the tests do not use are left to the nil embedded interface.
+type testChainClient struct {
+	chainclient.Interface
+	sendErr error
+	sent    []*wire.MsgTx
+}
+
+func (c *testChainClient) BlockStamp() (*waddrmgr.BlockStamp, error) {
+	return &waddrmgr.BlockStamp{Height: testChainHeight}, nil
+}
+
+func (c *testChainClient) SendRawTransaction(tx *wire.MsgTx, _ bool) (*chainhash.Hash, error) {
+	if c.sendErr != nil {
+		return nil, c.sendErr
+	}
+	c.sent = append(c.sent, tx)
+	txHash := tx.TxHash()
+	return &txHash, nil
+}
+
+func (c *testChainClient) Stop() {}
+
+func (c *testChainClient) WaitForShutdown() {}
+
+// testWallet creates a simulation network wallet in a temporary directory, then opens, starts and unlocks it with a
+// test chain client. The wallet is stopped and its database closed when the test finishes.
+func testWallet(t *testing.T) (w *Wallet, chain *testChainClient) {
+	// the default scrypt parameters make creating a wallet take seconds
+	oldKeyGen := waddrmgr.SetSecretKeyGen(
+		func(passphrase *[]byte, _ *waddrmgr.ScryptOptions) (*snacl.SecretKey, error) {
+			return snacl.NewSecretKey(passphrase, 16, 8, 1)
+		},
+	)
+	t.Cleanup(func() { waddrmgr.SetSecretKeyGen(oldKeyGen) })
+	db, e := walletdb.Create("bdb", filepath.Join(t.TempDir(), "wallet.db"))
+	if e != nil {
+		t.Fatal(e)
+	}
+	t.Cleanup(
+		func() {
+			if e := db.Close(); e != nil {
+				t.Error(e)
+			}
+		},
+	)
+	pubPass := []byte(InsecurePubPassphrase)
+	if e = Create(db, pubPass, testPrivPass, nil, &chaincfg.SimNetParams, time.Now()); e != nil {
+		t.Fatal(e)
+	}
+	if w, e = Open(db, pubPass, nil, &chaincfg.SimNetParams, 0, nil, qu.T()); e != nil {
+		t.Fatal(e)
+	}
+	w.Start()
+	t.Cleanup(w.Stop)
+	chain = &testChainClient{}
+	w.chainClient = chain
+	if e = w.Unlock(testPrivPass, nil); e != nil {
+		t.Fatal(e)
+	}
+	return
+}
+
+// fundTestWallet records a transaction mined at height 1 paying each amount to a new address of the default account,
+// and returns it.
+func fundTestWallet(t *testing.T, w *Wallet, amounts ...amt.Amount) *wire.MsgTx {
+	tx := wire.NewMsgTx(wire.TxVersion)
+	tx.AddTxIn(wire.NewTxIn(&wire.OutPoint{Hash: chainhash.Hash{1}}, nil, nil))
+	for _, amount := range amounts {
+		addr, e := w.NewAddress(waddrmgr.DefaultAccountNum, waddrmgr.KeyScopeBIP0044, true)
+		if e != nil {
+			t.Fatal(e)
+		}
+		var pkScript []byte
+		if pkScript, e = txscript.PayToAddrScript(addr); e != nil {
+			t.Fatal(e)
+		}
+		tx.AddTxOut(wire.NewTxOut(int64(amount), pkScript))
+	}
+	rec, e := wtxmgr.NewTxRecordFromMsgTx(tx, time.Now())
+	if e != nil {
+		t.Fatal(e)
+	}
+	block := &wtxmgr.BlockMeta{Block: wtxmgr.Block{Hash: chainhash.Hash{2}, Height: 1}, Time: time.Now()}
+	if e = walletdb.Update(
+		w.db, func(dbTx walletdb.ReadWriteTx) error {
+			return w.addRelevantTx(dbTx, rec, block)
+		},
+	); e != nil {
+		t.Fatal(e)
+	}
+	return tx
+}
+
+// testPayment returns an output paying amount to an address that does not belong to any wallet
+func testPayment(t *testing.T, amount amt.Amount) *wire.TxOut {
+	addr, e := btcaddr.NewPubKeyHash(make([]byte, 20), &chaincfg.SimNetParams)
+	if e != nil {
+		t.Fatal(e)
+	}
+	var pkScript []byte
+	if pkScript, e = txscript.PayToAddrScript(addr); e != nil {
+		t.Fatal(e)
+	}
+	return wire.NewTxOut(int64(amount), pkScript)
+}
+
+// unminedTxs returns the hashes of the unmined transactions in the wallet
+func unminedTxs(t *testing.T, w *Wallet) (hashes []chainhash.Hash) {
+	if e := walletdb.View(
+		w.db, func(dbTx walletdb.ReadTx) error {
+			txs, e := w.TxStore.UnminedTxs(dbTx.ReadBucket(wtxmgrNamespaceKey))
+			for _, tx := range txs {
+				hashes = append(hashes, tx.TxHash())
+			}
+			return e
+		},
+	); e != nil {
+		t.Fatal(e)
+	}
+	return
+}
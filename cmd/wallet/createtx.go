@@ -8,9 +8,11 @@ import (
 	"github.com/cybriq/p9/pkg/amt"
 	"github.com/cybriq/p9/pkg/btcaddr"
 	"github.com/cybriq/p9/pkg/chainclient"
+	"github.com/cybriq/p9/pkg/chainhash"
 
 	ec "github.com/cybriq/p9/pkg/ecc"
 	"github.com/cybriq/p9/pkg/txauthor"
+	"github.com/cybriq/p9/pkg/txrules"
 	"github.com/cybriq/p9/pkg/txscript"
 	"github.com/cybriq/p9/pkg/waddrmgr"
 	"github.com/cybriq/p9/pkg/walletdb"
@@ -105,10 +107,12 @@ func (s secretSource) GetScript(addr btcaddr.Address) ([]byte, error) {
 // outputs. Previous outputs to reedeem are chosen from the passed account's
 // UTXO set and minconf policy. An additional output may be added to return
 // change to the wallet. An appropriate fee is included based on the wallet's
-// current relay fee. The wallet must be unlocked to create the transaction.
+// current relay fee. If replaceable is set the transaction signals that it can
+// be replaced by one paying a higher fee. The wallet must be unlocked to create
+// the transaction.
 func (w *Wallet) txToOutputs(
 	outputs []*wire.TxOut, account uint32,
-	minconf int32, feeSatPerKb amt.Amount,
+	minconf int32, feeSatPerKb amt.Amount, replaceable bool,
 ) (tx *txauthor.AuthoredTx, e error) {
 	var chainClient chainclient.Interface
 	if chainClient, e = w.requireChainClient(); E.Chk(e) {
@@ -157,6 +161,9 @@ func (w *Wallet) txToOutputs(
 			if tx.ChangeIndex >= 0 {
 				tx.RandomizeChangePosition()
 			}
+			if replaceable {
+				tx.SignalReplacement()
+			}
 			return tx.AddAllInputScripts(secretSource{w.Manager, addrmgrNs})
 		},
 	)
@@ -175,6 +182,178 @@ func (w *Wallet) txToOutputs(
 	}
 	return
 }
+
+// bumpTx creates a signed transaction replacing an unmined wallet transaction
+// that signals replacement (BIP125) with one paying a fee rate of feeSatPerKb,
+// or the fee rate of the original plus the relay fee if it is zero. The
+// replacement spends the same inputs, and more from the passed account's UTXO
+// set if they no longer cover the fee, and pays the same outputs except for the
+// change, which pays for the increase in the fee. The wallet must be unlocked
+// to create the transaction.
+func (w *Wallet) bumpTx(
+	txHash *chainhash.Hash, account uint32,
+	minconf int32, feeSatPerKb amt.Amount,
+) (tx *txauthor.AuthoredTx, e error) {
+	var chainClient chainclient.Interface
+	if chainClient, e = w.requireChainClient(); E.Chk(e) {
+		return nil, e
+	}
+	e = walletdb.Update(
+		w.db, func(dbtx walletdb.ReadWriteTx) (e error) {
+			addrmgrNs := dbtx.ReadWriteBucket(waddrmgrNamespaceKey)
+			txmgrNs := dbtx.ReadBucket(wtxmgrNamespaceKey)
+			var details *wtxmgr.TxDetails
+			if details, e = w.TxStore.TxDetails(txmgrNs, txHash); E.Chk(e) {
+				return
+			}
+			var oldFee amt.Amount
+			if oldFee, e = replaceableTxFee(txHash, details); E.Chk(e) {
+				return
+			}
+			oldTx := &details.MsgTx
+			oldFeeRate := oldFee * 1000 / amt.Amount(oldTx.SerializeSize())
+			minFeeRate := oldFeeRate + txrules.DefaultRelayFeePerKb
+			if feeSatPerKb == 0 {
+				feeSatPerKb = minFeeRate
+			}
+			if feeSatPerKb < minFeeRate {
+				return fmt.Errorf(
+					"fee rate of %v per kB is less than the minimum of %v to replace transaction %v",
+					feeSatPerKb, minFeeRate, txHash,
+				)
+			}
+			// The replacement pays every output of the original except for its change, which is added again with the
+			// same script if there is any left after paying the higher fee.
+			changeIndex := -1
+			for _, credit := range details.Credits {
+				if credit.Change {
+					changeIndex = int(credit.Index)
+					break
+				}
+			}
+			outputs := make([]*wire.TxOut, 0, len(oldTx.TxOut))
+			for i, txOut := range oldTx.TxOut {
+				if i != changeIndex {
+					outputs = append(outputs, wire.NewTxOut(txOut.Value, txOut.PkScript))
+				}
+			}
+			changeSource := func() (b []byte, e error) {
+				if changeIndex >= 0 {
+					return oldTx.TxOut[changeIndex].PkScript, nil
+				}
+				var changeAddr btcaddr.Address
+				if changeAddr, e = w.newChangeAddress(addrmgrNs, account); E.Chk(e) {
+					return
+				}
+				return txscript.PayToAddrScript(changeAddr)
+			}
+			// The inputs of the original are always spent again so that the replacement conflicts with it, and the
+			// outputs of the account are only added to them when they are needed for the fee.
+			var (
+				origTotal   amt.Amount
+				origInputs  = make([]*wire.TxIn, 0, len(oldTx.TxIn))
+				origValues  = make([]amt.Amount, 0, len(oldTx.TxIn))
+				origScripts = make([][]byte, 0, len(oldTx.TxIn))
+			)
+			for _, txIn := range oldTx.TxIn {
+				prevOut := txIn.PreviousOutPoint
+				var prevDetails *wtxmgr.TxDetails
+				if prevDetails, e = w.TxStore.TxDetails(txmgrNs, &prevOut.Hash); E.Chk(e) {
+					return
+				}
+				if prevDetails == nil {
+					return fmt.Errorf("previous output %v of transaction %v not found", prevOut, txHash)
+				}
+				prevTxOut := prevDetails.MsgTx.TxOut[prevOut.Index]
+				origTotal += amt.Amount(prevTxOut.Value)
+				origInputs = append(origInputs, wire.NewTxIn(&prevOut, nil, nil))
+				origValues = append(origValues, amt.Amount(prevTxOut.Value))
+				origScripts = append(origScripts, prevTxOut.PkScript)
+			}
+			var bs *waddrmgr.BlockStamp
+			if bs, e = chainClient.BlockStamp(); E.Chk(e) {
+				return
+			}
+			var eligible []wtxmgr.Credit
+			if eligible, e = w.findEligibleOutputs(dbtx, account, minconf, bs); E.Chk(e) {
+				return
+			}
+			extraSource := makeInputSource(eligible)
+			inputSource := func(target amt.Amount) (
+				amt.Amount, []*wire.TxIn,
+				[]amt.Amount, [][]byte, error,
+			) {
+				if target <= origTotal {
+					return origTotal, origInputs, origValues, origScripts, nil
+				}
+				total, inputs, values, scripts, e := extraSource(target - origTotal)
+				if e != nil {
+					return 0, nil, nil, nil, e
+				}
+				n := len(origInputs)
+				return origTotal + total,
+					append(origInputs[:n:n], inputs...),
+					append(origValues[:n:n], values...),
+					append(origScripts[:n:n], scripts...),
+					nil
+			}
+			if tx, e = txauthor.NewUnsignedTransaction(
+				outputs, feeSatPerKb,
+				inputSource, changeSource,
+			); E.Chk(e) {
+				return
+			}
+			if tx.ChangeIndex >= 0 {
+				tx.RandomizeChangePosition()
+			}
+			tx.SignalReplacement()
+			return tx.AddAllInputScripts(secretSource{w.Manager, addrmgrNs})
+		},
+	)
+	if E.Chk(e) {
+		return
+	}
+	e = validateMsgTx(tx.Tx, tx.PrevScripts, tx.PrevInputValues)
+	return
+}
+
+// replaceableTxFee returns the fee paid by a wallet transaction after checking that it can be replaced by one paying a
+// higher fee, which requires it to be unmined, to signal replacement (BIP125), to only spend outputs of the wallet and
+// for none of its outputs to have been spent.
+func replaceableTxFee(txHash *chainhash.Hash, details *wtxmgr.TxDetails) (fee amt.Amount, e error) {
+	if details == nil {
+		return 0, fmt.Errorf("transaction %v is not in the wallet", txHash)
+	}
+	if details.Block.Height != -1 {
+		return 0, fmt.Errorf("transaction %v has already been mined", txHash)
+	}
+	signals := false
+	for _, txIn := range details.MsgTx.TxIn {
+		if txIn.Sequence <= wire.MaxReplaceableSequenceNum {
+			signals = true
+			break
+		}
+	}
+	if !signals {
+		return 0, fmt.Errorf("transaction %v does not signal replacement", txHash)
+	}
+	if len(details.Debits) != len(details.MsgTx.TxIn) {
+		return 0, fmt.Errorf("transaction %v spends outputs that do not belong to the wallet", txHash)
+	}
+	for _, credit := range details.Credits {
+		if credit.Spent {
+			return 0, fmt.Errorf("transaction %v has outputs that have already been spent", txHash)
+		}
+	}
+	for _, debit := range details.Debits {
+		fee += debit.Amount
+	}
+	for _, txOut := range details.MsgTx.TxOut {
+		fee -= amt.Amount(txOut.Value)
+	}
+	return fee, nil
+}
+
 func (w *Wallet) findEligibleOutputs(
 	dbtx walletdb.ReadTx,
 	account uint32,
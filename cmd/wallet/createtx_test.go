@@ -0,0 +1,123 @@
+package wallet
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/cybriq/p9/pkg/amt"
+	"github.com/cybriq/p9/pkg/chainhash"
+	"github.com/cybriq/p9/pkg/txrules"
+	"github.com/cybriq/p9/pkg/walletdb"
+	"github.com/cybriq/p9/pkg/wire"
+	"github.com/cybriq/p9/pkg/wtxmgr"
+)
+
+// txDetails returns the details of a wallet transaction, or nil if it is not in the wallet
+func txDetails(t *testing.T, w *Wallet, txHash *chainhash.Hash) (details *wtxmgr.TxDetails) {
+	if e := walletdb.View(
+		w.db, func(dbTx walletdb.ReadTx) (e error) {
+			details, e = w.TxStore.TxDetails(dbTx.ReadBucket(wtxmgrNamespaceKey), txHash)
+			return
+		},
+	); e != nil {
+		t.Fatal(e)
+	}
+	return
+}
+
+// TestBumpTx ensures a replacement pays at least the fee rate of the original plus the relay fee, spends the same
+// inputs and pays the same outputs, with the increase in the fee taken from the change.
+func TestBumpTx(t *testing.T) {
+	w, _ := testWallet(t)
+	fundTestWallet(t, w, 100000000)
+	payment := testPayment(t, 50000000)
+	oldHash, e := w.SendOutputs([]*wire.TxOut{payment}, 0, 1, txrules.DefaultRelayFeePerKb, true)
+	if e != nil {
+		t.Fatal(e)
+	}
+	details := txDetails(t, w, oldHash)
+	oldFee, e := replaceableTxFee(oldHash, details)
+	if e != nil {
+		t.Fatal(e)
+	}
+	oldTx := &details.MsgTx
+	var oldChange int64 = -1
+	for _, credit := range details.Credits {
+		if credit.Change {
+			oldChange = oldTx.TxOut[credit.Index].Value
+		}
+	}
+	if oldChange < 0 {
+		t.Fatal("original transaction has no change")
+	}
+	minFeeRate := oldFee*1000/amt.Amount(oldTx.SerializeSize()) + txrules.DefaultRelayFeePerKb
+	if _, e = w.bumpTx(oldHash, 0, 1, minFeeRate-1); e == nil {
+		t.Fatal("replacement paying less than the minimum fee rate was created")
+	}
+	tx, e := w.bumpTx(oldHash, 0, 1, 0)
+	if e != nil {
+		t.Fatal(e)
+	}
+	newFee := tx.TotalInput
+	for _, txOut := range tx.Tx.TxOut {
+		newFee -= amt.Amount(txOut.Value)
+	}
+	if newFee <= oldFee {
+		t.Fatalf("replacement fee %v is not more than the original fee %v", newFee, oldFee)
+	}
+	if feeRate := newFee * 1000 / amt.Amount(tx.Tx.SerializeSize()); feeRate < minFeeRate {
+		t.Fatalf("replacement fee rate %v is less than the minimum %v", feeRate, minFeeRate)
+	}
+	if len(tx.Tx.TxIn) != len(oldTx.TxIn) {
+		t.Fatalf("replacement has %d inputs, the original has %d", len(tx.Tx.TxIn), len(oldTx.TxIn))
+	}
+	for i, txIn := range tx.Tx.TxIn {
+		if txIn.PreviousOutPoint != oldTx.TxIn[i].PreviousOutPoint {
+			t.Fatalf("replacement input %d spends %v, not %v", i, txIn.PreviousOutPoint, oldTx.TxIn[i].PreviousOutPoint)
+		}
+		if txIn.Sequence > wire.MaxReplaceableSequenceNum {
+			t.Fatalf("replacement input %d does not signal replacement", i)
+		}
+	}
+	if len(tx.Tx.TxOut) != 2 || tx.ChangeIndex < 0 {
+		t.Fatalf("expected a payment and a change output, got %d outputs", len(tx.Tx.TxOut))
+	}
+	paid := tx.Tx.TxOut[1-tx.ChangeIndex]
+	if paid.Value != payment.Value || string(paid.PkScript) != string(payment.PkScript) {
+		t.Fatalf("replacement pays %d to %x instead of the original payment", paid.Value, paid.PkScript)
+	}
+	if change := tx.Tx.TxOut[tx.ChangeIndex].Value; change != oldChange-int64(newFee-oldFee) {
+		t.Fatalf("replacement change is %d, expected %d", change, oldChange-int64(newFee-oldFee))
+	}
+}
+
+// TestReplaceableTxFee ensures only unmined wallet transactions that signal replacement can be replaced.
+func TestReplaceableTxFee(t *testing.T) {
+	w, _ := testWallet(t)
+	funding := fundTestWallet(t, w, 100000000)
+	fundingHash := funding.TxHash()
+	txHash, e := w.SendOutputs(
+		[]*wire.TxOut{testPayment(t, 50000000)}, 0, 1, txrules.DefaultRelayFeePerKb, false,
+	)
+	if e != nil {
+		t.Fatal(e)
+	}
+	tests := []struct {
+		name    string
+		txHash  *chainhash.Hash
+		details *wtxmgr.TxDetails
+		err     string
+	}{
+		{"missing", &chainhash.Hash{3}, nil, "is not in the wallet"},
+		{"mined", &fundingHash, txDetails(t, w, &fundingHash), "has already been mined"},
+		{"not signalling", txHash, txDetails(t, w, txHash), "does not signal replacement"},
+	}
+	for _, test := range tests {
+		if _, e = replaceableTxFee(test.txHash, test.details); e == nil || !strings.Contains(e.Error(), test.err) {
+			t.Errorf("%s: expected an error containing %q, got %v", test.name, test.err, e)
+		}
+	}
+	if _, e = w.bumpTx(txHash, 0, 1, 0); e == nil || !strings.Contains(e.Error(), "does not signal replacement") {
+		t.Errorf("replacement of a transaction that does not signal replacement was created, error %v", e)
+	}
+}
@@ -2,14 +2,32 @@ package wallet
 
 import (
 	"encoding/binary"
-	"path/filepath"
 
 	"github.com/cybriq/p9/pkg/walletdb"
 	"github.com/cybriq/p9/pkg/wtxmgr"
-	"github.com/cybriq/p9/pod/config"
 )
 
-func DropWalletHistory(w *Wallet, cfg *config.Config) (e error) {
+// DropWalletHistory replaces the transaction history of a running wallet with an empty one through the database the
+// wallet has open. The wallet must be restarted afterwards to rescan the chain.
+func DropWalletHistory(w *Wallet) (e error) {
+	return dropHistory(w.Database())
+}
+
+// DropHistory replaces the transaction history of the wallet database at dbPath with an empty one and rewinds its sync
+// state to the wallet birthday so the history is rebuilt by rescanning the chain
+func DropHistory(dbPath string) (e error) {
+	// I.Ln("dbPath", dbPath)
+	var db walletdb.DB
+	db, e = walletdb.Open("bdb", dbPath)
+	if E.Chk(e) {
+		// DBError("failed to open database:", err)
+		return e
+	}
+	defer db.Close()
+	return dropHistory(db)
+}
+
+func dropHistory(db walletdb.DB) (e error) {
 	var (
 		// Namespace keys.
 		syncBucketName    = []byte("sync")
@@ -20,18 +38,6 @@ func DropWalletHistory(w *Wallet, cfg *config.Config) (e error) {
 		startBlockName   = []byte("startblock")
 		recentBlocksName = []byte("recentblocks")
 	)
-	dbPath := filepath.Join(
-		cfg.DataDir.V(),
-		cfg.Network.V(), "wallet.db",
-	)
-	// I.Ln("dbPath", dbPath)
-	var db walletdb.DB
-	db, e = walletdb.Open("bdb", dbPath)
-	if E.Chk(e) {
-		// DBError("failed to open database:", err)
-		return e
-	}
-	defer db.Close()
 	D.Ln("dropping wtxmgr namespace")
 	e = walletdb.Update(
 		db, func(tx walletdb.ReadWriteTx) (e error) {
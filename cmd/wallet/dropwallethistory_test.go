@@ -0,0 +1,21 @@
+package wallet
+
+import (
+	"testing"
+)
+
+// TestDropWalletHistory ensures the history of a running wallet is dropped through the database it has open.
+func TestDropWalletHistory(t *testing.T) {
+	w, _ := testWallet(t)
+	funding := fundTestWallet(t, w, 100000000)
+	fundingHash := funding.TxHash()
+	if txDetails(t, w, &fundingHash) == nil {
+		t.Fatal("funding transaction was not recorded")
+	}
+	if e := DropWalletHistory(w); e != nil {
+		t.Fatal(e)
+	}
+	if txDetails(t, w, &fundingHash) != nil {
+		t.Fatal("funding transaction is still in the wallet after dropping its history")
+	}
+}
@@ -252,6 +252,18 @@ var handlers = handlersT{
 		Cmd:     "*btcjson.AddMultisigAddressCmd",
 		ResType: "string",
 	},
+	{
+		Method:  "backupwallet",
+		Handler: "BackupWallet",
+		Cmd:     "*btcjson.BackupWalletCmd",
+		ResType: "None",
+	},
+	{
+		Method:  "bumpfee",
+		Handler: "BumpFee",
+		Cmd:     "*btcjson.BumpFeeCmd",
+		ResType: "btcjson.BumpFeeResult",
+	},
 	{
 		Method:  "createmultisig",
 		Handler: "CreateMultiSig",
@@ -264,6 +276,12 @@ var handlers = handlersT{
 		Cmd:     "*btcjson.DumpPrivKeyCmd",
 		ResType: "string",
 	},
+	{
+		Method:  "dumpwallet",
+		Handler: "DumpWallet",
+		Cmd:     "*btcjson.DumpWalletCmd",
+		ResType: "btcjson.DumpWalletResult",
+	},
 	{
 		Method:  "getaccount",
 		Handler: "GetAccount",
@@ -282,6 +300,12 @@ var handlers = handlersT{
 		Cmd:     "*btcjson.GetAddressesByAccountCmd",
 		ResType: "[]string",
 	},
+	{
+		Method:  "getaddressesbylabel",
+		Handler: "GetAddressesByLabel",
+		Cmd:     "*btcjson.GetAddressesByLabelCmd",
+		ResType: "map[string]btcjson.AddressPurposeResult",
+	},
 	{
 		Method:  "getbalance",
 		Handler: "GetBalance",
@@ -306,6 +330,12 @@ var handlers = handlersT{
 		Cmd:     "*None",
 		ResType: "btcjson.InfoWalletResult",
 	},
+	{
+		Method:  "getwalletinfo",
+		Handler: "GetWalletInfo",
+		Cmd:     "*btcjson.GetWalletInfoCmd",
+		ResType: "btcjson.GetWalletInfoResult",
+	},
 	{
 		Method:  "getnewaddress",
 		Handler: "GetNewAddress",
@@ -349,6 +379,12 @@ var handlers = handlersT{
 		Cmd:     "*btcjson.ImportPrivKeyCmd",
 		ResType: "None",
 	},
+	{
+		Method:  "importwallet",
+		Handler: "ImportWallet",
+		Cmd:     "*btcjson.ImportWalletCmd",
+		ResType: "None",
+	},
 	{
 		Method:  "keypoolrefill",
 		Handler: "KeypoolRefill",
@@ -361,6 +397,24 @@ var handlers = handlersT{
 		Cmd:     "*btcjson.ListAccountsCmd",
 		ResType: "map[string]float64",
 	},
+	{
+		Method:  "listaddressgroupings",
+		Handler: "ListAddressGroupings",
+		Cmd:     "*btcjson.ListAddressGroupingsCmd",
+		ResType: "[][]btcjson.AddressGroupingResult",
+	},
+	{
+		Method:  "listaddresslabels",
+		Handler: "ListAddressLabels",
+		Cmd:     "*btcjson.ListAddressLabelsCmd",
+		ResType: "map[string]btcjson.AddressLabelResult",
+	},
+	{
+		Method:  "listlabels",
+		Handler: "ListLabels",
+		Cmd:     "*btcjson.ListLabelsCmd",
+		ResType: "[]string",
+	},
 	{
 		Method:  "listlockunspent",
 		Handler: "ListLockUnspent",
@@ -417,6 +471,12 @@ var handlers = handlersT{
 		Cmd:     "*btcjson.SendToAddressCmd",
 		ResType: "string",
 	},
+	{
+		Method:  "setlabel",
+		Handler: "SetLabel",
+		Cmd:     "*btcjson.SetLabelCmd",
+		ResType: "None",
+	},
 	{
 		Method:  "settxfee",
 		Handler: "SetTxFee",
@@ -0,0 +1,203 @@
+package wallet
+
+import (
+	"errors"
+	"sort"
+
+	"github.com/cybriq/p9/pkg/btcaddr"
+	"github.com/cybriq/p9/pkg/btcjson"
+	"github.com/cybriq/p9/pkg/chainhash"
+	"github.com/cybriq/p9/pkg/walletdb"
+	"github.com/cybriq/p9/pkg/wtxmgr"
+)
+
+// Buckets of the labels namespace. Address labels are keyed by the encoded address and transaction labels by the
+// transaction hash.
+var (
+	addrLabelsBucketKey = []byte("addr")
+	txLabelsBucketKey   = []byte("tx")
+)
+
+// Purposes of labelled addresses. Addresses of the wallet are labelled to receive payments, all other addresses are
+// labelled to send payments to.
+const (
+	LabelPurposeReceive = "receive"
+	LabelPurposeSend    = "send"
+)
+
+// ErrTxNotInWallet describes an error where a transaction to label is not a transaction of the wallet.
+var ErrTxNotInWallet = errors.New("transaction is not in the wallet")
+
+// createLabelsNamespace creates the labels namespace and its buckets where they do not exist yet. This is also how
+// wallets created before labels were stored in the wallet database are upgraded.
+func createLabelsNamespace(tx walletdb.ReadWriteTx) (e error) {
+	ns := tx.ReadWriteBucket(labelsNamespaceKey)
+	if ns == nil {
+		if ns, e = tx.CreateTopLevelBucket(labelsNamespaceKey); E.Chk(e) {
+			return
+		}
+	}
+	for _, key := range [][]byte{addrLabelsBucketKey, txLabelsBucketKey} {
+		if _, e = ns.CreateBucketIfNotExists(key); E.Chk(e) {
+			return
+		}
+	}
+	return
+}
+
+// putLabel stores the label of a key in one of the label buckets, or removes it if the label is empty.
+func putLabel(ns walletdb.ReadWriteBucket, bucket, key []byte, label string) error {
+	b := ns.NestedReadWriteBucket(bucket)
+	if label == "" {
+		return b.Delete(key)
+	}
+	return b.Put(key, []byte(label))
+}
+
+// fetchLabel returns the label of a key in one of the label buckets, or an empty string if it has none.
+func fetchLabel(ns walletdb.ReadBucket, bucket, key []byte) string {
+	if ns == nil {
+		return ""
+	}
+	b := ns.NestedReadBucket(bucket)
+	if b == nil {
+		return ""
+	}
+	return string(b.Get(key))
+}
+
+// labelPurpose returns whether a labelled address is one of the wallet or one that is paid to.
+func (w *Wallet) labelPurpose(addrmgrNs walletdb.ReadBucket, addrStr string) string {
+	addr, e := btcaddr.Decode(addrStr, w.chainParams)
+	if e != nil {
+		return LabelPurposeSend
+	}
+	if _, e = w.Manager.Address(addrmgrNs, addr); e != nil {
+		return LabelPurposeSend
+	}
+	return LabelPurposeReceive
+}
+
+// SetAddressLabel sets the label of an address, or removes it if the label is empty. The address does not need to
+// belong to the wallet, so addresses that are paid to can be kept in an address book.
+func (w *Wallet) SetAddressLabel(addr btcaddr.Address, label string) error {
+	return walletdb.Update(
+		w.db, func(tx walletdb.ReadWriteTx) error {
+			labelsNs := tx.ReadWriteBucket(labelsNamespaceKey)
+			return putLabel(labelsNs, addrLabelsBucketKey, []byte(addr.EncodeAddress()), label)
+		},
+	)
+}
+
+// SetTxLabel sets the label of a wallet transaction, or removes it if the label is empty.
+func (w *Wallet) SetTxLabel(hash *chainhash.Hash, label string) error {
+	return walletdb.Update(
+		w.db, func(tx walletdb.ReadWriteTx) (e error) {
+			txmgrNs := tx.ReadBucket(wtxmgrNamespaceKey)
+			var details *wtxmgr.TxDetails
+			if details, e = w.TxStore.TxDetails(txmgrNs, hash); E.Chk(e) {
+				return
+			}
+			if details == nil {
+				return ErrTxNotInWallet
+			}
+			labelsNs := tx.ReadWriteBucket(labelsNamespaceKey)
+			return putLabel(labelsNs, txLabelsBucketKey, hash[:], label)
+		},
+	)
+}
+
+// AddressLabel returns the label of an address, or an empty string if it has none.
+func (w *Wallet) AddressLabel(addr btcaddr.Address) (label string, e error) {
+	e = walletdb.View(
+		w.db, func(tx walletdb.ReadTx) error {
+			labelsNs := tx.ReadBucket(labelsNamespaceKey)
+			label = fetchLabel(labelsNs, addrLabelsBucketKey, []byte(addr.EncodeAddress()))
+			return nil
+		},
+	)
+	return
+}
+
+// TxLabel returns the label of a transaction, or an empty string if it has none.
+func (w *Wallet) TxLabel(hash *chainhash.Hash) (label string, e error) {
+	e = walletdb.View(
+		w.db, func(tx walletdb.ReadTx) error {
+			labelsNs := tx.ReadBucket(labelsNamespaceKey)
+			label = fetchLabel(labelsNs, txLabelsBucketKey, hash[:])
+			return nil
+		},
+	)
+	return
+}
+
+// AddressesByLabel returns the addresses carrying a label, mapped to whether they are labelled to receive or to send
+// payments.
+func (w *Wallet) AddressesByLabel(label string) (addrs map[string]string, e error) {
+	addrs = make(map[string]string)
+	e = walletdb.View(
+		w.db, func(tx walletdb.ReadTx) error {
+			addrmgrNs := tx.ReadBucket(waddrmgrNamespaceKey)
+			labelsNs := tx.ReadBucket(labelsNamespaceKey)
+			return labelsNs.NestedReadBucket(addrLabelsBucketKey).ForEach(
+				func(k, v []byte) error {
+					if string(v) == label {
+						addrs[string(k)] = w.labelPurpose(addrmgrNs, string(k))
+					}
+					return nil
+				},
+			)
+		},
+	)
+	return
+}
+
+// AddressLabels returns every labelled address mapped to its label and to whether it is labelled to receive or to send
+// payments.
+func (w *Wallet) AddressLabels() (addrs map[string]btcjson.AddressLabelResult, e error) {
+	addrs = make(map[string]btcjson.AddressLabelResult)
+	e = walletdb.View(
+		w.db, func(tx walletdb.ReadTx) error {
+			addrmgrNs := tx.ReadBucket(waddrmgrNamespaceKey)
+			labelsNs := tx.ReadBucket(labelsNamespaceKey)
+			return labelsNs.NestedReadBucket(addrLabelsBucketKey).ForEach(
+				func(k, v []byte) error {
+					addrs[string(k)] = btcjson.AddressLabelResult{
+						Label:   string(v),
+						Purpose: w.labelPurpose(addrmgrNs, string(k)),
+					}
+					return nil
+				},
+			)
+		},
+	)
+	return
+}
+
+// Labels returns the sorted labels of addresses. If purpose is not empty only the labels of addresses with that
+// purpose are returned.
+func (w *Wallet) Labels(purpose string) (labels []string, e error) {
+	labels = []string{}
+	e = walletdb.View(
+		w.db, func(tx walletdb.ReadTx) error {
+			addrmgrNs := tx.ReadBucket(waddrmgrNamespaceKey)
+			labelsNs := tx.ReadBucket(labelsNamespaceKey)
+			seen := make(map[string]struct{})
+			return labelsNs.NestedReadBucket(addrLabelsBucketKey).ForEach(
+				func(k, v []byte) error {
+					if _, ok := seen[string(v)]; ok {
+						return nil
+					}
+					if purpose != "" && w.labelPurpose(addrmgrNs, string(k)) != purpose {
+						return nil
+					}
+					seen[string(v)] = struct{}{}
+					labels = append(labels, string(v))
+					return nil
+				},
+			)
+		},
+	)
+	sort.Strings(labels)
+	return
+}
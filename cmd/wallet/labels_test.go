@@ -0,0 +1,200 @@
+package wallet
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/cybriq/p9/pkg/btcjson"
+	"github.com/cybriq/p9/pkg/chainclient"
+	"github.com/cybriq/p9/pkg/chainhash"
+	"github.com/cybriq/p9/pkg/txrules"
+	"github.com/cybriq/p9/pkg/txscript"
+	"github.com/cybriq/p9/pkg/walletdb"
+	"github.com/cybriq/p9/pkg/wire"
+)
+
+// TestLabelBuckets ensures labels are stored and removed in the labels namespace, and that creating the namespace again,
+// as is done when a wallet is opened, keeps the labels already stored.
+func TestLabelBuckets(t *testing.T) {
+	db, e := walletdb.Create("bdb", filepath.Join(t.TempDir(), "wallet.db"))
+	if e != nil {
+		t.Fatal(e)
+	}
+	defer func() {
+		if e := db.Close(); e != nil {
+			t.Error(e)
+		}
+	}()
+	update := func(f func(ns walletdb.ReadWriteBucket) error) {
+		if e := walletdb.Update(
+			db, func(tx walletdb.ReadWriteTx) (e error) {
+				if e = createLabelsNamespace(tx); e != nil {
+					return e
+				}
+				return f(tx.ReadWriteBucket(labelsNamespaceKey))
+			},
+		); e != nil {
+			t.Fatal(e)
+		}
+	}
+	fetch := func(bucket, key []byte) (label string) {
+		if e := walletdb.View(
+			db, func(tx walletdb.ReadTx) error {
+				label = fetchLabel(tx.ReadBucket(labelsNamespaceKey), bucket, key)
+				return nil
+			},
+		); e != nil {
+			t.Fatal(e)
+		}
+		return
+	}
+	addr, txHash := []byte("SNQSMpXvVUKmfz4z7KSpwpLMpWwS9G4xe2"), make([]byte, 32)
+	update(
+		func(ns walletdb.ReadWriteBucket) (e error) {
+			if e = putLabel(ns, addrLabelsBucketKey, addr, "friend"); e != nil {
+				return
+			}
+			return putLabel(ns, txLabelsBucketKey, txHash, "rent")
+		},
+	)
+	update(func(ns walletdb.ReadWriteBucket) error { return nil })
+	if label := fetch(addrLabelsBucketKey, addr); label != "friend" {
+		t.Fatalf("unexpected address label %q", label)
+	}
+	if label := fetch(txLabelsBucketKey, txHash); label != "rent" {
+		t.Fatalf("unexpected transaction label %q", label)
+	}
+	if label := fetch(txLabelsBucketKey, addr); label != "" {
+		t.Fatalf("address label %q found in the transaction labels", label)
+	}
+	update(
+		func(ns walletdb.ReadWriteBucket) error {
+			return putLabel(ns, addrLabelsBucketKey, addr, "")
+		},
+	)
+	if label := fetch(addrLabelsBucketKey, addr); label != "" {
+		t.Fatalf("removed address label %q still found", label)
+	}
+	if label := fetchLabel(nil, addrLabelsBucketKey, addr); label != "" {
+		t.Fatalf("label %q found without a labels namespace", label)
+	}
+}
+
+// TestLabelHandlers ensures the labels set with setlabel are returned by the label requests with the purpose of the
+// address, wallet addresses being labelled to receive and all others to send, and by listtransactions as the label and
+// comment of the transactions.
+func TestLabelHandlers(t *testing.T) {
+	w, _ := testWallet(t)
+	funding := fundTestWallet(t, w, 100000000)
+	fundingHash := funding.TxHash()
+	_, addrs, _, e := txscript.ExtractPkScriptAddrs(funding.TxOut[0].PkScript, w.ChainParams())
+	if e != nil {
+		t.Fatal(e)
+	}
+	mine := addrs[0].EncodeAddress()
+	payment := testPayment(t, 50000000)
+	if _, addrs, _, e = txscript.ExtractPkScriptAddrs(payment.PkScript, w.ChainParams()); e != nil {
+		t.Fatal(e)
+	}
+	friend := addrs[0].EncodeAddress()
+	sentHash, e := w.SendOutputs([]*wire.TxOut{payment}, 0, 1, txrules.DefaultRelayFeePerKb, false)
+	if e != nil {
+		t.Fatal(e)
+	}
+	for _, cmd := range []*btcjson.SetLabelCmd{
+		btcjson.NewSetLabelCmd(mine, "savings"),
+		btcjson.NewSetLabelCmd(friend, "friend"),
+		btcjson.NewSetLabelCmd(fundingHash.String(), "salary"),
+		btcjson.NewSetLabelCmd(sentHash.String(), "rent"),
+	} {
+		if _, e = SetLabel(cmd, w); e != nil {
+			t.Fatalf("setlabel %s: %v", cmd.Target, e)
+		}
+	}
+	if _, e = SetLabel(btcjson.NewSetLabelCmd(chainhash.Hash{3}.String(), "unknown"), w); e != &ErrNoTransactionInfo {
+		t.Errorf("labelling a transaction that is not in the wallet returned %v", e)
+	}
+	if _, e = SetLabel(btcjson.NewSetLabelCmd("notanaddress", "unknown"), w); e == nil {
+		t.Errorf("labelling an invalid address succeeded")
+	}
+	for label, expected := range map[string]map[string]btcjson.AddressPurposeResult{
+		"savings": {mine: {Purpose: LabelPurposeReceive}},
+		"friend":  {friend: {Purpose: LabelPurposeSend}},
+	} {
+		res, e := GetAddressesByLabel(btcjson.NewGetAddressesByLabelCmd(label), w)
+		if e != nil {
+			t.Fatalf("getaddressesbylabel %s: %v", label, e)
+		}
+		if !reflect.DeepEqual(res, expected) {
+			t.Errorf("getaddressesbylabel %s: got %v, expected %v", label, res, expected)
+		}
+	}
+	if _, e = GetAddressesByLabel(btcjson.NewGetAddressesByLabelCmd("salary"), w); e == nil {
+		t.Errorf("getaddressesbylabel returned addresses for a transaction label")
+	}
+	for _, test := range []struct {
+		purpose  *string
+		expected []string
+	}{
+		{nil, []string{"friend", "savings"}},
+		{btcjson.String(LabelPurposeReceive), []string{"savings"}},
+		{btcjson.String(LabelPurposeSend), []string{"friend"}},
+	} {
+		res, e := ListLabels(btcjson.NewListLabelsCmd(test.purpose), w)
+		if e != nil {
+			t.Fatal(e)
+		}
+		if !reflect.DeepEqual(res, test.expected) {
+			t.Errorf("listlabels %v: got %v, expected %v", test.purpose, res, test.expected)
+		}
+	}
+	if _, e = ListLabels(btcjson.NewListLabelsCmd(btcjson.String("other")), w); e == nil {
+		t.Errorf("listlabels accepted an invalid purpose")
+	}
+	res, e := ListAddressLabels(btcjson.NewListAddressLabelsCmd(), w)
+	if e != nil {
+		t.Fatal(e)
+	}
+	expected := map[string]btcjson.AddressLabelResult{
+		mine:   {Label: "savings", Purpose: LabelPurposeReceive},
+		friend: {Label: "friend", Purpose: LabelPurposeSend},
+	}
+	if !reflect.DeepEqual(res, expected) {
+		t.Errorf("listaddresslabels: got %v, expected %v", res, expected)
+	}
+	res, e = ListTransactions(
+		btcjson.NewListTransactionsCmd(nil, btcjson.Int(10), btcjson.Int(0), nil), w, &chainclient.RPCClient{},
+	)
+	if e != nil {
+		t.Fatal(e)
+	}
+	var received, sent bool
+	for _, tx := range res.([]btcjson.ListTransactionsResult) {
+		switch {
+		case tx.TxID == fundingHash.String() && tx.Category == "receive":
+			received = true
+			if tx.Address != mine || tx.Label != "savings" || tx.Comment != "salary" {
+				t.Errorf("received payment has address %s, label %q and comment %q", tx.Address, tx.Label, tx.Comment)
+			}
+		case tx.TxID == sentHash.String() && tx.Category == "send" && tx.Address == friend:
+			sent = true
+			if tx.Label != "friend" || tx.Comment != "rent" {
+				t.Errorf("sent payment has label %q and comment %q", tx.Label, tx.Comment)
+			}
+		}
+	}
+	if !received || !sent {
+		t.Fatalf("listtransactions is missing the received (%v) or the sent (%v) payment", received, sent)
+	}
+	// removing the label of an address removes it from the labels and leaves the other addresses labelled
+	if _, e = SetLabel(btcjson.NewSetLabelCmd(mine, ""), w); e != nil {
+		t.Fatal(e)
+	}
+	if _, e = GetAddressesByLabel(btcjson.NewGetAddressesByLabelCmd("savings"), w); e == nil {
+		t.Errorf("getaddressesbylabel returned addresses for a removed label")
+	}
+	if res, e = ListLabels(btcjson.NewListLabelsCmd(nil), w); e != nil || !reflect.DeepEqual(res, []string{"friend"}) {
+		t.Errorf("listlabels after removing a label: got %v, %v", res, e)
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"sync"
 
 	"github.com/cybriq/p9/pkg/helpers"
+	"github.com/cybriq/p9/pkg/metrics"
 	"github.com/cybriq/p9/pkg/proc"
 	"github.com/cybriq/p9/pkg/qu"
 
@@ -44,10 +45,21 @@ func Main(cx *state.State) (e error) {
 		E.Ln("unable to create RPC servers:", e)
 		return
 	}
+	var registry *metrics.Registry
+	if listeners := cx.Config.WalletMetricsListeners.V(); len(listeners) > 0 {
+		registry = metrics.NewRegistry()
+		registry.Register(rpcDuration)
+		if e = metrics.Serve(registry, listeners, cx.WalletKill); E.Chk(e) {
+			return
+		}
+	}
 	loader.RunAfterLoad(
 		func(w *Wallet) {
 			D.Ln("starting wallet RPC services", w != nil)
 			startWalletRPCServices(w, legacyServer)
+			if registry != nil {
+				registry.Register(w.Metrics()...)
+			}
 			// cx.WalletChan <- w
 		},
 	)
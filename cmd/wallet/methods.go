@@ -7,6 +7,8 @@ import (
 	js "encoding/json"
 	"errors"
 	"fmt"
+	"os"
+	"path/filepath"
 	"sync"
 	"time"
 
@@ -24,6 +26,7 @@ import (
 	"github.com/cybriq/p9/pkg/txscript"
 	"github.com/cybriq/p9/pkg/util"
 	"github.com/cybriq/p9/pkg/waddrmgr"
+	"github.com/cybriq/p9/pkg/walletdb"
 	"github.com/cybriq/p9/pkg/wire"
 	"github.com/cybriq/p9/pkg/wtxmgr"
 )
@@ -247,12 +250,6 @@ func Confirms(txHeight, curHeight int32) int32 {
 // 		Params:  make(chan btcjson.WalletPassphraseChangeCmd),
 // 		Return:  func() interface{} { return make(chan WalletPassphraseChangeRes) },
 // 	},
-// 	// Reference implementation methods (still unimplemented)
-// 	"backupwallet":         {Handler: Unimplemented, NoHelp: true},
-// 	"dumpwallet":           {Handler: Unimplemented, NoHelp: true},
-// 	"getwalletinfo":        {Handler: Unimplemented, NoHelp: true},
-// 	"importwallet":         {Handler: Unimplemented, NoHelp: true},
-// 	"listaddressgroupings": {Handler: Unimplemented, NoHelp: true},
 // 	// Reference methods which can't be implemented by btcwallet due to
 // 	// design decision differences
 // 	"encryptwallet": {Handler: Unsupported, NoHelp: true},
@@ -542,6 +539,73 @@ func AddMultiSigAddress(
 	return p2shAddr.EncodeAddress(), nil
 }
 
+// BackupWallet handles a backupwallet request by writing a copy of the wallet database to the requested destination.
+func BackupWallet(
+	icmd interface{}, w *Wallet,
+	chainClient ...*chainclient.RPCClient,
+) (interface{}, error) {
+	cmd, ok := icmd.(*btcjson.BackupWalletCmd)
+	if !ok {
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCInvalidParameter,
+			Message: HelpDescsEnUS()["backupwallet"],
+		}
+	}
+	if e := w.BackupWallet(cmd.Destination); e != nil {
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCWallet,
+			Message: "Wallet backup failed: " + e.Error(),
+		}
+	}
+	return nil, nil
+}
+
+// BumpFee handles a bumpfee request by replacing an unconfirmed wallet transaction that signals replacement (BIP125)
+// with one paying a higher fee, and returns the hash of the replacement and the fees paid by it and the original.
+func BumpFee(
+	icmd interface{}, w *Wallet,
+	chainClient ...*chainclient.RPCClient,
+) (interface{}, error) {
+	cmd, ok := icmd.(*btcjson.BumpFeeCmd)
+	if !ok {
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCInvalidParameter,
+			Message: HelpDescsEnUS()["bumpfee"],
+		}
+	}
+	txHash, e := chainhash.NewHashFromStr(cmd.TxID)
+	if e != nil {
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCDecodeHexString,
+			Message: "Transaction hash string decode failed: " + e.Error(),
+		}
+	}
+	var feeRate amt.Amount
+	if cmd.FeeRate != nil {
+		if feeRate, e = amt.NewAmount(*cmd.FeeRate); E.Chk(e) {
+			return nil, e
+		}
+		if feeRate <= 0 {
+			return nil, ErrNeedPositiveAmount
+		}
+	}
+	newHash, oldFee, newFee, e := w.BumpFee(txHash, feeRate)
+	if e != nil {
+		if waddrmgr.IsError(e, waddrmgr.ErrLocked) {
+			return nil, &ErrWalletUnlockNeeded
+		}
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCWallet,
+			Message: e.Error(),
+		}
+	}
+	return btcjson.BumpFeeResult{
+		TxID:    newHash.String(),
+		OrigFee: oldFee.ToDUO(),
+		Fee:     newFee.ToDUO(),
+	}, nil
+}
+
 // CreateMultiSig handles an createmultisig request by returning a multisig address for the given inputs.
 func CreateMultiSig(
 	icmd interface{}, w *Wallet,
@@ -602,16 +666,37 @@ func DumpPrivKey(
 	return key, e
 }
 
-// // dumpWallet handles a dumpwallet request by returning  all private
-// // keys in a wallet, or an appropiate error if the wallet is locked.
-// // TODO: finish this to match bitcoind by writing the dump to a file.
-// func dumpWallet(// 	icmd interface{}, w *wallet.Wallet) (interface{}, error) {
-// 	keys, e := w.DumpPrivKeys()
-// 	if waddrmgr.IsError(err, waddrmgr.ErrLocked) {
-// 		return nil, &ErrWalletUnlockNeeded
-// 	}
-// 	return keys, err
-// }
+// DumpWallet handles a dumpwallet request by writing all private keys of the wallet to a new file, or returning an
+// appropriate error if the wallet is locked.
+func DumpWallet(
+	icmd interface{}, w *Wallet,
+	chainClient ...*chainclient.RPCClient,
+) (interface{}, error) {
+	cmd, ok := icmd.(*btcjson.DumpWalletCmd)
+	if !ok {
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCInvalidParameter,
+			Message: HelpDescsEnUS()["dumpwallet"],
+		}
+	}
+	filename, e := filepath.Abs(cmd.Filename)
+	if e != nil {
+		return nil, e
+	}
+	e = w.DumpWallet(filename)
+	switch {
+	case waddrmgr.IsError(e, waddrmgr.ErrLocked):
+		return nil, &ErrWalletUnlockNeeded
+	case os.IsExist(e):
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCWallet,
+			Message: filename + " already exists. If you are sure this is what you want, move it out of the way first",
+		}
+	case e != nil:
+		return nil, e
+	}
+	return &btcjson.DumpWalletResult{Filename: filename}, nil
+}
 
 // GetAddressesByAccount handles a getaddressesbyaccount request by returning
 // all addresses for an account, or an error if the requested account does not
@@ -646,6 +731,36 @@ func GetAddressesByAccount(
 	return addrStrs, nil
 }
 
+// GetAddressesByLabel handles a getaddressesbylabel request by returning the addresses carrying a label along with
+// whether they are labelled to receive or to send payments.
+func GetAddressesByLabel(
+	icmd interface{}, w *Wallet,
+	chainClient ...*chainclient.RPCClient,
+) (interface{}, error) {
+	cmd, ok := icmd.(*btcjson.GetAddressesByLabelCmd)
+	if !ok {
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCInvalidParameter,
+			Message: HelpDescsEnUS()["getaddressesbylabel"],
+		}
+	}
+	addrs, e := w.AddressesByLabel(cmd.Label)
+	if e != nil {
+		return nil, e
+	}
+	if len(addrs) == 0 {
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCWalletInvalidAccountName,
+			Message: "No addresses with label " + cmd.Label,
+		}
+	}
+	result := make(map[string]btcjson.AddressPurposeResult, len(addrs))
+	for addr, purpose := range addrs {
+		result[addr] = btcjson.AddressPurposeResult{Purpose: purpose}
+	}
+	return result, nil
+}
+
 // GetBalance handles a getbalance request by returning the balance for an
 // account (wallet), or an error if the requested account does not exist.
 func GetBalance(
@@ -755,6 +870,50 @@ func GetInfo(
 	return info, nil
 }
 
+// GetWalletInfo handles a getwalletinfo request by returning the balances, transaction count, lock state and sync
+// state of the wallet.
+func GetWalletInfo(
+	icmd interface{}, w *Wallet,
+	chainClient ...*chainclient.RPCClient,
+) (interface{}, error) {
+	bals, e := w.CalculateBalances(1)
+	if e != nil {
+		return nil, e
+	}
+	var txCount int
+	if e = walletdb.View(
+		w.db, func(tx walletdb.ReadTx) (e error) {
+			txmgrNs := tx.ReadBucket(wtxmgrNamespaceKey)
+			return w.TxStore.RangeTransactions(
+				txmgrNs, 0, -1, func(details []wtxmgr.TxDetails) (bool, error) {
+					txCount += len(details)
+					return false, nil
+				},
+			)
+		},
+	); E.Chk(e) {
+		return nil, e
+	}
+	var walletName string
+	if w.PodConfig != nil {
+		walletName = filepath.Base(w.PodConfig.WalletFile.V())
+	}
+	synced := w.Manager.SyncedTo()
+	return &btcjson.GetWalletInfoResult{
+		WalletName:         walletName,
+		WalletVersion:      int32(waddrmgr.LatestMgrVersion),
+		Balance:            bals.Spendable.ToDUO(),
+		UnconfirmedBalance: (bals.Total - bals.Spendable - bals.ImmatureReward).ToDUO(),
+		ImmatureBalance:    bals.ImmatureReward.ToDUO(),
+		TxCount:            txCount,
+		Unlocked:           !w.Locked(),
+		PayTxFee:           txrules.DefaultRelayFeePerKb.ToDUO(),
+		SyncedHeight:       synced.Height,
+		SyncedHash:         synced.Hash.String(),
+		Birthday:           w.Manager.Birthday().Unix(),
+	}, nil
+}
+
 func DecodeAddress(s string, params *chaincfg.Params) (btcaddr.Address, error) {
 	addr, e := btcaddr.Decode(s, params)
 	if e != nil {
@@ -914,6 +1073,32 @@ func ImportPrivKey(
 	return nil, e
 }
 
+// ImportWallet handles an importwallet request by importing all private keys of a wallet dump into the imported
+// account and rescanning the chain for their transactions.
+func ImportWallet(
+	icmd interface{}, w *Wallet,
+	chainClient ...*chainclient.RPCClient,
+) (interface{}, error) {
+	cmd, ok := icmd.(*btcjson.ImportWalletCmd)
+	if !ok {
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCInvalidParameter,
+			Message: HelpDescsEnUS()["importwallet"],
+		}
+	}
+	_, e := w.ImportWallet(cmd.Filename)
+	switch {
+	case waddrmgr.IsError(e, waddrmgr.ErrLocked):
+		return nil, &ErrWalletUnlockNeeded
+	case e != nil:
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCWallet,
+			Message: "Wallet import failed: " + e.Error(),
+		}
+	}
+	return nil, nil
+}
+
 // KeypoolRefill handles the keypoolrefill command. Since we handle the keypool automatically this does nothing since
 // refilling is never manually required.
 func KeypoolRefill(
@@ -1273,7 +1458,7 @@ func HandleDropWalletHistory(
 	out interface{}, e error,
 ) {
 	D.Ln("dropping wallet history")
-	if e = DropWalletHistory(w, w.PodConfig); E.Chk(e) {
+	if e = DropWalletHistory(w); E.Chk(e) {
 	}
 	D.Ln("dropped wallet history")
 	// go func() {
@@ -1428,6 +1613,57 @@ func ListAccounts(
 	return accountBalances, nil
 }
 
+// ListAddressGroupings handles a listaddressgroupings request by returning the addresses of the wallet grouped by
+// the common ownership made public by spending them together as inputs or receiving change.
+func ListAddressGroupings(
+	icmd interface{}, w *Wallet,
+	chainClient ...*chainclient.RPCClient,
+) (interface{}, error) {
+	return w.AddressGroupings()
+}
+
+// ListAddressLabels handles a listaddresslabels request by returning every labelled address along with its label and
+// whether it is labelled to receive or to send payments.
+func ListAddressLabels(
+	icmd interface{}, w *Wallet,
+	chainClient ...*chainclient.RPCClient,
+) (interface{}, error) {
+	if _, ok := icmd.(*btcjson.ListAddressLabelsCmd); !ok {
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCInvalidParameter,
+			Message: HelpDescsEnUS()["listaddresslabels"],
+		}
+	}
+	return w.AddressLabels()
+}
+
+// ListLabels handles a listlabels request by returning the sorted labels of addresses, optionally only those of
+// addresses labelled for one purpose.
+func ListLabels(
+	icmd interface{}, w *Wallet,
+	chainClient ...*chainclient.RPCClient,
+) (interface{}, error) {
+	cmd, ok := icmd.(*btcjson.ListLabelsCmd)
+	if !ok {
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCInvalidParameter,
+			Message: HelpDescsEnUS()["listlabels"],
+		}
+	}
+	var purpose string
+	if cmd.Purpose != nil {
+		purpose = *cmd.Purpose
+	}
+	switch purpose {
+	case "", LabelPurposeReceive, LabelPurposeSend:
+	default:
+		return nil, InvalidParameterError{
+			errors.New(`purpose must be "receive" or "send"`),
+		}
+	}
+	return w.Labels(purpose)
+}
+
 // ListLockUnspent handles a listlockunspent request by returning an slice of all locked outpoints.
 func ListLockUnspent(
 	icmd interface{}, w *Wallet,
@@ -1837,18 +2073,19 @@ func MakeOutputs(
 	return outputs, nil
 }
 
-// SendPairs creates and sends payment transactions. It returns the transaction hash in string format upon success All
-// errors are returned in json.RPCError format
+// SendPairs creates and sends payment transactions, which signal that they can be replaced by one paying a higher fee
+// if replaceable is set. It returns the transaction hash in string format upon success All errors are returned in
+// json.RPCError format
 func SendPairs(
 	w *Wallet, amounts map[string]amt.Amount,
-	account uint32, minconf int32, feeSatPerKb amt.Amount,
+	account uint32, minconf int32, feeSatPerKb amt.Amount, replaceable bool,
 ) (string, error) {
 	outputs, e := MakeOutputs(amounts, w.ChainParams())
 	if e != nil {
 		return "", e
 	}
 	var txHash *chainhash.Hash
-	txHash, e = w.SendOutputs(outputs, account, minconf, feeSatPerKb)
+	txHash, e = w.SendOutputs(outputs, account, minconf, feeSatPerKb, replaceable)
 	if e != nil {
 		if e == txrules.ErrAmountNegative {
 			return "", ErrNeedPositiveAmount
@@ -1918,7 +2155,7 @@ func SendFrom(
 	}
 	return SendPairs(
 		w, pairs, account, minConf,
-		txrules.DefaultRelayFeePerKb,
+		txrules.DefaultRelayFeePerKb, false,
 	)
 }
 
@@ -1964,7 +2201,10 @@ func SendMany(
 		}
 		pairs[k] = amt
 	}
-	return SendPairs(w, pairs, account, minConf, txrules.DefaultRelayFeePerKb)
+	return SendPairs(
+		w, pairs, account, minConf,
+		txrules.DefaultRelayFeePerKb, cmd.Replaceable != nil && *cmd.Replaceable,
+	)
 }
 
 // SendToAddress handles a sendtoaddress RPC request by creating a new transaction spending unspent transaction outputs
@@ -2009,10 +2249,38 @@ func SendToAddress(
 	// sendtoaddress always spends from the default account, this matches bitcoind
 	return SendPairs(
 		w, pairs, waddrmgr.DefaultAccountNum, 1,
-		txrules.DefaultRelayFeePerKb,
+		txrules.DefaultRelayFeePerKb, cmd.Replaceable != nil && *cmd.Replaceable,
 	)
 }
 
+// SetLabel handles a setlabel request by setting the label of an address or of a wallet transaction, which is removed
+// if the label is empty.
+func SetLabel(
+	icmd interface{}, w *Wallet,
+	chainClient ...*chainclient.RPCClient,
+) (interface{}, error) {
+	cmd, ok := icmd.(*btcjson.SetLabelCmd)
+	if !ok {
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCInvalidParameter,
+			Message: HelpDescsEnUS()["setlabel"],
+		}
+	}
+	if len(cmd.Target) == chainhash.MaxHashStringSize {
+		if txHash, e := chainhash.NewHashFromStr(cmd.Target); e == nil {
+			if e = w.SetTxLabel(txHash, cmd.Label); e == ErrTxNotInWallet {
+				return nil, &ErrNoTransactionInfo
+			}
+			return nil, e
+		}
+	}
+	addr, e := DecodeAddress(cmd.Target, w.ChainParams())
+	if e != nil {
+		return nil, e
+	}
+	return nil, w.SetAddressLabel(addr, cmd.Label)
+}
+
 // SetTxFee sets the transaction fee per kilobyte added to transactions.
 func SetTxFee(
 	icmd interface{}, w *Wallet,
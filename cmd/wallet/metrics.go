@@ -0,0 +1,37 @@
+package wallet
+
+import (
+	"github.com/cybriq/p9/pkg/metrics"
+)
+
+// rpcDuration is the time taken to handle each RPC method, including those passed through to the chain server
+var rpcDuration = metrics.NewHistogramVec(
+	"pod_wallet_rpc_duration_seconds", "time taken to handle wallet RPC requests", "method", metrics.DurationBuckets,
+)
+
+// Metrics returns the metrics of the wallet for exposition
+func (w *Wallet) Metrics() []metrics.Collector {
+	return []metrics.Collector{
+		metrics.NewGaugeFunc(
+			"pod_wallet_synced_height", "height of the last block the wallet has processed", func() float64 {
+				return float64(w.Manager.SyncedTo().Height)
+			},
+		),
+		metrics.NewGaugeFunc(
+			"pod_wallet_synced", "1 if the wallet is synced with the chain server", func() float64 {
+				if w.ChainSynced() {
+					return 1
+				}
+				return 0
+			},
+		),
+		metrics.NewGaugeFunc(
+			"pod_wallet_locked", "1 if the wallet is locked", func() float64 {
+				if w.Locked() {
+					return 1
+				}
+				return 0
+			},
+		),
+	}
+}
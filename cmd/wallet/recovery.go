@@ -221,6 +221,16 @@ func (rs *RecoveryState) AddWatchedOutPoint(
 	rs.watchedOutPoints[*outPoint] = addr
 }
 
+// NumFound returns the number of external and internal addresses that have been found to be used so far, counting all
+// addresses below the highest found child index of each branch as used.
+func (rs *RecoveryState) NumFound() (external, internal uint32) {
+	for _, scopeState := range rs.scopes {
+		external += scopeState.ExternalBranch.NextUnfound()
+		internal += scopeState.InternalBranch.NextUnfound()
+	}
+	return external, internal
+}
+
 // ScopeRecoveryState is used to manage the recovery of addresses generated under a particular BIP32 account. Each
 // account tracks both an external and internal branch recovery state, both of which use the same recovery window.
 type ScopeRecoveryState struct {
@@ -55,6 +55,10 @@ type (
 	None struct{} 
 	// AddMultiSigAddressRes is the result from a call to AddMultiSigAddress
 	AddMultiSigAddressRes struct { Res *string; e error }
+	// BackupWalletRes is the result from a call to BackupWallet
+	BackupWalletRes struct { Res *None; e error }
+	// BumpFeeRes is the result from a call to BumpFee
+	BumpFeeRes struct { Res *btcjson.BumpFeeResult; e error }
 	// CreateMultiSigRes is the result from a call to CreateMultiSig
 	CreateMultiSigRes struct { Res *btcjson.CreateMultiSigResult; e error }
 	// CreateNewAccountRes is the result from a call to CreateNewAccount
@@ -63,12 +67,16 @@ type (
 	HandleDropWalletHistoryRes struct { Res *string; e error }
 	// DumpPrivKeyRes is the result from a call to DumpPrivKey
 	DumpPrivKeyRes struct { Res *string; e error }
+	// DumpWalletRes is the result from a call to DumpWallet
+	DumpWalletRes struct { Res *btcjson.DumpWalletResult; e error }
 	// GetAccountRes is the result from a call to GetAccount
 	GetAccountRes struct { Res *string; e error }
 	// GetAccountAddressRes is the result from a call to GetAccountAddress
 	GetAccountAddressRes struct { Res *string; e error }
 	// GetAddressesByAccountRes is the result from a call to GetAddressesByAccount
 	GetAddressesByAccountRes struct { Res *[]string; e error }
+	// GetAddressesByLabelRes is the result from a call to GetAddressesByLabel
+	GetAddressesByLabelRes struct { Res *map[string]btcjson.AddressPurposeResult; e error }
 	// GetBalanceRes is the result from a call to GetBalance
 	GetBalanceRes struct { Res *float64; e error }
 	// GetBestBlockRes is the result from a call to GetBestBlock
@@ -91,18 +99,28 @@ type (
 	GetTransactionRes struct { Res *btcjson.GetTransactionResult; e error }
 	// GetUnconfirmedBalanceRes is the result from a call to GetUnconfirmedBalance
 	GetUnconfirmedBalanceRes struct { Res *float64; e error }
+	// GetWalletInfoRes is the result from a call to GetWalletInfo
+	GetWalletInfoRes struct { Res *btcjson.GetWalletInfoResult; e error }
 	// HelpNoChainRPCRes is the result from a call to HelpNoChainRPC
 	HelpNoChainRPCRes struct { Res *string; e error }
 	// ImportPrivKeyRes is the result from a call to ImportPrivKey
 	ImportPrivKeyRes struct { Res *None; e error }
+	// ImportWalletRes is the result from a call to ImportWallet
+	ImportWalletRes struct { Res *None; e error }
 	// KeypoolRefillRes is the result from a call to KeypoolRefill
 	KeypoolRefillRes struct { Res *None; e error }
 	// ListAccountsRes is the result from a call to ListAccounts
 	ListAccountsRes struct { Res *map[string]float64; e error }
+	// ListAddressGroupingsRes is the result from a call to ListAddressGroupings
+	ListAddressGroupingsRes struct { Res *[][]btcjson.AddressGroupingResult; e error }
+	// ListAddressLabelsRes is the result from a call to ListAddressLabels
+	ListAddressLabelsRes struct { Res *map[string]btcjson.AddressLabelResult; e error }
 	// ListAddressTransactionsRes is the result from a call to ListAddressTransactions
 	ListAddressTransactionsRes struct { Res *[]btcjson.ListTransactionsResult; e error }
 	// ListAllTransactionsRes is the result from a call to ListAllTransactions
 	ListAllTransactionsRes struct { Res *[]btcjson.ListTransactionsResult; e error }
+	// ListLabelsRes is the result from a call to ListLabels
+	ListLabelsRes struct { Res *[]string; e error }
 	// ListLockUnspentRes is the result from a call to ListLockUnspent
 	ListLockUnspentRes struct { Res *[]btcjson.TransactionInput; e error }
 	// ListReceivedByAccountRes is the result from a call to ListReceivedByAccount
@@ -123,6 +141,8 @@ type (
 	SendManyRes struct { Res *string; e error }
 	// SendToAddressRes is the result from a call to SendToAddress
 	SendToAddressRes struct { Res *string; e error }
+	// SetLabelRes is the result from a call to SetLabel
+	SetLabelRes struct { Res *None; e error }
 	// SetTxFeeRes is the result from a call to SetTxFee
 	SetTxFeeRes struct { Res *bool; e error }
 	// SignMessageRes is the result from a call to SignMessage
@@ -179,6 +199,12 @@ var RPCHandlers = map[string]struct {
 	"addmultisigaddress":{ 
 		Handler: AddMultiSigAddress, Call: make(chan API, 32),
 		Result: func() API { return API{Ch: make(chan AddMultiSigAddressRes)} }}, 
+	"backupwallet":{ 
+		Handler: BackupWallet, Call: make(chan API, 32),
+		Result: func() API { return API{Ch: make(chan BackupWalletRes)} }}, 
+	"bumpfee":{ 
+		Handler: BumpFee, Call: make(chan API, 32),
+		Result: func() API { return API{Ch: make(chan BumpFeeRes)} }}, 
 	"createmultisig":{ 
 		Handler: CreateMultiSig, Call: make(chan API, 32),
 		Result: func() API { return API{Ch: make(chan CreateMultiSigRes)} }}, 
@@ -191,6 +217,9 @@ var RPCHandlers = map[string]struct {
 	"dumpprivkey":{ 
 		Handler: DumpPrivKey, Call: make(chan API, 32),
 		Result: func() API { return API{Ch: make(chan DumpPrivKeyRes)} }}, 
+	"dumpwallet":{ 
+		Handler: DumpWallet, Call: make(chan API, 32),
+		Result: func() API { return API{Ch: make(chan DumpWalletRes)} }}, 
 	"getaccount":{ 
 		Handler: GetAccount, Call: make(chan API, 32),
 		Result: func() API { return API{Ch: make(chan GetAccountRes)} }}, 
@@ -200,6 +229,9 @@ var RPCHandlers = map[string]struct {
 	"getaddressesbyaccount":{ 
 		Handler: GetAddressesByAccount, Call: make(chan API, 32),
 		Result: func() API { return API{Ch: make(chan GetAddressesByAccountRes)} }}, 
+	"getaddressesbylabel":{ 
+		Handler: GetAddressesByLabel, Call: make(chan API, 32),
+		Result: func() API { return API{Ch: make(chan GetAddressesByLabelRes)} }}, 
 	"getbalance":{ 
 		Handler: GetBalance, Call: make(chan API, 32),
 		Result: func() API { return API{Ch: make(chan GetBalanceRes)} }}, 
@@ -233,24 +265,39 @@ var RPCHandlers = map[string]struct {
 	"getunconfirmedbalance":{ 
 		Handler: GetUnconfirmedBalance, Call: make(chan API, 32),
 		Result: func() API { return API{Ch: make(chan GetUnconfirmedBalanceRes)} }}, 
+	"getwalletinfo":{ 
+		Handler: GetWalletInfo, Call: make(chan API, 32),
+		Result: func() API { return API{Ch: make(chan GetWalletInfoRes)} }}, 
 	"help":{ 
 		Handler: HelpNoChainRPC, Call: make(chan API, 32),
 		Result: func() API { return API{Ch: make(chan HelpNoChainRPCRes)} }}, 
 	"importprivkey":{ 
 		Handler: ImportPrivKey, Call: make(chan API, 32),
 		Result: func() API { return API{Ch: make(chan ImportPrivKeyRes)} }}, 
+	"importwallet":{ 
+		Handler: ImportWallet, Call: make(chan API, 32),
+		Result: func() API { return API{Ch: make(chan ImportWalletRes)} }}, 
 	"keypoolrefill":{ 
 		Handler: KeypoolRefill, Call: make(chan API, 32),
 		Result: func() API { return API{Ch: make(chan KeypoolRefillRes)} }}, 
 	"listaccounts":{ 
 		Handler: ListAccounts, Call: make(chan API, 32),
 		Result: func() API { return API{Ch: make(chan ListAccountsRes)} }}, 
+	"listaddressgroupings":{ 
+		Handler: ListAddressGroupings, Call: make(chan API, 32),
+		Result: func() API { return API{Ch: make(chan ListAddressGroupingsRes)} }}, 
+	"listaddresslabels":{ 
+		Handler: ListAddressLabels, Call: make(chan API, 32),
+		Result: func() API { return API{Ch: make(chan ListAddressLabelsRes)} }}, 
 	"listaddresstransactions":{ 
 		Handler: ListAddressTransactions, Call: make(chan API, 32),
 		Result: func() API { return API{Ch: make(chan ListAddressTransactionsRes)} }}, 
 	"listalltransactions":{ 
 		Handler: ListAllTransactions, Call: make(chan API, 32),
 		Result: func() API { return API{Ch: make(chan ListAllTransactionsRes)} }}, 
+	"listlabels":{ 
+		Handler: ListLabels, Call: make(chan API, 32),
+		Result: func() API { return API{Ch: make(chan ListLabelsRes)} }}, 
 	"listlockunspent":{ 
 		Handler: ListLockUnspent, Call: make(chan API, 32),
 		Result: func() API { return API{Ch: make(chan ListLockUnspentRes)} }}, 
@@ -281,6 +328,9 @@ var RPCHandlers = map[string]struct {
 	"sendtoaddress":{ 
 		Handler: SendToAddress, Call: make(chan API, 32),
 		Result: func() API { return API{Ch: make(chan SendToAddressRes)} }}, 
+	"setlabel":{ 
+		Handler: SetLabel, Call: make(chan API, 32),
+		Result: func() API { return API{Ch: make(chan SetLabelRes)} }}, 
 	"settxfee":{ 
 		Handler: SetTxFee, Call: make(chan API, 32),
 		Result: func() API { return API{Ch: make(chan SetTxFeeRes)} }}, 
@@ -358,6 +408,88 @@ func (a API) AddMultiSigAddressWait(cmd *btcjson.AddMultisigAddressCmd) (out *st
 	return
 }
 
+// BackupWallet calls the method with the given parameters
+func (a API) BackupWallet(cmd *btcjson.BackupWalletCmd) (e error) {
+	RPCHandlers["backupwallet"].Call <- API{a.Ch, cmd, nil}
+	return
+}
+
+// BackupWalletCheck checks if a new message arrived on the result channel and returns true if it does, as well as 
+// storing the value in the Result field
+func (a API) BackupWalletCheck() (isNew bool) {
+	select {
+	case o := <- a.Ch.(chan BackupWalletRes):
+		if o.e != nil {
+			a.Result = o.e
+		} else {
+			a.Result = o.Res
+		}
+		isNew = true
+	default:
+	}
+	return
+}
+
+// BackupWalletGetRes returns a pointer to the value in the Result field
+func (a API) BackupWalletGetRes() (out *None, e error) {
+	out, _ = a.Result.(*None)
+	e, _ = a.Result.(error)
+	return 
+}
+
+// BackupWalletWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) BackupWalletWait(cmd *btcjson.BackupWalletCmd) (out *None, e error) {
+	RPCHandlers["backupwallet"].Call <- API{a.Ch, cmd, nil}
+	select {
+	case <-time.After(time.Second*5):
+		break
+	case o := <- a.Ch.(chan BackupWalletRes):
+		out, e = o.Res, o.e
+	}
+	return
+}
+
+// BumpFee calls the method with the given parameters
+func (a API) BumpFee(cmd *btcjson.BumpFeeCmd) (e error) {
+	RPCHandlers["bumpfee"].Call <- API{a.Ch, cmd, nil}
+	return
+}
+
+// BumpFeeCheck checks if a new message arrived on the result channel and returns true if it does, as well as 
+// storing the value in the Result field
+func (a API) BumpFeeCheck() (isNew bool) {
+	select {
+	case o := <- a.Ch.(chan BumpFeeRes):
+		if o.e != nil {
+			a.Result = o.e
+		} else {
+			a.Result = o.Res
+		}
+		isNew = true
+	default:
+	}
+	return
+}
+
+// BumpFeeGetRes returns a pointer to the value in the Result field
+func (a API) BumpFeeGetRes() (out *btcjson.BumpFeeResult, e error) {
+	out, _ = a.Result.(*btcjson.BumpFeeResult)
+	e, _ = a.Result.(error)
+	return 
+}
+
+// BumpFeeWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) BumpFeeWait(cmd *btcjson.BumpFeeCmd) (out *btcjson.BumpFeeResult, e error) {
+	RPCHandlers["bumpfee"].Call <- API{a.Ch, cmd, nil}
+	select {
+	case <-time.After(time.Second*5):
+		break
+	case o := <- a.Ch.(chan BumpFeeRes):
+		out, e = o.Res, o.e
+	}
+	return
+}
+
 // CreateMultiSig calls the method with the given parameters
 func (a API) CreateMultiSig(cmd *btcjson.CreateMultisigCmd) (e error) {
 	RPCHandlers["createmultisig"].Call <- API{a.Ch, cmd, nil}
@@ -522,6 +654,47 @@ func (a API) DumpPrivKeyWait(cmd *btcjson.DumpPrivKeyCmd) (out *string, e error)
 	return
 }
 
+// DumpWallet calls the method with the given parameters
+func (a API) DumpWallet(cmd *btcjson.DumpWalletCmd) (e error) {
+	RPCHandlers["dumpwallet"].Call <- API{a.Ch, cmd, nil}
+	return
+}
+
+// DumpWalletCheck checks if a new message arrived on the result channel and returns true if it does, as well as 
+// storing the value in the Result field
+func (a API) DumpWalletCheck() (isNew bool) {
+	select {
+	case o := <- a.Ch.(chan DumpWalletRes):
+		if o.e != nil {
+			a.Result = o.e
+		} else {
+			a.Result = o.Res
+		}
+		isNew = true
+	default:
+	}
+	return
+}
+
+// DumpWalletGetRes returns a pointer to the value in the Result field
+func (a API) DumpWalletGetRes() (out *btcjson.DumpWalletResult, e error) {
+	out, _ = a.Result.(*btcjson.DumpWalletResult)
+	e, _ = a.Result.(error)
+	return 
+}
+
+// DumpWalletWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) DumpWalletWait(cmd *btcjson.DumpWalletCmd) (out *btcjson.DumpWalletResult, e error) {
+	RPCHandlers["dumpwallet"].Call <- API{a.Ch, cmd, nil}
+	select {
+	case <-time.After(time.Second*5):
+		break
+	case o := <- a.Ch.(chan DumpWalletRes):
+		out, e = o.Res, o.e
+	}
+	return
+}
+
 // GetAccount calls the method with the given parameters
 func (a API) GetAccount(cmd *btcjson.GetAccountCmd) (e error) {
 	RPCHandlers["getaccount"].Call <- API{a.Ch, cmd, nil}
@@ -645,6 +818,47 @@ func (a API) GetAddressesByAccountWait(cmd *btcjson.GetAddressesByAccountCmd) (o
 	return
 }
 
+// GetAddressesByLabel calls the method with the given parameters
+func (a API) GetAddressesByLabel(cmd *btcjson.GetAddressesByLabelCmd) (e error) {
+	RPCHandlers["getaddressesbylabel"].Call <- API{a.Ch, cmd, nil}
+	return
+}
+
+// GetAddressesByLabelCheck checks if a new message arrived on the result channel and returns true if it does, as well as 
+// storing the value in the Result field
+func (a API) GetAddressesByLabelCheck() (isNew bool) {
+	select {
+	case o := <- a.Ch.(chan GetAddressesByLabelRes):
+		if o.e != nil {
+			a.Result = o.e
+		} else {
+			a.Result = o.Res
+		}
+		isNew = true
+	default:
+	}
+	return
+}
+
+// GetAddressesByLabelGetRes returns a pointer to the value in the Result field
+func (a API) GetAddressesByLabelGetRes() (out *map[string]btcjson.AddressPurposeResult, e error) {
+	out, _ = a.Result.(*map[string]btcjson.AddressPurposeResult)
+	e, _ = a.Result.(error)
+	return 
+}
+
+// GetAddressesByLabelWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) GetAddressesByLabelWait(cmd *btcjson.GetAddressesByLabelCmd) (out *map[string]btcjson.AddressPurposeResult, e error) {
+	RPCHandlers["getaddressesbylabel"].Call <- API{a.Ch, cmd, nil}
+	select {
+	case <-time.After(time.Second*5):
+		break
+	case o := <- a.Ch.(chan GetAddressesByLabelRes):
+		out, e = o.Res, o.e
+	}
+	return
+}
+
 // GetBalance calls the method with the given parameters
 func (a API) GetBalance(cmd *btcjson.GetBalanceCmd) (e error) {
 	RPCHandlers["getbalance"].Call <- API{a.Ch, cmd, nil}
@@ -1096,6 +1310,47 @@ func (a API) GetUnconfirmedBalanceWait(cmd *btcjson.GetUnconfirmedBalanceCmd) (o
 	return
 }
 
+// GetWalletInfo calls the method with the given parameters
+func (a API) GetWalletInfo(cmd *btcjson.GetWalletInfoCmd) (e error) {
+	RPCHandlers["getwalletinfo"].Call <- API{a.Ch, cmd, nil}
+	return
+}
+
+// GetWalletInfoCheck checks if a new message arrived on the result channel and returns true if it does, as well as 
+// storing the value in the Result field
+func (a API) GetWalletInfoCheck() (isNew bool) {
+	select {
+	case o := <- a.Ch.(chan GetWalletInfoRes):
+		if o.e != nil {
+			a.Result = o.e
+		} else {
+			a.Result = o.Res
+		}
+		isNew = true
+	default:
+	}
+	return
+}
+
+// GetWalletInfoGetRes returns a pointer to the value in the Result field
+func (a API) GetWalletInfoGetRes() (out *btcjson.GetWalletInfoResult, e error) {
+	out, _ = a.Result.(*btcjson.GetWalletInfoResult)
+	e, _ = a.Result.(error)
+	return 
+}
+
+// GetWalletInfoWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) GetWalletInfoWait(cmd *btcjson.GetWalletInfoCmd) (out *btcjson.GetWalletInfoResult, e error) {
+	RPCHandlers["getwalletinfo"].Call <- API{a.Ch, cmd, nil}
+	select {
+	case <-time.After(time.Second*5):
+		break
+	case o := <- a.Ch.(chan GetWalletInfoRes):
+		out, e = o.Res, o.e
+	}
+	return
+}
+
 // HelpNoChainRPC calls the method with the given parameters
 func (a API) HelpNoChainRPC(cmd btcjson.HelpCmd) (e error) {
 	RPCHandlers["help"].Call <- API{a.Ch, cmd, nil}
@@ -1178,6 +1433,47 @@ func (a API) ImportPrivKeyWait(cmd *btcjson.ImportPrivKeyCmd) (out *None, e erro
 	return
 }
 
+// ImportWallet calls the method with the given parameters
+func (a API) ImportWallet(cmd *btcjson.ImportWalletCmd) (e error) {
+	RPCHandlers["importwallet"].Call <- API{a.Ch, cmd, nil}
+	return
+}
+
+// ImportWalletCheck checks if a new message arrived on the result channel and returns true if it does, as well as 
+// storing the value in the Result field
+func (a API) ImportWalletCheck() (isNew bool) {
+	select {
+	case o := <- a.Ch.(chan ImportWalletRes):
+		if o.e != nil {
+			a.Result = o.e
+		} else {
+			a.Result = o.Res
+		}
+		isNew = true
+	default:
+	}
+	return
+}
+
+// ImportWalletGetRes returns a pointer to the value in the Result field
+func (a API) ImportWalletGetRes() (out *None, e error) {
+	out, _ = a.Result.(*None)
+	e, _ = a.Result.(error)
+	return 
+}
+
+// ImportWalletWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) ImportWalletWait(cmd *btcjson.ImportWalletCmd) (out *None, e error) {
+	RPCHandlers["importwallet"].Call <- API{a.Ch, cmd, nil}
+	select {
+	case <-time.After(time.Second*5):
+		break
+	case o := <- a.Ch.(chan ImportWalletRes):
+		out, e = o.Res, o.e
+	}
+	return
+}
+
 // KeypoolRefill calls the method with the given parameters
 func (a API) KeypoolRefill(cmd *None) (e error) {
 	RPCHandlers["keypoolrefill"].Call <- API{a.Ch, cmd, nil}
@@ -1260,6 +1556,88 @@ func (a API) ListAccountsWait(cmd *btcjson.ListAccountsCmd) (out *map[string]flo
 	return
 }
 
+// ListAddressGroupings calls the method with the given parameters
+func (a API) ListAddressGroupings(cmd *btcjson.ListAddressGroupingsCmd) (e error) {
+	RPCHandlers["listaddressgroupings"].Call <- API{a.Ch, cmd, nil}
+	return
+}
+
+// ListAddressGroupingsCheck checks if a new message arrived on the result channel and returns true if it does, as well as 
+// storing the value in the Result field
+func (a API) ListAddressGroupingsCheck() (isNew bool) {
+	select {
+	case o := <- a.Ch.(chan ListAddressGroupingsRes):
+		if o.e != nil {
+			a.Result = o.e
+		} else {
+			a.Result = o.Res
+		}
+		isNew = true
+	default:
+	}
+	return
+}
+
+// ListAddressGroupingsGetRes returns a pointer to the value in the Result field
+func (a API) ListAddressGroupingsGetRes() (out *[][]btcjson.AddressGroupingResult, e error) {
+	out, _ = a.Result.(*[][]btcjson.AddressGroupingResult)
+	e, _ = a.Result.(error)
+	return 
+}
+
+// ListAddressGroupingsWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) ListAddressGroupingsWait(cmd *btcjson.ListAddressGroupingsCmd) (out *[][]btcjson.AddressGroupingResult, e error) {
+	RPCHandlers["listaddressgroupings"].Call <- API{a.Ch, cmd, nil}
+	select {
+	case <-time.After(time.Second*5):
+		break
+	case o := <- a.Ch.(chan ListAddressGroupingsRes):
+		out, e = o.Res, o.e
+	}
+	return
+}
+
+// ListAddressLabels calls the method with the given parameters
+func (a API) ListAddressLabels(cmd *btcjson.ListAddressLabelsCmd) (e error) {
+	RPCHandlers["listaddresslabels"].Call <- API{a.Ch, cmd, nil}
+	return
+}
+
+// ListAddressLabelsCheck checks if a new message arrived on the result channel and returns true if it does, as well as 
+// storing the value in the Result field
+func (a API) ListAddressLabelsCheck() (isNew bool) {
+	select {
+	case o := <- a.Ch.(chan ListAddressLabelsRes):
+		if o.e != nil {
+			a.Result = o.e
+		} else {
+			a.Result = o.Res
+		}
+		isNew = true
+	default:
+	}
+	return
+}
+
+// ListAddressLabelsGetRes returns a pointer to the value in the Result field
+func (a API) ListAddressLabelsGetRes() (out *map[string]btcjson.AddressLabelResult, e error) {
+	out, _ = a.Result.(*map[string]btcjson.AddressLabelResult)
+	e, _ = a.Result.(error)
+	return 
+}
+
+// ListAddressLabelsWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) ListAddressLabelsWait(cmd *btcjson.ListAddressLabelsCmd) (out *map[string]btcjson.AddressLabelResult, e error) {
+	RPCHandlers["listaddresslabels"].Call <- API{a.Ch, cmd, nil}
+	select {
+	case <-time.After(time.Second*5):
+		break
+	case o := <- a.Ch.(chan ListAddressLabelsRes):
+		out, e = o.Res, o.e
+	}
+	return
+}
+
 // ListAddressTransactions calls the method with the given parameters
 func (a API) ListAddressTransactions(cmd *btcjson.ListAddressTransactionsCmd) (e error) {
 	RPCHandlers["listaddresstransactions"].Call <- API{a.Ch, cmd, nil}
@@ -1342,6 +1720,47 @@ func (a API) ListAllTransactionsWait(cmd *btcjson.ListAllTransactionsCmd) (out *
 	return
 }
 
+// ListLabels calls the method with the given parameters
+func (a API) ListLabels(cmd *btcjson.ListLabelsCmd) (e error) {
+	RPCHandlers["listlabels"].Call <- API{a.Ch, cmd, nil}
+	return
+}
+
+// ListLabelsCheck checks if a new message arrived on the result channel and returns true if it does, as well as 
+// storing the value in the Result field
+func (a API) ListLabelsCheck() (isNew bool) {
+	select {
+	case o := <- a.Ch.(chan ListLabelsRes):
+		if o.e != nil {
+			a.Result = o.e
+		} else {
+			a.Result = o.Res
+		}
+		isNew = true
+	default:
+	}
+	return
+}
+
+// ListLabelsGetRes returns a pointer to the value in the Result field
+func (a API) ListLabelsGetRes() (out *[]string, e error) {
+	out, _ = a.Result.(*[]string)
+	e, _ = a.Result.(error)
+	return 
+}
+
+// ListLabelsWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) ListLabelsWait(cmd *btcjson.ListLabelsCmd) (out *[]string, e error) {
+	RPCHandlers["listlabels"].Call <- API{a.Ch, cmd, nil}
+	select {
+	case <-time.After(time.Second*5):
+		break
+	case o := <- a.Ch.(chan ListLabelsRes):
+		out, e = o.Res, o.e
+	}
+	return
+}
+
 // ListLockUnspent calls the method with the given parameters
 func (a API) ListLockUnspent(cmd *None) (e error) {
 	RPCHandlers["listlockunspent"].Call <- API{a.Ch, cmd, nil}
@@ -1752,6 +2171,47 @@ func (a API) SendToAddressWait(cmd *btcjson.SendToAddressCmd) (out *string, e er
 	return
 }
 
+// SetLabel calls the method with the given parameters
+func (a API) SetLabel(cmd *btcjson.SetLabelCmd) (e error) {
+	RPCHandlers["setlabel"].Call <- API{a.Ch, cmd, nil}
+	return
+}
+
+// SetLabelCheck checks if a new message arrived on the result channel and returns true if it does, as well as 
+// storing the value in the Result field
+func (a API) SetLabelCheck() (isNew bool) {
+	select {
+	case o := <- a.Ch.(chan SetLabelRes):
+		if o.e != nil {
+			a.Result = o.e
+		} else {
+			a.Result = o.Res
+		}
+		isNew = true
+	default:
+	}
+	return
+}
+
+// SetLabelGetRes returns a pointer to the value in the Result field
+func (a API) SetLabelGetRes() (out *None, e error) {
+	out, _ = a.Result.(*None)
+	e, _ = a.Result.(error)
+	return 
+}
+
+// SetLabelWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) SetLabelWait(cmd *btcjson.SetLabelCmd) (out *None, e error) {
+	RPCHandlers["setlabel"].Call <- API{a.Ch, cmd, nil}
+	select {
+	case <-time.After(time.Second*5):
+		break
+	case o := <- a.Ch.(chan SetLabelRes):
+		out, e = o.Res, o.e
+	}
+	return
+}
+
 // SetTxFee calls the method with the given parameters
 func (a API) SetTxFee(cmd *btcjson.SetTxFeeCmd) (e error) {
 	RPCHandlers["settxfee"].Call <- API{a.Ch, cmd, nil}
@@ -2141,6 +2601,20 @@ func RunAPI(chainRPC *chainclient.RPCClient, wallet *Wallet,
 				}
 				if r, ok := res.(string); ok { 
 					msg.Ch.(chan AddMultiSigAddressRes) <- AddMultiSigAddressRes{&r, e} } 
+			case msg := <-nrh["backupwallet"].Call:
+				if res, e = nrh["backupwallet"].
+					Handler(msg.Params.(*btcjson.BackupWalletCmd), wallet, 
+						chainRPC); E.Chk(e) {
+				}
+				if r, ok := res.(None); ok { 
+					msg.Ch.(chan BackupWalletRes) <- BackupWalletRes{&r, e} } 
+			case msg := <-nrh["bumpfee"].Call:
+				if res, e = nrh["bumpfee"].
+					Handler(msg.Params.(*btcjson.BumpFeeCmd), wallet, 
+						chainRPC); E.Chk(e) {
+				}
+				if r, ok := res.(btcjson.BumpFeeResult); ok { 
+					msg.Ch.(chan BumpFeeRes) <- BumpFeeRes{&r, e} } 
 			case msg := <-nrh["createmultisig"].Call:
 				if res, e = nrh["createmultisig"].
 					Handler(msg.Params.(*btcjson.CreateMultisigCmd), wallet, 
@@ -2169,6 +2643,13 @@ func RunAPI(chainRPC *chainclient.RPCClient, wallet *Wallet,
 				}
 				if r, ok := res.(string); ok { 
 					msg.Ch.(chan DumpPrivKeyRes) <- DumpPrivKeyRes{&r, e} } 
+			case msg := <-nrh["dumpwallet"].Call:
+				if res, e = nrh["dumpwallet"].
+					Handler(msg.Params.(*btcjson.DumpWalletCmd), wallet, 
+						chainRPC); E.Chk(e) {
+				}
+				if r, ok := res.(btcjson.DumpWalletResult); ok { 
+					msg.Ch.(chan DumpWalletRes) <- DumpWalletRes{&r, e} } 
 			case msg := <-nrh["getaccount"].Call:
 				if res, e = nrh["getaccount"].
 					Handler(msg.Params.(*btcjson.GetAccountCmd), wallet, 
@@ -2190,6 +2671,13 @@ func RunAPI(chainRPC *chainclient.RPCClient, wallet *Wallet,
 				}
 				if r, ok := res.([]string); ok { 
 					msg.Ch.(chan GetAddressesByAccountRes) <- GetAddressesByAccountRes{&r, e} } 
+			case msg := <-nrh["getaddressesbylabel"].Call:
+				if res, e = nrh["getaddressesbylabel"].
+					Handler(msg.Params.(*btcjson.GetAddressesByLabelCmd), wallet, 
+						chainRPC); E.Chk(e) {
+				}
+				if r, ok := res.(map[string]btcjson.AddressPurposeResult); ok { 
+					msg.Ch.(chan GetAddressesByLabelRes) <- GetAddressesByLabelRes{&r, e} } 
 			case msg := <-nrh["getbalance"].Call:
 				if res, e = nrh["getbalance"].
 					Handler(msg.Params.(*btcjson.GetBalanceCmd), wallet, 
@@ -2267,6 +2755,13 @@ func RunAPI(chainRPC *chainclient.RPCClient, wallet *Wallet,
 				}
 				if r, ok := res.(float64); ok { 
 					msg.Ch.(chan GetUnconfirmedBalanceRes) <- GetUnconfirmedBalanceRes{&r, e} } 
+			case msg := <-nrh["getwalletinfo"].Call:
+				if res, e = nrh["getwalletinfo"].
+					Handler(msg.Params.(*btcjson.GetWalletInfoCmd), wallet, 
+						chainRPC); E.Chk(e) {
+				}
+				if r, ok := res.(btcjson.GetWalletInfoResult); ok { 
+					msg.Ch.(chan GetWalletInfoRes) <- GetWalletInfoRes{&r, e} } 
 			case msg := <-nrh["help"].Call:
 				if res, e = nrh["help"].
 					Handler(msg.Params.(btcjson.HelpCmd), wallet, 
@@ -2281,6 +2776,13 @@ func RunAPI(chainRPC *chainclient.RPCClient, wallet *Wallet,
 				}
 				if r, ok := res.(None); ok { 
 					msg.Ch.(chan ImportPrivKeyRes) <- ImportPrivKeyRes{&r, e} } 
+			case msg := <-nrh["importwallet"].Call:
+				if res, e = nrh["importwallet"].
+					Handler(msg.Params.(*btcjson.ImportWalletCmd), wallet, 
+						chainRPC); E.Chk(e) {
+				}
+				if r, ok := res.(None); ok { 
+					msg.Ch.(chan ImportWalletRes) <- ImportWalletRes{&r, e} } 
 			case msg := <-nrh["keypoolrefill"].Call:
 				if res, e = nrh["keypoolrefill"].
 					Handler(msg.Params.(*None), wallet, 
@@ -2295,6 +2797,20 @@ func RunAPI(chainRPC *chainclient.RPCClient, wallet *Wallet,
 				}
 				if r, ok := res.(map[string]float64); ok { 
 					msg.Ch.(chan ListAccountsRes) <- ListAccountsRes{&r, e} } 
+			case msg := <-nrh["listaddressgroupings"].Call:
+				if res, e = nrh["listaddressgroupings"].
+					Handler(msg.Params.(*btcjson.ListAddressGroupingsCmd), wallet, 
+						chainRPC); E.Chk(e) {
+				}
+				if r, ok := res.([][]btcjson.AddressGroupingResult); ok { 
+					msg.Ch.(chan ListAddressGroupingsRes) <- ListAddressGroupingsRes{&r, e} } 
+			case msg := <-nrh["listaddresslabels"].Call:
+				if res, e = nrh["listaddresslabels"].
+					Handler(msg.Params.(*btcjson.ListAddressLabelsCmd), wallet, 
+						chainRPC); E.Chk(e) {
+				}
+				if r, ok := res.(map[string]btcjson.AddressLabelResult); ok { 
+					msg.Ch.(chan ListAddressLabelsRes) <- ListAddressLabelsRes{&r, e} } 
 			case msg := <-nrh["listaddresstransactions"].Call:
 				if res, e = nrh["listaddresstransactions"].
 					Handler(msg.Params.(*btcjson.ListAddressTransactionsCmd), wallet, 
@@ -2309,6 +2825,13 @@ func RunAPI(chainRPC *chainclient.RPCClient, wallet *Wallet,
 				}
 				if r, ok := res.([]btcjson.ListTransactionsResult); ok { 
 					msg.Ch.(chan ListAllTransactionsRes) <- ListAllTransactionsRes{&r, e} } 
+			case msg := <-nrh["listlabels"].Call:
+				if res, e = nrh["listlabels"].
+					Handler(msg.Params.(*btcjson.ListLabelsCmd), wallet, 
+						chainRPC); E.Chk(e) {
+				}
+				if r, ok := res.([]string); ok { 
+					msg.Ch.(chan ListLabelsRes) <- ListLabelsRes{&r, e} } 
 			case msg := <-nrh["listlockunspent"].Call:
 				if res, e = nrh["listlockunspent"].
 					Handler(msg.Params.(*None), wallet, 
@@ -2379,6 +2902,13 @@ func RunAPI(chainRPC *chainclient.RPCClient, wallet *Wallet,
 				}
 				if r, ok := res.(string); ok { 
 					msg.Ch.(chan SendToAddressRes) <- SendToAddressRes{&r, e} } 
+			case msg := <-nrh["setlabel"].Call:
+				if res, e = nrh["setlabel"].
+					Handler(msg.Params.(*btcjson.SetLabelCmd), wallet, 
+						chainRPC); E.Chk(e) {
+				}
+				if r, ok := res.(None); ok { 
+					msg.Ch.(chan SetLabelRes) <- SetLabelRes{&r, e} } 
 			case msg := <-nrh["settxfee"].Call:
 				if res, e = nrh["settxfee"].
 					Handler(msg.Params.(*btcjson.SetTxFeeCmd), wallet, 
@@ -2465,6 +2995,32 @@ func (c *CAPI) AddMultiSigAddress(req *btcjson.AddMultisigAddressCmd, resp strin
 	return 
 }
 
+func (c *CAPI) BackupWallet(req *btcjson.BackupWalletCmd, resp None) (e error) {
+	nrh := RPCHandlers
+	res := nrh["backupwallet"].Result()
+	res.Params = req
+	nrh["backupwallet"].Call <- res
+	select {
+	case resp = <-res.Ch.(chan None):
+	case <-time.After(c.Timeout):
+	case <-c.quit.Wait():
+	} 
+	return 
+}
+
+func (c *CAPI) BumpFee(req *btcjson.BumpFeeCmd, resp btcjson.BumpFeeResult) (e error) {
+	nrh := RPCHandlers
+	res := nrh["bumpfee"].Result()
+	res.Params = req
+	nrh["bumpfee"].Call <- res
+	select {
+	case resp = <-res.Ch.(chan btcjson.BumpFeeResult):
+	case <-time.After(c.Timeout):
+	case <-c.quit.Wait():
+	} 
+	return 
+}
+
 func (c *CAPI) CreateMultiSig(req *btcjson.CreateMultisigCmd, resp btcjson.CreateMultiSigResult) (e error) {
 	nrh := RPCHandlers
 	res := nrh["createmultisig"].Result()
@@ -2517,6 +3073,19 @@ func (c *CAPI) DumpPrivKey(req *btcjson.DumpPrivKeyCmd, resp string) (e error) {
 	return 
 }
 
+func (c *CAPI) DumpWallet(req *btcjson.DumpWalletCmd, resp btcjson.DumpWalletResult) (e error) {
+	nrh := RPCHandlers
+	res := nrh["dumpwallet"].Result()
+	res.Params = req
+	nrh["dumpwallet"].Call <- res
+	select {
+	case resp = <-res.Ch.(chan btcjson.DumpWalletResult):
+	case <-time.After(c.Timeout):
+	case <-c.quit.Wait():
+	} 
+	return 
+}
+
 func (c *CAPI) GetAccount(req *btcjson.GetAccountCmd, resp string) (e error) {
 	nrh := RPCHandlers
 	res := nrh["getaccount"].Result()
@@ -2556,6 +3125,19 @@ func (c *CAPI) GetAddressesByAccount(req *btcjson.GetAddressesByAccountCmd, resp
 	return 
 }
 
+func (c *CAPI) GetAddressesByLabel(req *btcjson.GetAddressesByLabelCmd, resp map[string]btcjson.AddressPurposeResult) (e error) {
+	nrh := RPCHandlers
+	res := nrh["getaddressesbylabel"].Result()
+	res.Params = req
+	nrh["getaddressesbylabel"].Call <- res
+	select {
+	case resp = <-res.Ch.(chan map[string]btcjson.AddressPurposeResult):
+	case <-time.After(c.Timeout):
+	case <-c.quit.Wait():
+	} 
+	return 
+}
+
 func (c *CAPI) GetBalance(req *btcjson.GetBalanceCmd, resp float64) (e error) {
 	nrh := RPCHandlers
 	res := nrh["getbalance"].Result()
@@ -2699,6 +3281,19 @@ func (c *CAPI) GetUnconfirmedBalance(req *btcjson.GetUnconfirmedBalanceCmd, resp
 	return 
 }
 
+func (c *CAPI) GetWalletInfo(req *btcjson.GetWalletInfoCmd, resp btcjson.GetWalletInfoResult) (e error) {
+	nrh := RPCHandlers
+	res := nrh["getwalletinfo"].Result()
+	res.Params = req
+	nrh["getwalletinfo"].Call <- res
+	select {
+	case resp = <-res.Ch.(chan btcjson.GetWalletInfoResult):
+	case <-time.After(c.Timeout):
+	case <-c.quit.Wait():
+	} 
+	return 
+}
+
 func (c *CAPI) HelpNoChainRPC(req btcjson.HelpCmd, resp string) (e error) {
 	nrh := RPCHandlers
 	res := nrh["help"].Result()
@@ -2725,6 +3320,19 @@ func (c *CAPI) ImportPrivKey(req *btcjson.ImportPrivKeyCmd, resp None) (e error)
 	return 
 }
 
+func (c *CAPI) ImportWallet(req *btcjson.ImportWalletCmd, resp None) (e error) {
+	nrh := RPCHandlers
+	res := nrh["importwallet"].Result()
+	res.Params = req
+	nrh["importwallet"].Call <- res
+	select {
+	case resp = <-res.Ch.(chan None):
+	case <-time.After(c.Timeout):
+	case <-c.quit.Wait():
+	} 
+	return 
+}
+
 func (c *CAPI) KeypoolRefill(req *None, resp None) (e error) {
 	nrh := RPCHandlers
 	res := nrh["keypoolrefill"].Result()
@@ -2751,6 +3359,32 @@ func (c *CAPI) ListAccounts(req *btcjson.ListAccountsCmd, resp map[string]float6
 	return 
 }
 
+func (c *CAPI) ListAddressGroupings(req *btcjson.ListAddressGroupingsCmd, resp [][]btcjson.AddressGroupingResult) (e error) {
+	nrh := RPCHandlers
+	res := nrh["listaddressgroupings"].Result()
+	res.Params = req
+	nrh["listaddressgroupings"].Call <- res
+	select {
+	case resp = <-res.Ch.(chan [][]btcjson.AddressGroupingResult):
+	case <-time.After(c.Timeout):
+	case <-c.quit.Wait():
+	} 
+	return 
+}
+
+func (c *CAPI) ListAddressLabels(req *btcjson.ListAddressLabelsCmd, resp map[string]btcjson.AddressLabelResult) (e error) {
+	nrh := RPCHandlers
+	res := nrh["listaddresslabels"].Result()
+	res.Params = req
+	nrh["listaddresslabels"].Call <- res
+	select {
+	case resp = <-res.Ch.(chan map[string]btcjson.AddressLabelResult):
+	case <-time.After(c.Timeout):
+	case <-c.quit.Wait():
+	} 
+	return 
+}
+
 func (c *CAPI) ListAddressTransactions(req *btcjson.ListAddressTransactionsCmd, resp []btcjson.ListTransactionsResult) (e error) {
 	nrh := RPCHandlers
 	res := nrh["listaddresstransactions"].Result()
@@ -2777,6 +3411,19 @@ func (c *CAPI) ListAllTransactions(req *btcjson.ListAllTransactionsCmd, resp []b
 	return 
 }
 
+func (c *CAPI) ListLabels(req *btcjson.ListLabelsCmd, resp []string) (e error) {
+	nrh := RPCHandlers
+	res := nrh["listlabels"].Result()
+	res.Params = req
+	nrh["listlabels"].Call <- res
+	select {
+	case resp = <-res.Ch.(chan []string):
+	case <-time.After(c.Timeout):
+	case <-c.quit.Wait():
+	} 
+	return 
+}
+
 func (c *CAPI) ListLockUnspent(req *None, resp []btcjson.TransactionInput) (e error) {
 	nrh := RPCHandlers
 	res := nrh["listlockunspent"].Result()
@@ -2907,6 +3554,19 @@ func (c *CAPI) SendToAddress(req *btcjson.SendToAddressCmd, resp string) (e erro
 	return 
 }
 
+func (c *CAPI) SetLabel(req *btcjson.SetLabelCmd, resp None) (e error) {
+	nrh := RPCHandlers
+	res := nrh["setlabel"].Result()
+	res.Params = req
+	nrh["setlabel"].Call <- res
+	select {
+	case resp = <-res.Ch.(chan None):
+	case <-time.After(c.Timeout):
+	case <-c.quit.Wait():
+	} 
+	return 
+}
+
 func (c *CAPI) SetTxFee(req *btcjson.SetTxFeeCmd, resp bool) (e error) {
 	nrh := RPCHandlers
 	res := nrh["settxfee"].Result()
@@ -3036,6 +3696,26 @@ func (r *CAPIClient) AddMultiSigAddress(cmd ...*btcjson.AddMultisigAddressCmd) (
 	return
 }
 
+func (r *CAPIClient) BackupWallet(cmd ...*btcjson.BackupWalletCmd) (res None, e error) {
+	var c *btcjson.BackupWalletCmd
+	if len(cmd) > 0 {
+		c = cmd[0]
+	}
+	if e = r.Call("CAPI.BackupWallet", c, &res); E.Chk(e) {
+	}
+	return
+}
+
+func (r *CAPIClient) BumpFee(cmd ...*btcjson.BumpFeeCmd) (res btcjson.BumpFeeResult, e error) {
+	var c *btcjson.BumpFeeCmd
+	if len(cmd) > 0 {
+		c = cmd[0]
+	}
+	if e = r.Call("CAPI.BumpFee", c, &res); E.Chk(e) {
+	}
+	return
+}
+
 func (r *CAPIClient) CreateMultiSig(cmd ...*btcjson.CreateMultisigCmd) (res btcjson.CreateMultiSigResult, e error) {
 	var c *btcjson.CreateMultisigCmd
 	if len(cmd) > 0 {
@@ -3076,6 +3756,16 @@ func (r *CAPIClient) DumpPrivKey(cmd ...*btcjson.DumpPrivKeyCmd) (res string, e
 	return
 }
 
+func (r *CAPIClient) DumpWallet(cmd ...*btcjson.DumpWalletCmd) (res btcjson.DumpWalletResult, e error) {
+	var c *btcjson.DumpWalletCmd
+	if len(cmd) > 0 {
+		c = cmd[0]
+	}
+	if e = r.Call("CAPI.DumpWallet", c, &res); E.Chk(e) {
+	}
+	return
+}
+
 func (r *CAPIClient) GetAccount(cmd ...*btcjson.GetAccountCmd) (res string, e error) {
 	var c *btcjson.GetAccountCmd
 	if len(cmd) > 0 {
@@ -3106,6 +3796,16 @@ func (r *CAPIClient) GetAddressesByAccount(cmd ...*btcjson.GetAddressesByAccount
 	return
 }
 
+func (r *CAPIClient) GetAddressesByLabel(cmd ...*btcjson.GetAddressesByLabelCmd) (res map[string]btcjson.AddressPurposeResult, e error) {
+	var c *btcjson.GetAddressesByLabelCmd
+	if len(cmd) > 0 {
+		c = cmd[0]
+	}
+	if e = r.Call("CAPI.GetAddressesByLabel", c, &res); E.Chk(e) {
+	}
+	return
+}
+
 func (r *CAPIClient) GetBalance(cmd ...*btcjson.GetBalanceCmd) (res float64, e error) {
 	var c *btcjson.GetBalanceCmd
 	if len(cmd) > 0 {
@@ -3216,6 +3916,16 @@ func (r *CAPIClient) GetUnconfirmedBalance(cmd ...*btcjson.GetUnconfirmedBalance
 	return
 }
 
+func (r *CAPIClient) GetWalletInfo(cmd ...*btcjson.GetWalletInfoCmd) (res btcjson.GetWalletInfoResult, e error) {
+	var c *btcjson.GetWalletInfoCmd
+	if len(cmd) > 0 {
+		c = cmd[0]
+	}
+	if e = r.Call("CAPI.GetWalletInfo", c, &res); E.Chk(e) {
+	}
+	return
+}
+
 func (r *CAPIClient) HelpNoChainRPC(cmd ...btcjson.HelpCmd) (res string, e error) {
 	var c btcjson.HelpCmd
 	if len(cmd) > 0 {
@@ -3236,6 +3946,16 @@ func (r *CAPIClient) ImportPrivKey(cmd ...*btcjson.ImportPrivKeyCmd) (res None,
 	return
 }
 
+func (r *CAPIClient) ImportWallet(cmd ...*btcjson.ImportWalletCmd) (res None, e error) {
+	var c *btcjson.ImportWalletCmd
+	if len(cmd) > 0 {
+		c = cmd[0]
+	}
+	if e = r.Call("CAPI.ImportWallet", c, &res); E.Chk(e) {
+	}
+	return
+}
+
 func (r *CAPIClient) KeypoolRefill(cmd ...*None) (res None, e error) {
 	var c *None
 	if len(cmd) > 0 {
@@ -3256,6 +3976,26 @@ func (r *CAPIClient) ListAccounts(cmd ...*btcjson.ListAccountsCmd) (res map[stri
 	return
 }
 
+func (r *CAPIClient) ListAddressGroupings(cmd ...*btcjson.ListAddressGroupingsCmd) (res [][]btcjson.AddressGroupingResult, e error) {
+	var c *btcjson.ListAddressGroupingsCmd
+	if len(cmd) > 0 {
+		c = cmd[0]
+	}
+	if e = r.Call("CAPI.ListAddressGroupings", c, &res); E.Chk(e) {
+	}
+	return
+}
+
+func (r *CAPIClient) ListAddressLabels(cmd ...*btcjson.ListAddressLabelsCmd) (res map[string]btcjson.AddressLabelResult, e error) {
+	var c *btcjson.ListAddressLabelsCmd
+	if len(cmd) > 0 {
+		c = cmd[0]
+	}
+	if e = r.Call("CAPI.ListAddressLabels", c, &res); E.Chk(e) {
+	}
+	return
+}
+
 func (r *CAPIClient) ListAddressTransactions(cmd ...*btcjson.ListAddressTransactionsCmd) (res []btcjson.ListTransactionsResult, e error) {
 	var c *btcjson.ListAddressTransactionsCmd
 	if len(cmd) > 0 {
@@ -3276,6 +4016,16 @@ func (r *CAPIClient) ListAllTransactions(cmd ...*btcjson.ListAllTransactionsCmd)
 	return
 }
 
+func (r *CAPIClient) ListLabels(cmd ...*btcjson.ListLabelsCmd) (res []string, e error) {
+	var c *btcjson.ListLabelsCmd
+	if len(cmd) > 0 {
+		c = cmd[0]
+	}
+	if e = r.Call("CAPI.ListLabels", c, &res); E.Chk(e) {
+	}
+	return
+}
+
 func (r *CAPIClient) ListLockUnspent(cmd ...*None) (res []btcjson.TransactionInput, e error) {
 	var c *None
 	if len(cmd) > 0 {
@@ -3376,6 +4126,16 @@ func (r *CAPIClient) SendToAddress(cmd ...*btcjson.SendToAddressCmd) (res string
 	return
 }
 
+func (r *CAPIClient) SetLabel(cmd ...*btcjson.SetLabelCmd) (res None, e error) {
+	var c *btcjson.SetLabelCmd
+	if len(cmd) > 0 {
+		c = cmd[0]
+	}
+	if e = r.Call("CAPI.SetLabel", c, &res); E.Chk(e) {
+	}
+	return
+}
+
 func (r *CAPIClient) SetTxFee(cmd ...*btcjson.SetTxFeeCmd) (res bool, e error) {
 	var c *btcjson.SetTxFeeCmd
 	if len(cmd) > 0 {
@@ -275,7 +275,11 @@ func (s *Server) HandlerClosure(request *btcjson.Request) LazyHandler {
 		D.Ln("HandlerClosure got the ChainClient")
 	}
 	s.HandlerMutex.Unlock()
-	return LazyApplyHandler(request, wllt, chainClient)
+	handler := LazyApplyHandler(request, wllt, chainClient)
+	return func() (interface{}, *btcjson.RPCError) {
+		defer rpcDuration.Since(request.Method, time.Now())
+		return handler()
+	}
 }
 
 // ErrNoAuth represents an error where authentication could not succeed due to a
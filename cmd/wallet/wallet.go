@@ -57,6 +57,7 @@ var ErrNotSynced = errors.New("wallet is not synchronized with the chain server"
 var (
 	waddrmgrNamespaceKey = []byte("waddrmgr")
 	wtxmgrNamespaceKey   = []byte("wtxmgr")
+	labelsNamespaceKey   = []byte("labels")
 )
 
 // Wallet is a structure containing all the components for a complete wallet. It contains the Armory-style key store
@@ -414,7 +415,7 @@ func (w *Wallet) syncWithChain() (e error) {
 		}
 		I.Ln("startHeight", startHeight, "bestHeight", bestHeight)
 		for height := startHeight; height <= bestHeight; height++ {
-			I.Ln("current height", height)
+			T.Ln("current height", height)
 			var hash *chainhash.Hash
 			if hash, e = chainClient.GetBlockHash(int64(height)); E.Chk(e) {
 				if e = tx.Rollback(); E.Chk(e) {
@@ -502,6 +503,7 @@ func (w *Wallet) syncWithChain() (e error) {
 				}
 				// Clear the batch of all processed blocks.
 				recoveryMgr.ResetBlockBatch()
+				logRecoveryProgress(height, bestHeight, recoveryMgr.State())
 			}
 			// Every 10K blocks, commit and start a new database TX.
 			if height%10000 == 0 {
@@ -536,6 +538,7 @@ func (w *Wallet) syncWithChain() (e error) {
 				}
 				return e
 			}
+			logRecoveryProgress(bestHeight, bestHeight, recoveryMgr.State())
 		}
 		// Commit (or roll back) the final database transaction.
 		if e = tx.Commit(); E.Chk(e) {
@@ -936,6 +939,20 @@ func extendFoundAddresses(
 	return nil
 }
 
+// logRecoveryProgress reports how far the search of the chain for the used addresses of a recovering wallet has
+// progressed, and how many addresses have been found so far.
+func logRecoveryProgress(height, bestHeight int32, state *RecoveryState) {
+	var progress float64 = 100
+	if bestHeight > 0 {
+		progress = float64(height) * 100 / float64(bestHeight)
+	}
+	external, internal := state.NumFound()
+	I.F(
+		"recovery scanned to height %d of %d (%.1f%%), found %d receiving and %d change addresses",
+		height, bestHeight, progress, external, internal,
+	)
+}
+
 // logFilterBlocksResp provides useful logging information when filtering succeeded in finding relevant transactions.
 func logFilterBlocksResp(
 	block wtxmgr.BlockMeta,
@@ -979,7 +996,11 @@ type (
 		outputs     []*wire.TxOut
 		minconf     int32
 		feeSatPerKB amt.Amount
-		resp        chan createTxResponse
+		replaceable bool
+		// replaces is the hash of an unmined transaction that the created transaction replaces with a higher fee, in
+		// which case outputs is not used.
+		replaces *chainhash.Hash
+		resp     chan createTxResponse
 	}
 	createTxResponse struct {
 		tx *txauthor.AuthoredTx
@@ -1007,10 +1028,17 @@ out:
 				continue
 			}
 			var tx *txauthor.AuthoredTx
-			tx, e = w.txToOutputs(
-				txr.outputs, txr.account,
-				txr.minconf, txr.feeSatPerKB,
-			)
+			if txr.replaces != nil {
+				tx, e = w.bumpTx(
+					txr.replaces, txr.account,
+					txr.minconf, txr.feeSatPerKB,
+				)
+			} else {
+				tx, e = w.txToOutputs(
+					txr.outputs, txr.account,
+					txr.minconf, txr.feeSatPerKB, txr.replaceable,
+				)
+			}
 			h.release()
 			txr.resp <- createTxResponse{tx, e}
 		case <-quit.Wait():
@@ -1022,17 +1050,19 @@ out:
 
 // CreateSimpleTx creates a new signed transaction spending unspent P2PKH outputs with at least minconf confirmations
 // spending to any number of address/amount pairs. Change and an appropriate transaction fee are automatically included,
-// if necessary. All transaction creation through this function is serialized to prevent the creation of many
-// transactions which spend the same outputs.
+// if necessary. If replaceable is set the transaction signals that it can be replaced by one paying a higher fee
+// (BIP125). All transaction creation through this function is serialized to prevent the creation of many transactions
+// which spend the same outputs.
 func (w *Wallet) CreateSimpleTx(
 	account uint32, outputs []*wire.TxOut,
-	minconf int32, satPerKb amt.Amount,
+	minconf int32, satPerKb amt.Amount, replaceable bool,
 ) (*txauthor.AuthoredTx, error) {
 	req := createTxRequest{
 		account:     account,
 		outputs:     outputs,
 		minconf:     minconf,
 		feeSatPerKB: satPerKb,
+		replaceable: replaceable,
 		resp:        make(chan createTxResponse),
 	}
 	w.createTxRequests <- req
@@ -1371,6 +1401,35 @@ func (w *Wallet) CalculateAccountBalances(
 	return bals, e
 }
 
+// CalculateBalances sums the amounts of all unspent transaction outputs of the wallet, splitting them the same way as
+// CalculateAccountBalances does for a single account.
+func (w *Wallet) CalculateBalances(confirms int32) (bals Balances, e error) {
+	e = walletdb.View(
+		w.db, func(tx walletdb.ReadTx) (e error) {
+			txmgrNs := tx.ReadBucket(wtxmgrNamespaceKey)
+			syncBlock := w.Manager.SyncedTo()
+			var unspent []wtxmgr.Credit
+			if unspent, e = w.TxStore.UnspentOutputs(txmgrNs); E.Chk(e) {
+				return e
+			}
+			for i := range unspent {
+				output := &unspent[i]
+				bals.Total += output.Amount
+				if output.FromCoinBase && !confirmed(
+					int32(w.chainParams.CoinbaseMaturity),
+					output.Height, syncBlock.Height,
+				) {
+					bals.ImmatureReward += output.Amount
+				} else if confirmed(confirms, output.Height, syncBlock.Height) {
+					bals.Spendable += output.Amount
+				}
+			}
+			return nil
+		},
+	)
+	return bals, e
+}
+
 // CurrentAddress gets the most recently requested Bitcoin payment address from a wallet for a particular key-chain
 // scope. If the address has already been used (there is at least one transaction spending to it in the blockchain or
 // pod mempool), the next chained address is returned.
@@ -1693,6 +1752,7 @@ func listTransactions(
 	syncHeight int32, net *chaincfg.Params,
 ) []btcjson.ListTransactionsResult {
 	addrmgrNs := tx.ReadBucket(waddrmgrNamespaceKey)
+	labelsNs := tx.ReadBucket(labelsNamespaceKey)
 	var (
 		blockHashStr  string
 		blockTime     int64
@@ -1705,6 +1765,7 @@ func listTransactions(
 	}
 	results := []btcjson.ListTransactionsResult{}
 	txHashStr := details.Hash.String()
+	txLabel := fetchLabel(labelsNs, txLabelsBucketKey, details.Hash[:])
 	received := details.Received.Unix()
 	generated := blockchain.IsCoinBaseTx(&details.MsgTx)
 	recvCat := RecvCategory(details, syncHeight, net).String()
@@ -1742,10 +1803,12 @@ outputs:
 		}
 		var address string
 		var accountName string
+		var label string
 		_, addrs, _, _ := txscript.ExtractPkScriptAddrs(output.PkScript, net)
 		if len(addrs) == 1 {
 			addr := addrs[0]
 			address = addr.EncodeAddress()
+			label = fetchLabel(labelsNs, addrLabelsBucketKey, []byte(address))
 			mgr, account, e := addrMgr.AddrAccount(addrmgrNs, addrs[0])
 			if e == nil {
 				accountName, e = mgr.AccountName(addrmgrNs, account)
@@ -1767,6 +1830,7 @@ outputs:
 			//   Amount
 			//   Fee
 			Address:         address,
+			Label:           label,
 			Vout:            uint32(i),
 			Confirmations:   confirmations,
 			Generated:       generated,
@@ -1777,6 +1841,7 @@ outputs:
 			WalletConflicts: []string{},
 			Time:            received,
 			TimeReceived:    received,
+			Comment:         txLabel,
 		}
 		// Add a received/generated/immature result if this is a credit. If the output was spent, create a second result
 		// under the send category with the inverse of the output amount. It is therefore possible that a single output
@@ -2968,10 +3033,11 @@ func (w *Wallet) TotalReceivedForAddr(
 	return amount, e
 }
 
-// SendOutputs creates and sends payment transactions. It returns the transaction hash upon success.
+// SendOutputs creates and sends payment transactions, which signal that they can be replaced by one paying a higher
+// fee if replaceable is set. It returns the transaction hash upon success.
 func (w *Wallet) SendOutputs(
 	outputs []*wire.TxOut, account uint32,
-	minconf int32, satPerKb amt.Amount,
+	minconf int32, satPerKb amt.Amount, replaceable bool,
 ) (*chainhash.Hash, error) {
 	// Ensure the outputs to be created adhere to the network's consensus rules.
 	for _, output := range outputs {
@@ -2981,7 +3047,7 @@ func (w *Wallet) SendOutputs(
 	}
 	// Create the transaction and broadcast it to the network. The transaction will be added to the database in order to
 	// ensure that we continue to re-broadcast the transaction upon restarts until it has been confirmed.
-	createdTx, e := w.CreateSimpleTx(account, outputs, minconf, satPerKb)
+	createdTx, e := w.CreateSimpleTx(account, outputs, minconf, satPerKb, replaceable)
 	if e != nil {
 		return nil, e
 	}
@@ -2989,6 +3055,74 @@ func (w *Wallet) SendOutputs(
 	return w.publishTransaction(createdTx.Tx)
 }
 
+// BumpFee replaces an unmined transaction that signals replacement (BIP125) with one paying a fee rate of satPerKb, or
+// the fee rate of the original plus the relay fee if it is zero, and broadcasts it. It returns the hash of the
+// replacement and the fees paid by the original and the replacement.
+func (w *Wallet) BumpFee(txHash *chainhash.Hash, satPerKb amt.Amount) (
+	newHash *chainhash.Hash, oldFee, newFee amt.Amount, e error,
+) {
+	req := createTxRequest{
+		account:     waddrmgr.DefaultAccountNum,
+		minconf:     1,
+		feeSatPerKB: satPerKb,
+		replaces:    txHash,
+		resp:        make(chan createTxResponse),
+	}
+	w.createTxRequests <- req
+	resp := <-req.resp
+	if e = resp.e; E.Chk(e) {
+		return
+	}
+	createdTx := resp.tx
+	var oldRec *wtxmgr.TxRecord
+	if e = walletdb.View(
+		w.db, func(dbTx walletdb.ReadTx) (e error) {
+			txmgrNs := dbTx.ReadBucket(wtxmgrNamespaceKey)
+			var details *wtxmgr.TxDetails
+			if details, e = w.TxStore.TxDetails(txmgrNs, txHash); E.Chk(e) {
+				return
+			}
+			if oldFee, e = replaceableTxFee(txHash, details); E.Chk(e) {
+				return
+			}
+			oldRec = &details.TxRecord
+			return
+		},
+	); E.Chk(e) {
+		return
+	}
+	var newRec *wtxmgr.TxRecord
+	if newRec, e = wtxmgr.NewTxRecordFromMsgTx(createdTx.Tx, time.Now()); E.Chk(e) {
+		return
+	}
+	// The replacement spends the same outputs as the original, so the wallet is only changed once the node has
+	// accepted it, and then the original is swapped for it in a single update. A rejected replacement leaves the
+	// original in the wallet to be rebroadcast as before.
+	var server chainclient.Interface
+	if server, e = w.requireChainClient(); E.Chk(e) {
+		return
+	}
+	if newHash, e = server.SendRawTransaction(createdTx.Tx, false); E.Chk(e) {
+		return
+	}
+	if e = walletdb.Update(
+		w.db, func(dbTx walletdb.ReadWriteTx) (e error) {
+			txmgrNs := dbTx.ReadWriteBucket(wtxmgrNamespaceKey)
+			if e = w.TxStore.RemoveUnminedTx(txmgrNs, oldRec); E.Chk(e) {
+				return
+			}
+			return w.addRelevantTx(dbTx, newRec, nil)
+		},
+	); E.Chk(e) {
+		return
+	}
+	newFee = createdTx.TotalInput
+	for _, txOut := range createdTx.Tx.TxOut {
+		newFee -= amt.Amount(txOut.Value)
+	}
+	return
+}
+
 // SignatureError records the underlying error when validating a transaction input signature.
 type SignatureError struct {
 	InputIndex uint32
@@ -3256,6 +3390,9 @@ func Create(
 			if e != nil {
 				return e
 			}
+			if e = createLabelsNamespace(tx); e != nil {
+				return e
+			}
 			e = waddrmgr.Create(
 				addrmgrNs, seed, pubPass, privPass, params, nil,
 				birthday,
@@ -3312,6 +3449,12 @@ func Open(
 	if e != nil {
 		return nil, e
 	}
+	// Wallets created before labels were stored in the wallet database do not have the labels namespace yet.
+	T.Ln("creating missing label buckets")
+	e = walletdb.Update(db, createLabelsNamespace)
+	if e != nil {
+		return nil, e
+	}
 	// Open database abstraction instances
 	var (
 		addrMgr *waddrmgr.Manager
@@ -0,0 +1,55 @@
+package wallet
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/cybriq/p9/pkg/amt"
+	"github.com/cybriq/p9/pkg/txrules"
+	"github.com/cybriq/p9/pkg/wire"
+)
+
+// TestBumpFee ensures the original transaction is only swapped for its replacement in the wallet once the node has
+// accepted the replacement, and is kept in the wallet alone when the replacement is rejected.
+func TestBumpFee(t *testing.T) {
+	w, chain := testWallet(t)
+	fundTestWallet(t, w, 100000000)
+	oldHash, e := w.SendOutputs(
+		[]*wire.TxOut{testPayment(t, 50000000)}, 0, 1, txrules.DefaultRelayFeePerKb, true,
+	)
+	if e != nil {
+		t.Fatal(e)
+	}
+	chain.sendErr = errors.New("-26: insufficient fee for replacement")
+	if _, _, _, e = w.BumpFee(oldHash, 0); e == nil {
+		t.Fatal("rejected replacement was reported as sent")
+	}
+	if unmined := unminedTxs(t, w); len(unmined) != 1 || unmined[0] != *oldHash {
+		t.Fatalf("expected only the original %v unmined after a rejected replacement, got %v", oldHash, unmined)
+	}
+	chain.sendErr = nil
+	newHash, oldFee, newFee, e := w.BumpFee(oldHash, 0)
+	if e != nil {
+		t.Fatal(e)
+	}
+	if newFee <= oldFee {
+		t.Fatalf("replacement fee %v is not more than the original fee %v", newFee, oldFee)
+	}
+	sent := chain.sent[len(chain.sent)-1]
+	if sent.TxHash() != *newHash {
+		t.Fatalf("replacement %v was not sent to the node", newHash)
+	}
+	var paid amt.Amount
+	for _, txOut := range sent.TxOut {
+		paid += amt.Amount(txOut.Value)
+	}
+	if paid+newFee != 100000000 {
+		t.Fatalf("replacement pays %v with a fee of %v from an input of 1 DUO", paid, newFee)
+	}
+	if unmined := unminedTxs(t, w); len(unmined) != 1 || unmined[0] != *newHash {
+		t.Fatalf("expected only the replacement %v unmined, got %v", newHash, unmined)
+	}
+	if details := txDetails(t, w, oldHash); details != nil {
+		t.Fatalf("replaced transaction %v is still in the wallet", oldHash)
+	}
+}
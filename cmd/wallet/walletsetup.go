@@ -142,15 +142,22 @@ func CreateWallet(activenet *chaincfg.Params, config *config.Config) (e error) {
 	}
 	// Ascertain the wallet generation seed. This will either be an automatically generated value the user has already
 	// confirmed or a value the user has entered which has already been validated.
-	seed, e := prompt.Seed(reader)
+	seed, existing, e := prompt.Seed(reader)
 	if e != nil {
 		D.Ln(e)
 		time.Sleep(time.Second * 5)
 		return e
 	}
+	// A wallet restored from an existing seed may have been used at any time, so its birthday is set to the genesis
+	// block to have the first sync search the whole chain for its addresses.
+	bday := time.Now()
+	if existing {
+		bday = activenet.GenesisBlock.Header.Timestamp
+		I.Ln("restoring the wallet, its addresses will be recovered from the chain once it is started")
+	}
 	D.Ln("Creating the wallet")
 	w, e := loader.CreateNewWallet(
-		pubPass, privPass, seed, time.Now(), false,
+		pubPass, privPass, seed, bday, false,
 		config, nil,
 	)
 	if e != nil {
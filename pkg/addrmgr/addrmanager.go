@@ -976,6 +976,17 @@ func getReachabilityFrom(localAddr, remoteAddr *wire.NetAddress) int {
 	return Ipv6Strong
 }
 
+// LocalAddresses returns the known local addresses along with the priority each of them is advertised with.
+func (a *AddrManager) LocalAddresses() (addrs []*wire.NetAddress, priorities []AddressPriority) {
+	a.lamtx.Lock()
+	defer a.lamtx.Unlock()
+	for _, la := range a.localAddresses {
+		addrs = append(addrs, la.na)
+		priorities = append(priorities, la.score)
+	}
+	return
+}
+
 // GetBestLocalAddress returns the most appropriate local address to use for the given remote address.
 func (a *AddrManager) GetBestLocalAddress(remoteAddr *wire.NetAddress) *wire.NetAddress {
 	a.lamtx.Lock()
@@ -57,7 +57,7 @@ func (b *BlockChain) maybeAcceptBlock(
 		var i int64
 		pn = prevNode
 		for ; i < b.params.AveragingInterval-1; i++ {
-			pn = pn.GetLastWithAlgo(a)
+			pn = pn.GetLastWithAlgo(b.params.Forks, a)
 			if pn == nil {
 				break
 			}
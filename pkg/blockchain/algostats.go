@@ -0,0 +1,100 @@
+package blockchain
+
+import (
+	"math/big"
+	"sort"
+)
+
+// AlgoStats is the recent activity of one block version of the current hard fork
+type AlgoStats struct {
+	Name    string
+	Version int32
+	// Bits is the target of the next block of the version
+	Bits uint32
+	// TargetInterval is the number of seconds between blocks of the version the difficulty adjustment aims for
+	TargetInterval float64
+	// AverageInterval is the moving average of the seconds between recent blocks of the version
+	AverageInterval float64
+	// SinceLast is the number of seconds between the last block of the version and the best block
+	SinceLast float64
+	// LastHeight is the height of the last block of the version, or -1 if none was found
+	LastHeight int32
+	// Blocks is the number of blocks of the version in the sampled blocks
+	Blocks int
+	// HashesPerSec is the work of the sampled blocks of the version divided by the time they span
+	HashesPerSec float64
+}
+
+// AlgoStats returns the activity of each block version of the current hard fork, ordered by version, over up to the
+// given number of the most recent blocks of the best chain since the hard fork, along with the number of blocks
+// sampled and the number of seconds they span.
+func (b *BlockChain) AlgoStats(blocks int32) (stats []AlgoStats, sampled int, span int64, e error) {
+	tip := b.BestChain.Tip()
+	forks := b.params.Forks
+	current := forks.GetCurrent(tip.height + 1)
+	hf := forks.List[current]
+	index := make(map[int32]int, len(hf.Algos))
+	for name, algo := range hf.Algos {
+		stats = append(stats, AlgoStats{Name: name, Version: algo.Version, LastHeight: -1})
+	}
+	sort.Slice(stats, func(i, j int) bool { return stats[i].Version < stats[j].Version })
+	// before Plan 9 each algorithm aims for an even share of the target time per block
+	targetInterval := float64(int(hf.TargetTimePerBlock) * len(hf.Algos))
+	for i := range stats {
+		index[stats[i].Version] = i
+		if stats[i].Bits, e = b.CalcNextRequiredDifficulty(stats[i].Name); E.Chk(e) {
+			return
+		}
+		stats[i].TargetInterval = targetInterval
+		if current > 0 {
+			stats[i].TargetInterval = float64(hf.Algos[stats[i].Name].VersionInterval)
+		}
+	}
+	// newest and oldest timestamps of each version in the sample for the average before Plan 9
+	newest := make([]int64, len(stats))
+	oldest := make([]int64, len(stats))
+	work := make([]*big.Int, len(stats))
+	oldestNode := tip
+	for n := tip; n != nil && sampled < int(blocks) && n.height >= hf.ActivationHeight; n = n.parent {
+		oldestNode = n
+		sampled++
+		i, ok := index[n.version]
+		if !ok {
+			continue
+		}
+		if work[i] == nil {
+			work[i] = new(big.Int)
+			newest[i] = n.timestamp
+			stats[i].LastHeight = n.height
+			stats[i].SinceLast = float64(tip.timestamp - n.timestamp)
+		}
+		oldest[i] = n.timestamp
+		work[i].Add(work[i], CalcWork(n.bits, n.height, n.version))
+		stats[i].Blocks++
+	}
+	span = tip.timestamp - oldestNode.timestamp
+	for i := range stats {
+		if work[i] != nil && span > 0 {
+			stats[i].HashesPerSec, _ = new(big.Float).Quo(
+				new(big.Float).SetInt(work[i]), big.NewFloat(float64(span)),
+			).Float64()
+		}
+		if current == 0 {
+			if stats[i].Blocks > 1 {
+				stats[i].AverageInterval = float64(newest[i]-oldest[i]) / float64(stats[i].Blocks-1)
+			}
+			continue
+		}
+		// after Plan 9 the intervals are those the difficulty adjustment uses, which look further back than the
+		// sample when the version is rare
+		last, found, algStamps, _ := GetAlgStamps(forks, stats[i].Name, hf.ActivationHeight, tip)
+		if found && len(algStamps) > 1 {
+			stats[i].AverageInterval, _ = GetAlg(algStamps, stats[i].TargetInterval)
+		}
+		if found && stats[i].LastHeight < 0 {
+			stats[i].SinceLast = float64(tip.timestamp - last.timestamp)
+			stats[i].LastHeight = last.height
+		}
+	}
+	return
+}
@@ -0,0 +1,68 @@
+package blockchain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cybriq/p9/pkg/chaincfg"
+	"github.com/cybriq/p9/pkg/fork"
+)
+
+func TestAlgoStats(t *testing.T) {
+	// Plan 9 is active from the genesis block on the simulation network
+	params := chaincfg.SimNetParams
+	chain := newFakeChain(&params)
+	tip := chain.BestChain.Tip()
+	start := time.Unix(tip.timestamp, 0)
+	// version 5 every 10 seconds and version 6 every 30 seconds, with no blocks of the other versions
+	for i := 1; i <= 60; i++ {
+		version := int32(5)
+		if i%3 == 0 {
+			version = 6
+		}
+		tip = newFakeNode(tip, version, fork.SecondPowLimitBits, start.Add(time.Duration(i)*time.Second*10))
+		chain.Index.AddNode(tip)
+	}
+	chain.BestChain.SetTip(tip)
+	stats, sampled, span, e := chain.AlgoStats(30)
+	if e != nil {
+		t.Fatal(e)
+	}
+	if sampled != 30 || span != 290 {
+		t.Fatalf("expected 30 blocks over 290 seconds, got %d over %d", sampled, span)
+	}
+	hf := params.Forks.List[1]
+	if len(stats) != len(hf.Algos) {
+		t.Fatalf("expected stats for %d versions, got %d", len(hf.Algos), len(stats))
+	}
+	for i := range stats {
+		s := stats[i]
+		if i > 0 && stats[i-1].Version >= s.Version {
+			t.Errorf("stats are not ordered by version")
+		}
+		if s.TargetInterval != float64(hf.Algos[s.Name].VersionInterval) {
+			t.Errorf("%s target interval %v is not the version interval", s.Name, s.TargetInterval)
+		}
+		if s.Bits == 0 {
+			t.Errorf("%s has no target", s.Name)
+		}
+		switch s.Version {
+		case 5:
+			if s.Blocks != 20 || s.LastHeight != 59 || s.SinceLast != 10 {
+				t.Errorf("unexpected stats for version 5 %+v", s)
+			}
+		case 6:
+			if s.Blocks != 10 || s.LastHeight != 60 || s.SinceLast != 0 || s.AverageInterval != 30 {
+				t.Errorf("unexpected stats for version 6 %+v", s)
+			}
+		default:
+			if s.Blocks != 0 || s.LastHeight != -1 || s.HashesPerSec != 0 {
+				t.Errorf("version %d has activity without blocks %+v", s.Version, s)
+			}
+			continue
+		}
+		if s.HashesPerSec <= 0 {
+			t.Errorf("version %d has no hashrate", s.Version)
+		}
+	}
+}
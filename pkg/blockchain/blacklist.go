@@ -2,7 +2,6 @@ package blockchain
 
 import (
 	"github.com/cybriq/p9/pkg/btcaddr"
-	"github.com/cybriq/p9/pkg/fork"
 	"github.com/cybriq/p9/pkg/txscript"
 	"github.com/cybriq/p9/pkg/util"
 )
@@ -17,7 +16,7 @@ func ContainsBlacklisted(
 		return false
 	}
 	// blacklist only applies from hard fork
-	if fork.GetCurrent(b.BestSnapshot().Height) < 1 {
+	if b.params.Forks.GetCurrent(b.BestSnapshot().Height) < 1 {
 		return false
 	}
 	var addrs []btcaddr.Address
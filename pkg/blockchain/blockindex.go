@@ -296,11 +296,11 @@ func (node *BlockNode) GetAlgo() int32 {
 }
 
 // GetLastWithAlgo returns the newest block from node with specified algo
-func (node *BlockNode) GetLastWithAlgo(algo int32) (prev *BlockNode) {
+func (node *BlockNode) GetLastWithAlgo(forks *fork.Schedule, algo int32) (prev *BlockNode) {
 	if node == nil {
 		return
 	}
-	if fork.GetCurrent(node.height+1) == 0 {
+	if forks.GetCurrent(node.height+1) == 0 {
 		// F.Ln("checking pre-hardfork algo versions")
 		if algo != 514 &&
 			algo != 2 {
@@ -315,7 +315,7 @@ func (node *BlockNode) GetLastWithAlgo(algo int32) (prev *BlockNode) {
 		}
 		// Tracef("node %d %d %8x", prev.height, prev.version, prev.bits)
 		prevversion := prev.version
-		if fork.GetCurrent(prev.height) == 0 {
+		if forks.GetCurrent(prev.height) == 0 {
 			// F.Ln("checking pre-hardfork algo versions")
 			if prev.version != 514 &&
 				prev.version != 2 {
@@ -7,7 +7,6 @@ import (
 	"time"
 
 	block2 "github.com/cybriq/p9/pkg/block"
-	"github.com/cybriq/p9/pkg/fork"
 
 	"go.uber.org/atomic"
 
@@ -22,6 +21,10 @@ const // maxOrphanBlocks is the maximum number of orphan blocks that can be
 // queued.
 maxOrphanBlocks = 100
 
+// PruneKeepBlocks is the number of most recent main chain blocks that are never pruned, so their spend journal entries
+// remain available to disconnect them in a reorganization.
+const PruneKeepBlocks = 288
+
 // BlockLocator is used to help locate a specific block. The algorithm for building the block locator is to add the
 // hashes in reverse order until the genesis block is reached. In order to keep the list of locator hashes to a
 // reasonable number of entries, first the most recent previous 12 block hashes are added, then the step is doubled each
@@ -92,6 +95,8 @@ type BlockChain struct {
 	sigCache            *txscript.SigCache
 	indexManager        IndexManager
 	hashCache           *txscript.HashCache
+	pruneTarget         uint64
+	assumeValid         *chainhash.Hash
 	// The following fields are calculated based upon the provided chain parameters.
 	// They are also set when the instance is created and can't be changed
 	// afterwards, so there is no need to protect them with a separate mutex.
@@ -182,6 +187,13 @@ func (b *BlockChain) IsKnownOrphan(hash *chainhash.Hash) bool {
 	return exists
 }
 
+// OrphanCount returns the number of orphan blocks held in memory. This function is safe for concurrent access.
+func (b *BlockChain) OrphanCount() int {
+	b.orphanLock.RLock()
+	defer b.orphanLock.RUnlock()
+	return len(b.orphans)
+}
+
 // GetOrphanRoot returns the head of the chain for the provided hash from the
 // map of orphan blocks. This function is safe for concurrent access.
 func (b *BlockChain) GetOrphanRoot(hash *chainhash.Hash) *chainhash.Hash {
@@ -546,6 +558,12 @@ func (b *BlockChain) connectBlock(
 				T.Ln("dbPutSpendJournalEntry", e)
 				return e
 			}
+			// Delete the oldest blocks once they take more space than the prune target.
+			if b.pruneTarget != 0 {
+				if e = b.pruneBlocks(dbTx, node); E.Chk(e) {
+					return e
+				}
+			}
 			// Allow the index manager to call each of the currently active optional indexes with the block being connected
 			// so they can update themselves accordingly
 			if b.indexManager != nil {
@@ -594,6 +612,49 @@ func (b *BlockChain) connectBlock(
 	return nil
 }
 
+// pruneBlocks uses an existing database transaction to delete the oldest block files while they take more than the
+// prune target, along with the spend journal entries of the blocks stored in them. The PruneKeepBlocks blocks ending
+// at the passed node are always kept.
+func (b *BlockChain) pruneBlocks(dbTx database.Tx, node *BlockNode) (e error) {
+	keep := node.Ancestor(node.height - PruneKeepBlocks + 1)
+	if keep == nil {
+		return nil
+	}
+	var pruned []chainhash.Hash
+	if pruned, e = dbTx.PruneBlocks(b.pruneTarget, &keep.hash); E.Chk(e) {
+		return e
+	}
+	for i := range pruned {
+		if e = dbRemoveSpendJournalEntry(dbTx, &pruned[i]); E.Chk(e) {
+			return e
+		}
+		// the index entry is written in this transaction so it never claims to have the data of a pruned block
+		n := b.Index.LookupNode(&pruned[i])
+		if n == nil {
+			continue
+		}
+		b.Index.UnsetStatusFlags(n, statusDataStored)
+		if e = dbStoreBlockNode(dbTx, n); E.Chk(e) {
+			return e
+		}
+	}
+	if len(pruned) > 0 {
+		I.F("pruned %d blocks below height %d", len(pruned), keep.height)
+	}
+	return nil
+}
+
+// IsPruned returns whether blocks have ever been pruned from the database.
+func (b *BlockChain) IsPruned() (pruned bool, e error) {
+	e = b.db.View(
+		func(dbTx database.Tx) (e error) {
+			pruned, e = dbTx.BeenPruned()
+			return e
+		},
+	)
+	return
+}
+
 // disconnectBlock handles disconnecting the passed node/block from the end of the main (best) chain. This function MUST
 // be called with the chain state lock held (for writes).
 func (b *BlockChain) disconnectBlock(
@@ -857,7 +918,7 @@ func (b *BlockChain) reorganizeChain(detachNodes, attachNodes *list.List) (e err
 		//
 		// In the case the block is determined to be invalid due to a rule violation, mark it as invalid and mark all of
 		// its descendants as having an invalid ancestor.
-		er = b.checkConnectBlock(n, block, view, nil)
+		er = b.checkConnectBlock(n, block, view, nil, BFNone)
 		if er != nil {
 			if _, ok := er.(RuleError); ok {
 				b.Index.SetStatusFlags(n, statusValidateFailed)
@@ -952,6 +1013,9 @@ func (b *BlockChain) reorganizeChain(detachNodes, attachNodes *list.List) (e err
 //  - BFFastAdd: Avoids several expensive transaction validation operations.
 //    This is useful when using checkpoints.
 //
+//  - BFAssumeValid: Avoids checking the scripts when the block extends the main
+//    chain. Blocks connected by a reorganization are always fully checked.
+//
 // This function MUST be called with the chain state lock held (for writes).
 func (b *BlockChain) connectBestChain(
 	node *BlockNode, block *block2.Block,
@@ -979,7 +1043,7 @@ func (b *BlockChain) connectBestChain(
 		view.SetBestHash(parentHash)
 		stxos := make([]SpentTxOut, 0, countSpentOutputs(block))
 		if !fastAdd {
-			e := b.checkConnectBlock(node, block, view, &stxos)
+			e := b.checkConnectBlock(node, block, view, &stxos, flags)
 			if e == nil {
 				b.Index.SetStatusFlags(node, statusValid)
 			} else if _, ok := e.(RuleError); ok {
@@ -1504,6 +1568,13 @@ type Config struct {
 	// O(N^2) validation complexity due to the SigHashAll flag. This field can be nil if the caller is not interested in
 	// using a signature cache.
 	HashCache *txscript.HashCache
+	// PruneTarget is the size in bytes the block files are kept below by deleting the oldest of them. The most recent
+	// PruneKeepBlocks blocks are always kept. This field can be zero to keep all blocks.
+	PruneTarget uint64
+	// AssumeValid is the hash of the block whose ancestors are assumed to have valid scripts. Blocks processed with
+	// BFAssumeValid do not have their scripts checked when they extend the main chain. This field can be nil to check
+	// the scripts of all blocks.
+	AssumeValid *chainhash.Hash
 }
 
 // New returns a BlockChain instance using the provided configuration details.
@@ -1552,6 +1623,8 @@ func New(config *Config) (*BlockChain, error) {
 		blocksPerRetarget:   int32(targetTimespan / targetTimePerBlock),
 		Index:               newBlockIndex(config.DB, params),
 		hashCache:           config.HashCache,
+		pruneTarget:         config.PruneTarget,
+		assumeValid:         config.AssumeValid,
 		BestChain:           newChainView(nil),
 		orphans:             make(map[chainhash.Hash]*orphanBlock),
 		prevOrphans:         make(map[chainhash.Hash][]*orphanBlock),
@@ -1583,7 +1656,7 @@ func New(config *Config) (*BlockChain, error) {
 	bestNode := b.BestChain.Tip()
 	df, ok := bestNode.Diffs.Load().(Diffs)
 	if df == nil || !ok ||
-		len(df) != len(fork.List[1].AlgoVers) {
+		len(df) != len(b.params.Forks.List[1].AlgoVers) {
 		bitsMap, e := b.CalcNextRequiredDifficultyPlan9Controller(bestNode)
 		if e != nil {
 		}
@@ -1594,5 +1667,8 @@ func New(config *Config) (*BlockChain, error) {
 		bestNode.height, bestNode.hash, b.stateSnapshot.TotalTxns,
 		bestNode.workSum,
 	)
+	if b.assumeValid != nil {
+		I.Ln("the scripts of the blocks leading up to", b.assumeValid, "are assumed to be valid")
+	}
 	return &b, nil
 }
@@ -6,6 +6,7 @@ import (
 
 	"github.com/cybriq/p9/pkg/chaincfg"
 	"github.com/cybriq/p9/pkg/chainhash"
+	"github.com/cybriq/p9/pkg/txscript"
 	"github.com/cybriq/p9/pkg/wire"
 )
 
@@ -890,3 +891,27 @@ func TestIntervalBlockHashes(t *testing.T) {
 		}
 	}
 }
+
+// TestReorganizeValidatesScripts ensures the scripts of the blocks connected by a reorganisation are run even if they
+// were processed as ancestors of the assumed valid block.
+func TestReorganizeValidatesScripts(t *testing.T) {
+	chain, teardown, badSpend := assumeValidSetup(t, "reorganizevalidatesscripts")
+	defer teardown()
+	tip := chain.BestChain.Tip()
+	chain.assumeValid = &tip.hash
+	// a side chain block with the same work as the tip is only stored, not connected
+	b, e := newTestBlock(chain, tip.parent, 1, []byte{txscript.OP_TRUE}, badSpend)
+	if e != nil {
+		t.Fatalf("failed to create block: %v", e)
+	}
+	processTestBlocks(t, chain, BFAssumeValid, b)
+	if e = chain.PreciousBlock(b.Hash()); e != nil {
+		t.Fatalf("PreciousBlock: %v", e)
+	}
+	if chain.BestChain.Tip() != tip {
+		t.Errorf("the chain was reorganised to a block with a failing script")
+	}
+	if !chain.Index.NodeStatus(chain.Index.LookupNode(b.Hash())).KnownInvalid() {
+		t.Errorf("the block with a failing script was not marked invalid")
+	}
+}
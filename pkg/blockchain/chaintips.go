@@ -0,0 +1,289 @@
+package blockchain
+
+import (
+	"container/list"
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/cybriq/p9/pkg/chainhash"
+)
+
+// The statuses of a chain tip
+const (
+	// TipActive is the tip of the main chain
+	TipActive = "active"
+	// TipValidFork is a side chain that has been fully validated but has less work than the main chain
+	TipValidFork = "valid-fork"
+	// TipValidHeaders is a side chain that has all of its blocks but has not been fully validated
+	TipValidHeaders = "valid-headers"
+	// TipHeadersOnly is a side chain that does not have all of its blocks
+	TipHeadersOnly = "headers-only"
+	// TipInvalid is a side chain containing a block that is invalid or has been invalidated
+	TipInvalid = "invalid"
+)
+
+// ChainTip is a block known to the block index that no other known block builds on
+type ChainTip struct {
+	Height int32
+	Hash   chainhash.Hash
+	// BranchLen is the number of blocks between the tip and the main chain
+	BranchLen int32
+	Status    string
+}
+
+// ChainTips returns every tip in the block index, including the tip of the main chain, ordered from the highest. This
+// function is safe for concurrent access.
+func (b *BlockChain) ChainTips() (tips []ChainTip) {
+	b.ChainLock.RLock()
+	defer b.ChainLock.RUnlock()
+	best := b.BestChain.Tip()
+	b.Index.RLock()
+	parents := make(map[*BlockNode]struct{}, len(b.Index.index))
+	for _, n := range b.Index.index {
+		if n.parent != nil {
+			parents[n.parent] = struct{}{}
+		}
+	}
+	for _, n := range b.Index.index {
+		if _, ok := parents[n]; ok {
+			continue
+		}
+		tip := ChainTip{
+			Height:    n.height,
+			Hash:      n.hash,
+			BranchLen: n.height - b.BestChain.FindFork(n).height,
+		}
+		switch {
+		case n == best:
+			tip.Status = TipActive
+		case n.status.KnownInvalid():
+			tip.Status = TipInvalid
+		case !branchHasData(n, b.BestChain.FindFork(n)):
+			tip.Status = TipHeadersOnly
+		case n.status.KnownValid():
+			tip.Status = TipValidFork
+		default:
+			tip.Status = TipValidHeaders
+		}
+		tips = append(tips, tip)
+	}
+	b.Index.RUnlock()
+	sort.Slice(
+		tips, func(i, j int) bool {
+			if tips[i].Height == tips[j].Height {
+				return tips[i].Status == TipActive
+			}
+			return tips[i].Height > tips[j].Height
+		},
+	)
+	return
+}
+
+// InvalidateBlock marks a block and all of the blocks built on it as invalid, so the chain is reorganised away from it
+// to the valid chain with the most work if it is on the main chain. The marks are kept in the block index until the
+// block is reconsidered. This function is safe for concurrent access.
+func (b *BlockChain) InvalidateBlock(hash *chainhash.Hash) (e error) {
+	b.ChainLock.Lock()
+	defer b.ChainLock.Unlock()
+	node := b.Index.LookupNode(hash)
+	if node == nil {
+		return fmt.Errorf("block %v is not known", hash)
+	}
+	if node.parent == nil {
+		return fmt.Errorf("the genesis block cannot be invalidated")
+	}
+	// the blocks are disconnected before they are marked, so if that fails the main chain is not left marked invalid
+	if b.BestChain.Contains(node) {
+		W.F("INVALIDATE: disconnecting block %v (height %d) and the %d blocks after it",
+			node.hash, node.height, b.BestChain.Tip().height-node.height,
+		)
+		detachNodes := list.New()
+		for n := b.BestChain.Tip(); n != node.parent; n = n.parent {
+			detachNodes.PushBack(n)
+		}
+		if e = b.reorganizeChain(detachNodes, list.New()); E.Chk(e) {
+			return
+		}
+	}
+	b.Index.SetStatusFlags(node, statusValidateFailed)
+	for _, n := range b.Index.descendants(node) {
+		b.Index.SetStatusFlags(n, statusInvalidAncestor)
+	}
+	defer b.flushIndex()
+	return b.activateBestChain(nil)
+}
+
+// ReconsiderBlock removes the invalid marks from a block, its ancestors and all of the blocks built on it, and
+// reorganises the chain to them if that gives the valid chain with the most work. Blocks that fail validation again are
+// marked as invalid once more. This function is safe for concurrent access.
+func (b *BlockChain) ReconsiderBlock(hash *chainhash.Hash) (e error) {
+	b.ChainLock.Lock()
+	defer b.ChainLock.Unlock()
+	node := b.Index.LookupNode(hash)
+	if node == nil {
+		return fmt.Errorf("block %v is not known", hash)
+	}
+	invalid := statusValidateFailed | statusInvalidAncestor
+	for n := node; n != nil; n = n.parent {
+		if b.Index.NodeStatus(n).KnownInvalid() {
+			b.Index.UnsetStatusFlags(n, invalid)
+		}
+	}
+	for _, n := range b.Index.descendants(node) {
+		if b.Index.NodeStatus(n).KnownInvalid() {
+			b.Index.UnsetStatusFlags(n, invalid)
+		}
+	}
+	defer b.flushIndex()
+	return b.activateBestChain(nil)
+}
+
+// PreciousBlock reorganises the chain to a block, or the valid block built on it with the most work, if that has at
+// least as much work as the main chain since they forked, so a competing tip of equal work can be preferred. This
+// function is safe for concurrent access.
+func (b *BlockChain) PreciousBlock(hash *chainhash.Hash) (e error) {
+	b.ChainLock.Lock()
+	defer b.ChainLock.Unlock()
+	node := b.Index.LookupNode(hash)
+	if node == nil {
+		return fmt.Errorf("block %v is not known", hash)
+	}
+	if b.Index.NodeStatus(node).KnownInvalid() {
+		return fmt.Errorf("block %v is invalid", hash)
+	}
+	if b.BestChain.Contains(node) {
+		return
+	}
+	defer b.flushIndex()
+	return b.activateBestChain(node)
+}
+
+// activateBestChain reorganises the chain to the valid block off the main chain that has more work since it forked
+// from the main chain than the main chain has since then, and which has the most work of those, until there is none.
+// If preferred is not nil only it and the blocks built on it are considered, and equal work is enough. This function
+// MUST be called with the chain state lock held (for writes).
+func (b *BlockChain) activateBestChain(preferred *BlockNode) (e error) {
+	tried := make(map[*BlockNode]struct{})
+	for {
+		candidate := b.bestCandidate(preferred, tried)
+		if candidate == nil {
+			// an earlier candidate may have failed to connect, which is not an error
+			return nil
+		}
+		tried[candidate] = struct{}{}
+		detachNodes, attachNodes := b.getReorganizeNodes(candidate)
+		if attachNodes.Len() == 0 {
+			// the candidate has an invalid ancestor, which is now marked on it
+			continue
+		}
+		W.F("REORGANIZE: block %v (height %d) has the most work", candidate.hash, candidate.height)
+		if e = b.reorganizeChain(detachNodes, attachNodes); e != nil {
+			// the block that failed to connect and those built on it are now marked invalid, so look again
+			if _, ok := e.(RuleError); ok {
+				W.Ln("REORGANIZE: failed:", e)
+				continue
+			}
+			return
+		}
+		if preferred != nil {
+			return
+		}
+	}
+}
+
+// bestCandidate returns the block that activateBestChain should reorganise to next, other than those it has already
+// tried, or nil if there is none. This function MUST be called with the chain state lock held (for reads).
+func (b *BlockChain) bestCandidate(preferred *BlockNode, tried map[*BlockNode]struct{}) (best *BlockNode) {
+	tip := b.BestChain.Tip()
+	var nodes []*BlockNode
+	if preferred != nil {
+		nodes = append(b.Index.descendants(preferred), preferred)
+	} else {
+		b.Index.RLock()
+		for _, n := range b.Index.index {
+			nodes = append(nodes, n)
+		}
+		b.Index.RUnlock()
+	}
+	var bestWork *big.Int
+	for _, n := range nodes {
+		if _, ok := tried[n]; ok {
+			continue
+		}
+		status := b.Index.NodeStatus(n)
+		if status.KnownInvalid() || !status.HaveData() || b.BestChain.Contains(n) {
+			continue
+		}
+		fork := b.BestChain.FindFork(n)
+		if fork == nil {
+			continue
+		}
+		b.Index.RLock()
+		haveData := branchHasData(n, fork)
+		b.Index.RUnlock()
+		// blocks that have been pruned can't be connected
+		if !haveData {
+			continue
+		}
+		work, mainWork := branchWork(n, fork), branchWork(tip, fork)
+		cmp := work.Cmp(mainWork)
+		if cmp < 0 || cmp == 0 && preferred == nil {
+			continue
+		}
+		if bestWork == nil || work.Cmp(bestWork) > 0 ||
+			// the lower of two blocks with equal work is the one with fewer blocks to validate
+			work.Cmp(bestWork) == 0 && n.height < best.height {
+			best, bestWork = n, work
+		}
+	}
+	return
+}
+
+// branchWork returns the total work of the blocks from a block back to, but not including, one of its ancestors
+func branchWork(from, to *BlockNode) *big.Int {
+	work := new(big.Int)
+	for n := from; n != nil && n != to; n = n.parent {
+		work.Add(work, CalcWork(n.bits, n.height, n.version))
+	}
+	return work
+}
+
+// branchHasData returns whether the data of every block from a block back to, but not including, one of its ancestors
+// is stored. This function MUST be called with the block index lock held (for reads).
+func branchHasData(from, to *BlockNode) bool {
+	for n := from; n != nil && n != to; n = n.parent {
+		if !n.status.HaveData() {
+			return false
+		}
+	}
+	return true
+}
+
+// flushIndex writes the changed statuses in the block index to the database
+func (b *BlockChain) flushIndex() {
+	if e := b.Index.flushToDB(); E.Chk(e) {
+	}
+}
+
+// descendants returns all of the blocks in the index built on a block. This function is safe for concurrent access.
+func (bi *blockIndex) descendants(node *BlockNode) (nodes []*BlockNode) {
+	bi.RLock()
+	var higher []*BlockNode
+	for _, n := range bi.index {
+		if n.height > node.height {
+			higher = append(higher, n)
+		}
+	}
+	bi.RUnlock()
+	sort.Slice(higher, func(i, j int) bool { return higher[i].height < higher[j].height })
+	// the parent of each block is visited before it, so it is known whether the parent is a descendant
+	found := map[*BlockNode]struct{}{node: {}}
+	for _, n := range higher {
+		if _, ok := found[n.parent]; ok {
+			found[n] = struct{}{}
+			nodes = append(nodes, n)
+		}
+	}
+	return
+}
@@ -0,0 +1,203 @@
+package blockchain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cybriq/p9/pkg/chaincfg"
+	"github.com/cybriq/p9/pkg/chainhash"
+	"github.com/cybriq/p9/pkg/fork"
+	"github.com/cybriq/p9/pkg/txscript"
+)
+
+func TestChainTips(t *testing.T) {
+	params := chaincfg.SimNetParams
+	chain := newFakeChain(&params)
+	genesis := chain.BestChain.Tip()
+	start := time.Unix(genesis.timestamp, 0)
+	// the blocks of each branch are a second apart from those of the others so they all have different hashes
+	var branch time.Duration
+	extend := func(parent *BlockNode, n int, status blockStatus) (nodes []*BlockNode) {
+		branch += time.Second
+		for i := 0; i < n; i++ {
+			parent = newFakeNode(
+				parent, 5, fork.SecondPowLimitBits, start.Add(time.Duration(parent.height+1)*time.Minute+branch),
+			)
+			parent.status = status
+			chain.Index.AddNode(parent)
+			nodes = append(nodes, parent)
+		}
+		return
+	}
+	valid := statusDataStored | statusValid
+	main := extend(genesis, 5, valid)
+	chain.BestChain.SetTip(main[4])
+	// a fork from height 2 with the same work as the main chain, one from height 3 that is invalid, one from height 4
+	// that has not been validated and one with only headers
+	equal := extend(main[1], 3, valid)
+	invalid := extend(main[2], 3, statusDataStored|statusValidateFailed)
+	unchecked := extend(main[3], 1, statusDataStored)
+	headers := extend(main[3], 2, statusNone)
+	// a fork from height 1 whose first block has been pruned
+	pruned := extend(main[0], 2, valid)
+	pruned[0].status = statusValid
+	tips := chain.ChainTips()
+	expected := []struct {
+		node      *BlockNode
+		branchLen int32
+		status    string
+	}{
+		{main[4], 0, TipActive},
+		{equal[2], 3, TipValidFork},
+		{invalid[2], 3, TipInvalid},
+		{headers[1], 2, TipHeadersOnly},
+		{unchecked[0], 1, TipValidHeaders},
+		{pruned[1], 2, TipHeadersOnly},
+	}
+	if len(tips) != len(expected) {
+		t.Fatalf("expected %d tips, got %d", len(expected), len(tips))
+	}
+	found := make(map[BlockNode]ChainTip)
+	for i, tip := range tips {
+		if i > 0 && tips[i-1].Height < tip.Height {
+			t.Errorf("tips are not ordered from the highest")
+		}
+		found[*chain.Index.LookupNode(&tip.Hash)] = tip
+	}
+	for _, tip := range tips {
+		if tip.Height == main[4].height {
+			if tip.Hash != main[4].hash {
+				t.Errorf("the active tip is not first of those at its height")
+			}
+			break
+		}
+	}
+	for _, x := range expected {
+		tip, ok := found[*x.node]
+		if !ok {
+			t.Errorf("tip %v is missing", x.node.hash)
+			continue
+		}
+		if tip.Height != x.node.height || tip.BranchLen != x.branchLen || tip.Status != x.status {
+			t.Errorf("expected tip at %d branch %d %s, got %+v", x.node.height, x.branchLen, x.status, tip)
+		}
+	}
+	if d := chain.Index.descendants(main[2]); len(d) != 2+3+1+2 {
+		t.Errorf("expected 8 descendants, got %d", len(d))
+	}
+	// the fork with equal work is only a candidate when it is preferred, and then the highest block is chosen
+	if c := chain.bestCandidate(nil, nil); c != nil {
+		t.Errorf("block %v with no more work than the main chain was chosen", c.hash)
+	}
+	if c := chain.bestCandidate(equal[0], nil); c != equal[2] {
+		t.Errorf("the preferred fork was not chosen")
+	}
+	// once the main chain tip is invalid the fork has more work, but the invalid fork and the blocks without data do
+	// not
+	chain.Index.SetStatusFlags(main[4], statusValidateFailed)
+	chain.BestChain.SetTip(main[3])
+	if c := chain.bestCandidate(nil, nil); c != equal[2] {
+		t.Errorf("the fork with the most work was not chosen")
+	}
+	if c := chain.bestCandidate(nil, map[*BlockNode]struct{}{equal[2]: {}}); c != unchecked[0] {
+		t.Errorf("the next best fork was not chosen")
+	}
+}
+
+// TestInvalidateReconsiderPrecious ensures invalidating, reconsidering and preferring blocks moves the tip of a real
+// chain as expected and that the invalid marks are kept when the chain is reopened.
+func TestInvalidateReconsiderPrecious(t *testing.T) {
+	chain, teardown, e := chainSetup("invalidateblock", testChainParams())
+	if e != nil {
+		t.Fatalf("failed to set up chain: %v", e)
+	}
+	defer func() { teardown() }()
+	extend := func(parent *BlockNode, n int, nonce uint32) (blocks []*chainhash.Hash) {
+		for i := 0; i < n; i++ {
+			b, e := newTestBlock(chain, parent, nonce, []byte{txscript.OP_TRUE})
+			if e != nil {
+				t.Fatalf("failed to create block: %v", e)
+			}
+			processTestBlocks(t, chain, BFNone, b)
+			parent = chain.Index.LookupNode(b.Hash())
+			blocks = append(blocks, b.Hash())
+		}
+		return
+	}
+	checkTip := func(expected *chainhash.Hash) {
+		t.Helper()
+		if tip := chain.BestChain.Tip(); tip.hash != *expected {
+			t.Fatalf("expected tip %v, got %v at height %d", expected, tip.hash, tip.height)
+		}
+	}
+	checkInvalid := func(expected bool, hashes ...*chainhash.Hash) {
+		t.Helper()
+		for _, hash := range hashes {
+			if chain.Index.NodeStatus(chain.Index.LookupNode(hash)).KnownInvalid() != expected {
+				t.Errorf("expected block %v known invalid %v", hash, expected)
+			}
+		}
+	}
+	main := extend(chain.BestChain.Tip(), 5, 0)
+	checkTip(main[4])
+	// with nothing else to reorganise to, invalidating the third block leaves the second as the tip
+	if e = chain.InvalidateBlock(main[2]); e != nil {
+		t.Fatalf("InvalidateBlock: %v", e)
+	}
+	checkTip(main[1])
+	checkInvalid(true, main[2], main[3], main[4])
+	// blocks are only processed on the main chain, so the side chain is built while the second block is the tip. It
+	// has the same work as the first 4 blocks of the main chain.
+	side := extend(chain.Index.LookupNode(main[1]), 2, 1)
+	checkTip(side[1])
+	if e = chain.ReconsiderBlock(main[4]); e != nil {
+		t.Fatalf("ReconsiderBlock: %v", e)
+	}
+	checkTip(main[4])
+	checkInvalid(false, main[2], main[3], main[4])
+	// the side chain has less work than the main chain so it can't be preferred
+	if e = chain.PreciousBlock(side[1]); e != nil {
+		t.Fatalf("PreciousBlock: %v", e)
+	}
+	checkTip(main[4])
+	// after invalidating the last block the main chain has the same work as the side chain, so it remains the tip
+	// unless the side chain is preferred
+	if e = chain.InvalidateBlock(main[4]); e != nil {
+		t.Fatalf("InvalidateBlock: %v", e)
+	}
+	checkTip(main[3])
+	checkInvalid(true, main[4])
+	checkInvalid(false, main[3], side[1])
+	if e = chain.PreciousBlock(side[1]); e != nil {
+		t.Fatalf("PreciousBlock: %v", e)
+	}
+	checkTip(side[1])
+	if e = chain.PreciousBlock(main[3]); e != nil {
+		t.Fatalf("PreciousBlock: %v", e)
+	}
+	checkTip(main[3])
+	if e = chain.PreciousBlock(main[4]); e == nil {
+		t.Errorf("PreciousBlock: an invalid block was preferred")
+	}
+	if e = chain.PreciousBlock(side[1]); e != nil {
+		t.Fatalf("PreciousBlock: %v", e)
+	}
+	checkTip(side[1])
+	// the marks and the tip are kept when the chain is reopened
+	teardown = func() {}
+	if chain, teardown, e = chainReopen(chain, "invalidateblock"); e != nil {
+		t.Fatalf("failed to reopen chain: %v", e)
+	}
+	checkTip(side[1])
+	checkInvalid(true, main[4])
+	if e = chain.ReconsiderBlock(main[4]); e != nil {
+		t.Fatalf("ReconsiderBlock: %v", e)
+	}
+	checkTip(main[4])
+	checkInvalid(false, main[4])
+	if chain, teardown, e = chainReopen(chain, "invalidateblock"); e != nil {
+		t.Fatalf("failed to reopen chain: %v", e)
+	}
+	checkTip(main[4])
+	checkInvalid(false, main[4])
+}
@@ -51,6 +51,14 @@ func (b *BlockChain) LatestCheckpoint() *chaincfg.Checkpoint {
 	return &b.checkpoints[len(b.checkpoints)-1]
 }
 
+// AssumeValid returns the hash of the block whose ancestors are assumed to have valid scripts, or nil when the scripts
+// of all blocks are checked.
+//
+// This function is safe for concurrent access.
+func (b *BlockChain) AssumeValid() *chainhash.Hash {
+	return b.assumeValid
+}
+
 // verifyCheckpoint returns whether the passed block height and hash combination match the checkpoint data. It also
 // returns true if there is no checkpoint data for the passed block height.
 func (b *BlockChain) verifyCheckpoint(height int32, hash *chainhash.Hash) bool {
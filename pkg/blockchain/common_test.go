@@ -8,6 +8,7 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"testing"
 	"time"
 
 	"github.com/cybriq/p9/pkg/block"
@@ -15,6 +16,7 @@ import (
 	"github.com/cybriq/p9/pkg/chaincfg"
 	"github.com/cybriq/p9/pkg/chainhash"
 	"github.com/cybriq/p9/pkg/txscript"
+	"github.com/cybriq/p9/pkg/util"
 
 	"github.com/cybriq/p9/pkg/database"
 	_ "github.com/cybriq/p9/pkg/database/ffldb"
@@ -354,3 +356,103 @@ func newFakeNode(
 	}
 	return NewBlockNode(header, parent)
 }
+
+// testChainParams returns a copy of the regression test network parameters with the genesis hash matching the genesis
+// block, so a chain created with them can be reopened.
+func testChainParams() *chaincfg.Params {
+	params := chaincfg.RegressionTestParams
+	genesisHash := params.GenesisBlock.BlockHash()
+	params.GenesisHash = &genesisHash
+	return &params
+}
+
+// chainReopen closes the database of a chain created by chainSetup and creates a new chain instance from the same
+// database, returning a teardown function to use in place of the one returned by chainSetup.
+func chainReopen(chain *BlockChain, dbName string) (reopened *BlockChain, teardown func(), e error) {
+	if e = chain.db.Close(); E.Chk(e) {
+		return
+	}
+	dbPath := filepath.Join(testDbRoot, dbName)
+	var db database.DB
+	if db, e = database.Open(testDbType, dbPath, blockDataNet); E.Chk(e) {
+		return
+	}
+	teardown = func() {
+		if e := db.Close(); E.Chk(e) {
+		}
+		if e := os.RemoveAll(dbPath); E.Chk(e) {
+		}
+		if e := os.RemoveAll(testDbRoot); E.Chk(e) {
+		}
+	}
+	if reopened, e = New(
+		&Config{
+			DB:          db,
+			ChainParams: chain.params,
+			TimeSource:  NewMedianTime(),
+			SigCache:    txscript.NewSigCache(1000),
+		},
+	); E.Chk(e) {
+		teardown()
+		return nil, nil, e
+	}
+	return
+}
+
+// newTestBlock returns a block built on parent containing a coinbase paying the subsidy to pkScript followed by txs.
+// The block has the difficulty bits the chain requires but its proof of work is not solved, so it must be processed
+// with BFNoPoWCheck. The nonce is only used to give blocks that are otherwise the same different hashes.
+func newTestBlock(
+	chain *BlockChain, parent *BlockNode, nonce uint32, pkScript []byte, txs ...*wire.MsgTx,
+) (b *block.Block, e error) {
+	height := parent.height + 1
+	version := chain.params.Forks.AlgoSlices[chain.params.Forks.GetCurrent(height)][0].Version
+	var bits uint32
+	if bits, e = chain.CalcNextRequiredDifficultyFromNode(
+		parent, chain.params.Forks.GetAlgoName(version, height), true,
+	); E.Chk(e) {
+		return
+	}
+	var coinbaseScript []byte
+	if coinbaseScript, e = txscript.NewScriptBuilder().AddInt64(int64(height)).AddInt64(int64(nonce)).
+		Script(); E.Chk(e) {
+		return
+	}
+	coinbase := wire.NewMsgTx(wire.TxVersion)
+	coinbase.AddTxIn(wire.NewTxIn(wire.NewOutPoint(&chainhash.Hash{}, wire.MaxPrevOutIndex), coinbaseScript, nil))
+	coinbase.AddTxOut(wire.NewTxOut(CalcBlockSubsidy(height, chain.params, version), pkScript))
+	msgBlock := &wire.Block{
+		Header: wire.BlockHeader{
+			Version:   version,
+			PrevBlock: parent.hash,
+			Timestamp: time.Unix(parent.timestamp+int64(chain.params.Forks.GetTargetTimePerBlock(height)), 0),
+			Bits:      bits,
+			Nonce:     nonce,
+		},
+	}
+	utxs := []*util.Tx{util.NewTx(coinbase)}
+	if e = msgBlock.AddTransaction(coinbase); E.Chk(e) {
+		return
+	}
+	for _, tx := range txs {
+		utxs = append(utxs, util.NewTx(tx))
+		if e = msgBlock.AddTransaction(tx); E.Chk(e) {
+			return
+		}
+	}
+	msgBlock.Header.MerkleRoot = *BuildMerkleTreeStore(utxs, false).GetRoot()
+	b = block.NewBlock(msgBlock)
+	b.SetHeight(height)
+	return
+}
+
+// processTestBlocks processes blocks built by newTestBlock with BFNoPoWCheck and the given flags, failing the test if
+// any is not accepted.
+func processTestBlocks(t *testing.T, chain *BlockChain, flags BehaviorFlags, blocks ...*block.Block) {
+	t.Helper()
+	for _, b := range blocks {
+		if _, _, e := chain.ProcessBlock(0, b, BFNoPoWCheck|flags, b.Height()); e != nil {
+			t.Fatalf("block %v at height %d was not accepted: %v", b.Hash(), b.Height(), e)
+		}
+	}
+}
@@ -7,7 +7,6 @@ import (
 	"time"
 
 	bits2 "github.com/cybriq/p9/pkg/bits"
-	"github.com/cybriq/p9/pkg/fork"
 
 	"github.com/cybriq/p9/pkg/chainhash"
 )
@@ -83,8 +82,8 @@ func (b *BlockChain) CalcNextRequiredDifficultyFromNode(
 	e error,
 ) {
 	nH := lastNode.height + 1
-	cF := fork.GetCurrent(nH)
-	newTargetBits = fork.GetMinBits(algoname, nH)
+	cF := b.params.Forks.GetCurrent(nH)
+	newTargetBits = b.params.Forks.GetMinBits(algoname, nH)
 	// Tracef("CalcNextRequiredDifficultyFromNode %08x", newTargetBits)
 	switch cF {
 	// Legacy difficulty adjustment
@@ -97,7 +96,7 @@ func (b *BlockChain) CalcNextRequiredDifficultyFromNode(
 		if bits == nil || !ok {
 			lastNode.Diffs.Store(make(Diffs))
 		}
-		version := fork.GetAlgoVer(algoname, lastNode.height+1)
+		version := b.params.Forks.GetAlgoVer(algoname, lastNode.height+1)
 		if bits[version] == 0 {
 			bits, e = b.CalcNextRequiredDifficultyPlan9Controller(lastNode)
 			if e != nil {
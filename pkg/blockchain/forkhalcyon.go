@@ -5,7 +5,6 @@ import (
 	"math/big"
 
 	"github.com/cybriq/p9/pkg/bits"
-	"github.com/cybriq/p9/pkg/fork"
 )
 
 // CalcNextRequiredDifficultyHalcyon calculates the required difficulty for the block after the passed previous block
@@ -25,10 +24,10 @@ func (b *BlockChain) CalcNextRequiredDifficultyHalcyon(
 		return newTargetBits, nil
 	}
 	// this sanitises invalid block versions according to legacy consensus quirks
-	algo := fork.GetAlgoVer(algoname, nH)
-	algoName := fork.GetAlgoName(algo, nH)
-	newTargetBits = fork.GetMinBits(algoName, nH)
-	prevNode := lastNode.GetLastWithAlgo(algo)
+	algo := b.params.Forks.GetAlgoVer(algoname, nH)
+	algoName := b.params.Forks.GetAlgoName(algo, nH)
+	newTargetBits = b.params.Forks.GetMinBits(algoName, nH)
+	prevNode := lastNode.GetLastWithAlgo(b.params.Forks, algo)
 	if prevNode == nil {
 		if l {
 			D.Ln("prevNode is nil")
@@ -37,9 +36,9 @@ func (b *BlockChain) CalcNextRequiredDifficultyHalcyon(
 	}
 	firstNode := prevNode
 	for i := int32(0); firstNode != nil &&
-		i < fork.GetAveragingInterval(nH)-1; i++ {
+		i < b.params.Forks.GetAveragingInterval(nH)-1; i++ {
 		firstNode = firstNode.RelativeAncestor(1)
-		firstNode = firstNode.GetLastWithAlgo(algo)
+		firstNode = firstNode.GetLastWithAlgo(b.params.Forks, algo)
 	}
 	if firstNode == nil {
 		return newTargetBits, nil
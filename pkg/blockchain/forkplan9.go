@@ -9,21 +9,19 @@ import (
 	"github.com/cybriq/p9/pkg/fork"
 
 	"github.com/VividCortex/ewma"
-
-	"github.com/cybriq/p9/pkg/wire"
 )
 
 // GetAlgStamps ...
 func GetAlgStamps(
-	algoName string, startHeight int32, lastNode *BlockNode,
+	forks *fork.Schedule, algoName string, startHeight int32, lastNode *BlockNode,
 ) (
 	last *BlockNode,
 	found bool, algStamps []int64, version int32,
 ) {
 
-	version = fork.P9Algos[algoName].Version
+	version = forks.List[1].Algos[algoName].Version
 	for ln := lastNode; ln != nil && ln.height > startHeight &&
-		len(algStamps) <= int(fork.List[1].AveragingInterval); ln = ln.
+		len(algStamps) <= int(forks.List[1].AveragingInterval); ln = ln.
 		RelativeAncestor(1) {
 		if ln.version == version && ln.height > startHeight {
 			algStamps = append(algStamps, ln.timestamp)
@@ -44,10 +42,10 @@ func GetAlgStamps(
 	return
 }
 
-func GetAllStamps(startHeight int32, lastNode *BlockNode) (allStamps []int64) {
+func GetAllStamps(forks *fork.Schedule, startHeight int32, lastNode *BlockNode) (allStamps []int64) {
 
 	for ln := lastNode; ln != nil && ln.height > startHeight &&
-		len(allStamps) <= int(fork.List[1].AveragingInterval); ln = ln.RelativeAncestor(1) {
+		len(allStamps) <= int(forks.List[1].AveragingInterval); ln = ln.RelativeAncestor(1) {
 		allStamps = append(allStamps, ln.timestamp)
 	}
 	// D.Ln(allStamps)
@@ -60,9 +58,9 @@ func GetAllStamps(startHeight int32, lastNode *BlockNode) (allStamps []int64) {
 	return
 }
 
-func GetAll(allStamps []int64) (allAv, allAdj float64) {
+func GetAll(forks *fork.Schedule, allStamps []int64) (allAv, allAdj float64) {
 	allAdj = 1
-	allAv = fork.P9Average
+	allAv = forks.P9Average
 	// calculate intervals
 	allIntervals := make([]float64, len(allStamps)-1)
 	for i := range allStamps {
@@ -80,7 +78,7 @@ func GetAll(allStamps []int64) (allAv, allAdj float64) {
 	allAv = aewma.Value()
 	// W.Ln(allAv)
 	if allAv != 0 {
-		allAdj = allAv / fork.P9Average
+		allAdj = allAv / forks.P9Average
 	}
 	return
 }
@@ -109,59 +107,34 @@ func GetAlg(
 	return
 }
 
-// CalcNextRequiredDifficultyPlan9 returns the consensus difficulty adjustment by processing recent past blocks
-func (b *BlockChain) CalcNextRequiredDifficultyPlan9(
-	lastNodeP *BlockNode,
-	algoName string,
-	l bool,
-) (newTargetBits uint32, adjustment float64, e error) {
-	lastNode := lastNodeP
-
-	algoVer := fork.GetAlgoVer(algoName, lastNode.height+1)
-	ttpb := float64(fork.List[1].Algos[algoName].VersionInterval)
+// CalcPlan9Target computes the next target bits for the named algorithm from the chain of blocks ending at lastNode,
+// counting only blocks after the activation of Plan 9 in the given hard fork schedule. It has no dependency on a
+// BlockChain so it can be driven by a synthetic chain of block nodes, as is done by the difficulty simulator.
+func CalcPlan9Target(forks *fork.Schedule, lastNode *BlockNode, algoName string) (
+	newTargetBits, bits uint32, adjustment, algAv, allAv float64, algoVer int32,
+) {
+	startHeight := forks.List[1].ActivationHeight
+	ttpb := float64(forks.List[1].Algos[algoName].VersionInterval)
 	newTargetBits = fork.SecondPowLimitBits
 	const minAvSamples = 3
 	adjustment = 1
-	var algAdj, allAdj, algAv, allAv float64 = 1, 1, ttpb, fork.P9Average
-	if lastNode == nil {
-		D.Ln("lastNode is nil")
-	}
-	// algoInterval := fork.P9Algos[algoname].VersionInterval
-	startHeight := fork.List[1].ActivationHeight
-	if b.params.Net == wire.TestNet3 {
-		startHeight = fork.List[1].TestnetStart
-	}
-	allStamps := GetAllStamps(startHeight, lastNode)
-	last, _, algStamps, algoVer := GetAlgStamps(algoName, startHeight, lastNode)
+	var algAdj, allAdj float64 = 1, 1
+	algAv, allAv = ttpb, forks.P9Average
+	allStamps := GetAllStamps(forks, startHeight, lastNode)
+	var last *BlockNode
+	var algStamps []int64
+	last, _, algStamps, algoVer = GetAlgStamps(forks, algoName, startHeight, lastNode)
 	if len(allStamps) > minAvSamples {
-		allAv, allAdj = GetAll(allStamps)
+		allAv, allAdj = GetAll(forks, allStamps)
 	}
 	if len(algStamps) > minAvSamples {
 		algAv, algAdj = GetAlg(algStamps, ttpb)
 	}
-	bits := fork.SecondPowLimitBits
+	bits = fork.SecondPowLimitBits
 	if last != nil {
 		bits = last.bits
 	}
-	// D.Ln(
-	//	"allAv", allAv,
-	//	"fork.P9Average", fork.P9Average,
-	//	"allAv/fork.P9Average", allAv/fork.P9Average,
-	//	"algAv", algAv,
-	//	"algAdj", algAdj,
-	//	"allAdj", allAdj)
-
 	adjustment = algAdj * allAdj
-
-	// thisInterval := fork.P9AlgosNumeric[algoVer].VersionInterval
-	// baseInterval := fork.P9AlgosNumeric[5].VersionInterval
-	// timeFactor := float64(baseInterval) / float64(thisInterval)
-	// // if adjustment < 1 {
-	// // if the difficulty is adjusting upwards, accelerate it in proportion with block interval's ratio
-	// adjustment *= timeFactor
-	// // }
-
-	// adjustment *= adjustment
 	bigAdjustment := big.NewFloat(adjustment)
 	bigOldTarget := big.NewFloat(1.0).SetInt(bits2.CompactToBig(bits))
 	bigNewTargetFloat := big.NewFloat(1.0).Mul(bigAdjustment, bigOldTarget)
@@ -174,11 +147,32 @@ func (b *BlockChain) CalcNextRequiredDifficultyPlan9(
 		newTargetBits = bits2.BigToCompact(newTarget)
 		// Tracef("newTarget %064x %08x", newTarget, newTargetBits)
 	}
+	return
+}
+
+// CalcNextRequiredDifficultyPlan9 returns the consensus difficulty adjustment by processing recent past blocks
+func (b *BlockChain) CalcNextRequiredDifficultyPlan9(
+	lastNodeP *BlockNode,
+	algoName string,
+	l bool,
+) (newTargetBits uint32, adjustment float64, e error) {
+	lastNode := lastNodeP
+	if lastNode == nil {
+		D.Ln("lastNode is nil")
+		return fork.SecondPowLimitBits, 1, nil
+	}
+	forks := b.params.Forks
+	var bits uint32
+	var algAv, allAv float64
+	var algoVer int32
+	newTargetBits, bits, adjustment, algAv, allAv, algoVer = CalcPlan9Target(
+		forks, lastNode, algoName,
+	)
 	// if l {
 	// if lastNode.version == algoVer {
 	I.Ln(
 		func() string {
-			an := fork.List[1].AlgoVers[algoVer]
+			an := forks.List[1].AlgoVers[algoVer]
 			pad := 8 - len(an)
 			if pad > 0 {
 				an += strings.Repeat(" ", pad)
@@ -201,7 +195,7 @@ func (b *BlockChain) CalcNextRequiredDifficultyPlan9(
 				an,
 				RightJustify(fmt.Sprintf("%4.2f", algAv), 8),
 				RightJustify(fmt.Sprintf("%4.2f", allAv), 7),
-				fork.P9Average,
+				forks.P9Average,
 				RightJustify(fmt.Sprintf("%4.2f", factor), 7),
 				symbol,
 				bits,
@@ -232,7 +226,7 @@ func (b *BlockChain) CalcNextRequiredDifficultyPlan9old(
 	}
 	allTimeAv, allTimeDiv, qhourDiv, hourDiv,
 		dayDiv := b.GetCommonP9Averages(lastNode, nH)
-	algoVer := fork.GetAlgoVer(algoName, nH)
+	algoVer := b.params.Forks.GetAlgoVer(algoName, nH)
 	since, ttpb, timeSinceAlgo, startHeight, last := b.GetP9Since(
 		lastNode,
 		algoVer,
@@ -256,7 +250,7 @@ func (b *BlockChain) CalcNextRequiredDifficultyPlan9old(
 		T.F("newTarget %064x %08x", newTarget, newTargetBits)
 	}
 	if l {
-		an := fork.List[1].AlgoVers[algoVer]
+		an := b.params.Forks.List[1].AlgoVers[algoVer]
 		pad := 9 - len(an)
 		if pad > 0 {
 			an += strings.Repeat(" ", pad)
@@ -278,7 +272,7 @@ func (b *BlockChain) CalcNextRequiredDifficultyPlan9old(
 					RightJustify(
 						fmt.Sprintf(
 							"%3.0f %3.3fD",
-							since-ttpb*float64(len(fork.List[1].Algos)),
+							since-ttpb*float64(len(b.params.Forks.List[1].Algos)),
 							timeSinceAlgo*ttpb,
 						), 13,
 					),
@@ -3,6 +3,7 @@ package blockchain
 import (
 	"sort"
 
+	"github.com/cybriq/p9/pkg/chaincfg"
 	"github.com/cybriq/p9/pkg/chainhash"
 	"github.com/cybriq/p9/pkg/fork"
 )
@@ -30,31 +31,40 @@ type Diffs map[int32]uint32
 
 type Merkles map[int32]*chainhash.Hash
 
+// NewDifficultyChain returns a chain with no database or block index that is only usable for computing difficulty
+// targets with CalcNextRequiredDifficultyPlan9Controller from a chain of block nodes built by the caller, as is done by
+// the difficulty simulator.
+func NewDifficultyChain(params *chaincfg.Params) (b *BlockChain) {
+	b = &BlockChain{params: params}
+	b.DifficultyBits.Store(make(Diffs))
+	return
+}
+
 // CalcNextRequiredDifficultyPlan9Controller returns all of the algorithm difficulty targets for sending out with the
 // other pieces required to construct a block, as these numbers are generated from block timestamps
 func (b *BlockChain) CalcNextRequiredDifficultyPlan9Controller(lastNode *BlockNode) (
 	diffs Diffs, e error,
 ) {
 	nH := lastNode.height + 1
-	currFork := fork.GetCurrent(nH)
+	currFork := b.params.Forks.GetCurrent(nH)
 	nTB := make(Diffs)
 	switch currFork {
 	case 0:
-		for i := range fork.List[0].Algos {
-			v := fork.List[currFork].Algos[i].Version
+		for i := range b.params.Forks.List[0].Algos {
+			v := b.params.Forks.List[currFork].Algos[i].Version
 			nTB[v], e = b.CalcNextRequiredDifficultyHalcyon(lastNode, i, true)
 		}
 		return nTB, nil
 	case 1:
 		if b.DifficultyHeight.Load() != nH {
 			b.DifficultyHeight.Store(nH)
-			currFork := fork.GetCurrent(nH)
-			algos := make(AlgoList, len(fork.List[currFork].Algos))
+			currFork := b.params.Forks.GetCurrent(nH)
+			algos := make(AlgoList, len(b.params.Forks.List[currFork].Algos))
 			var counter int
-			for i := range fork.List[1].Algos {
+			for i := range b.params.Forks.List[1].Algos {
 				algos[counter] = Algo{
 					Name:   i,
-					Params: fork.List[currFork].Algos[i],
+					Params: b.params.Forks.List[currFork].Algos[i],
 				}
 				counter++
 			}
@@ -2,9 +2,6 @@ package blockchain
 
 import (
 	"github.com/VividCortex/ewma"
-	"github.com/cybriq/p9/pkg/fork"
-
-	"github.com/cybriq/p9/pkg/wire"
 )
 
 func (b *BlockChain) GetCommonP9Averages(lastNode *BlockNode, nH int32) (
@@ -16,11 +13,8 @@ func (b *BlockChain) GetCommonP9Averages(lastNode *BlockNode, nH int32) (
 ) {
 	const minAvSamples = 2
 	allTimeAv, allTimeDiv, qhourDiv, hourDiv, dayDiv = 1.0, 1.0, 1.0, 1.0, 1.0
-	ttpb := float64(fork.List[1].TargetTimePerBlock)
-	startHeight := fork.List[1].ActivationHeight
-	if b.params.Net == wire.TestNet3 {
-		startHeight = fork.List[1].TestnetStart
-	}
+	ttpb := float64(b.params.Forks.List[1].TargetTimePerBlock)
+	startHeight := b.params.Forks.List[1].ActivationHeight
 	if nH <= startHeight {
 		D.Ln("on hard fork", nH, startHeight)
 		return
@@ -48,16 +42,16 @@ func (b *BlockChain) GetCommonP9Averages(lastNode *BlockNode, nH int32) (
 		// the previous if should prevent this occurring
 	}
 	allTimeDiv = capP9Adjustment(allTimeDiv)
-	oneHour := 60 * 60 / fork.List[1].TargetTimePerBlock
+	oneHour := 60 * 60 / b.params.Forks.List[1].TargetTimePerBlock
 	oneDay := oneHour * 24
-	qHour := 60 * 60 / fork.List[1].TargetTimePerBlock / 4
+	qHour := 60 * 60 / b.params.Forks.List[1].TargetTimePerBlock / 4
 	dayBlock := lastNode.RelativeAncestor(oneDay)
 	dayDiv = allTimeDiv
 	if dayBlock != nil {
 		// collect timestamps within averaging interval
 		dayStamps := []int64{lastNode.timestamp}
 		for ln := lastNode; ln != nil && ln.height > startHeight+2 &&
-			len(dayStamps) <= int(fork.List[1].AveragingInterval); {
+			len(dayStamps) <= int(b.params.Forks.List[1].AveragingInterval); {
 			ln = ln.RelativeAncestor(oneDay)
 			if ln == nil || ln.timestamp < oldestStamp || ln.height < startHeight {
 				break
@@ -91,7 +85,7 @@ func (b *BlockChain) GetCommonP9Averages(lastNode *BlockNode, nH int32) (
 		// collect timestamps within averaging interval
 		hourStamps := []int64{lastNode.timestamp}
 		for ln := lastNode; ln.height > startHeight+2 &&
-			len(hourStamps) <= int(fork.List[1].AveragingInterval); {
+			len(hourStamps) <= int(b.params.Forks.List[1].AveragingInterval); {
 			ln = ln.RelativeAncestor(oneHour)
 			if ln == nil || ln.timestamp < oldestStamp || ln.height < startHeight {
 				break
@@ -125,7 +119,7 @@ func (b *BlockChain) GetCommonP9Averages(lastNode *BlockNode, nH int32) (
 		// collect timestamps within averaging interval
 		qhourStamps := []int64{lastNode.timestamp}
 		for ln := lastNode; ln != nil && ln.height > startHeight &&
-			len(qhourStamps) <= int(fork.List[1].AveragingInterval); {
+			len(qhourStamps) <= int(b.params.Forks.List[1].AveragingInterval); {
 			ln = ln.RelativeAncestor(qHour)
 			if ln == nil || ln.timestamp < oldestStamp || ln.height < startHeight {
 				break
@@ -168,7 +162,7 @@ func (b *BlockChain) GetP9AlgoDiv(
 	algDiv = allTimeDiv
 	algStamps := []uint64{uint64(last.timestamp)}
 	for ln := last; ln != nil && ln.height > startHeight &&
-		len(algStamps) <= int(fork.List[1].AveragingInterval); ln = ln.
+		len(algStamps) <= int(b.params.Forks.List[1].AveragingInterval); ln = ln.
 		RelativeAncestor(1) {
 		if ln.version == algoVer && ln.height > startHeight {
 			algStamps = append(algStamps, uint64(ln.timestamp))
@@ -191,7 +185,7 @@ func (b *BlockChain) GetP9AlgoDiv(
 			for _, x := range algIntervals {
 				awi.Add(float64(x))
 			}
-			algDiv = capP9Adjustment(awi.Value() / ttpb / float64(len(fork.P9Algos)))
+			algDiv = capP9Adjustment(awi.Value() / ttpb / float64(len(b.params.Forks.List[1].Algos)))
 		}
 	}
 	return
@@ -217,8 +211,8 @@ func (b *BlockChain) GetP9Since(lastNode *BlockNode, algoVer int32) (
 		last = ln
 	}
 	since = float64(lastNode.timestamp - last.timestamp)
-	ttpb = float64(fork.List[1].TargetTimePerBlock)
-	tspb := ttpb * float64(len(fork.List[1].Algos))
+	ttpb = float64(b.params.Forks.List[1].TargetTimePerBlock)
+	tspb := ttpb * float64(len(b.params.Forks.List[1].Algos))
 	// ratio of seconds since to target seconds per block times the all time divergence ensures the change scales with
 	// the divergence from the target, and favours algos that are later
 	timeSinceAlgo = capP9Adjustment((since / tspb) / 5)
@@ -228,17 +222,7 @@ func (b *BlockChain) GetP9Since(lastNode *BlockNode, algoVer int32) (
 
 func (b *BlockChain) IsP9HardFork(nH int32) bool {
 	// At activation difficulty resets
-	switch b.params.Net {
-	case wire.MainNet:
-		if fork.List[1].ActivationHeight == nH {
-			return true
-		}
-	case wire.TestNet3:
-		if fork.List[1].TestnetStart == nH {
-			return true
-		}
-	}
-	return false
+	return b.params.Forks.List[1].ActivationHeight == nH
 }
 
 func capP9Adjustment(adjustment float64) float64 {
@@ -9,7 +9,6 @@ import (
 	"github.com/cybriq/p9/pkg/block"
 	"github.com/cybriq/p9/pkg/chainhash"
 	"github.com/cybriq/p9/pkg/database"
-	"github.com/cybriq/p9/pkg/fork"
 	"github.com/cybriq/p9/pkg/helpers"
 )
 
@@ -26,6 +25,10 @@ const (
 	// BFNoPoWCheck may be set to indicate the proof of work check which ensures a
 	// block hashes to a value less than the required target will not be performed.
 	BFNoPoWCheck
+	// BFAssumeValid may be set to indicate the block is already proven to be an
+	// ancestor of the assumed valid block, so its scripts are not checked. All
+	// other checks are still performed.
+	BFAssumeValid
 	// BFNone is a convenience value to specifically indicate no flags.
 	BFNone BehaviorFlags = 0
 )
@@ -59,7 +62,7 @@ func (b *BlockChain) ProcessBlock(
 	defer b.ChainLock.Unlock()
 	fastAdd := flags&BFFastAdd == BFFastAdd
 	blockHash := candidateBlock.Hash()
-	hf := fork.GetCurrent(blockHeight)
+	hf := b.params.Forks.GetCurrent(blockHeight)
 	bhwa := candidateBlock.WireBlock().BlockHashWithAlgos
 	var algo int32
 	switch hf {
@@ -82,7 +85,7 @@ func (b *BlockChain) ProcessBlock(
 			ErrDuplicateBlock,
 			fmt.Sprintf(
 				"already have candidateBlock %v",
-				bhwa(blockHeight).String(),
+				bhwa(b.params.Forks, blockHeight).String(),
 			),
 		)
 		E.Ln(str)
@@ -99,10 +102,10 @@ func (b *BlockChain) ProcessBlock(
 	}
 	// Perform preliminary sanity checks on the candidateBlock and its transactions.
 	var DoNotCheckPow bool
-	pl := fork.GetMinDiff(fork.GetAlgoName(algo, blockHeight), blockHeight)
+	pl := b.params.Forks.GetMinDiff(b.params.Forks.GetAlgoName(algo, blockHeight), blockHeight)
 	T.F(
 		"powLimit %d %s %d %064x", algo,
-		fork.GetAlgoName(algo, blockHeight),
+		b.params.Forks.GetAlgoName(algo, blockHeight),
 		blockHeight, pl,
 	)
 	ph := &candidateBlock.WireBlock().Header.PrevBlock
@@ -111,18 +114,19 @@ func (b *BlockChain) ProcessBlock(
 		return false, false, errors.New("could not find parent block of candidate block")
 	}
 	var pb *BlockNode
-	pb = pn.GetLastWithAlgo(algo)
+	pb = pn.GetLastWithAlgo(b.params.Forks, algo)
 	if pb == nil {
 		DoNotCheckPow = true
 	}
 	T.F(
 		"checkBlockSanity powLimit %d %s %d %064x ts %v", algo,
-		fork.GetAlgoName(algo, blockHeight), blockHeight, pl,
+		b.params.Forks.GetAlgoName(algo, blockHeight), blockHeight, pl,
 		pn.Header().Timestamp,
 	)
 	if e = checkBlockSanity(
 		candidateBlock,
 		pl,
+		b.params.Forks,
 		b.timeSource,
 		flags,
 		DoNotCheckPow,
@@ -149,7 +153,7 @@ func (b *BlockChain) ProcessBlock(
 		if blockHeader.Timestamp.Before(checkpointTime) {
 			str := fmt.Sprintf(
 				"candidateBlock %v has timestamp %v before last checkpoint timestamp %v",
-				bhwa(blockHeight).String(),
+				bhwa(b.params.Forks, blockHeight).String(),
 				blockHeader.Timestamp,
 				checkpointTime,
 			)
@@ -194,7 +198,7 @@ func (b *BlockChain) ProcessBlock(
 			func() string {
 				return fmt.Sprintf(
 					"adding orphan candidateBlock %v with parent %v",
-					bhwa(blockHeight).String(),
+					bhwa(b.params.Forks, blockHeight).String(),
 					prevHash,
 				)
 			},
@@ -224,7 +228,7 @@ func (b *BlockChain) ProcessBlock(
 	}
 	T.F(
 		"accepted candidateBlock %d %v %s",
-		blockHeight, bhwa(blockHeight).String(), fork.GetAlgoName(
+		blockHeight, bhwa(b.params.Forks, blockHeight).String(), b.params.Forks.GetAlgoName(
 			candidateBlock.WireBlock().
 				Header.Version, blockHeight,
 		),
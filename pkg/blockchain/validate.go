@@ -71,12 +71,17 @@ var (
 // main chain whereas CheckConnectBlockTemplate creates a new node which specifically connects to the end of the current
 // main chain and then calls this function with that node.
 //
+// The flags modify the behavior of this function as follows:
+//
+//  - BFAssumeValid: The transaction scripts are not checked when the chain has an assumed valid block.
+//
 // This function MUST be called with the chain state lock held (for writes).
 func (b *BlockChain) checkConnectBlock(
 	node *BlockNode,
 	block *block.Block,
 	view *UtxoViewpoint,
 	stxos *[]SpentTxOut,
+	flags BehaviorFlags,
 ) (e error) {
 	// If the side chain blocks end up in the database, a call to CheckBlockSanity should be done here in case a
 	// previous version allowed a block that is no longer valid. However, since the implementation only currently uses
@@ -225,6 +230,11 @@ func (b *BlockChain) checkConnectBlock(
 	if checkpoint != nil && node.height <= checkpoint.Height {
 		runScripts = false
 	}
+	// Likewise the scripts of the ancestors of the assumed valid block are not run, as the sync manager has proven the
+	// block links to it with the headers leading up to it.
+	if b.assumeValid != nil && flags&BFAssumeValid == BFAssumeValid {
+		runScripts = false
+	}
 	// BlockC created after the BIP0016 activation time need to have the pay -to-script-hash checks enabled.
 	var scriptFlags txscript.ScriptFlags
 	if enforceBIP0016 {
@@ -306,8 +316,8 @@ func (b *BlockChain) checkConnectBlock(
 func (b *BlockChain) CheckConnectBlockTemplate(block *block.Block) (e error) {
 	algo := block.WireBlock().Header.Version
 	height := block.Height()
-	algoname := fork.GetAlgoName(algo, height)
-	powLimit := fork.GetMinDiff(algoname, height)
+	algoname := b.params.Forks.GetAlgoName(algo, height)
+	powLimit := b.params.Forks.GetMinDiff(algoname, height)
 	// Skip the proof of work check as this is just a block template.
 	flags := BFNoPoWCheck
 	// This only checks whether the block can be connected to the tip of the current chain.
@@ -329,6 +339,7 @@ func (b *BlockChain) CheckConnectBlockTemplate(block *block.Block) (e error) {
 	if e = checkBlockSanity(
 		block,
 		powLimit,
+		b.params.Forks,
 		b.timeSource,
 		flags,
 		false,
@@ -346,7 +357,7 @@ func (b *BlockChain) CheckConnectBlockTemplate(block *block.Block) (e error) {
 	view := NewUtxoViewpoint()
 	view.SetBestHash(&tip.hash)
 	newNode := NewBlockNode(&header, tip)
-	return b.checkConnectBlock(newNode, block, view, nil)
+	return b.checkConnectBlock(newNode, block, view, nil, flags)
 }
 
 // checkBIP0030 ensures blocks do not contain duplicate transactions which 'overwrite' older transactions that are not
@@ -513,13 +524,13 @@ func (b *BlockChain) checkBlockHeaderContext(
 		// Ensure the difficulty specified in the block header matches the calculated difficulty based on the previous
 		// block and difficulty retarget rules.
 		//
-		// a := fork.GetAlgoName(header.Version, prevNode.height+1)
+		// a := b.params.Forks.GetAlgoName(header.Version, prevNode.height+1)
 		// I.F("algo %s %d %8x %d", a, header.Version, header.Bits,
 		// 	prevNode.height+1)
 		var expectedDifficulty uint32
 		expectedDifficulty, e = b.CalcNextRequiredDifficultyFromNode(
 			prevNode,
-			fork.GetAlgoName(header.Version, prevNode.height+1),
+			b.params.Forks.GetAlgoName(header.Version, prevNode.height+1),
 			true,
 		)
 		if e != nil {
@@ -539,7 +550,7 @@ func (b *BlockChain) checkBlockHeaderContext(
 			E.Ln(str)
 			return ruleError(ErrUnexpectedDifficulty, str)
 		}
-		if fork.GetCurrent(prevNode.height+1) > 0 {
+		if b.params.Forks.GetCurrent(prevNode.height+1) > 0 {
 			ct := header.Timestamp.Truncate(time.Second)
 			pt := prevNode.Header().Timestamp.Truncate(time.Second)
 			if ct.Sub(pt) < time.Second {
@@ -620,7 +631,7 @@ func CalcBlockSubsidy(
 		return int64(baseSubsidy)
 	}
 	// Equivalent to: baseSubsidy / 2^(height/subsidyHalvingInterval)
-	switch fork.GetCurrent(height) {
+	switch chainParams.Forks.GetCurrent(height) {
 
 	case 0:
 		return int64(baseSubsidy) >> uint64(
@@ -632,7 +643,7 @@ func CalcBlockSubsidy(
 		// Plan 9 hard fork prescribes a smooth supply curve made using an exponential decay formula adjusted to fit the
 		// previous halving cycle and accounting for the block time difference
 		ttpb := float64(
-			fork.List[1].Algos[fork.GetAlgoName(
+			chainParams.Forks.List[1].Algos[chainParams.Forks.GetAlgoName(
 				version,
 				height,
 			)].VersionInterval,
@@ -655,6 +666,7 @@ func CalcBlockSubsidy(
 func CheckBlockSanity(
 	block *block.Block,
 	powLimit *big.Int,
+	forks *fork.Schedule,
 	timeSource MedianTimeSource,
 	DoNotCheckPow bool,
 	height int32,
@@ -662,7 +674,7 @@ func CheckBlockSanity(
 ) (e error) {
 	F.Ln("CheckBlockSanity powlimit %64x", powLimit)
 	return checkBlockSanity(
-		block, powLimit, timeSource, BFNone, DoNotCheckPow,
+		block, powLimit, forks, timeSource, BFNone, DoNotCheckPow,
 		height, prevBlockTimestamp,
 	)
 }
@@ -670,9 +682,9 @@ func CheckBlockSanity(
 // CheckProofOfWork ensures the block header bits which indicate the target difficulty is in min/max range and that the
 // block hash is less than the target difficulty as claimed.
 func CheckProofOfWork(
-	block *block.Block, powLimit *big.Int, height int32,
+	block *block.Block, powLimit *big.Int, forks *fork.Schedule, height int32,
 ) (e error) {
-	return checkProofOfWork(&block.WireBlock().Header, powLimit, BFNone, height)
+	return checkProofOfWork(&block.WireBlock().Header, powLimit, forks, BFNone, height)
 }
 
 // CheckTransactionInputs performs a series of checks on the inputs to a transaction to ensure they are valid.
@@ -1101,6 +1113,7 @@ func ShouldHaveSerializedBlockHeight(header *wire.BlockHeader) bool {
 func checkBlockHeaderSanity(
 	header *wire.BlockHeader,
 	powLimit *big.Int,
+	forks *fork.Schedule,
 	timeSource MedianTimeSource,
 	flags BehaviorFlags,
 	height int32,
@@ -1108,7 +1121,7 @@ func checkBlockHeaderSanity(
 ) (e error) {
 	// Ensure the proof of work bits in the block header is in min/max range and the
 	// block hash is less than the target value described by the bits.
-	e = checkProofOfWork(header, powLimit, flags, height)
+	e = checkProofOfWork(header, powLimit, forks, flags, height)
 	if e != nil {
 		E.F("%+v %v", header, e)
 		return e
@@ -1135,7 +1148,7 @@ func checkBlockHeaderSanity(
 		)
 		return ruleError(ErrTimeTooNew, str)
 	}
-	if fork.GetCurrent(height) > 0 {
+	if forks.GetCurrent(height) > 0 {
 		cbts := header.Timestamp.Truncate(time.Second)
 		pbts := prevBlockTimestamp.Truncate(time.Second)
 		// D.Ln("TIMESTAMP PREV", pbts, "CANDIDATE", cbts)
@@ -1162,6 +1175,7 @@ func checkBlockHeaderSanity(
 func checkBlockSanity(
 	block *block.Block,
 	powLimit *big.Int,
+	forks *fork.Schedule,
 	timeSource MedianTimeSource,
 	flags BehaviorFlags,
 	DoNotCheckPow bool,
@@ -1172,7 +1186,7 @@ func checkBlockSanity(
 	msgBlock := block.WireBlock()
 	header := &msgBlock.Header
 	e = checkBlockHeaderSanity(
-		header, powLimit, timeSource, flags, height,
+		header, powLimit, forks, timeSource, flags, height,
 		prevBlockTimestamp,
 	)
 	if e != nil {
@@ -1285,7 +1299,7 @@ func checkBlockSanity(
 //  - BFNoPoWCheck: The check to ensure the block hash is less than the target
 //  difficulty is not performed.
 func checkProofOfWork(
-	header *wire.BlockHeader, powLimit *big.Int, flags BehaviorFlags,
+	header *wire.BlockHeader, powLimit *big.Int, forks *fork.Schedule, flags BehaviorFlags,
 	height int32,
 ) (e error) {
 	// The target difficulty must be larger than zero.
@@ -1318,7 +1332,7 @@ func checkProofOfWork(
 	if flags&BFNoPoWCheck == 0 {
 		// The block hash must be less than the claimed target. Unless there is less
 		// than 10 previous with the same version (algo)...
-		hash := header.BlockHashWithAlgos(height)
+		hash := header.BlockHashWithAlgos(forks, height)
 		bigHash := HashToBig(&hash)
 		if bigHash.Cmp(target) > 0 {
 			str := fmt.Sprintf(
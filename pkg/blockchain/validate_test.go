@@ -10,6 +10,7 @@ import (
 
 	"github.com/cybriq/p9/pkg/chaincfg"
 	"github.com/cybriq/p9/pkg/chainhash"
+	"github.com/cybriq/p9/pkg/txscript"
 	"github.com/cybriq/p9/pkg/util"
 	"github.com/cybriq/p9/pkg/wire"
 )
@@ -142,6 +143,7 @@ func TestCheckBlockSanity(t *testing.T) {
 	e := CheckBlockSanity(
 		block,
 		powLimit,
+		chaincfg.MainNetParams.Forks,
 		timeSource,
 		false,
 		1,
@@ -156,6 +158,7 @@ func TestCheckBlockSanity(t *testing.T) {
 	e = CheckBlockSanity(
 		block,
 		powLimit,
+		chaincfg.MainNetParams.Forks,
 		timeSource,
 		false,
 		1,
@@ -494,3 +497,68 @@ var Block100000 = wire.Block{
 		},
 	},
 }
+
+// assumeValidSetup returns a chain of two blocks, the first of which pays its coinbase to a script that always fails,
+// along with a transaction spending that output, so a block containing it only connects if its scripts are not run.
+func assumeValidSetup(t *testing.T, dbName string) (chain *BlockChain, teardown func(), badSpend *wire.MsgTx) {
+	var e error
+	if chain, teardown, e = chainSetup(dbName, testChainParams()); e != nil {
+		t.Fatalf("failed to set up chain: %v", e)
+	}
+	chain.TstSetCoinbaseMaturity(1)
+	b1, e := newTestBlock(chain, chain.BestChain.Tip(), 0, []byte{txscript.OP_FALSE})
+	if e != nil {
+		teardown()
+		t.Fatalf("failed to create block: %v", e)
+	}
+	processTestBlocks(t, chain, BFNone, b1)
+	b2, e := newTestBlock(chain, chain.BestChain.Tip(), 0, []byte{txscript.OP_TRUE})
+	if e != nil {
+		teardown()
+		t.Fatalf("failed to create block: %v", e)
+	}
+	processTestBlocks(t, chain, BFNone, b2)
+	coinbase := b1.Transactions()[0]
+	badSpend = wire.NewMsgTx(wire.TxVersion)
+	badSpend.AddTxIn(wire.NewTxIn(wire.NewOutPoint(coinbase.Hash(), 0), nil, nil))
+	badSpend.AddTxOut(wire.NewTxOut(coinbase.MsgTx().TxOut[0].Value, []byte{txscript.OP_TRUE}))
+	return
+}
+
+// TestCheckConnectBlockAssumeValid ensures the scripts of a block are only skipped when it is flagged as an ancestor
+// of the assumed valid block and the chain has an assumed valid block.
+func TestCheckConnectBlockAssumeValid(t *testing.T) {
+	chain, teardown, badSpend := assumeValidSetup(t, "checkconnectblockassumevalid")
+	defer teardown()
+	assumeValid := chain.BestChain.Tip().hash
+	tests := []struct {
+		name        string
+		assumeValid *chainhash.Hash
+		flags       BehaviorFlags
+		accepted    bool
+	}{
+		{"no assumed valid block", nil, BFAssumeValid, false},
+		{"not flagged", &assumeValid, BFNone, false},
+		{"flagged", &assumeValid, BFAssumeValid, true},
+	}
+	for i, test := range tests {
+		chain.assumeValid = test.assumeValid
+		b, e := newTestBlock(chain, chain.BestChain.Tip(), uint32(i), []byte{txscript.OP_TRUE}, badSpend)
+		if e != nil {
+			t.Fatalf("%s: failed to create block: %v", test.name, e)
+		}
+		_, _, e = chain.ProcessBlock(0, b, BFNoPoWCheck|test.flags, b.Height())
+		if test.accepted {
+			if e != nil {
+				t.Errorf("%s: block was not accepted: %v", test.name, e)
+			}
+			continue
+		}
+		if re, ok := e.(RuleError); !ok || re.ErrorCode != ErrScriptValidation {
+			t.Errorf("%s: expected a script validation error, got %v", test.name, e)
+		}
+	}
+	if chain.BestChain.Tip().height != 3 {
+		t.Errorf("expected the flagged block to be the tip, got height %d", chain.BestChain.Tip().height)
+	}
+}
@@ -456,6 +456,34 @@ func TestChainSvrCmds(t *testing.T) {
 				TxID: "txhash",
 			},
 		},
+		{
+			name: "getmempoolancestors",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("getmempoolancestors", "txhash")
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewGetMempoolAncestorsCmd("txhash", nil)
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"getmempoolancestors","netparams":["txhash"],"id":1}`,
+			unmarshalled: &btcjson.GetMempoolAncestorsCmd{
+				TxID:    "txhash",
+				Verbose: btcjson.Bool(false),
+			},
+		},
+		{
+			name: "getmempooldescendants optional",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("getmempooldescendants", "txhash", true)
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewGetMempoolDescendantsCmd("txhash", btcjson.Bool(true))
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"getmempooldescendants","netparams":["txhash",true],"id":1}`,
+			unmarshalled: &btcjson.GetMempoolDescendantsCmd{
+				TxID:    "txhash",
+				Verbose: btcjson.Bool(true),
+			},
+		},
 		{
 			name: "getmempoolinfo",
 			newCmd: func() (interface{}, error) {
@@ -467,6 +495,17 @@ func TestChainSvrCmds(t *testing.T) {
 			marshalled:   `{"jsonrpc":"1.0","method":"getmempoolinfo","netparams":[],"id":1}`,
 			unmarshalled: &btcjson.GetMempoolInfoCmd{},
 		},
+		{
+			name: "getlanpeers",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("getlanpeers")
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewGetLANPeersCmd()
+			},
+			marshalled:   `{"jsonrpc":"1.0","method":"getlanpeers","netparams":[],"id":1}`,
+			unmarshalled: &btcjson.GetLANPeersCmd{},
+		},
 		{
 			name: "getmininginfo",
 			newCmd: func() (interface{}, error) {
@@ -750,6 +789,28 @@ func TestChainSvrCmds(t *testing.T) {
 				BlockHash: "123",
 			},
 		},
+		{
+			name: "loadmempool",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("loadmempool")
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewLoadMempoolCmd()
+			},
+			marshalled:   `{"jsonrpc":"1.0","method":"loadmempool","netparams":[],"id":1}`,
+			unmarshalled: &btcjson.LoadMempoolCmd{},
+		},
+		{
+			name: "savemempool",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("savemempool")
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewSaveMempoolCmd()
+			},
+			marshalled:   `{"jsonrpc":"1.0","method":"savemempool","netparams":[],"id":1}`,
+			unmarshalled: &btcjson.SaveMempoolCmd{},
+		},
 		{
 			name: "ping",
 			newCmd: func() (interface{}, error) {
@@ -152,9 +152,18 @@ type GetBlockVerboseResult struct {
 	NextHash      string        `json:"nextblockhash,omitempty"`
 }
 
+// GetChainTipsResult models the data returned from the getchaintips command.
+type GetChainTipsResult struct {
+	Height    int32  `json:"height"`
+	Hash      string `json:"hash"`
+	BranchLen int32  `json:"branchlen"`
+	Status    string `json:"status"`
+}
+
 // GetMempoolEntryResult models the data returned from the getmempoolentry command.
 type GetMempoolEntryResult struct {
 	Size             int32    `json:"size"`
+	VSize            int32    `json:"vsize"`
 	Fee              float64  `json:"fee"`
 	ModifiedFee      float64  `json:"modifiedfee"`
 	Time             int64    `json:"time"`
@@ -167,13 +176,31 @@ type GetMempoolEntryResult struct {
 	AncestorCount    int64    `json:"ancestorcount"`
 	AncestorSize     int64    `json:"ancestorsize"`
 	AncestorFees     float64  `json:"ancestorfees"`
+	PackageFeeRate   float64  `json:"packagefeerate"`
 	Depends          []string `json:"depends"`
+	SpentBy          []string `json:"spentby"`
 }
 
 // GetMempoolInfoResult models the data returned from the getmempoolinfo command.
 type GetMempoolInfoResult struct {
-	Size  int64 `json:"size"`
-	Bytes int64 `json:"bytes"`
+	Size          int64   `json:"size"`
+	Bytes         int64   `json:"bytes"`
+	MaxMempool    int64   `json:"maxmempool"`
+	MempoolMinFee float64 `json:"mempoolminfee"`
+	MinRelayTxFee float64 `json:"minrelaytxfee"`
+}
+
+// LoadMempoolResult models the data returned from the loadmempool command.
+type LoadMempoolResult struct {
+	Accepted int `json:"accepted"`
+	Present  int `json:"present"`
+	Failed   int `json:"failed"`
+}
+
+// SaveMempoolResult models the data returned from the savemempool command.
+type SaveMempoolResult struct {
+	Saved    int    `json:"saved"`
+	Filename string `json:"filename"`
 }
 
 // GetMiningInfoResult models the data from the getmininginfo command.
@@ -204,6 +231,68 @@ type GetMiningInfoResult struct {
 	TestNet             bool    `json:"testnet"`
 }
 
+// GetAlgoStatsResult models the data returned from the getalgostats command.
+type GetAlgoStatsResult struct {
+	Height   int32             `json:"height"`
+	Fork     string            `json:"fork"`
+	Blocks   int               `json:"blocks"`
+	Timespan int64             `json:"timespan"`
+	Algos    []AlgoStatsResult `json:"algos"`
+}
+
+// AlgoStatsResult models the recent activity of a block version.
+type AlgoStatsResult struct {
+	Name            string  `json:"name"`
+	Version         int32   `json:"version"`
+	Bits            string  `json:"bits"`
+	Difficulty      float64 `json:"difficulty"`
+	TargetInterval  float64 `json:"targetinterval"`
+	AverageInterval float64 `json:"averageinterval"`
+	SinceLast       float64 `json:"sincelast"`
+	LastHeight      int32   `json:"lastheight"`
+	Blocks          int     `json:"blocks"`
+	Share           float64 `json:"share"`
+	HashesPerSec    float64 `json:"hashespersec"`
+}
+
+// GetLANPeersResult models a node on the local network found from its advertisments, as returned by the getlanpeers
+// command.
+type GetLANPeersResult struct {
+	UUID      uint64   `json:"uuid"`
+	Addr      string   `json:"addr"`
+	IPs       []string `json:"ips"`
+	Outbound  bool     `json:"outbound"`
+	FirstSeen int64    `json:"firstseen"`
+	LastSeen  int64    `json:"lastseen"`
+}
+
+// GetPoolInfoResult models the data returned from the getpoolinfo command.
+type GetPoolInfoResult struct {
+	Window     int               `json:"window"`
+	ShareRatio int               `json:"shareratio"`
+	Shares     int               `json:"shares"`
+	Reward     float64           `json:"reward"`
+	Miners     []PoolMinerResult `json:"miners"`
+	Blocks     []PoolBlockResult `json:"blocks"`
+}
+
+// PoolMinerResult models the shares and balances of an address in the share window of a pool.
+type PoolMinerResult struct {
+	Address   string  `json:"address"`
+	Shares    int     `json:"shares"`
+	LastShare int64   `json:"lastshare"`
+	Pending   float64 `json:"pending"`
+	Paid      float64 `json:"paid"`
+}
+
+// PoolBlockResult models a block found by a pool and its coinbase payouts.
+type PoolBlockResult struct {
+	Height  int32              `json:"height"`
+	Hash    string             `json:"hash"`
+	Time    int64              `json:"time"`
+	Payouts map[string]float64 `json:"payouts"`
+}
+
 // GetMiningInfoResult0 is the pre-hardfork mining info response
 type GetMiningInfoResult0 struct {
 	Blocks             int64   `json:"blocks"`
@@ -284,6 +373,13 @@ type GetRawMempoolVerboseResult struct {
 	StartingPriority float64  `json:"startingpriority"`
 	CurrentPriority  float64  `json:"currentpriority"`
 	Depends          []string `json:"depends"`
+	AncestorCount    int64    `json:"ancestorcount"`
+	AncestorSize     int64    `json:"ancestorsize"`
+	AncestorFees     float64  `json:"ancestorfees"`
+	DescendantCount  int64    `json:"descendantcount"`
+	DescendantSize   int64    `json:"descendantsize"`
+	DescendantFees   float64  `json:"descendantfees"`
+	PackageFeeRate   float64  `json:"packagefeerate"`
 }
 
 // GetTxOutResult models the data from the gettxout command.
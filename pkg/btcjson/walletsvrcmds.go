@@ -35,6 +35,33 @@ func NewAddWitnessAddressCmd(address string) *AddWitnessAddressCmd {
 	}
 }
 
+// BackupWalletCmd defines the backupwallet JSON-RPC command.
+type BackupWalletCmd struct {
+	Destination string
+}
+
+// NewBackupWalletCmd returns a new instance which can be used to issue a backupwallet JSON-RPC command.
+func NewBackupWalletCmd(destination string) *BackupWalletCmd {
+	return &BackupWalletCmd{
+		Destination: destination,
+	}
+}
+
+// BumpFeeCmd defines the bumpfee JSON-RPC command.
+type BumpFeeCmd struct {
+	TxID    string
+	FeeRate *float64 // In DUO/kB
+}
+
+// NewBumpFeeCmd returns a new instance which can be used to issue a bumpfee JSON-RPC command. The parameters which are
+// pointers indicate they are optional. Passing nil for optional parameters will use the default value.
+func NewBumpFeeCmd(txID string, feeRate *float64) *BumpFeeCmd {
+	return &BumpFeeCmd{
+		TxID:    txID,
+		FeeRate: feeRate,
+	}
+}
+
 // CreateMultisigCmd defines the createmultisig JSON-RPC command.
 type CreateMultisigCmd struct {
 	NRequired int
@@ -142,6 +169,18 @@ func NewGetAddressesByAccountCmd(account string) *GetAddressesByAccountCmd {
 	}
 }
 
+// GetAddressesByLabelCmd defines the getaddressesbylabel JSON-RPC command.
+type GetAddressesByLabelCmd struct {
+	Label string
+}
+
+// NewGetAddressesByLabelCmd returns a new instance which can be used to issue a getaddressesbylabel JSON-RPC command.
+func NewGetAddressesByLabelCmd(label string) *GetAddressesByLabelCmd {
+	return &GetAddressesByLabelCmd{
+		Label: label,
+	}
+}
+
 // GetBalanceCmd defines the getbalance JSON-RPC command.
 type GetBalanceCmd struct {
 	Account *string
@@ -308,6 +347,27 @@ func NewListAddressGroupingsCmd() *ListAddressGroupingsCmd {
 	return &ListAddressGroupingsCmd{}
 }
 
+// ListAddressLabelsCmd defines the listaddresslabels JSON-RPC command.
+type ListAddressLabelsCmd struct{}
+
+// NewListAddressLabelsCmd returns a new instance which can be used to issue a listaddresslabels JSON-RPC command.
+func NewListAddressLabelsCmd() *ListAddressLabelsCmd {
+	return &ListAddressLabelsCmd{}
+}
+
+// ListLabelsCmd defines the listlabels JSON-RPC command.
+type ListLabelsCmd struct {
+	Purpose *string
+}
+
+// NewListLabelsCmd returns a new instance which can be used to issue a listlabels JSON-RPC command. The parameters
+// which are pointers indicate they are optional. Passing nil for optional parameters will use the default value.
+func NewListLabelsCmd(purpose *string) *ListLabelsCmd {
+	return &ListLabelsCmd{
+		Purpose: purpose,
+	}
+}
+
 // ListLockUnspentCmd defines the listlockunspent JSON-RPC command.
 type ListLockUnspentCmd struct{}
 
@@ -501,6 +561,7 @@ type SendManyCmd struct {
 	Amounts     map[string]float64 `jsonrpcusage:"{\"address\":amount,...}"` // In DUO
 	MinConf     *int               `jsonrpcdefault:"1"`
 	Comment     *string
+	Replaceable *bool
 }
 
 // NewSendManyCmd returns a new instance which can be used to issue a sendmany JSON-RPC command. The parameters which
@@ -510,21 +571,24 @@ func NewSendManyCmd(
 	amounts map[string]float64,
 	minConf *int,
 	comment *string,
+	replaceable *bool,
 ) *SendManyCmd {
 	return &SendManyCmd{
 		FromAccount: fromAccount,
 		Amounts:     amounts,
 		MinConf:     minConf,
 		Comment:     comment,
+		Replaceable: replaceable,
 	}
 }
 
 // SendToAddressCmd defines the sendtoaddress JSON-RPC command.
 type SendToAddressCmd struct {
-	Address   string
-	Amount    float64
-	Comment   *string
-	CommentTo *string
+	Address     string
+	Amount      float64
+	Comment     *string
+	CommentTo   *string
+	Replaceable *bool
 }
 
 // NewSendToAddressCmd returns a new instance which can be used to issue a sendtoaddress JSON-RPC command. The
@@ -534,12 +598,14 @@ func NewSendToAddressCmd(
 	address string,
 	amount float64,
 	comment, commentTo *string,
+	replaceable *bool,
 ) *SendToAddressCmd {
 	return &SendToAddressCmd{
-		Address:   address,
-		Amount:    amount,
-		Comment:   comment,
-		CommentTo: commentTo,
+		Address:     address,
+		Amount:      amount,
+		Comment:     comment,
+		CommentTo:   commentTo,
+		Replaceable: replaceable,
 	}
 }
 
@@ -557,6 +623,21 @@ func NewSetAccountCmd(address, account string) *SetAccountCmd {
 	}
 }
 
+// SetLabelCmd defines the setlabel JSON-RPC command.
+type SetLabelCmd struct {
+	Target string
+	Label  string
+}
+
+// NewSetLabelCmd returns a new instance which can be used to issue a setlabel JSON-RPC command. The target is either
+// an address or the hash of a wallet transaction.
+func NewSetLabelCmd(target, label string) *SetLabelCmd {
+	return &SetLabelCmd{
+		Target: target,
+		Label:  label,
+	}
+}
+
 // SetTxFeeCmd defines the settxfee JSON-RPC command.
 type SetTxFeeCmd struct {
 	Amount float64 // In DUO
@@ -661,6 +742,8 @@ func init() {
 	flags := UFWalletOnly
 	MustRegisterCmd("addmultisigaddress", (*AddMultisigAddressCmd)(nil), flags)
 	MustRegisterCmd("addwitnessaddress", (*AddWitnessAddressCmd)(nil), flags)
+	MustRegisterCmd("backupwallet", (*BackupWalletCmd)(nil), flags)
+	MustRegisterCmd("bumpfee", (*BumpFeeCmd)(nil), flags)
 	MustRegisterCmd("createmultisig", (*CreateMultisigCmd)(nil), flags)
 	MustRegisterCmd("dropwallethistory", (*DropWalletHistoryCmd)(nil), flags)
 	MustRegisterCmd("dumpprivkey", (*DumpPrivKeyCmd)(nil), flags)
@@ -674,6 +757,11 @@ func init() {
 		(*GetAddressesByAccountCmd)(nil),
 		flags,
 	)
+	MustRegisterCmd(
+		"getaddressesbylabel",
+		(*GetAddressesByLabelCmd)(nil),
+		flags,
+	)
 	MustRegisterCmd("getbalance", (*GetBalanceCmd)(nil), flags)
 	MustRegisterCmd("getnewaddress", (*GetNewAddressCmd)(nil), flags)
 	MustRegisterCmd(
@@ -701,6 +789,8 @@ func init() {
 		(*ListAddressGroupingsCmd)(nil),
 		flags,
 	)
+	MustRegisterCmd("listaddresslabels", (*ListAddressLabelsCmd)(nil), flags)
+	MustRegisterCmd("listlabels", (*ListLabelsCmd)(nil), flags)
 	MustRegisterCmd("listlockunspent", (*ListLockUnspentCmd)(nil), flags)
 	MustRegisterCmd(
 		"listreceivedbyaccount",
@@ -721,6 +811,7 @@ func init() {
 	MustRegisterCmd("sendmany", (*SendManyCmd)(nil), flags)
 	MustRegisterCmd("sendtoaddress", (*SendToAddressCmd)(nil), flags)
 	MustRegisterCmd("setaccount", (*SetAccountCmd)(nil), flags)
+	MustRegisterCmd("setlabel", (*SetLabelCmd)(nil), flags)
 	MustRegisterCmd("settxfee", (*SetTxFeeCmd)(nil), flags)
 	MustRegisterCmd("signmessage", (*SignMessageCmd)(nil), flags)
 	MustRegisterCmd("signrawtransaction", (*SignRawTransactionCmd)(nil), flags)
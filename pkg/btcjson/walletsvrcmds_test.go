@@ -77,6 +77,47 @@ func TestWalletSvrCmds(t *testing.T) {
 				Address: "1address",
 			},
 		},
+		{
+			name: "backupwallet",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("backupwallet", "/tmp/backup")
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewBackupWalletCmd("/tmp/backup")
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"backupwallet","netparams":["/tmp/backup"],"id":1}`,
+			unmarshalled: &btcjson.BackupWalletCmd{
+				Destination: "/tmp/backup",
+			},
+		},
+		{
+			name: "bumpfee",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("bumpfee", "123")
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewBumpFeeCmd("123", nil)
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"bumpfee","netparams":["123"],"id":1}`,
+			unmarshalled: &btcjson.BumpFeeCmd{
+				TxID:    "123",
+				FeeRate: nil,
+			},
+		},
+		{
+			name: "bumpfee optional",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("bumpfee", "123", 0.0002)
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewBumpFeeCmd("123", btcjson.Float64(0.0002))
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"bumpfee","netparams":["123",0.0002],"id":1}`,
+			unmarshalled: &btcjson.BumpFeeCmd{
+				TxID:    "123",
+				FeeRate: btcjson.Float64(0.0002),
+			},
+		},
 		{
 			name: "createmultisig",
 			newCmd: func() (interface{}, error) {
@@ -186,6 +227,19 @@ func TestWalletSvrCmds(t *testing.T) {
 				Account: "acct",
 			},
 		},
+		{
+			name: "getaddressesbylabel",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("getaddressesbylabel", "friends")
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewGetAddressesByLabelCmd("friends")
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"getaddressesbylabel","netparams":["friends"],"id":1}`,
+			unmarshalled: &btcjson.GetAddressesByLabelCmd{
+				Label: "friends",
+			},
+		},
 		{
 			name: "getbalance",
 			newCmd: func() (interface{}, error) {
@@ -498,6 +552,43 @@ func TestWalletSvrCmds(t *testing.T) {
 			marshalled:   `{"jsonrpc":"1.0","method":"listaddressgroupings","netparams":[],"id":1}`,
 			unmarshalled: &btcjson.ListAddressGroupingsCmd{},
 		},
+		{
+			name: "listaddresslabels",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("listaddresslabels")
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewListAddressLabelsCmd()
+			},
+			marshalled:   `{"jsonrpc":"1.0","method":"listaddresslabels","netparams":[],"id":1}`,
+			unmarshalled: &btcjson.ListAddressLabelsCmd{},
+		},
+		{
+			name: "listlabels",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("listlabels")
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewListLabelsCmd(nil)
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"listlabels","netparams":[],"id":1}`,
+			unmarshalled: &btcjson.ListLabelsCmd{
+				Purpose: nil,
+			},
+		},
+		{
+			name: "listlabels optional",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("listlabels", "send")
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewListLabelsCmd(btcjson.String("send"))
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"listlabels","netparams":["send"],"id":1}`,
+			unmarshalled: &btcjson.ListLabelsCmd{
+				Purpose: btcjson.String("send"),
+			},
+		},
 		{
 			name: "listlockunspent",
 			newCmd: func() (interface{}, error) {
@@ -1058,7 +1149,7 @@ func TestWalletSvrCmds(t *testing.T) {
 			},
 			staticCmd: func() interface{} {
 				amounts := map[string]float64{"1Address": 0.5}
-				return btcjson.NewSendManyCmd("from", amounts, nil, nil)
+				return btcjson.NewSendManyCmd("from", amounts, nil, nil, nil)
 			},
 			marshalled: `{"jsonrpc":"1.0","method":"sendmany","netparams":["from",{"1Address":0.5}],"id":1}`,
 			unmarshalled: &btcjson.SendManyCmd{
@@ -1077,7 +1168,7 @@ func TestWalletSvrCmds(t *testing.T) {
 				amounts := map[string]float64{"1Address": 0.5}
 				return btcjson.NewSendManyCmd(
 					"from", amounts, btcjson.Int(6),
-					nil,
+					nil, nil,
 				)
 			},
 			marshalled: `{"jsonrpc":"1.0","method":"sendmany","netparams":["from",{"1Address":0.5},6],"id":1}`,
@@ -1100,7 +1191,7 @@ func TestWalletSvrCmds(t *testing.T) {
 				amounts := map[string]float64{"1Address": 0.5}
 				return btcjson.NewSendManyCmd(
 					"from", amounts, btcjson.Int(6),
-					btcjson.String("comment"),
+					btcjson.String("comment"), nil,
 				)
 			},
 			marshalled: `{"jsonrpc":"1.0","method":"sendmany","netparams":["from",{"1Address":0.5},6,"comment"],"id":1}`,
@@ -1117,7 +1208,7 @@ func TestWalletSvrCmds(t *testing.T) {
 				return btcjson.NewCmd("sendtoaddress", "1Address", 0.5)
 			},
 			staticCmd: func() interface{} {
-				return btcjson.NewSendToAddressCmd("1Address", 0.5, nil, nil)
+				return btcjson.NewSendToAddressCmd("1Address", 0.5, nil, nil, nil)
 			},
 			marshalled: `{"jsonrpc":"1.0","method":"sendtoaddress","netparams":["1Address",0.5],"id":1}`,
 			unmarshalled: &btcjson.SendToAddressCmd{
@@ -1139,7 +1230,7 @@ func TestWalletSvrCmds(t *testing.T) {
 				return btcjson.NewSendToAddressCmd(
 					"1Address", 0.5,
 					btcjson.String("comment"),
-					btcjson.String("commentto"),
+					btcjson.String("commentto"), nil,
 				)
 			},
 			marshalled: `{"jsonrpc":"1.0","method":"sendtoaddress","netparams":["1Address",0.5,"comment","commentto"],"id":1}`,
@@ -1150,6 +1241,30 @@ func TestWalletSvrCmds(t *testing.T) {
 				CommentTo: btcjson.String("commentto"),
 			},
 		},
+		{
+			name: "sendtoaddress optional2",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd(
+					"sendtoaddress", "1Address", 0.5,
+					"", "", true,
+				)
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewSendToAddressCmd(
+					"1Address", 0.5,
+					btcjson.String(""),
+					btcjson.String(""), btcjson.Bool(true),
+				)
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"sendtoaddress","netparams":["1Address",0.5,"","",true],"id":1}`,
+			unmarshalled: &btcjson.SendToAddressCmd{
+				Address:     "1Address",
+				Amount:      0.5,
+				Comment:     btcjson.String(""),
+				CommentTo:   btcjson.String(""),
+				Replaceable: btcjson.Bool(true),
+			},
+		},
 		{
 			name: "setaccount",
 			newCmd: func() (interface{}, error) {
@@ -1164,6 +1279,20 @@ func TestWalletSvrCmds(t *testing.T) {
 				Account: "acct",
 			},
 		},
+		{
+			name: "setlabel",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("setlabel", "1Address", "friends")
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewSetLabelCmd("1Address", "friends")
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"setlabel","netparams":["1Address","friends"],"id":1}`,
+			unmarshalled: &btcjson.SetLabelCmd{
+				Target: "1Address",
+				Label:  "friends",
+			},
+		},
 		{
 			name: "settxfee",
 			newCmd: func() (interface{}, error) {
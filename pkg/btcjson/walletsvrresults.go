@@ -1,6 +1,31 @@
 package btcjson
 
 type (
+	// AddressGroupingResult models an address in one of the groups of the listaddressgroupings command.
+	AddressGroupingResult struct {
+		Address string  `json:"address"`
+		Amount  float64 `json:"amount"`
+		Account string  `json:"account,omitempty"`
+	}
+	// AddressLabelResult models an address of the listaddresslabels command.
+	AddressLabelResult struct {
+		Label   string `json:"label"`
+		Purpose string `json:"purpose"`
+	}
+	// AddressPurposeResult models an address of the getaddressesbylabel command.
+	AddressPurposeResult struct {
+		Purpose string `json:"purpose"`
+	}
+	// BumpFeeResult models the data from the bumpfee command.
+	BumpFeeResult struct {
+		TxID    string  `json:"txid"`
+		OrigFee float64 `json:"origfee"`
+		Fee     float64 `json:"fee"`
+	}
+	// DumpWalletResult models the data from the dumpwallet command.
+	DumpWalletResult struct {
+		Filename string `json:"filename"`
+	}
 	// GetTransactionDetailsResult models the details data from the gettransaction command. This models the "short" version of the ListTransactionsResult type, which excludes fields common to the transaction.  These common fields are instead part of the GetTransactionResult.
 	GetTransactionDetailsResult struct {
 		Account           string   `json:"account"`
@@ -60,6 +85,7 @@ type (
 		Fee               float64  `json:"fee,omitempty"`
 		Generated         bool     `json:"generated,omitempty"`
 		InvolvesWatchOnly bool     `json:"involveswatchonly,omitempty"`
+		Label             string   `json:"label,omitempty"`
 		Time              int64    `json:"time"`
 		TimeReceived      int64    `json:"timereceived"`
 		Trusted           bool     `json:"trusted"`
@@ -131,6 +157,20 @@ type (
 		Script       string   `json:"script,omitempty"`
 		SigsRequired int32    `json:"sigsrequired,omitempty"`
 	}
+	// GetWalletInfoResult models the data from the getwalletinfo command.
+	GetWalletInfoResult struct {
+		WalletName         string  `json:"walletname"`
+		WalletVersion      int32   `json:"walletversion"`
+		Balance            float64 `json:"balance"`
+		UnconfirmedBalance float64 `json:"unconfirmed_balance"`
+		ImmatureBalance    float64 `json:"immature_balance"`
+		TxCount            int     `json:"txcount"`
+		Unlocked           bool    `json:"unlocked"`
+		PayTxFee           float64 `json:"paytxfee"`
+		SyncedHeight       int32   `json:"syncedheight"`
+		SyncedHash         string  `json:"syncedhash"`
+		Birthday           int64   `json:"birthday"`
+	}
 	// GetBestBlockResult models the data from the getbestblock command.
 	GetBestBlockResult struct {
 		Hash   string `json:"hash"`
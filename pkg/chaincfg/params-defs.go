@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/cybriq/p9/pkg/chainhash"
+	"github.com/cybriq/p9/pkg/fork"
 	"github.com/cybriq/p9/pkg/wire"
 )
 
@@ -157,6 +158,9 @@ type Params struct {
 	PowLimit *big.Int
 	// PowLimitBits defines the highest allowed proof of work value for a block in compact form.
 	PowLimitBits uint32
+	// Forks is the hard fork schedule of the network, which determines the consensus rules and proof of work
+	// algorithms in force at each block height.
+	Forks *fork.Schedule
 	// // These fields define the block heights at which the specified softfork BIP became active.
 	// BIP0034Height int32
 	// BIP0065Height int32
@@ -184,6 +188,10 @@ type Params struct {
 	GenerateSupported bool
 	// Checkpoints ordered from oldest to newest.
 	Checkpoints []Checkpoint
+	// AssumeValid is the hash of a block that is assumed to only have valid ancestors, so the scripts of the blocks
+	// leading up to it are not checked during the initial sync. All other checks are still done. It is nil when all
+	// scripts are checked.
+	AssumeValid *chainhash.Hash
 	// These fields are related to voting on consensus rule changes as defined by BIP0009.
 	//
 	// RuleChangeActivationThreshold is the number of blocks in a threshold state retarget window for which a positive
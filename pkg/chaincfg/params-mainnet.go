@@ -1,6 +1,7 @@
 package chaincfg
 
 import (
+	"github.com/cybriq/p9/pkg/fork"
 	"github.com/cybriq/p9/pkg/wire"
 )
 
@@ -34,6 +35,7 @@ var MainNetParams = Params{
 	GenesisHash:  &genesisHash,
 	PowLimit:     &mainPowLimit,
 	PowLimitBits: MainPowLimitBits, // 0x1e0fffff,
+	Forks:        fork.MainNetSchedule(),
 	// BIP0034Height:            math.MaxInt32,        // Reserved for future change
 	// BIP0065Height:            math.MaxInt32,
 	// BIP0066Height:            math.MaxInt32,
@@ -50,6 +52,8 @@ var MainNetParams = Params{
 		// {, newHashFromStr("")},
 		// {200069, newHashFromStr("000000000000044e641986c8ee672460e853a11b352869cb8a4a8ba0b3f3e6dc")},
 	},
+	// Block whose ancestors are assumed to have valid scripts, updated to a recent block with each release.
+	// AssumeValid: newHashFromStr(""),
 	// Consensus rule change deployments.
 	//
 	// The miner confirmation window is defined as:
@@ -1,6 +1,7 @@
 package chaincfg
 
 import (
+	"github.com/cybriq/p9/pkg/fork"
 	"github.com/cybriq/p9/pkg/wire"
 )
 
@@ -16,6 +17,7 @@ var RegressionTestParams = Params{
 	GenesisHash:      &regTestGenesisHash,
 	PowLimit:         regressionPowLimit,
 	PowLimitBits:     0x207fffff,
+	Forks:            fork.TestNetSchedule(),
 	CoinbaseMaturity: 100,
 	// BIP0034Height:            100000000, // Not active - Permit ver 1 blocks
 	// BIP0065Height:            100000000, // Used by regression tests
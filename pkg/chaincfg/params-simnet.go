@@ -3,6 +3,7 @@ package chaincfg
 import (
 	"time"
 
+	"github.com/cybriq/p9/pkg/fork"
 	"github.com/cybriq/p9/pkg/wire"
 )
 
@@ -21,6 +22,7 @@ var SimNetParams = Params{
 	GenesisHash:  &simNetGenesisHash,
 	PowLimit:     simNetPowLimit,
 	PowLimitBits: 0x207fffff,
+	Forks:        fork.TestNetSchedule(),
 	// BIP0034Height:            0, // Always active on simnet
 	// BIP0065Height:            0, // Always active on simnet
 	// BIP0066Height:            0, // Always active on simnet
@@ -19,6 +19,7 @@ var TestNet3Params = Params{
 	GenesisHash:  &testNet3GenesisHash,
 	PowLimit:     &fork.SecondPowLimit,    // fork&testNet3PowLimit,
 	PowLimitBits: fork.SecondPowLimitBits, // testnetBits,
+	Forks:        fork.TestNetSchedule(),
 	// BIP0034Height:            math.MaxInt32,                       // 0000000023b3a96d3484e5abb3755c413e7d41500f8e2a5c3f0dd01299cd8ef8
 	// BIP0065Height:            math.MaxInt32,                       // 00000000007f6655f22f98e72ed80d8b06dc761d5da09df0fa1dc4be4f861eb6
 	// BIP0066Height:            math.MaxInt32,                       // 000000002104c8c45e99a8853285a3b592602a3ccde2b832481da85e9e4ba182
@@ -34,6 +35,8 @@ var TestNet3Params = Params{
 	Checkpoints: []Checkpoint{
 		// {546, newHashFromStr("000000002a936ca763904c3c35fce2f3556c559c0214345d31b1bcebf76acb70")},
 	},
+	// Block whose ancestors are assumed to have valid scripts, updated to a recent block with each release.
+	// AssumeValid: newHashFromStr(""),
 	// Consensus rule change deployments.
 	//
 	// The miner confirmation window is defined as:
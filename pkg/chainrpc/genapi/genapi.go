@@ -69,6 +69,12 @@ var handlers = handlersT{
 		Cmd:     "*btcjson.GetAddedNodeInfoCmd",
 		ResType: "[]btcjson.GetAddedNodeInfoResultAddr",
 	},
+	{
+		Method:  "getalgostats",
+		Handler: "GetAlgoStats",
+		Cmd:     "*btcjson.GetAlgoStatsCmd",
+		ResType: "btcjson.GetAlgoStatsResult",
+	},
 	{
 		Method:  "getbestblock",
 		Handler: "GetBestBlock",
@@ -129,6 +135,12 @@ var handlers = handlersT{
 		Cmd:     "*btcjson.GetCFilterHeaderCmd",
 		ResType: "string",
 	},
+	{
+		Method:  "getchaintips",
+		Handler: "GetChainTips",
+		Cmd:     "*None",
+		ResType: "[]btcjson.GetChainTipsResult",
+	},
 	{
 		Method:  "getconnectioncount",
 		Handler: "GetConnectionCount",
@@ -171,6 +183,30 @@ var handlers = handlersT{
 		Cmd:     "*None",
 		ResType: "btcjson.InfoChainResult0",
 	},
+	{
+		Method:  "getlanpeers",
+		Handler: "GetLANPeers",
+		Cmd:     "*None",
+		ResType: "[]btcjson.GetLANPeersResult",
+	},
+	{
+		Method:  "getmempoolancestors",
+		Handler: "GetMempoolAncestors",
+		Cmd:     "*btcjson.GetMempoolAncestorsCmd",
+		ResType: "[]string",
+	},
+	{
+		Method:  "getmempooldescendants",
+		Handler: "GetMempoolDescendants",
+		Cmd:     "*btcjson.GetMempoolDescendantsCmd",
+		ResType: "[]string",
+	},
+	{
+		Method:  "getmempoolentry",
+		Handler: "GetMempoolEntry",
+		Cmd:     "*btcjson.GetMempoolEntryCmd",
+		ResType: "btcjson.GetMempoolEntryResult",
+	},
 	{
 		Method:  "getmempoolinfo",
 		Handler: "GetMempoolInfo",
@@ -195,12 +231,24 @@ var handlers = handlersT{
 		Cmd:     "*btcjson.GetNetworkHashPSCmd",
 		ResType: "[]btcjson.GetPeerInfoResult",
 	},
+	{
+		Method:  "getnetworkinfo",
+		Handler: "GetNetworkInfo",
+		Cmd:     "*None",
+		ResType: "btcjson.GetNetworkInfoResult",
+	},
 	{
 		Method:  "getpeerinfo",
 		Handler: "GetPeerInfo",
 		Cmd:     "*None",
 		ResType: "[]btcjson.GetPeerInfoResult",
 	},
+	{
+		Method:  "getpoolinfo",
+		Handler: "GetPoolInfo",
+		Cmd:     "*btcjson.GetPoolInfoCmd",
+		ResType: "btcjson.GetPoolInfoResult",
+	},
 	{
 		Method:  "getrawmempool",
 		Handler: "GetRawMempool",
@@ -225,6 +273,18 @@ var handlers = handlersT{
 		Cmd:     "*btcjson.HelpCmd",
 		ResType: "string",
 	},
+	{
+		Method:  "invalidateblock",
+		Handler: "InvalidateBlock",
+		Cmd:     "*btcjson.InvalidateBlockCmd",
+		ResType: "None",
+	},
+	{
+		Method:  "loadmempool",
+		Handler: "LoadMempool",
+		Cmd:     "*None",
+		ResType: "btcjson.LoadMempoolResult",
+	},
 	{
 		Method:  "node",
 		Handler: "Node",
@@ -237,6 +297,24 @@ var handlers = handlersT{
 		Cmd:     "*None",
 		ResType: "None",
 	},
+	{
+		Method:  "preciousblock",
+		Handler: "PreciousBlock",
+		Cmd:     "*btcjson.PreciousBlockCmd",
+		ResType: "None",
+	},
+	{
+		Method:  "reconsiderblock",
+		Handler: "ReconsiderBlock",
+		Cmd:     "*btcjson.ReconsiderBlockCmd",
+		ResType: "None",
+	},
+	{
+		Method:  "savemempool",
+		Handler: "SaveMempool",
+		Cmd:     "*None",
+		ResType: "btcjson.SaveMempoolResult",
+	},
 	{
 		Method:  "searchrawtransactions",
 		Handler: "SearchRawTransactions",
@@ -11,7 +11,6 @@ import (
 
 	"github.com/cybriq/p9/pkg/bits"
 	block2 "github.com/cybriq/p9/pkg/block"
-	"github.com/cybriq/p9/pkg/fork"
 
 	"github.com/cybriq/p9/pkg/qu"
 
@@ -312,9 +311,9 @@ func HandleGetWorkSubmission(s *Server, hexData string) (interface{}, error) {
 	merkles := blockchain.BuildMerkleTreeStore(block.Transactions(), false)
 	msgBlock.Header.MerkleRoot = *merkles.GetRoot()
 	// Ensure the submitted block hash is less than the target difficulty.
-	pl := fork.GetMinDiff(s.Cfg.Algo, s.Cfg.Chain.BestSnapshot().Height)
+	pl := s.Cfg.ChainParams.Forks.GetMinDiff(s.Cfg.Algo, s.Cfg.Chain.BestSnapshot().Height)
 	e = blockchain.CheckProofOfWork(
-		block, pl,
+		block, pl, s.Cfg.ChainParams.Forks,
 		s.Cfg.Chain.BestSnapshot().Height,
 	)
 	if e != nil {
@@ -8,6 +8,8 @@ import (
 	"fmt"
 	"math/big"
 	"net"
+	"os"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -30,6 +32,7 @@ import (
 	"github.com/cybriq/p9/pkg/txscript"
 	"github.com/cybriq/p9/pkg/util"
 	"github.com/cybriq/p9/pkg/wire"
+	"github.com/cybriq/p9/version"
 )
 
 // HandleAddNode handles addnode commands.
@@ -519,6 +522,69 @@ func HandleGetAddedNodeInfo(
 	return results, nil
 }
 
+// HandleGetAlgoStats implements the getalgostats command.
+func HandleGetAlgoStats(s *Server, cmd interface{}, closeChan qu.C) (
+	interface{},
+	error,
+) {
+	var msg string
+	var e error
+	c, ok := cmd.(*btcjson.GetAlgoStatsCmd)
+	if !ok {
+		var h string
+		h, e = s.HelpCacher.RPCMethodHelp("getalgostats")
+		if e != nil {
+			msg = e.Error() + "\n\n"
+		}
+		msg += h
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCInvalidParameter,
+			Message: msg,
+		}
+	}
+	blocks := 100
+	if c.Blocks != nil {
+		blocks = *c.Blocks
+	}
+	if blocks < 1 {
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCInvalidParameter,
+			Message: "the number of blocks must be at least 1",
+		}
+	}
+	stats, sampled, span, e := s.Cfg.Chain.AlgoStats(int32(blocks))
+	if e != nil {
+		return nil, InternalRPCError(e.Error(), "Failed to calculate algorithm statistics")
+	}
+	best := s.Cfg.Chain.BestSnapshot()
+	forks := s.Cfg.ChainParams.Forks
+	r := btcjson.GetAlgoStatsResult{
+		Height:   best.Height,
+		Fork:     forks.List[forks.GetCurrent(best.Height+1)].Name,
+		Blocks:   sampled,
+		Timespan: span,
+	}
+	for i := range stats {
+		a := btcjson.AlgoStatsResult{
+			Name:            stats[i].Name,
+			Version:         stats[i].Version,
+			Bits:            fmt.Sprintf("%08x", stats[i].Bits),
+			Difficulty:      GetDifficultyRatio(stats[i].Bits, s.Cfg.ChainParams, stats[i].Version),
+			TargetInterval:  stats[i].TargetInterval,
+			AverageInterval: stats[i].AverageInterval,
+			SinceLast:       stats[i].SinceLast,
+			LastHeight:      stats[i].LastHeight,
+			Blocks:          stats[i].Blocks,
+			HashesPerSec:    stats[i].HashesPerSec,
+		}
+		if sampled > 0 {
+			a.Share = float64(stats[i].Blocks) / float64(sampled)
+		}
+		r.Algos = append(r.Algos, a)
+	}
+	return r, nil
+}
+
 // HandleGetBestBlock implements the getbestblock command.
 func HandleGetBestBlock(
 	s *Server, cmd interface{}, closeChan qu.C,
@@ -575,6 +641,13 @@ func HandleGetBlock(s *Server, cmd interface{}, closeChan qu.C) (
 		},
 	)
 	if e != nil {
+		// The block index still knows the blocks deleted by pruning.
+		if s.Cfg.Chain.Index.HaveBlock(hash) {
+			return nil, &btcjson.RPCError{
+				Code:    btcjson.ErrRPCMisc,
+				Message: "Block not available (pruned data)",
+			}
+		}
 		return nil, &btcjson.RPCError{
 			Code:    btcjson.ErrRPCBlockNotFound,
 			Message: "Block not found",
@@ -610,16 +683,16 @@ func HandleGetBlock(s *Server, cmd interface{}, closeChan qu.C) (
 	}
 	params := s.Cfg.ChainParams
 	blockHeader := &blk.WireBlock().Header
-	algoname := fork.GetAlgoName(blockHeader.Version, blockHeight)
-	a := fork.GetAlgoVer(algoname, blockHeight)
-	algoid := fork.GetAlgoID(algoname, blockHeight)
+	algoname := s.Cfg.ChainParams.Forks.GetAlgoName(blockHeader.Version, blockHeight)
+	a := s.Cfg.ChainParams.Forks.GetAlgoVer(algoname, blockHeight)
+	algoid := s.Cfg.ChainParams.Forks.GetAlgoID(algoname, blockHeight)
 	blockReply := btcjson.GetBlockVerboseResult{
 		Hash:          c.Hash,
 		Version:       blockHeader.Version,
 		VersionHex:    fmt.Sprintf("%08x", blockHeader.Version),
 		PowAlgoID:     algoid,
 		PowAlgo:       algoname,
-		PowHash:       blk.WireBlock().BlockHashWithAlgos(blockHeight).String(),
+		PowHash:       blk.WireBlock().BlockHashWithAlgos(s.Cfg.ChainParams.Forks, blockHeight).String(),
 		MerkleRoot:    blockHeader.MerkleRoot.String(),
 		PreviousHash:  blockHeader.PrevBlock.String(),
 		Nonce:         blockHeader.Nonce,
@@ -1247,6 +1320,23 @@ func HandleGetCFilterHeader(
 	return hash.String(), nil
 }
 
+// HandleGetChainTips implements the getchaintips command.
+func HandleGetChainTips(
+	s *Server, cmd interface{}, closeChan qu.C,
+) (interface{}, error) {
+	tips := s.Cfg.Chain.ChainTips()
+	r := make([]btcjson.GetChainTipsResult, len(tips))
+	for i := range tips {
+		r[i] = btcjson.GetChainTipsResult{
+			Height:    tips[i].Height,
+			Hash:      tips[i].Hash.String(),
+			BranchLen: tips[i].BranchLen,
+			Status:    tips[i].Status,
+		}
+	}
+	return r, nil
+}
+
 // HandleGetConnectionCount implements the getconnectioncount command.
 func HandleGetConnectionCount(
 	s *Server, cmd interface{}, closeChan qu.C,
@@ -1437,10 +1527,10 @@ func HandleGetInfo(
 		BestSnapshot()
 	v := s.Cfg.Chain.Index.LookupNode(&best.Hash)
 	foundcount, height := 0, best.Height
-	switch fork.GetCurrent(height) {
+	switch s.Cfg.ChainParams.Forks.GetCurrent(height) {
 	case 0:
 		for foundcount < 9 && height > 0 {
-			switch fork.GetAlgoName(v.Header().Version, height) {
+			switch s.Cfg.ChainParams.Forks.GetAlgoName(v.Header().Version, height) {
 			case fork.SHA256d:
 				if lastbitsSHA256D == 0 {
 					foundcount++
@@ -1485,7 +1575,7 @@ func HandleGetInfo(
 			TimeOffset:        int64(s.Cfg.TimeSource.Offset().Seconds()),
 			Connections:       s.Cfg.ConnMgr.ConnectedCount(),
 			Proxy:             s.Config.ProxyAddress.V(),
-			PowAlgoID:         fork.GetAlgoID(s.Cfg.Algo, height),
+			PowAlgoID:         s.Cfg.ChainParams.Forks.GetAlgoID(s.Cfg.Algo, height),
 			PowAlgo:           s.Cfg.Algo,
 			Difficulty:        Difficulty,
 			DifficultySHA256D: dSHA256D,
@@ -1496,8 +1586,8 @@ func HandleGetInfo(
 	case 1:
 		foundcount, height := 0, best.Height
 		for foundcount < 9 &&
-			height > fork.List[fork.GetCurrent(height)].ActivationHeight-512 {
-			switch fork.GetAlgoName(v.Header().Version, height) {
+			height > s.Cfg.ChainParams.Forks.List[s.Cfg.ChainParams.Forks.GetCurrent(height)].ActivationHeight-512 {
+			switch s.Cfg.ChainParams.Forks.GetAlgoName(v.Header().Version, height) {
 			case fork.Scrypt:
 				if lastbitsScrypt == 0 {
 					foundcount++
@@ -1541,7 +1631,7 @@ func HandleGetInfo(
 			TimeOffset:          int64(s.Cfg.TimeSource.Offset().Seconds()),
 			Connections:         s.Cfg.ConnMgr.ConnectedCount(),
 			Proxy:               s.Config.ProxyAddress.V(),
-			PowAlgoID:           fork.GetAlgoID(s.Cfg.Algo, height),
+			PowAlgoID:           s.Cfg.ChainParams.Forks.GetAlgoID(s.Cfg.Algo, height),
 			PowAlgo:             s.Cfg.Algo,
 			Difficulty:          Difficulty,
 			DifficultyBlake2b:   dBlake2b,
@@ -1560,18 +1650,130 @@ func HandleGetInfo(
 	return ret, nil
 }
 
+// HandleGetLANPeers implements the getlanpeers command.
+func HandleGetLANPeers(s *Server, cmd interface{}, closeChan qu.C) (
+	interface{},
+	error,
+) {
+	if s.Cfg.LANPeers == nil {
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCMisc,
+			Message: "LAN peer discovery is not enabled",
+		}
+	}
+	return s.Cfg.LANPeers(), nil
+}
+
+// HandleGetMempoolAncestors implements the getmempoolancestors command.
+func HandleGetMempoolAncestors(s *Server, cmd interface{}, closeChan qu.C) (
+	interface{}, error,
+) {
+	c, ok := cmd.(*btcjson.GetMempoolAncestorsCmd)
+	if !ok {
+		var msg string
+		h, e := s.HelpCacher.RPCMethodHelp("getmempoolancestors")
+		if e != nil {
+			msg = e.Error() + "\n\n"
+		}
+		msg += h
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCInvalidParameter,
+			Message: msg,
+		}
+	}
+	return MempoolRelativesCommand(c.TxID, c.Verbose, s.Cfg.TxMemPool.MempoolAncestors)
+}
+
+// HandleGetMempoolDescendants implements the getmempooldescendants command.
+func HandleGetMempoolDescendants(s *Server, cmd interface{}, closeChan qu.C) (
+	interface{}, error,
+) {
+	c, ok := cmd.(*btcjson.GetMempoolDescendantsCmd)
+	if !ok {
+		var msg string
+		h, e := s.HelpCacher.RPCMethodHelp("getmempooldescendants")
+		if e != nil {
+			msg = e.Error() + "\n\n"
+		}
+		msg += h
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCInvalidParameter,
+			Message: msg,
+		}
+	}
+	return MempoolRelativesCommand(c.TxID, c.Verbose, s.Cfg.TxMemPool.MempoolDescendants)
+}
+
+// MempoolRelativesCommand runs one of the commands that return the relatives in the mempool of a transaction given by
+// its hash, returning their details keyed by hash when verbose is set or a sorted array of their hashes otherwise.
+func MempoolRelativesCommand(
+	txID string, verbose *bool,
+	fn func(hash *chainhash.Hash) (map[string]*btcjson.GetMempoolEntryResult, error),
+) (interface{}, error) {
+	hash, e := chainhash.NewHashFromStr(txID)
+	if e != nil {
+		return nil, DecodeHexError(txID)
+	}
+	relatives, e := fn(hash)
+	if e != nil {
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCNoTxInfo,
+			Message: "Transaction not in mempool",
+		}
+	}
+	if verbose != nil && *verbose {
+		return relatives, nil
+	}
+	hashStrings := make([]string, 0, len(relatives))
+	for hashString := range relatives {
+		hashStrings = append(hashStrings, hashString)
+	}
+	sort.Strings(hashStrings)
+	return hashStrings, nil
+}
+
+// HandleGetMempoolEntry implements the getmempoolentry command.
+func HandleGetMempoolEntry(s *Server, cmd interface{}, closeChan qu.C) (
+	interface{}, error,
+) {
+	c, ok := cmd.(*btcjson.GetMempoolEntryCmd)
+	if !ok {
+		var msg string
+		h, e := s.HelpCacher.RPCMethodHelp("getmempoolentry")
+		if e != nil {
+			msg = e.Error() + "\n\n"
+		}
+		msg += h
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCInvalidParameter,
+			Message: msg,
+		}
+	}
+	hash, e := chainhash.NewHashFromStr(c.TxID)
+	if e != nil {
+		return nil, DecodeHexError(c.TxID)
+	}
+	entry, e := s.Cfg.TxMemPool.MempoolEntry(hash)
+	if e != nil {
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCNoTxInfo,
+			Message: "Transaction not in mempool",
+		}
+	}
+	return entry, nil
+}
+
 // HandleGetMempoolInfo implements the getmempoolinfo command.
 func HandleGetMempoolInfo(
 	s *Server, cmd interface{}, closeChan qu.C,
 ) (interface{}, error) {
-	mempoolTxns := s.Cfg.TxMemPool.TxDescs()
-	var numBytes int64
-	for _, txD := range mempoolTxns {
-		numBytes += int64(txD.Tx.MsgTx().SerializeSize())
-	}
+	mp := s.Cfg.TxMemPool
 	ret := &btcjson.GetMempoolInfoResult{
-		Size:  int64(len(mempoolTxns)),
-		Bytes: numBytes,
+		Size:          int64(mp.Count()),
+		Bytes:         int64(mp.Size()),
+		MaxMempool:    mp.MaxSize(),
+		MempoolMinFee: mp.MinFee().ToDUO(),
+		MinRelayTxFee: s.StateCfg.ActiveMinRelayTxFee.ToDUO(),
 	}
 	return ret, nil
 }
@@ -1604,10 +1806,10 @@ func HandleGetMiningInfo(
 	best := s.Cfg.Chain.BestSnapshot()
 	v := s.Cfg.Chain.Index.LookupNode(&best.Hash)
 	foundCount, height := 0, best.Height
-	switch fork.GetCurrent(height) {
+	switch s.Cfg.ChainParams.Forks.GetCurrent(height) {
 	case 0:
 		for foundCount < 2 && height > 0 {
-			switch fork.GetAlgoName(v.Header().Version, height) {
+			switch s.Cfg.ChainParams.Forks.GetAlgoName(v.Header().Version, height) {
 			case fork.SHA256d:
 				if lastbitsSHA256D == 0 {
 					foundCount++
@@ -1646,7 +1848,7 @@ func HandleGetMiningInfo(
 			CurrentBlockSize:   best.BlockSize,
 			CurrentBlockWeight: best.BlockWeight,
 			CurrentBlockTx:     best.NumTxns,
-			PowAlgoID:          fork.GetAlgoID(s.Cfg.Algo, height),
+			PowAlgoID:          s.Cfg.ChainParams.Forks.GetAlgoID(s.Cfg.Algo, height),
 			PowAlgo:            s.Cfg.Algo,
 			Difficulty:         Difficulty,
 			DifficultySHA256D:  dSHA256D,
@@ -1660,8 +1862,8 @@ func HandleGetMiningInfo(
 		}
 	case 1:
 		fc, height := 0, best.Height
-		for fc < 9 && height > fork.List[fork.GetCurrent(height)].ActivationHeight-512 {
-			switch fork.GetAlgoName(v.Header().Version, height) {
+		for fc < 9 && height > s.Cfg.ChainParams.Forks.List[s.Cfg.ChainParams.Forks.GetCurrent(height)].ActivationHeight-512 {
+			switch s.Cfg.ChainParams.Forks.GetAlgoName(v.Header().Version, height) {
 			case fork.Scrypt:
 				if lastbitsScrypt == 0 {
 					fc++
@@ -1700,7 +1902,7 @@ func HandleGetMiningInfo(
 			CurrentBlockSize:   best.BlockSize,
 			CurrentBlockWeight: best.BlockWeight,
 			CurrentBlockTx:     best.NumTxns,
-			PowAlgoID:          fork.GetAlgoID(s.Cfg.Algo, height),
+			PowAlgoID:          s.Cfg.ChainParams.Forks.GetAlgoID(s.Cfg.Algo, height),
 			PowAlgo:            s.Cfg.Algo,
 			Difficulty:         Difficulty,
 			DifficultyScrypt:   dScrypt,
@@ -1837,6 +2039,121 @@ func HandleGetNetworkHashPS(
 	return hashesPerSec.Int64(), nil
 }
 
+// HandleGetNetworkInfo implements the getnetworkinfo command.
+func HandleGetNetworkInfo(s *Server, cmd interface{}, closeChan qu.C) (
+	interface{}, error,
+) {
+	// The user agent is built the same way as the one sent to peers in version messages.
+	msgVersion := wire.MsgVersion{UserAgent: wire.DefaultUserAgent}
+	if e := msgVersion.AddUserAgent(
+		UserAgentName, UserAgentVersion,
+		s.Config.UserAgentComments.S()...,
+	); E.Chk(e) {
+	}
+	// Onion addresses are reached through the onion proxy, or the general proxy when there is no onion specific one.
+	proxy := s.Config.ProxyAddress.V()
+	onionProxy := s.Config.OnionProxyAddress.V()
+	if onionProxy == "" {
+		onionProxy = proxy
+	}
+	onionReachable := s.Config.OnionEnabled.True() && onionProxy != ""
+	randomizeCredentials := s.Config.TorIsolation.True()
+	networks := []btcjson.NetworksResult{
+		{
+			Name:                      "ipv4",
+			Reachable:                 true,
+			Proxy:                     proxy,
+			ProxyRandomizeCredentials: randomizeCredentials,
+		},
+		{
+			Name:                      "ipv6",
+			Reachable:                 true,
+			Proxy:                     proxy,
+			ProxyRandomizeCredentials: randomizeCredentials,
+		},
+		{
+			Name:                      "onion",
+			Limited:                   !onionReachable,
+			Reachable:                 onionReachable,
+			Proxy:                     onionProxy,
+			ProxyRandomizeCredentials: randomizeCredentials,
+		},
+	}
+	addrs, priorities := s.Cfg.ConnMgr.LocalAddresses()
+	localAddresses := make([]btcjson.LocalAddressesResult, len(addrs))
+	for i, na := range addrs {
+		localAddresses[i] = btcjson.LocalAddressesResult{
+			Address: na.IP.String(),
+			Port:    na.Port,
+			Score:   int32(priorities[i]),
+		}
+	}
+	sort.Slice(
+		localAddresses, func(i, j int) bool {
+			if localAddresses[i].Score != localAddresses[j].Score {
+				return localAddresses[i].Score > localAddresses[j].Score
+			}
+			return localAddresses[i].Address < localAddresses[j].Address
+		},
+	)
+	ret := &btcjson.GetNetworkInfoResult{
+		Version:         int32(1000000*version.Major + 10000*version.Minor + 100*version.Patch),
+		SubVersion:      msgVersion.UserAgent,
+		ProtocolVersion: int32(MaxProtocolVersion),
+		LocalServices:   fmt.Sprintf("%016x", uint64(s.Cfg.ConnMgr.LocalServices())),
+		LocalRelay:      s.Config.BlocksOnly.False(),
+		TimeOffset:      int64(s.Cfg.TimeSource.Offset().Seconds()),
+		Connections:     s.Cfg.ConnMgr.ConnectedCount(),
+		NetworkActive:   true,
+		Networks:        networks,
+		RelayFee:        s.StateCfg.ActiveMinRelayTxFee.ToDUO(),
+		IncrementalFee:  s.StateCfg.ActiveMinRelayTxFee.ToDUO(),
+		LocalAddresses:  localAddresses,
+		Warnings:        "",
+	}
+	return ret, nil
+}
+
+// HandleGetPoolInfo implements the getpoolinfo command.
+func HandleGetPoolInfo(s *Server, cmd interface{}, closeChan qu.C) (
+	interface{},
+	error,
+) {
+	var msg string
+	var e error
+	c, ok := cmd.(*btcjson.GetPoolInfoCmd)
+	if !ok {
+		var h string
+		h, e = s.HelpCacher.RPCMethodHelp("getpoolinfo")
+		if e != nil {
+			msg = e.Error() + "\n\n"
+		}
+		msg += h
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCInvalidParameter,
+			Message: msg,
+		}
+	}
+	if s.Cfg.PoolInfo == nil {
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCMisc,
+			Message: "controller is not running",
+		}
+	}
+	blocks := 10
+	if c.Blocks != nil {
+		blocks = *c.Blocks
+	}
+	var r *btcjson.GetPoolInfoResult
+	if r, e = s.Cfg.PoolInfo(blocks); E.Chk(e) {
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCMisc,
+			Message: e.Error(),
+		}
+	}
+	return *r, nil
+}
+
 // HandleGetPeerInfo implements the getpeerinfo command.
 func HandleGetPeerInfo(s *Server, cmd interface{}, closeChan qu.C) (
 	interface{},
@@ -2202,6 +2519,70 @@ func HandleHelp(s *Server, cmd interface{}, closeChan qu.C) (
 	return help, nil
 }
 
+// HandleInvalidateBlock implements the invalidateblock command.
+func HandleInvalidateBlock(s *Server, cmd interface{}, closeChan qu.C) (
+	interface{}, error,
+) {
+	c, ok := cmd.(*btcjson.InvalidateBlockCmd)
+	if !ok {
+		var msg string
+		h, e := s.HelpCacher.RPCMethodHelp("invalidateblock")
+		if e != nil {
+			msg = e.Error() + "\n\n"
+		}
+		msg += h
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCInvalidParameter,
+			Message: msg,
+		}
+	}
+	return nil, ChainTipCommand(s, c.BlockHash, s.Cfg.Chain.InvalidateBlock)
+}
+
+// ChainTipCommand runs one of the chain tip management commands on a block given by its hash.
+func ChainTipCommand(s *Server, blockHash string, fn func(hash *chainhash.Hash) error) error {
+	hash, e := chainhash.NewHashFromStr(blockHash)
+	if e != nil {
+		return DecodeHexError(blockHash)
+	}
+	if !s.Cfg.Chain.Index.HaveBlock(hash) {
+		return &btcjson.RPCError{
+			Code:    btcjson.ErrRPCBlockNotFound,
+			Message: "Block not found",
+		}
+	}
+	if e = fn(hash); e != nil {
+		return &btcjson.RPCError{
+			Code:    btcjson.ErrRPCDatabase,
+			Message: e.Error(),
+		}
+	}
+	return nil
+}
+
+// HandleLoadMempool implements the loadmempool command.
+func HandleLoadMempool(s *Server, cmd interface{}, closeChan qu.C) (
+	interface{},
+	error,
+) {
+	accepted, present, failed, e := s.Cfg.LoadMempool()
+	if e != nil {
+		msg := e.Error()
+		if os.IsNotExist(e) {
+			msg = "the mempool has not been saved"
+		}
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCMisc,
+			Message: msg,
+		}
+	}
+	return btcjson.LoadMempoolResult{
+		Accepted: accepted,
+		Present:  present,
+		Failed:   failed,
+	}, nil
+}
+
 // HandleNode handles node commands.
 func HandleNode(s *Server, cmd interface{}, closeChan qu.C) (
 	interface{}, error,
@@ -2308,6 +2689,46 @@ func HandleNode(s *Server, cmd interface{}, closeChan qu.C) (
 	return nil, nil
 }
 
+// HandlePreciousBlock implements the preciousblock command.
+func HandlePreciousBlock(s *Server, cmd interface{}, closeChan qu.C) (
+	interface{}, error,
+) {
+	c, ok := cmd.(*btcjson.PreciousBlockCmd)
+	if !ok {
+		var msg string
+		h, e := s.HelpCacher.RPCMethodHelp("preciousblock")
+		if e != nil {
+			msg = e.Error() + "\n\n"
+		}
+		msg += h
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCInvalidParameter,
+			Message: msg,
+		}
+	}
+	return nil, ChainTipCommand(s, c.BlockHash, s.Cfg.Chain.PreciousBlock)
+}
+
+// HandleReconsiderBlock implements the reconsiderblock command.
+func HandleReconsiderBlock(s *Server, cmd interface{}, closeChan qu.C) (
+	interface{}, error,
+) {
+	c, ok := cmd.(*btcjson.ReconsiderBlockCmd)
+	if !ok {
+		var msg string
+		h, e := s.HelpCacher.RPCMethodHelp("reconsiderblock")
+		if e != nil {
+			msg = e.Error() + "\n\n"
+		}
+		msg += h
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCInvalidParameter,
+			Message: msg,
+		}
+	}
+	return nil, ChainTipCommand(s, c.BlockHash, s.Cfg.Chain.ReconsiderBlock)
+}
+
 // HandlePing implements the ping command.
 func HandlePing(s *Server, cmd interface{}, closeChan qu.C) (
 	interface{}, error,
@@ -2324,6 +2745,24 @@ func HandlePing(s *Server, cmd interface{}, closeChan qu.C) (
 	return nil, nil
 }
 
+// HandleSaveMempool implements the savemempool command.
+func HandleSaveMempool(s *Server, cmd interface{}, closeChan qu.C) (
+	interface{},
+	error,
+) {
+	count, e := s.Cfg.SaveMempool()
+	if e != nil {
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCMisc,
+			Message: e.Error(),
+		}
+	}
+	return btcjson.SaveMempoolResult{
+		Saved:    count,
+		Filename: s.Cfg.MempoolFile,
+	}, nil
+}
+
 // HandleSearchRawTransactions implements the searchrawtransactions command.
 // TODO: simplify this, break it up
 func HandleSearchRawTransactions(
@@ -0,0 +1,65 @@
+package chainrpc
+
+import (
+	"os"
+	"path/filepath"
+)
+
+// MempoolFilename is the name of the file in the network data directory the mempool is saved in
+const MempoolFilename = "mempool.dat"
+
+// MempoolFile returns the path of the file the mempool is saved in
+func (n *Node) MempoolFile() string {
+	return filepath.Join(n.Config.DataDir.V(), n.ActiveNet.Name, MempoolFilename)
+}
+
+// SaveMempool writes the transactions in the mempool to the mempool file, replacing it only once they have all been
+// written. It returns the number of transactions saved.
+func (n *Node) SaveMempool() (count int, e error) {
+	path := n.MempoolFile()
+	tmp := path + ".new"
+	var f *os.File
+	if f, e = os.OpenFile(tmp, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600); E.Chk(e) {
+		return
+	}
+	if count, e = n.TxMemPool.Save(f); E.Chk(e) {
+		_ = f.Close()
+		_ = os.Remove(tmp)
+		return
+	}
+	if e = f.Close(); E.Chk(e) {
+		_ = os.Remove(tmp)
+		return
+	}
+	if e = os.Rename(tmp, path); E.Chk(e) {
+	}
+	I.F("saved %d mempool transactions to %s", count, path)
+	return
+}
+
+// LoadMempool validates the transactions in the mempool file again and adds those that are still valid to the mempool,
+// announcing them to peers. It returns the numbers of transactions accepted, already in the mempool and rejected.
+func (n *Node) LoadMempool() (accepted, present, failed int, e error) {
+	path := n.MempoolFile()
+	var f *os.File
+	if f, e = os.Open(path); e != nil {
+		return
+	}
+	defer func() {
+		if e := f.Close(); E.Chk(e) {
+		}
+	}()
+	txDescs, present, failed, e := n.TxMemPool.Load(n.Chain, f)
+	if E.Chk(e) {
+		return
+	}
+	accepted = len(txDescs)
+	I.F(
+		"loaded %d mempool transactions from %s (%d already present, %d rejected)", accepted, path, present,
+		failed,
+	)
+	if accepted > 0 {
+		n.AnnounceNewTransactions(txDescs)
+	}
+	return
+}
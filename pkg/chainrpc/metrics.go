@@ -0,0 +1,76 @@
+package chainrpc
+
+import (
+	"github.com/cybriq/p9/pkg/metrics"
+)
+
+var (
+	// rpcDuration is the time taken to handle each RPC method, including those called over websockets
+	rpcDuration = metrics.NewHistogramVec(
+		"pod_node_rpc_duration_seconds", "time taken to handle node RPC requests", "method",
+		metrics.DurationBuckets,
+	)
+	// bans counts the peers banned since the node started
+	bans = metrics.NewCounter("pod_node_peer_bans_total", "number of peers banned")
+)
+
+// Metrics returns the metrics of the node for exposition
+func (n *Node) Metrics() []metrics.Collector {
+	height := func() float64 {
+		return float64(n.Chain.BestSnapshot().Height)
+	}
+	return []metrics.Collector{
+		rpcDuration,
+		bans,
+		metrics.NewGaugeFunc("pod_node_block_height", "height of the best block", height),
+		metrics.NewGaugeFunc(
+			"pod_node_sync_progress", "height of the best block as a fraction of the highest height reported by peers",
+			func() float64 {
+				h, highest := height(), float64(n.HighestKnown.Load())
+				if h >= highest {
+					return 1
+				}
+				return h / highest
+			},
+		),
+		metrics.NewGaugeFunc(
+			"pod_node_synced", "1 if the node believes it is synced with the network", func() float64 {
+				if n.SyncManager.IsCurrent() {
+					return 1
+				}
+				return 0
+			},
+		),
+		metrics.NewGaugeFunc(
+			"pod_node_peers", "number of connected peers", func() float64 {
+				return float64(n.ConnectedCount())
+			},
+		),
+		metrics.NewGaugeFunc(
+			"pod_node_mempool_transactions", "number of transactions in the mempool", func() float64 {
+				return float64(n.TxMemPool.Count())
+			},
+		),
+		metrics.NewGaugeFunc(
+			"pod_node_mempool_bytes", "serialized size of the transactions in the mempool", func() float64 {
+				return float64(n.TxMemPool.Size())
+			},
+		),
+		metrics.NewGaugeFunc(
+			"pod_node_mempool_min_fee", "minimum fee rate in DUO/kB for a transaction to be accepted into the mempool",
+			func() float64 {
+				return n.TxMemPool.MinFee().ToDUO()
+			},
+		),
+		metrics.NewGaugeFunc(
+			"pod_node_orphan_transactions", "number of orphan transactions held by the mempool", func() float64 {
+				return float64(n.TxMemPool.OrphanCount())
+			},
+		),
+		metrics.NewGaugeFunc(
+			"pod_node_orphan_blocks", "number of orphan blocks held by the chain", func() float64 {
+				return float64(n.Chain.OrphanCount())
+			},
+		),
+	}
+}
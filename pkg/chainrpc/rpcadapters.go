@@ -3,6 +3,7 @@ package chainrpc
 import (
 	"sync/atomic"
 
+	"github.com/cybriq/p9/pkg/addrmgr"
 	"github.com/cybriq/p9/pkg/block"
 
 	"github.com/cybriq/p9/pkg/blockchain"
@@ -202,6 +203,20 @@ func (cm *ConnManager) RelayTransactions(txns []*mempool.TxDesc) {
 	cm.Server.RelayTransactions(txns)
 }
 
+// LocalServices returns the services the node advertises to its peers.
+//
+// This function is safe for concurrent access and is part of the RPCServerConnManager interface implementation.
+func (cm *ConnManager) LocalServices() wire.ServiceFlag {
+	return cm.Server.Services
+}
+
+// LocalAddresses returns the addresses the node advertises to its peers along with their priorities.
+//
+// This function is safe for concurrent access and is part of the RPCServerConnManager interface implementation.
+func (cm *ConnManager) LocalAddresses() ([]*wire.NetAddress, []addrmgr.AddressPriority) {
+	return cm.Server.AddrManager.LocalAddresses()
+}
+
 // SyncManager provides a block manager for use with the RPC Server and implements the RPCServerSyncManager interface.
 type SyncManager struct {
 	Server  *Node
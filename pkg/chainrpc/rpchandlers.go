@@ -67,6 +67,8 @@ type (
 	GenerateRes struct { Res *[]string; Err error }
 	// GetAddedNodeInfoRes is the result from a call to GetAddedNodeInfo
 	GetAddedNodeInfoRes struct { Res *[]btcjson.GetAddedNodeInfoResultAddr; Err error }
+	// GetAlgoStatsRes is the result from a call to GetAlgoStats
+	GetAlgoStatsRes struct { Res *btcjson.GetAlgoStatsResult; Err error }
 	// GetBestBlockRes is the result from a call to GetBestBlock
 	GetBestBlockRes struct { Res *btcjson.GetBestBlockResult; Err error }
 	// GetBestBlockHashRes is the result from a call to GetBestBlockHash
@@ -87,6 +89,8 @@ type (
 	GetCFilterRes struct { Res *string; Err error }
 	// GetCFilterHeaderRes is the result from a call to GetCFilterHeader
 	GetCFilterHeaderRes struct { Res *string; Err error }
+	// GetChainTipsRes is the result from a call to GetChainTips
+	GetChainTipsRes struct { Res *[]btcjson.GetChainTipsResult; Err error }
 	// GetConnectionCountRes is the result from a call to GetConnectionCount
 	GetConnectionCountRes struct { Res *int32; Err error }
 	// GetCurrentNetRes is the result from a call to GetCurrentNet
@@ -101,6 +105,14 @@ type (
 	GetHeadersRes struct { Res *[]string; Err error }
 	// GetInfoRes is the result from a call to GetInfo
 	GetInfoRes struct { Res *btcjson.InfoChainResult0; Err error }
+	// GetLANPeersRes is the result from a call to GetLANPeers
+	GetLANPeersRes struct { Res *[]btcjson.GetLANPeersResult; Err error }
+	// GetMempoolAncestorsRes is the result from a call to GetMempoolAncestors
+	GetMempoolAncestorsRes struct { Res *[]string; Err error }
+	// GetMempoolDescendantsRes is the result from a call to GetMempoolDescendants
+	GetMempoolDescendantsRes struct { Res *[]string; Err error }
+	// GetMempoolEntryRes is the result from a call to GetMempoolEntry
+	GetMempoolEntryRes struct { Res *btcjson.GetMempoolEntryResult; Err error }
 	// GetMempoolInfoRes is the result from a call to GetMempoolInfo
 	GetMempoolInfoRes struct { Res *btcjson.GetMempoolInfoResult; Err error }
 	// GetMiningInfoRes is the result from a call to GetMiningInfo
@@ -109,8 +121,12 @@ type (
 	GetNetTotalsRes struct { Res *btcjson.GetNetTotalsResult; Err error }
 	// GetNetworkHashPSRes is the result from a call to GetNetworkHashPS
 	GetNetworkHashPSRes struct { Res *[]btcjson.GetPeerInfoResult; Err error }
+	// GetNetworkInfoRes is the result from a call to GetNetworkInfo
+	GetNetworkInfoRes struct { Res *btcjson.GetNetworkInfoResult; Err error }
 	// GetPeerInfoRes is the result from a call to GetPeerInfo
 	GetPeerInfoRes struct { Res *[]btcjson.GetPeerInfoResult; Err error }
+	// GetPoolInfoRes is the result from a call to GetPoolInfo
+	GetPoolInfoRes struct { Res *btcjson.GetPoolInfoResult; Err error }
 	// GetRawMempoolRes is the result from a call to GetRawMempool
 	GetRawMempoolRes struct { Res *[]string; Err error }
 	// GetRawTransactionRes is the result from a call to GetRawTransaction
@@ -119,14 +135,24 @@ type (
 	GetTxOutRes struct { Res *string; Err error }
 	// HelpRes is the result from a call to Help
 	HelpRes struct { Res *string; Err error }
+	// InvalidateBlockRes is the result from a call to InvalidateBlock
+	InvalidateBlockRes struct { Res *None; Err error }
+	// LoadMempoolRes is the result from a call to LoadMempool
+	LoadMempoolRes struct { Res *btcjson.LoadMempoolResult; Err error }
 	// NodeRes is the result from a call to Node
 	NodeRes struct { Res *None; Err error }
 	// PingRes is the result from a call to Ping
 	PingRes struct { Res *None; Err error }
+	// PreciousBlockRes is the result from a call to PreciousBlock
+	PreciousBlockRes struct { Res *None; Err error }
+	// ReconsiderBlockRes is the result from a call to ReconsiderBlock
+	ReconsiderBlockRes struct { Res *None; Err error }
 	// ResetChainRes is the result from a call to ResetChain
 	ResetChainRes struct { Res *None; Err error }
 	// RestartRes is the result from a call to Restart
 	RestartRes struct { Res *None; Err error }
+	// SaveMempoolRes is the result from a call to SaveMempool
+	SaveMempoolRes struct { Res *btcjson.SaveMempoolResult; Err error }
 	// SearchRawTransactionsRes is the result from a call to SearchRawTransactions
 	SearchRawTransactionsRes struct { Res *[]btcjson.SearchRawTransactionsResult; Err error }
 	// SendRawTransactionRes is the result from a call to SendRawTransaction
@@ -184,6 +210,9 @@ var RPCHandlersBeforeInit = map[string]CommandHandler{
 	"getaddednodeinfo":{ 
 		Fn: HandleGetAddedNodeInfo, Call: make(chan API, 32), 
 		Result: func() API { return API{Ch: make(chan GetAddedNodeInfoRes)} }}, 
+	"getalgostats":{ 
+		Fn: HandleGetAlgoStats, Call: make(chan API, 32), 
+		Result: func() API { return API{Ch: make(chan GetAlgoStatsRes)} }}, 
 	"getbestblock":{ 
 		Fn: HandleGetBestBlock, Call: make(chan API, 32), 
 		Result: func() API { return API{Ch: make(chan GetBestBlockRes)} }}, 
@@ -214,6 +243,9 @@ var RPCHandlersBeforeInit = map[string]CommandHandler{
 	"getcfilterheader":{ 
 		Fn: HandleGetCFilterHeader, Call: make(chan API, 32), 
 		Result: func() API { return API{Ch: make(chan GetCFilterHeaderRes)} }}, 
+	"getchaintips":{ 
+		Fn: HandleGetChainTips, Call: make(chan API, 32), 
+		Result: func() API { return API{Ch: make(chan GetChainTipsRes)} }}, 
 	"getconnectioncount":{ 
 		Fn: HandleGetConnectionCount, Call: make(chan API, 32), 
 		Result: func() API { return API{Ch: make(chan GetConnectionCountRes)} }}, 
@@ -235,6 +267,18 @@ var RPCHandlersBeforeInit = map[string]CommandHandler{
 	"getinfo":{ 
 		Fn: HandleGetInfo, Call: make(chan API, 32), 
 		Result: func() API { return API{Ch: make(chan GetInfoRes)} }}, 
+	"getlanpeers":{ 
+		Fn: HandleGetLANPeers, Call: make(chan API, 32), 
+		Result: func() API { return API{Ch: make(chan GetLANPeersRes)} }}, 
+	"getmempoolancestors":{ 
+		Fn: HandleGetMempoolAncestors, Call: make(chan API, 32), 
+		Result: func() API { return API{Ch: make(chan GetMempoolAncestorsRes)} }}, 
+	"getmempooldescendants":{ 
+		Fn: HandleGetMempoolDescendants, Call: make(chan API, 32), 
+		Result: func() API { return API{Ch: make(chan GetMempoolDescendantsRes)} }}, 
+	"getmempoolentry":{ 
+		Fn: HandleGetMempoolEntry, Call: make(chan API, 32), 
+		Result: func() API { return API{Ch: make(chan GetMempoolEntryRes)} }}, 
 	"getmempoolinfo":{ 
 		Fn: HandleGetMempoolInfo, Call: make(chan API, 32), 
 		Result: func() API { return API{Ch: make(chan GetMempoolInfoRes)} }}, 
@@ -247,9 +291,15 @@ var RPCHandlersBeforeInit = map[string]CommandHandler{
 	"getnetworkhashps":{ 
 		Fn: HandleGetNetworkHashPS, Call: make(chan API, 32), 
 		Result: func() API { return API{Ch: make(chan GetNetworkHashPSRes)} }}, 
+	"getnetworkinfo":{ 
+		Fn: HandleGetNetworkInfo, Call: make(chan API, 32), 
+		Result: func() API { return API{Ch: make(chan GetNetworkInfoRes)} }}, 
 	"getpeerinfo":{ 
 		Fn: HandleGetPeerInfo, Call: make(chan API, 32), 
 		Result: func() API { return API{Ch: make(chan GetPeerInfoRes)} }}, 
+	"getpoolinfo":{ 
+		Fn: HandleGetPoolInfo, Call: make(chan API, 32), 
+		Result: func() API { return API{Ch: make(chan GetPoolInfoRes)} }}, 
 	"getrawmempool":{ 
 		Fn: HandleGetRawMempool, Call: make(chan API, 32), 
 		Result: func() API { return API{Ch: make(chan GetRawMempoolRes)} }}, 
@@ -262,18 +312,33 @@ var RPCHandlersBeforeInit = map[string]CommandHandler{
 	"help":{ 
 		Fn: HandleHelp, Call: make(chan API, 32), 
 		Result: func() API { return API{Ch: make(chan HelpRes)} }}, 
+	"invalidateblock":{ 
+		Fn: HandleInvalidateBlock, Call: make(chan API, 32), 
+		Result: func() API { return API{Ch: make(chan InvalidateBlockRes)} }}, 
+	"loadmempool":{ 
+		Fn: HandleLoadMempool, Call: make(chan API, 32), 
+		Result: func() API { return API{Ch: make(chan LoadMempoolRes)} }}, 
 	"node":{ 
 		Fn: HandleNode, Call: make(chan API, 32), 
 		Result: func() API { return API{Ch: make(chan NodeRes)} }}, 
 	"ping":{ 
 		Fn: HandlePing, Call: make(chan API, 32), 
 		Result: func() API { return API{Ch: make(chan PingRes)} }}, 
+	"preciousblock":{ 
+		Fn: HandlePreciousBlock, Call: make(chan API, 32), 
+		Result: func() API { return API{Ch: make(chan PreciousBlockRes)} }}, 
+	"reconsiderblock":{ 
+		Fn: HandleReconsiderBlock, Call: make(chan API, 32), 
+		Result: func() API { return API{Ch: make(chan ReconsiderBlockRes)} }}, 
 	"resetchain":{ 
 		Fn: HandleResetChain, Call: make(chan API, 32), 
 		Result: func() API { return API{Ch: make(chan ResetChainRes)} }}, 
 	"restart":{ 
 		Fn: HandleRestart, Call: make(chan API, 32), 
 		Result: func() API { return API{Ch: make(chan RestartRes)} }}, 
+	"savemempool":{ 
+		Fn: HandleSaveMempool, Call: make(chan API, 32), 
+		Result: func() API { return API{Ch: make(chan SaveMempoolRes)} }}, 
 	"searchrawtransactions":{ 
 		Fn: HandleSearchRawTransactions, Call: make(chan API, 32), 
 		Result: func() API { return API{Ch: make(chan SearchRawTransactionsRes)} }}, 
@@ -601,6 +666,47 @@ func (a API) GetAddedNodeInfoWait(cmd *btcjson.GetAddedNodeInfoCmd) (out *[]btcj
 	return
 }
 
+// GetAlgoStats calls the method with the given parameters
+func (a API) GetAlgoStats(cmd *btcjson.GetAlgoStatsCmd) (e error) {
+	RPCHandlers["getalgostats"].Call <-API{a.Ch, cmd, nil}
+	return
+}
+
+// GetAlgoStatsChk checks if a new message arrived on the result channel and
+// returns true if it does, as well as storing the value in the Result field
+func (a API) GetAlgoStatsChk() (isNew bool) {
+	select {
+	case o := <-a.Ch.(chan GetAlgoStatsRes):
+		if o.Err != nil {
+			a.Result = o.Err
+		} else {
+			a.Result = o.Res
+		}
+		isNew = true
+	default:
+	}
+	return
+}
+
+// GetAlgoStatsGetRes returns a pointer to the value in the Result field
+func (a API) GetAlgoStatsGetRes() (out *btcjson.GetAlgoStatsResult, e error) {
+	out, _ = a.Result.(*btcjson.GetAlgoStatsResult)
+	e, _ = a.Result.(error)
+	return 
+}
+
+// GetAlgoStatsWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) GetAlgoStatsWait(cmd *btcjson.GetAlgoStatsCmd) (out *btcjson.GetAlgoStatsResult, e error) {
+	RPCHandlers["getalgostats"].Call <-API{a.Ch, cmd, nil}
+	select {
+	case <-time.After(time.Second*5):
+		break
+	case o := <-a.Ch.(chan GetAlgoStatsRes):
+		out, e = o.Res, o.Err
+	}
+	return
+}
+
 // GetBestBlock calls the method with the given parameters
 func (a API) GetBestBlock(cmd *None) (e error) {
 	RPCHandlers["getbestblock"].Call <-API{a.Ch, cmd, nil}
@@ -1011,6 +1117,47 @@ func (a API) GetCFilterHeaderWait(cmd *btcjson.GetCFilterHeaderCmd) (out *string
 	return
 }
 
+// GetChainTips calls the method with the given parameters
+func (a API) GetChainTips(cmd *None) (e error) {
+	RPCHandlers["getchaintips"].Call <-API{a.Ch, cmd, nil}
+	return
+}
+
+// GetChainTipsChk checks if a new message arrived on the result channel and
+// returns true if it does, as well as storing the value in the Result field
+func (a API) GetChainTipsChk() (isNew bool) {
+	select {
+	case o := <-a.Ch.(chan GetChainTipsRes):
+		if o.Err != nil {
+			a.Result = o.Err
+		} else {
+			a.Result = o.Res
+		}
+		isNew = true
+	default:
+	}
+	return
+}
+
+// GetChainTipsGetRes returns a pointer to the value in the Result field
+func (a API) GetChainTipsGetRes() (out *[]btcjson.GetChainTipsResult, e error) {
+	out, _ = a.Result.(*[]btcjson.GetChainTipsResult)
+	e, _ = a.Result.(error)
+	return 
+}
+
+// GetChainTipsWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) GetChainTipsWait(cmd *None) (out *[]btcjson.GetChainTipsResult, e error) {
+	RPCHandlers["getchaintips"].Call <-API{a.Ch, cmd, nil}
+	select {
+	case <-time.After(time.Second*5):
+		break
+	case o := <-a.Ch.(chan GetChainTipsRes):
+		out, e = o.Res, o.Err
+	}
+	return
+}
+
 // GetConnectionCount calls the method with the given parameters
 func (a API) GetConnectionCount(cmd *None) (e error) {
 	RPCHandlers["getconnectioncount"].Call <-API{a.Ch, cmd, nil}
@@ -1298,6 +1445,170 @@ func (a API) GetInfoWait(cmd *None) (out *btcjson.InfoChainResult0, e error) {
 	return
 }
 
+// GetLANPeers calls the method with the given parameters
+func (a API) GetLANPeers(cmd *None) (e error) {
+	RPCHandlers["getlanpeers"].Call <-API{a.Ch, cmd, nil}
+	return
+}
+
+// GetLANPeersChk checks if a new message arrived on the result channel and
+// returns true if it does, as well as storing the value in the Result field
+func (a API) GetLANPeersChk() (isNew bool) {
+	select {
+	case o := <-a.Ch.(chan GetLANPeersRes):
+		if o.Err != nil {
+			a.Result = o.Err
+		} else {
+			a.Result = o.Res
+		}
+		isNew = true
+	default:
+	}
+	return
+}
+
+// GetLANPeersGetRes returns a pointer to the value in the Result field
+func (a API) GetLANPeersGetRes() (out *[]btcjson.GetLANPeersResult, e error) {
+	out, _ = a.Result.(*[]btcjson.GetLANPeersResult)
+	e, _ = a.Result.(error)
+	return 
+}
+
+// GetLANPeersWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) GetLANPeersWait(cmd *None) (out *[]btcjson.GetLANPeersResult, e error) {
+	RPCHandlers["getlanpeers"].Call <-API{a.Ch, cmd, nil}
+	select {
+	case <-time.After(time.Second*5):
+		break
+	case o := <-a.Ch.(chan GetLANPeersRes):
+		out, e = o.Res, o.Err
+	}
+	return
+}
+
+// GetMempoolAncestors calls the method with the given parameters
+func (a API) GetMempoolAncestors(cmd *btcjson.GetMempoolAncestorsCmd) (e error) {
+	RPCHandlers["getmempoolancestors"].Call <-API{a.Ch, cmd, nil}
+	return
+}
+
+// GetMempoolAncestorsChk checks if a new message arrived on the result channel and
+// returns true if it does, as well as storing the value in the Result field
+func (a API) GetMempoolAncestorsChk() (isNew bool) {
+	select {
+	case o := <-a.Ch.(chan GetMempoolAncestorsRes):
+		if o.Err != nil {
+			a.Result = o.Err
+		} else {
+			a.Result = o.Res
+		}
+		isNew = true
+	default:
+	}
+	return
+}
+
+// GetMempoolAncestorsGetRes returns a pointer to the value in the Result field
+func (a API) GetMempoolAncestorsGetRes() (out *[]string, e error) {
+	out, _ = a.Result.(*[]string)
+	e, _ = a.Result.(error)
+	return 
+}
+
+// GetMempoolAncestorsWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) GetMempoolAncestorsWait(cmd *btcjson.GetMempoolAncestorsCmd) (out *[]string, e error) {
+	RPCHandlers["getmempoolancestors"].Call <-API{a.Ch, cmd, nil}
+	select {
+	case <-time.After(time.Second*5):
+		break
+	case o := <-a.Ch.(chan GetMempoolAncestorsRes):
+		out, e = o.Res, o.Err
+	}
+	return
+}
+
+// GetMempoolDescendants calls the method with the given parameters
+func (a API) GetMempoolDescendants(cmd *btcjson.GetMempoolDescendantsCmd) (e error) {
+	RPCHandlers["getmempooldescendants"].Call <-API{a.Ch, cmd, nil}
+	return
+}
+
+// GetMempoolDescendantsChk checks if a new message arrived on the result channel and
+// returns true if it does, as well as storing the value in the Result field
+func (a API) GetMempoolDescendantsChk() (isNew bool) {
+	select {
+	case o := <-a.Ch.(chan GetMempoolDescendantsRes):
+		if o.Err != nil {
+			a.Result = o.Err
+		} else {
+			a.Result = o.Res
+		}
+		isNew = true
+	default:
+	}
+	return
+}
+
+// GetMempoolDescendantsGetRes returns a pointer to the value in the Result field
+func (a API) GetMempoolDescendantsGetRes() (out *[]string, e error) {
+	out, _ = a.Result.(*[]string)
+	e, _ = a.Result.(error)
+	return 
+}
+
+// GetMempoolDescendantsWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) GetMempoolDescendantsWait(cmd *btcjson.GetMempoolDescendantsCmd) (out *[]string, e error) {
+	RPCHandlers["getmempooldescendants"].Call <-API{a.Ch, cmd, nil}
+	select {
+	case <-time.After(time.Second*5):
+		break
+	case o := <-a.Ch.(chan GetMempoolDescendantsRes):
+		out, e = o.Res, o.Err
+	}
+	return
+}
+
+// GetMempoolEntry calls the method with the given parameters
+func (a API) GetMempoolEntry(cmd *btcjson.GetMempoolEntryCmd) (e error) {
+	RPCHandlers["getmempoolentry"].Call <-API{a.Ch, cmd, nil}
+	return
+}
+
+// GetMempoolEntryChk checks if a new message arrived on the result channel and
+// returns true if it does, as well as storing the value in the Result field
+func (a API) GetMempoolEntryChk() (isNew bool) {
+	select {
+	case o := <-a.Ch.(chan GetMempoolEntryRes):
+		if o.Err != nil {
+			a.Result = o.Err
+		} else {
+			a.Result = o.Res
+		}
+		isNew = true
+	default:
+	}
+	return
+}
+
+// GetMempoolEntryGetRes returns a pointer to the value in the Result field
+func (a API) GetMempoolEntryGetRes() (out *btcjson.GetMempoolEntryResult, e error) {
+	out, _ = a.Result.(*btcjson.GetMempoolEntryResult)
+	e, _ = a.Result.(error)
+	return 
+}
+
+// GetMempoolEntryWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) GetMempoolEntryWait(cmd *btcjson.GetMempoolEntryCmd) (out *btcjson.GetMempoolEntryResult, e error) {
+	RPCHandlers["getmempoolentry"].Call <-API{a.Ch, cmd, nil}
+	select {
+	case <-time.After(time.Second*5):
+		break
+	case o := <-a.Ch.(chan GetMempoolEntryRes):
+		out, e = o.Res, o.Err
+	}
+	return
+}
+
 // GetMempoolInfo calls the method with the given parameters
 func (a API) GetMempoolInfo(cmd *None) (e error) {
 	RPCHandlers["getmempoolinfo"].Call <-API{a.Ch, cmd, nil}
@@ -1462,6 +1773,47 @@ func (a API) GetNetworkHashPSWait(cmd *btcjson.GetNetworkHashPSCmd) (out *[]btcj
 	return
 }
 
+// GetNetworkInfo calls the method with the given parameters
+func (a API) GetNetworkInfo(cmd *None) (e error) {
+	RPCHandlers["getnetworkinfo"].Call <-API{a.Ch, cmd, nil}
+	return
+}
+
+// GetNetworkInfoChk checks if a new message arrived on the result channel and
+// returns true if it does, as well as storing the value in the Result field
+func (a API) GetNetworkInfoChk() (isNew bool) {
+	select {
+	case o := <-a.Ch.(chan GetNetworkInfoRes):
+		if o.Err != nil {
+			a.Result = o.Err
+		} else {
+			a.Result = o.Res
+		}
+		isNew = true
+	default:
+	}
+	return
+}
+
+// GetNetworkInfoGetRes returns a pointer to the value in the Result field
+func (a API) GetNetworkInfoGetRes() (out *btcjson.GetNetworkInfoResult, e error) {
+	out, _ = a.Result.(*btcjson.GetNetworkInfoResult)
+	e, _ = a.Result.(error)
+	return 
+}
+
+// GetNetworkInfoWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) GetNetworkInfoWait(cmd *None) (out *btcjson.GetNetworkInfoResult, e error) {
+	RPCHandlers["getnetworkinfo"].Call <-API{a.Ch, cmd, nil}
+	select {
+	case <-time.After(time.Second*5):
+		break
+	case o := <-a.Ch.(chan GetNetworkInfoRes):
+		out, e = o.Res, o.Err
+	}
+	return
+}
+
 // GetPeerInfo calls the method with the given parameters
 func (a API) GetPeerInfo(cmd *None) (e error) {
 	RPCHandlers["getpeerinfo"].Call <-API{a.Ch, cmd, nil}
@@ -1503,6 +1855,47 @@ func (a API) GetPeerInfoWait(cmd *None) (out *[]btcjson.GetPeerInfoResult, e err
 	return
 }
 
+// GetPoolInfo calls the method with the given parameters
+func (a API) GetPoolInfo(cmd *btcjson.GetPoolInfoCmd) (e error) {
+	RPCHandlers["getpoolinfo"].Call <-API{a.Ch, cmd, nil}
+	return
+}
+
+// GetPoolInfoChk checks if a new message arrived on the result channel and
+// returns true if it does, as well as storing the value in the Result field
+func (a API) GetPoolInfoChk() (isNew bool) {
+	select {
+	case o := <-a.Ch.(chan GetPoolInfoRes):
+		if o.Err != nil {
+			a.Result = o.Err
+		} else {
+			a.Result = o.Res
+		}
+		isNew = true
+	default:
+	}
+	return
+}
+
+// GetPoolInfoGetRes returns a pointer to the value in the Result field
+func (a API) GetPoolInfoGetRes() (out *btcjson.GetPoolInfoResult, e error) {
+	out, _ = a.Result.(*btcjson.GetPoolInfoResult)
+	e, _ = a.Result.(error)
+	return 
+}
+
+// GetPoolInfoWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) GetPoolInfoWait(cmd *btcjson.GetPoolInfoCmd) (out *btcjson.GetPoolInfoResult, e error) {
+	RPCHandlers["getpoolinfo"].Call <-API{a.Ch, cmd, nil}
+	select {
+	case <-time.After(time.Second*5):
+		break
+	case o := <-a.Ch.(chan GetPoolInfoRes):
+		out, e = o.Res, o.Err
+	}
+	return
+}
+
 // GetRawMempool calls the method with the given parameters
 func (a API) GetRawMempool(cmd *btcjson.GetRawMempoolCmd) (e error) {
 	RPCHandlers["getrawmempool"].Call <-API{a.Ch, cmd, nil}
@@ -1667,6 +2060,88 @@ func (a API) HelpWait(cmd *btcjson.HelpCmd) (out *string, e error) {
 	return
 }
 
+// InvalidateBlock calls the method with the given parameters
+func (a API) InvalidateBlock(cmd *btcjson.InvalidateBlockCmd) (e error) {
+	RPCHandlers["invalidateblock"].Call <-API{a.Ch, cmd, nil}
+	return
+}
+
+// InvalidateBlockChk checks if a new message arrived on the result channel and
+// returns true if it does, as well as storing the value in the Result field
+func (a API) InvalidateBlockChk() (isNew bool) {
+	select {
+	case o := <-a.Ch.(chan InvalidateBlockRes):
+		if o.Err != nil {
+			a.Result = o.Err
+		} else {
+			a.Result = o.Res
+		}
+		isNew = true
+	default:
+	}
+	return
+}
+
+// InvalidateBlockGetRes returns a pointer to the value in the Result field
+func (a API) InvalidateBlockGetRes() (out *None, e error) {
+	out, _ = a.Result.(*None)
+	e, _ = a.Result.(error)
+	return 
+}
+
+// InvalidateBlockWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) InvalidateBlockWait(cmd *btcjson.InvalidateBlockCmd) (out *None, e error) {
+	RPCHandlers["invalidateblock"].Call <-API{a.Ch, cmd, nil}
+	select {
+	case <-time.After(time.Second*5):
+		break
+	case o := <-a.Ch.(chan InvalidateBlockRes):
+		out, e = o.Res, o.Err
+	}
+	return
+}
+
+// LoadMempool calls the method with the given parameters
+func (a API) LoadMempool(cmd *None) (e error) {
+	RPCHandlers["loadmempool"].Call <-API{a.Ch, cmd, nil}
+	return
+}
+
+// LoadMempoolChk checks if a new message arrived on the result channel and
+// returns true if it does, as well as storing the value in the Result field
+func (a API) LoadMempoolChk() (isNew bool) {
+	select {
+	case o := <-a.Ch.(chan LoadMempoolRes):
+		if o.Err != nil {
+			a.Result = o.Err
+		} else {
+			a.Result = o.Res
+		}
+		isNew = true
+	default:
+	}
+	return
+}
+
+// LoadMempoolGetRes returns a pointer to the value in the Result field
+func (a API) LoadMempoolGetRes() (out *btcjson.LoadMempoolResult, e error) {
+	out, _ = a.Result.(*btcjson.LoadMempoolResult)
+	e, _ = a.Result.(error)
+	return 
+}
+
+// LoadMempoolWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) LoadMempoolWait(cmd *None) (out *btcjson.LoadMempoolResult, e error) {
+	RPCHandlers["loadmempool"].Call <-API{a.Ch, cmd, nil}
+	select {
+	case <-time.After(time.Second*5):
+		break
+	case o := <-a.Ch.(chan LoadMempoolRes):
+		out, e = o.Res, o.Err
+	}
+	return
+}
+
 // Node calls the method with the given parameters
 func (a API) Node(cmd *btcjson.NodeCmd) (e error) {
 	RPCHandlers["node"].Call <-API{a.Ch, cmd, nil}
@@ -1749,6 +2224,88 @@ func (a API) PingWait(cmd *None) (out *None, e error) {
 	return
 }
 
+// PreciousBlock calls the method with the given parameters
+func (a API) PreciousBlock(cmd *btcjson.PreciousBlockCmd) (e error) {
+	RPCHandlers["preciousblock"].Call <-API{a.Ch, cmd, nil}
+	return
+}
+
+// PreciousBlockChk checks if a new message arrived on the result channel and
+// returns true if it does, as well as storing the value in the Result field
+func (a API) PreciousBlockChk() (isNew bool) {
+	select {
+	case o := <-a.Ch.(chan PreciousBlockRes):
+		if o.Err != nil {
+			a.Result = o.Err
+		} else {
+			a.Result = o.Res
+		}
+		isNew = true
+	default:
+	}
+	return
+}
+
+// PreciousBlockGetRes returns a pointer to the value in the Result field
+func (a API) PreciousBlockGetRes() (out *None, e error) {
+	out, _ = a.Result.(*None)
+	e, _ = a.Result.(error)
+	return 
+}
+
+// PreciousBlockWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) PreciousBlockWait(cmd *btcjson.PreciousBlockCmd) (out *None, e error) {
+	RPCHandlers["preciousblock"].Call <-API{a.Ch, cmd, nil}
+	select {
+	case <-time.After(time.Second*5):
+		break
+	case o := <-a.Ch.(chan PreciousBlockRes):
+		out, e = o.Res, o.Err
+	}
+	return
+}
+
+// ReconsiderBlock calls the method with the given parameters
+func (a API) ReconsiderBlock(cmd *btcjson.ReconsiderBlockCmd) (e error) {
+	RPCHandlers["reconsiderblock"].Call <-API{a.Ch, cmd, nil}
+	return
+}
+
+// ReconsiderBlockChk checks if a new message arrived on the result channel and
+// returns true if it does, as well as storing the value in the Result field
+func (a API) ReconsiderBlockChk() (isNew bool) {
+	select {
+	case o := <-a.Ch.(chan ReconsiderBlockRes):
+		if o.Err != nil {
+			a.Result = o.Err
+		} else {
+			a.Result = o.Res
+		}
+		isNew = true
+	default:
+	}
+	return
+}
+
+// ReconsiderBlockGetRes returns a pointer to the value in the Result field
+func (a API) ReconsiderBlockGetRes() (out *None, e error) {
+	out, _ = a.Result.(*None)
+	e, _ = a.Result.(error)
+	return 
+}
+
+// ReconsiderBlockWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) ReconsiderBlockWait(cmd *btcjson.ReconsiderBlockCmd) (out *None, e error) {
+	RPCHandlers["reconsiderblock"].Call <-API{a.Ch, cmd, nil}
+	select {
+	case <-time.After(time.Second*5):
+		break
+	case o := <-a.Ch.(chan ReconsiderBlockRes):
+		out, e = o.Res, o.Err
+	}
+	return
+}
+
 // ResetChain calls the method with the given parameters
 func (a API) ResetChain(cmd *None) (e error) {
 	RPCHandlers["resetchain"].Call <-API{a.Ch, cmd, nil}
@@ -1784,23 +2341,64 @@ func (a API) ResetChainWait(cmd *None) (out *None, e error) {
 	select {
 	case <-time.After(time.Second*5):
 		break
-	case o := <-a.Ch.(chan ResetChainRes):
+	case o := <-a.Ch.(chan ResetChainRes):
+		out, e = o.Res, o.Err
+	}
+	return
+}
+
+// Restart calls the method with the given parameters
+func (a API) Restart(cmd *None) (e error) {
+	RPCHandlers["restart"].Call <-API{a.Ch, cmd, nil}
+	return
+}
+
+// RestartChk checks if a new message arrived on the result channel and
+// returns true if it does, as well as storing the value in the Result field
+func (a API) RestartChk() (isNew bool) {
+	select {
+	case o := <-a.Ch.(chan RestartRes):
+		if o.Err != nil {
+			a.Result = o.Err
+		} else {
+			a.Result = o.Res
+		}
+		isNew = true
+	default:
+	}
+	return
+}
+
+// RestartGetRes returns a pointer to the value in the Result field
+func (a API) RestartGetRes() (out *None, e error) {
+	out, _ = a.Result.(*None)
+	e, _ = a.Result.(error)
+	return 
+}
+
+// RestartWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) RestartWait(cmd *None) (out *None, e error) {
+	RPCHandlers["restart"].Call <-API{a.Ch, cmd, nil}
+	select {
+	case <-time.After(time.Second*5):
+		break
+	case o := <-a.Ch.(chan RestartRes):
 		out, e = o.Res, o.Err
 	}
 	return
 }
 
-// Restart calls the method with the given parameters
-func (a API) Restart(cmd *None) (e error) {
-	RPCHandlers["restart"].Call <-API{a.Ch, cmd, nil}
+// SaveMempool calls the method with the given parameters
+func (a API) SaveMempool(cmd *None) (e error) {
+	RPCHandlers["savemempool"].Call <-API{a.Ch, cmd, nil}
 	return
 }
 
-// RestartChk checks if a new message arrived on the result channel and
+// SaveMempoolChk checks if a new message arrived on the result channel and
 // returns true if it does, as well as storing the value in the Result field
-func (a API) RestartChk() (isNew bool) {
+func (a API) SaveMempoolChk() (isNew bool) {
 	select {
-	case o := <-a.Ch.(chan RestartRes):
+	case o := <-a.Ch.(chan SaveMempoolRes):
 		if o.Err != nil {
 			a.Result = o.Err
 		} else {
@@ -1812,20 +2410,20 @@ func (a API) RestartChk() (isNew bool) {
 	return
 }
 
-// RestartGetRes returns a pointer to the value in the Result field
-func (a API) RestartGetRes() (out *None, e error) {
-	out, _ = a.Result.(*None)
+// SaveMempoolGetRes returns a pointer to the value in the Result field
+func (a API) SaveMempoolGetRes() (out *btcjson.SaveMempoolResult, e error) {
+	out, _ = a.Result.(*btcjson.SaveMempoolResult)
 	e, _ = a.Result.(error)
 	return 
 }
 
-// RestartWait calls the method and blocks until it returns or 5 seconds passes
-func (a API) RestartWait(cmd *None) (out *None, e error) {
-	RPCHandlers["restart"].Call <-API{a.Ch, cmd, nil}
+// SaveMempoolWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) SaveMempoolWait(cmd *None) (out *btcjson.SaveMempoolResult, e error) {
+	RPCHandlers["savemempool"].Call <-API{a.Ch, cmd, nil}
 	select {
 	case <-time.After(time.Second*5):
 		break
-	case o := <-a.Ch.(chan RestartRes):
+	case o := <-a.Ch.(chan SaveMempoolRes):
 		out, e = o.Res, o.Err
 	}
 	return
@@ -2295,6 +2893,12 @@ func RunAPI(server *Server, quit qu.C) {
 				}
 				if r, ok := res.([]btcjson.GetAddedNodeInfoResultAddr); ok { 
 					msg.Ch.(chan GetAddedNodeInfoRes) <-GetAddedNodeInfoRes{&r, e} } 
+			case msg := <-nrh["getalgostats"].Call:
+				if res, e = nrh["getalgostats"].
+					Fn(server, msg.Params.(*btcjson.GetAlgoStatsCmd), nil); E.Chk(e) {
+				}
+				if r, ok := res.(btcjson.GetAlgoStatsResult); ok { 
+					msg.Ch.(chan GetAlgoStatsRes) <-GetAlgoStatsRes{&r, e} } 
 			case msg := <-nrh["getbestblock"].Call:
 				if res, e = nrh["getbestblock"].
 					Fn(server, msg.Params.(*None), nil); E.Chk(e) {
@@ -2355,6 +2959,12 @@ func RunAPI(server *Server, quit qu.C) {
 				}
 				if r, ok := res.(string); ok { 
 					msg.Ch.(chan GetCFilterHeaderRes) <-GetCFilterHeaderRes{&r, e} } 
+			case msg := <-nrh["getchaintips"].Call:
+				if res, e = nrh["getchaintips"].
+					Fn(server, msg.Params.(*None), nil); E.Chk(e) {
+				}
+				if r, ok := res.([]btcjson.GetChainTipsResult); ok { 
+					msg.Ch.(chan GetChainTipsRes) <-GetChainTipsRes{&r, e} } 
 			case msg := <-nrh["getconnectioncount"].Call:
 				if res, e = nrh["getconnectioncount"].
 					Fn(server, msg.Params.(*None), nil); E.Chk(e) {
@@ -2397,6 +3007,30 @@ func RunAPI(server *Server, quit qu.C) {
 				}
 				if r, ok := res.(btcjson.InfoChainResult0); ok { 
 					msg.Ch.(chan GetInfoRes) <-GetInfoRes{&r, e} } 
+			case msg := <-nrh["getlanpeers"].Call:
+				if res, e = nrh["getlanpeers"].
+					Fn(server, msg.Params.(*None), nil); E.Chk(e) {
+				}
+				if r, ok := res.([]btcjson.GetLANPeersResult); ok { 
+					msg.Ch.(chan GetLANPeersRes) <-GetLANPeersRes{&r, e} } 
+			case msg := <-nrh["getmempoolancestors"].Call:
+				if res, e = nrh["getmempoolancestors"].
+					Fn(server, msg.Params.(*btcjson.GetMempoolAncestorsCmd), nil); E.Chk(e) {
+				}
+				if r, ok := res.([]string); ok { 
+					msg.Ch.(chan GetMempoolAncestorsRes) <-GetMempoolAncestorsRes{&r, e} } 
+			case msg := <-nrh["getmempooldescendants"].Call:
+				if res, e = nrh["getmempooldescendants"].
+					Fn(server, msg.Params.(*btcjson.GetMempoolDescendantsCmd), nil); E.Chk(e) {
+				}
+				if r, ok := res.([]string); ok { 
+					msg.Ch.(chan GetMempoolDescendantsRes) <-GetMempoolDescendantsRes{&r, e} } 
+			case msg := <-nrh["getmempoolentry"].Call:
+				if res, e = nrh["getmempoolentry"].
+					Fn(server, msg.Params.(*btcjson.GetMempoolEntryCmd), nil); E.Chk(e) {
+				}
+				if r, ok := res.(btcjson.GetMempoolEntryResult); ok { 
+					msg.Ch.(chan GetMempoolEntryRes) <-GetMempoolEntryRes{&r, e} } 
 			case msg := <-nrh["getmempoolinfo"].Call:
 				if res, e = nrh["getmempoolinfo"].
 					Fn(server, msg.Params.(*None), nil); E.Chk(e) {
@@ -2421,12 +3055,24 @@ func RunAPI(server *Server, quit qu.C) {
 				}
 				if r, ok := res.([]btcjson.GetPeerInfoResult); ok { 
 					msg.Ch.(chan GetNetworkHashPSRes) <-GetNetworkHashPSRes{&r, e} } 
+			case msg := <-nrh["getnetworkinfo"].Call:
+				if res, e = nrh["getnetworkinfo"].
+					Fn(server, msg.Params.(*None), nil); E.Chk(e) {
+				}
+				if r, ok := res.(btcjson.GetNetworkInfoResult); ok { 
+					msg.Ch.(chan GetNetworkInfoRes) <-GetNetworkInfoRes{&r, e} } 
 			case msg := <-nrh["getpeerinfo"].Call:
 				if res, e = nrh["getpeerinfo"].
 					Fn(server, msg.Params.(*None), nil); E.Chk(e) {
 				}
 				if r, ok := res.([]btcjson.GetPeerInfoResult); ok { 
 					msg.Ch.(chan GetPeerInfoRes) <-GetPeerInfoRes{&r, e} } 
+			case msg := <-nrh["getpoolinfo"].Call:
+				if res, e = nrh["getpoolinfo"].
+					Fn(server, msg.Params.(*btcjson.GetPoolInfoCmd), nil); E.Chk(e) {
+				}
+				if r, ok := res.(btcjson.GetPoolInfoResult); ok { 
+					msg.Ch.(chan GetPoolInfoRes) <-GetPoolInfoRes{&r, e} } 
 			case msg := <-nrh["getrawmempool"].Call:
 				if res, e = nrh["getrawmempool"].
 					Fn(server, msg.Params.(*btcjson.GetRawMempoolCmd), nil); E.Chk(e) {
@@ -2451,6 +3097,18 @@ func RunAPI(server *Server, quit qu.C) {
 				}
 				if r, ok := res.(string); ok { 
 					msg.Ch.(chan HelpRes) <-HelpRes{&r, e} } 
+			case msg := <-nrh["invalidateblock"].Call:
+				if res, e = nrh["invalidateblock"].
+					Fn(server, msg.Params.(*btcjson.InvalidateBlockCmd), nil); E.Chk(e) {
+				}
+				if r, ok := res.(None); ok { 
+					msg.Ch.(chan InvalidateBlockRes) <-InvalidateBlockRes{&r, e} } 
+			case msg := <-nrh["loadmempool"].Call:
+				if res, e = nrh["loadmempool"].
+					Fn(server, msg.Params.(*None), nil); E.Chk(e) {
+				}
+				if r, ok := res.(btcjson.LoadMempoolResult); ok { 
+					msg.Ch.(chan LoadMempoolRes) <-LoadMempoolRes{&r, e} } 
 			case msg := <-nrh["node"].Call:
 				if res, e = nrh["node"].
 					Fn(server, msg.Params.(*btcjson.NodeCmd), nil); E.Chk(e) {
@@ -2463,6 +3121,18 @@ func RunAPI(server *Server, quit qu.C) {
 				}
 				if r, ok := res.(None); ok { 
 					msg.Ch.(chan PingRes) <-PingRes{&r, e} } 
+			case msg := <-nrh["preciousblock"].Call:
+				if res, e = nrh["preciousblock"].
+					Fn(server, msg.Params.(*btcjson.PreciousBlockCmd), nil); E.Chk(e) {
+				}
+				if r, ok := res.(None); ok { 
+					msg.Ch.(chan PreciousBlockRes) <-PreciousBlockRes{&r, e} } 
+			case msg := <-nrh["reconsiderblock"].Call:
+				if res, e = nrh["reconsiderblock"].
+					Fn(server, msg.Params.(*btcjson.ReconsiderBlockCmd), nil); E.Chk(e) {
+				}
+				if r, ok := res.(None); ok { 
+					msg.Ch.(chan ReconsiderBlockRes) <-ReconsiderBlockRes{&r, e} } 
 			case msg := <-nrh["resetchain"].Call:
 				if res, e = nrh["resetchain"].
 					Fn(server, msg.Params.(*None), nil); E.Chk(e) {
@@ -2475,6 +3145,12 @@ func RunAPI(server *Server, quit qu.C) {
 				}
 				if r, ok := res.(None); ok { 
 					msg.Ch.(chan RestartRes) <-RestartRes{&r, e} } 
+			case msg := <-nrh["savemempool"].Call:
+				if res, e = nrh["savemempool"].
+					Fn(server, msg.Params.(*None), nil); E.Chk(e) {
+				}
+				if r, ok := res.(btcjson.SaveMempoolResult); ok { 
+					msg.Ch.(chan SaveMempoolRes) <-SaveMempoolRes{&r, e} } 
 			case msg := <-nrh["searchrawtransactions"].Call:
 				if res, e = nrh["searchrawtransactions"].
 					Fn(server, msg.Params.(*btcjson.SearchRawTransactionsCmd), nil); E.Chk(e) {
@@ -2636,6 +3312,19 @@ func (c *CAPI) GetAddedNodeInfo(req *btcjson.GetAddedNodeInfoCmd, resp []btcjson
 	return 
 }
 
+func (c *CAPI) GetAlgoStats(req *btcjson.GetAlgoStatsCmd, resp btcjson.GetAlgoStatsResult) (e error) {
+	nrh := RPCHandlers
+	res := nrh["getalgostats"].Result()
+	res.Params = req
+	nrh["getalgostats"].Call <- res
+	select {
+	case resp = <-res.Ch.(chan btcjson.GetAlgoStatsResult):
+	case <-time.After(c.Timeout):
+	case <-c.quit.Wait():
+	} 
+	return 
+}
+
 func (c *CAPI) GetBestBlock(req *None, resp btcjson.GetBestBlockResult) (e error) {
 	nrh := RPCHandlers
 	res := nrh["getbestblock"].Result()
@@ -2766,6 +3455,19 @@ func (c *CAPI) GetCFilterHeader(req *btcjson.GetCFilterHeaderCmd, resp string) (
 	return 
 }
 
+func (c *CAPI) GetChainTips(req *None, resp []btcjson.GetChainTipsResult) (e error) {
+	nrh := RPCHandlers
+	res := nrh["getchaintips"].Result()
+	res.Params = req
+	nrh["getchaintips"].Call <- res
+	select {
+	case resp = <-res.Ch.(chan []btcjson.GetChainTipsResult):
+	case <-time.After(c.Timeout):
+	case <-c.quit.Wait():
+	} 
+	return 
+}
+
 func (c *CAPI) GetConnectionCount(req *None, resp int32) (e error) {
 	nrh := RPCHandlers
 	res := nrh["getconnectioncount"].Result()
@@ -2857,6 +3559,58 @@ func (c *CAPI) GetInfo(req *None, resp btcjson.InfoChainResult0) (e error) {
 	return 
 }
 
+func (c *CAPI) GetLANPeers(req *None, resp []btcjson.GetLANPeersResult) (e error) {
+	nrh := RPCHandlers
+	res := nrh["getlanpeers"].Result()
+	res.Params = req
+	nrh["getlanpeers"].Call <- res
+	select {
+	case resp = <-res.Ch.(chan []btcjson.GetLANPeersResult):
+	case <-time.After(c.Timeout):
+	case <-c.quit.Wait():
+	} 
+	return 
+}
+
+func (c *CAPI) GetMempoolAncestors(req *btcjson.GetMempoolAncestorsCmd, resp []string) (e error) {
+	nrh := RPCHandlers
+	res := nrh["getmempoolancestors"].Result()
+	res.Params = req
+	nrh["getmempoolancestors"].Call <- res
+	select {
+	case resp = <-res.Ch.(chan []string):
+	case <-time.After(c.Timeout):
+	case <-c.quit.Wait():
+	} 
+	return 
+}
+
+func (c *CAPI) GetMempoolDescendants(req *btcjson.GetMempoolDescendantsCmd, resp []string) (e error) {
+	nrh := RPCHandlers
+	res := nrh["getmempooldescendants"].Result()
+	res.Params = req
+	nrh["getmempooldescendants"].Call <- res
+	select {
+	case resp = <-res.Ch.(chan []string):
+	case <-time.After(c.Timeout):
+	case <-c.quit.Wait():
+	} 
+	return 
+}
+
+func (c *CAPI) GetMempoolEntry(req *btcjson.GetMempoolEntryCmd, resp btcjson.GetMempoolEntryResult) (e error) {
+	nrh := RPCHandlers
+	res := nrh["getmempoolentry"].Result()
+	res.Params = req
+	nrh["getmempoolentry"].Call <- res
+	select {
+	case resp = <-res.Ch.(chan btcjson.GetMempoolEntryResult):
+	case <-time.After(c.Timeout):
+	case <-c.quit.Wait():
+	} 
+	return 
+}
+
 func (c *CAPI) GetMempoolInfo(req *None, resp btcjson.GetMempoolInfoResult) (e error) {
 	nrh := RPCHandlers
 	res := nrh["getmempoolinfo"].Result()
@@ -2909,6 +3663,19 @@ func (c *CAPI) GetNetworkHashPS(req *btcjson.GetNetworkHashPSCmd, resp []btcjson
 	return 
 }
 
+func (c *CAPI) GetNetworkInfo(req *None, resp btcjson.GetNetworkInfoResult) (e error) {
+	nrh := RPCHandlers
+	res := nrh["getnetworkinfo"].Result()
+	res.Params = req
+	nrh["getnetworkinfo"].Call <- res
+	select {
+	case resp = <-res.Ch.(chan btcjson.GetNetworkInfoResult):
+	case <-time.After(c.Timeout):
+	case <-c.quit.Wait():
+	} 
+	return 
+}
+
 func (c *CAPI) GetPeerInfo(req *None, resp []btcjson.GetPeerInfoResult) (e error) {
 	nrh := RPCHandlers
 	res := nrh["getpeerinfo"].Result()
@@ -2922,6 +3689,19 @@ func (c *CAPI) GetPeerInfo(req *None, resp []btcjson.GetPeerInfoResult) (e error
 	return 
 }
 
+func (c *CAPI) GetPoolInfo(req *btcjson.GetPoolInfoCmd, resp btcjson.GetPoolInfoResult) (e error) {
+	nrh := RPCHandlers
+	res := nrh["getpoolinfo"].Result()
+	res.Params = req
+	nrh["getpoolinfo"].Call <- res
+	select {
+	case resp = <-res.Ch.(chan btcjson.GetPoolInfoResult):
+	case <-time.After(c.Timeout):
+	case <-c.quit.Wait():
+	} 
+	return 
+}
+
 func (c *CAPI) GetRawMempool(req *btcjson.GetRawMempoolCmd, resp []string) (e error) {
 	nrh := RPCHandlers
 	res := nrh["getrawmempool"].Result()
@@ -2974,6 +3754,32 @@ func (c *CAPI) Help(req *btcjson.HelpCmd, resp string) (e error) {
 	return 
 }
 
+func (c *CAPI) InvalidateBlock(req *btcjson.InvalidateBlockCmd, resp None) (e error) {
+	nrh := RPCHandlers
+	res := nrh["invalidateblock"].Result()
+	res.Params = req
+	nrh["invalidateblock"].Call <- res
+	select {
+	case resp = <-res.Ch.(chan None):
+	case <-time.After(c.Timeout):
+	case <-c.quit.Wait():
+	} 
+	return 
+}
+
+func (c *CAPI) LoadMempool(req *None, resp btcjson.LoadMempoolResult) (e error) {
+	nrh := RPCHandlers
+	res := nrh["loadmempool"].Result()
+	res.Params = req
+	nrh["loadmempool"].Call <- res
+	select {
+	case resp = <-res.Ch.(chan btcjson.LoadMempoolResult):
+	case <-time.After(c.Timeout):
+	case <-c.quit.Wait():
+	} 
+	return 
+}
+
 func (c *CAPI) Node(req *btcjson.NodeCmd, resp None) (e error) {
 	nrh := RPCHandlers
 	res := nrh["node"].Result()
@@ -3000,6 +3806,32 @@ func (c *CAPI) Ping(req *None, resp None) (e error) {
 	return 
 }
 
+func (c *CAPI) PreciousBlock(req *btcjson.PreciousBlockCmd, resp None) (e error) {
+	nrh := RPCHandlers
+	res := nrh["preciousblock"].Result()
+	res.Params = req
+	nrh["preciousblock"].Call <- res
+	select {
+	case resp = <-res.Ch.(chan None):
+	case <-time.After(c.Timeout):
+	case <-c.quit.Wait():
+	} 
+	return 
+}
+
+func (c *CAPI) ReconsiderBlock(req *btcjson.ReconsiderBlockCmd, resp None) (e error) {
+	nrh := RPCHandlers
+	res := nrh["reconsiderblock"].Result()
+	res.Params = req
+	nrh["reconsiderblock"].Call <- res
+	select {
+	case resp = <-res.Ch.(chan None):
+	case <-time.After(c.Timeout):
+	case <-c.quit.Wait():
+	} 
+	return 
+}
+
 func (c *CAPI) ResetChain(req *None, resp None) (e error) {
 	nrh := RPCHandlers
 	res := nrh["resetchain"].Result()
@@ -3026,6 +3858,19 @@ func (c *CAPI) Restart(req *None, resp None) (e error) {
 	return 
 }
 
+func (c *CAPI) SaveMempool(req *None, resp btcjson.SaveMempoolResult) (e error) {
+	nrh := RPCHandlers
+	res := nrh["savemempool"].Result()
+	res.Params = req
+	nrh["savemempool"].Call <- res
+	select {
+	case resp = <-res.Ch.(chan btcjson.SaveMempoolResult):
+	case <-time.After(c.Timeout):
+	case <-c.quit.Wait():
+	} 
+	return 
+}
+
 func (c *CAPI) SearchRawTransactions(req *btcjson.SearchRawTransactionsCmd, resp []btcjson.SearchRawTransactionsResult) (e error) {
 	nrh := RPCHandlers
 	res := nrh["searchrawtransactions"].Result()
@@ -3228,6 +4073,16 @@ func (r *CAPIClient) GetAddedNodeInfo(cmd ...*btcjson.GetAddedNodeInfoCmd) (res
 	return
 }
 
+func (r *CAPIClient) GetAlgoStats(cmd ...*btcjson.GetAlgoStatsCmd) (res btcjson.GetAlgoStatsResult, e error) {
+	var c *btcjson.GetAlgoStatsCmd
+	if len(cmd) > 0 {
+		c = cmd[0]
+	}
+	if e = r.Call("CAPI.GetAlgoStats", c, &res); E.Chk(e) {
+	}
+	return
+}
+
 func (r *CAPIClient) GetBestBlock(cmd ...*None) (res btcjson.GetBestBlockResult, e error) {
 	var c *None
 	if len(cmd) > 0 {
@@ -3328,6 +4183,16 @@ func (r *CAPIClient) GetCFilterHeader(cmd ...*btcjson.GetCFilterHeaderCmd) (res
 	return
 }
 
+func (r *CAPIClient) GetChainTips(cmd ...*None) (res []btcjson.GetChainTipsResult, e error) {
+	var c *None
+	if len(cmd) > 0 {
+		c = cmd[0]
+	}
+	if e = r.Call("CAPI.GetChainTips", c, &res); E.Chk(e) {
+	}
+	return
+}
+
 func (r *CAPIClient) GetConnectionCount(cmd ...*None) (res int32, e error) {
 	var c *None
 	if len(cmd) > 0 {
@@ -3398,6 +4263,46 @@ func (r *CAPIClient) GetInfo(cmd ...*None) (res btcjson.InfoChainResult0, e erro
 	return
 }
 
+func (r *CAPIClient) GetLANPeers(cmd ...*None) (res []btcjson.GetLANPeersResult, e error) {
+	var c *None
+	if len(cmd) > 0 {
+		c = cmd[0]
+	}
+	if e = r.Call("CAPI.GetLANPeers", c, &res); E.Chk(e) {
+	}
+	return
+}
+
+func (r *CAPIClient) GetMempoolAncestors(cmd ...*btcjson.GetMempoolAncestorsCmd) (res []string, e error) {
+	var c *btcjson.GetMempoolAncestorsCmd
+	if len(cmd) > 0 {
+		c = cmd[0]
+	}
+	if e = r.Call("CAPI.GetMempoolAncestors", c, &res); E.Chk(e) {
+	}
+	return
+}
+
+func (r *CAPIClient) GetMempoolDescendants(cmd ...*btcjson.GetMempoolDescendantsCmd) (res []string, e error) {
+	var c *btcjson.GetMempoolDescendantsCmd
+	if len(cmd) > 0 {
+		c = cmd[0]
+	}
+	if e = r.Call("CAPI.GetMempoolDescendants", c, &res); E.Chk(e) {
+	}
+	return
+}
+
+func (r *CAPIClient) GetMempoolEntry(cmd ...*btcjson.GetMempoolEntryCmd) (res btcjson.GetMempoolEntryResult, e error) {
+	var c *btcjson.GetMempoolEntryCmd
+	if len(cmd) > 0 {
+		c = cmd[0]
+	}
+	if e = r.Call("CAPI.GetMempoolEntry", c, &res); E.Chk(e) {
+	}
+	return
+}
+
 func (r *CAPIClient) GetMempoolInfo(cmd ...*None) (res btcjson.GetMempoolInfoResult, e error) {
 	var c *None
 	if len(cmd) > 0 {
@@ -3438,6 +4343,16 @@ func (r *CAPIClient) GetNetworkHashPS(cmd ...*btcjson.GetNetworkHashPSCmd) (res
 	return
 }
 
+func (r *CAPIClient) GetNetworkInfo(cmd ...*None) (res btcjson.GetNetworkInfoResult, e error) {
+	var c *None
+	if len(cmd) > 0 {
+		c = cmd[0]
+	}
+	if e = r.Call("CAPI.GetNetworkInfo", c, &res); E.Chk(e) {
+	}
+	return
+}
+
 func (r *CAPIClient) GetPeerInfo(cmd ...*None) (res []btcjson.GetPeerInfoResult, e error) {
 	var c *None
 	if len(cmd) > 0 {
@@ -3448,6 +4363,16 @@ func (r *CAPIClient) GetPeerInfo(cmd ...*None) (res []btcjson.GetPeerInfoResult,
 	return
 }
 
+func (r *CAPIClient) GetPoolInfo(cmd ...*btcjson.GetPoolInfoCmd) (res btcjson.GetPoolInfoResult, e error) {
+	var c *btcjson.GetPoolInfoCmd
+	if len(cmd) > 0 {
+		c = cmd[0]
+	}
+	if e = r.Call("CAPI.GetPoolInfo", c, &res); E.Chk(e) {
+	}
+	return
+}
+
 func (r *CAPIClient) GetRawMempool(cmd ...*btcjson.GetRawMempoolCmd) (res []string, e error) {
 	var c *btcjson.GetRawMempoolCmd
 	if len(cmd) > 0 {
@@ -3488,6 +4413,26 @@ func (r *CAPIClient) Help(cmd ...*btcjson.HelpCmd) (res string, e error) {
 	return
 }
 
+func (r *CAPIClient) InvalidateBlock(cmd ...*btcjson.InvalidateBlockCmd) (res None, e error) {
+	var c *btcjson.InvalidateBlockCmd
+	if len(cmd) > 0 {
+		c = cmd[0]
+	}
+	if e = r.Call("CAPI.InvalidateBlock", c, &res); E.Chk(e) {
+	}
+	return
+}
+
+func (r *CAPIClient) LoadMempool(cmd ...*None) (res btcjson.LoadMempoolResult, e error) {
+	var c *None
+	if len(cmd) > 0 {
+		c = cmd[0]
+	}
+	if e = r.Call("CAPI.LoadMempool", c, &res); E.Chk(e) {
+	}
+	return
+}
+
 func (r *CAPIClient) Node(cmd ...*btcjson.NodeCmd) (res None, e error) {
 	var c *btcjson.NodeCmd
 	if len(cmd) > 0 {
@@ -3508,6 +4453,26 @@ func (r *CAPIClient) Ping(cmd ...*None) (res None, e error) {
 	return
 }
 
+func (r *CAPIClient) PreciousBlock(cmd ...*btcjson.PreciousBlockCmd) (res None, e error) {
+	var c *btcjson.PreciousBlockCmd
+	if len(cmd) > 0 {
+		c = cmd[0]
+	}
+	if e = r.Call("CAPI.PreciousBlock", c, &res); E.Chk(e) {
+	}
+	return
+}
+
+func (r *CAPIClient) ReconsiderBlock(cmd ...*btcjson.ReconsiderBlockCmd) (res None, e error) {
+	var c *btcjson.ReconsiderBlockCmd
+	if len(cmd) > 0 {
+		c = cmd[0]
+	}
+	if e = r.Call("CAPI.ReconsiderBlock", c, &res); E.Chk(e) {
+	}
+	return
+}
+
 func (r *CAPIClient) ResetChain(cmd ...*None) (res None, e error) {
 	var c *None
 	if len(cmd) > 0 {
@@ -3528,6 +4493,16 @@ func (r *CAPIClient) Restart(cmd ...*None) (res None, e error) {
 	return
 }
 
+func (r *CAPIClient) SaveMempool(cmd ...*None) (res btcjson.SaveMempoolResult, e error) {
+	var c *None
+	if len(cmd) > 0 {
+		c = cmd[0]
+	}
+	if e = r.Call("CAPI.SaveMempool", c, &res); E.Chk(e) {
+	}
+	return
+}
+
 func (r *CAPIClient) SearchRawTransactions(cmd ...*btcjson.SearchRawTransactionsCmd) (res []btcjson.SearchRawTransactionsResult, e error) {
 	var c *btcjson.SearchRawTransactionsCmd
 	if len(cmd) > 0 {
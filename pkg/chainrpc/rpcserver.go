@@ -27,12 +27,12 @@ import (
 	"github.com/btcsuite/websocket"
 	uberatomic "go.uber.org/atomic"
 
+	"github.com/cybriq/p9/pkg/addrmgr"
 	"github.com/cybriq/p9/pkg/amt"
 	"github.com/cybriq/p9/pkg/bits"
 	"github.com/cybriq/p9/pkg/block"
 	"github.com/cybriq/p9/pkg/btcaddr"
 	"github.com/cybriq/p9/pkg/chaincfg"
-	"github.com/cybriq/p9/pkg/fork"
 	"github.com/cybriq/p9/pod/config"
 
 	"github.com/cybriq/p9/cmd/node/active"
@@ -165,6 +165,17 @@ type ServerConfig struct {
 	Hashrate                        uberatomic.Uint64
 	Quit                            qu.C
 	StartController, StopController qu.C
+	// PoolInfo returns the share window and balances of the controller when it is running in pool mode
+	PoolInfo func(blocks int) (*btcjson.GetPoolInfoResult, error)
+	// LANPeers returns the nodes found on the local network when LAN peer discovery is enabled
+	LANPeers func() []btcjson.GetLANPeersResult
+	// MempoolFile is the path of the file the mempool is saved in
+	MempoolFile string
+	// SaveMempool writes the mempool to the mempool file and returns the number of transactions saved
+	SaveMempool func() (count int, e error)
+	// LoadMempool adds the transactions in the mempool file that are still valid to the mempool and returns the numbers
+	// accepted, already in the mempool and rejected
+	LoadMempool func() (accepted, present, failed int, e error)
 }
 
 // ServerConnManager represents a connection manager for use with the RPC Server. The interface contract requires that
@@ -209,6 +220,10 @@ type ServerConnManager interface {
 	// RelayTransactions generates and relays inventory vectors for all of the passed transactions to all connected
 	// peers.
 	RelayTransactions(txns []*mempool.TxDesc)
+	// LocalServices returns the services the node advertises to its peers.
+	LocalServices() wire.ServiceFlag
+	// LocalAddresses returns the addresses the node advertises to its peers along with their priorities.
+	LocalAddresses() ([]*wire.NetAddress, []addrmgr.AddressPriority)
 }
 
 // ServerPeer represents a peer for use with the RPC Server.
@@ -670,12 +685,17 @@ var (
 		"getblockheader":        {},
 		"getcfilter":            {},
 		"getcfilterheader":      {},
+		"getchaintips":          {},
 		"getcurrentnet":         {},
 		"getdifficulty":         {},
 		"getheaders":            {},
 		"getinfo":               {},
+		"getmempoolancestors":   {},
+		"getmempooldescendants": {},
+		"getmempoolentry":       {},
 		"getnettotals":          {},
 		"getnetworkhashps":      {},
+		"getnetworkinfo":        {},
 		"getrawmempool":         {},
 		"getrawtransaction":     {},
 		"gettxout":              {},
@@ -690,13 +710,7 @@ var (
 	// RPCUnimplemented is commands that are currently unimplemented, but should ultimately be.
 	RPCUnimplemented = map[string]struct{}{
 		"estimatepriority": {},
-		"getchaintips":     {},
-		"getmempoolentry":  {},
-		"getnetworkinfo":   {},
 		"getwork":          {},
-		"invalidateblock":  {},
-		"preciousblock":    {},
-		"reconsiderblock":  {},
 	}
 )
 
@@ -1510,6 +1524,7 @@ func (s *Server) StandardCmdResult(
 	}
 	return nil, btcjson.ErrRPCMethodNotFound
 handled:
+	defer rpcDuration.Since(cmd.Method, time.Now())
 	return handler.Fn(s, cmd.Cmd, closeChan)
 }
 
@@ -2224,8 +2239,8 @@ func VerifyChain(s *Server, level, depth int32) (e error) {
 			E.F("verify is unable to fetch block at height %d: %v", height, e)
 			return e
 		}
-		powLimit := fork.GetMinDiff(
-			fork.GetAlgoName(
+		powLimit := s.Cfg.ChainParams.Forks.GetMinDiff(
+			s.Cfg.ChainParams.Forks.GetAlgoName(
 				blk.WireBlock().Header.
 					Version, height,
 			), height,
@@ -2239,6 +2254,7 @@ func VerifyChain(s *Server, level, depth int32) (e error) {
 			e = blockchain.CheckBlockSanity(
 				blk,
 				powLimit,
+				s.Cfg.ChainParams.Forks,
 				s.Cfg.TimeSource,
 				true,
 				blk.Height(),
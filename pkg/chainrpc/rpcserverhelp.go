@@ -141,6 +141,27 @@ var HelpDescsEnUS = map[string]string{
 	"getaddednodeinfo--condition0": "dns=false",
 	"getaddednodeinfo--condition1": "dns=true",
 	"getaddednodeinfo--result0":    "List of added peers",
+	// GetAlgoStatsCmd help.
+	"getalgostats--synopsis": "Returns the target, block intervals, share of recent blocks and estimated hashrate of each block version of the current hard fork.",
+	"getalgostats-blocks":    "The number of most recent blocks to count blocks and estimate hashrates over",
+	// GetAlgoStatsResult help.
+	"getalgostatsresult-height":   "Height of the best block",
+	"getalgostatsresult-fork":     "Name of the hard fork the next block is in",
+	"getalgostatsresult-blocks":   "Number of blocks sampled, which only includes blocks since the hard fork",
+	"getalgostatsresult-timespan": "Number of seconds between the oldest sampled block and the best block",
+	"getalgostatsresult-algos":    "The activity of each block version, ordered by version",
+	// AlgoStatsResult help.
+	"algostatsresult-name":            "Name of the algorithm",
+	"algostatsresult-version":         "Block version of the algorithm",
+	"algostatsresult-bits":            "Hex-encoded target bits of the next block of this version",
+	"algostatsresult-difficulty":      "Difficulty of the next block of this version",
+	"algostatsresult-targetinterval":  "Number of seconds between blocks of this version the difficulty adjustment aims for",
+	"algostatsresult-averageinterval": "Moving average of the seconds between recent blocks of this version, 0 if there are too few",
+	"algostatsresult-sincelast":       "Number of seconds between the last block of this version and the best block",
+	"algostatsresult-lastheight":      "Height of the last block of this version, -1 if none was found",
+	"algostatsresult-blocks":          "Number of sampled blocks of this version",
+	"algostatsresult-share":           "Fraction of the sampled blocks that are of this version",
+	"algostatsresult-hashespersec":    "Estimated hashes per second of this version over the sampled blocks",
 	// GetBestBlockResult help.
 	"getbestblockresult-hash":   "Hex-encoded bytes of the best block hash",
 	"getbestblockresult-height": "Height of the best block",
@@ -368,6 +389,16 @@ var HelpDescsEnUS = map[string]string{
 	"getcfilterheader-hash":       "The hash of the block",
 	"getcfilterheader--result0":   "The block's gcs filter header",
 
+	// GetChainTipsCmd help.
+	"getchaintips--synopsis": "Returns every block known to the node that no other known block builds on, including the tip of the main chain.",
+	"getchaintips--result0":  "The chain tips, ordered from the highest",
+
+	// GetChainTipsResult help.
+	"getchaintipsresult-height":    "Height of the tip",
+	"getchaintipsresult-hash":      "Hex-encoded hash of the tip",
+	"getchaintipsresult-branchlen": "Number of blocks between the tip and the main chain, 0 for the main chain",
+	"getchaintipsresult-status":    "Status of the branch (active, valid-fork, valid-headers, headers-only or invalid)",
+
 	// GetConnectionCountCmd help.
 	"getconnectioncount--synopsis": "Returns the number of active connections to other peers.",
 	"getconnectioncount--result0":  "The number of connections",
@@ -427,12 +458,65 @@ var HelpDescsEnUS = map[string]string{
 	// GetInfoCmd help.
 	"getinfo--synopsis": "Returns a JSON object containing various state info.",
 
+	// GetLANPeersCmd help.
+	"getlanpeers--synopsis": "Returns the nodes found on the local network from their advertisments when LAN peer discovery is enabled.",
+
+	// GetLANPeersResult help.
+	"getlanpeersresult-uuid":      "The identifier the node advertises, generated each time it starts",
+	"getlanpeersresult-addr":      "The address of the P2P listener of the node",
+	"getlanpeersresult-ips":       "The addresses the node advertises",
+	"getlanpeersresult-outbound":  "Whether this node connects to the other, which is the case when its UUID is the lower one",
+	"getlanpeersresult-firstseen": "Time the first advertisment of the node was received in seconds since 1 Jan 1970 GMT",
+	"getlanpeersresult-lastseen":  "Time the last advertisment of the node was received in seconds since 1 Jan 1970 GMT",
+
+	// GetMempoolAncestorsCmd help.
+	"getmempoolancestors--synopsis":   "Returns the unconfirmed ancestors in the memory pool of a transaction in the memory pool.",
+	"getmempoolancestors-txid":        "The hash of the transaction",
+	"getmempoolancestors-verbose":     "Returns JSON objects keyed by transaction hash when true or an array of transaction hashes when false",
+	"getmempoolancestors--condition0": "verbose=false",
+	"getmempoolancestors--condition1": "verbose=true",
+	"getmempoolancestors--result0":    "Array of the hashes of the ancestors",
+
+	// GetMempoolDescendantsCmd help.
+	"getmempooldescendants--synopsis":   "Returns the transactions in the memory pool that spend the outputs of a transaction in the memory pool, directly or through other transactions.",
+	"getmempooldescendants-txid":        "The hash of the transaction",
+	"getmempooldescendants-verbose":     "Returns JSON objects keyed by transaction hash when true or an array of transaction hashes when false",
+	"getmempooldescendants--condition0": "verbose=false",
+	"getmempooldescendants--condition1": "verbose=true",
+	"getmempooldescendants--result0":    "Array of the hashes of the descendants",
+
+	// GetMempoolEntryCmd help.
+	"getmempoolentry--synopsis": "Returns details about a transaction in the memory pool.",
+	"getmempoolentry-txid":      "The hash of the transaction",
+
+	// GetMempoolEntryResult help.
+	"getmempoolentryresult-size":             "Transaction size in bytes",
+	"getmempoolentryresult-vsize":            "The virtual size of the transaction",
+	"getmempoolentryresult-fee":              "Transaction fee in DUO",
+	"getmempoolentryresult-modifiedfee":      "Transaction fee in DUO used for mining priority, which is the same as the fee",
+	"getmempoolentryresult-time":             "Local time transaction entered pool in seconds since 1 Jan 1970 GMT",
+	"getmempoolentryresult-height":           "Block height when transaction entered the pool",
+	"getmempoolentryresult-startingpriority": "Priority when transaction entered the pool",
+	"getmempoolentryresult-currentpriority":  "Current priority",
+	"getmempoolentryresult-descendantcount":  "Number of in-pool transactions in the package of this transaction and its descendants",
+	"getmempoolentryresult-descendantsize":   "Virtual size of the transaction and its in-pool descendants",
+	"getmempoolentryresult-descendantfees":   "Fees paid by the transaction and its in-pool descendants in DUO",
+	"getmempoolentryresult-ancestorcount":    "Number of in-pool transactions in the package of this transaction and its unconfirmed ancestors",
+	"getmempoolentryresult-ancestorsize":     "Virtual size of the transaction and its unconfirmed ancestors",
+	"getmempoolentryresult-ancestorfees":     "Fees paid by the transaction and its unconfirmed ancestors in DUO",
+	"getmempoolentryresult-packagefeerate":   "Fee rate in DUO/kB of the transaction and its unconfirmed ancestors, which block templates select transactions by",
+	"getmempoolentryresult-depends":          "Unconfirmed transactions used as inputs for this transaction",
+	"getmempoolentryresult-spentby":          "Transactions in the pool that spend outputs of this transaction",
+
 	// GetMempoolInfoCmd help.
 	"getmempoolinfo--synopsis": "Returns memory pool information",
 
 	// GetMempoolInfoResult help.
-	"getmempoolinforesult-bytes": "Size in bytes of the mempool",
-	"getmempoolinforesult-size":  "Number of transactions in the mempool",
+	"getmempoolinforesult-bytes":         "Size in bytes of the mempool",
+	"getmempoolinforesult-size":          "Number of transactions in the mempool",
+	"getmempoolinforesult-maxmempool":    "Maximum size in bytes of the mempool, beyond which the transactions paying the lowest fee rates are evicted (0 for no limit)",
+	"getmempoolinforesult-mempoolminfee": "Minimum fee rate in DUO/kB for a transaction to be accepted, which is raised above the minimum relay fee after transactions have been evicted",
+	"getmempoolinforesult-minrelaytxfee": "Minimum fee rate in DUO/kB for a transaction to be relayed",
 
 	// GetMiningInfoResult help.
 	"getmininginforesult-blocks":             "Height of the latest best block",
@@ -457,9 +541,67 @@ var HelpDescsEnUS = map[string]string{
 	"getnetworkhashps-height":    "Perform estimate ending with this height or -1 for current best chain block height",
 	"getnetworkhashps--result0":  "Estimated hashes per second",
 
+	// GetPoolInfoCmd help.
+	"getpoolinfo--synopsis": "Returns the share window, pending balances and recently found blocks of a controller running in pool mode.",
+	"getpoolinfo-blocks":    "The number of most recent blocks found by the pool to return",
+
+	// GetPoolInfoResult help.
+	"getpoolinforesult-window":     "Number of most recent shares block rewards are split between",
+	"getpoolinforesult-shareratio": "How many times easier than the block target the share target is",
+	"getpoolinforesult-shares":     "Number of shares in the current window",
+	"getpoolinforesult-reward":     "Block reward of the current template in DUO",
+	"getpoolinforesult-miners":     "Addresses with shares in the current window",
+	"getpoolinforesult-blocks":     "Most recent blocks found by the pool, highest first",
+
+	// PoolMinerResult help.
+	"poolminerresult-address":   "The payout address",
+	"poolminerresult-shares":    "Number of shares in the current window",
+	"poolminerresult-lastshare": "Time of the most recent share in seconds since 1 Jan 1970 GMT",
+	"poolminerresult-pending":   "Amount in DUO the address would be paid if a block was found now",
+	"poolminerresult-paid":      "Total amount in DUO paid to the address in blocks found by the pool",
+
+	// PoolBlockResult help.
+	"poolblockresult-height":         "Height of the block",
+	"poolblockresult-hash":           "Hash of the block",
+	"poolblockresult-time":           "Time the block was found in seconds since 1 Jan 1970 GMT",
+	"poolblockresult-payouts":        "Amounts in DUO paid to each address in the coinbase",
+	"poolblockresult-payouts--key":   "address",
+	"poolblockresult-payouts--value": "n.nnn",
+	"poolblockresult-payouts--desc":  "The amount in DUO paid to the address",
+
 	// GetNetTotalsCmd help.
 	"getnettotals--synopsis": "Returns a JSON object containing network traffic statistics.",
 
+	// GetNetworkInfoCmd help.
+	"getnetworkinfo--synopsis": "Returns a JSON object containing information about the node's connection to the network.",
+
+	// GetNetworkInfoResult help.
+	"getnetworkinforesult-version":         "The version of the node as a numeric value",
+	"getnetworkinforesult-subversion":      "The user agent the node sends to its peers",
+	"getnetworkinforesult-protocolversion": "The latest supported protocol version",
+	"getnetworkinforesult-localservices":   "Hex-encoded services the node offers to the network",
+	"getnetworkinforesult-localrelay":      "Whether the node asks its peers to relay transactions",
+	"getnetworkinforesult-timeoffset":      "The time offset in seconds",
+	"getnetworkinforesult-connections":     "The number of connections",
+	"getnetworkinforesult-networkactive":   "Whether networking is enabled",
+	"getnetworkinforesult-networks":        "Information about each network the node can connect to",
+	"getnetworkinforesult-relayfee":        "Minimum fee rate in DUO/kB for a transaction to be relayed",
+	"getnetworkinforesult-incrementalfee":  "Minimum fee rate increase in DUO/kB for replacing a transaction or raising the mempool minimum fee",
+	"getnetworkinforesult-localaddresses":  "Addresses the node advertises to its peers",
+	"getnetworkinforesult-warnings":        "Any current network warnings",
+
+	// NetworksResult help.
+	"networksresult-name":                        "The network (ipv4, ipv6 or onion)",
+	"networksresult-limited":                     "Whether connections to the network are disabled",
+	"networksresult-reachable":                   "Whether the network can be connected to",
+	"networksresult-proxy":                       "The proxy used for connections to the network, if any",
+	"networksresult-proxy_randomize_credentials": "Whether random credentials are used for the proxy to isolate each connection",
+
+	// LocalAddressesResult help.
+	"localaddressesresult-address": "The advertised address",
+	"localaddressesresult-port":    "The advertised port",
+	"localaddressesresult-score":   "The priority of the address, higher for addresses that are more certain to be reachable",
+
 	// GetNetTotalsResult help.
 	"getnettotalsresult-totalbytesrecv": "Total bytes received",
 	"getnettotalsresult-totalbytessent": "Total bytes sent",
@@ -501,6 +643,13 @@ var HelpDescsEnUS = map[string]string{
 	"getrawmempoolverboseresult-depends":          "Unconfirmed transactions used as inputs for this transaction",
 	"getrawmempoolverboseresult-vsize":            "The virtual size of a transaction",
 	"getrawmempoolverboseresult-weight":           "The transaction's weight (between vsize*4-3 and vsize*4)",
+	"getrawmempoolverboseresult-ancestorcount":    "Number of in-pool transactions in the package of this transaction and its unconfirmed ancestors",
+	"getrawmempoolverboseresult-ancestorsize":     "Virtual size of the transaction and its unconfirmed ancestors",
+	"getrawmempoolverboseresult-ancestorfees":     "Fees paid by the transaction and its unconfirmed ancestors in DUO",
+	"getrawmempoolverboseresult-descendantcount":  "Number of in-pool transactions in the package of this transaction and its descendants",
+	"getrawmempoolverboseresult-descendantsize":   "Virtual size of the transaction and its in-pool descendants",
+	"getrawmempoolverboseresult-descendantfees":   "Fees paid by the transaction and its in-pool descendants in DUO",
+	"getrawmempoolverboseresult-packagefeerate":   "Fee rate in DUO/kB of the transaction and its unconfirmed ancestors, which block templates select transactions by",
 
 	// GetRawMempoolCmd help.
 	"getrawmempool--synopsis":   "Returns information about all of the transactions currently in the memory pool.",
@@ -539,10 +688,37 @@ var HelpDescsEnUS = map[string]string{
 	"help--result0":    "List of commands",
 	"help--result1":    "Help for specified command",
 
+	// InvalidateBlockCmd help.
+	"invalidateblock--synopsis": "Marks a block and all of the blocks built on it as invalid, reorganizing the chain away from it if it is on the main chain.",
+	"invalidateblock-blockhash": "The hash of the block to invalidate",
+
+	// LoadMempoolCmd help.
+	"loadmempool--synopsis": "Validates the transactions in the saved mempool file again and adds those that are still valid to the mempool.",
+
+	// LoadMempoolResult help.
+	"loadmempoolresult-accepted": "Number of transactions added to the mempool",
+	"loadmempoolresult-present":  "Number of transactions that were already in the mempool",
+	"loadmempoolresult-failed":   "Number of transactions that are no longer valid, have expired or were not accepted by the mempool",
+
 	// PingCmd help.
 	"ping--synopsis": "Queues a ping to be sent to each connected peer.\n" +
 		"Ping times are provided by getpeerinfo via the pingtime and pingwait fields.",
 
+	// PreciousBlockCmd help.
+	"preciousblock--synopsis": "Reorganizes the chain to a block if its branch has at least as much work as the main chain, so it is preferred over a competing tip of equal work.",
+	"preciousblock-blockhash": "The hash of the block to prefer",
+
+	// ReconsiderBlockCmd help.
+	"reconsiderblock--synopsis": "Removes the invalid marks from a block, its ancestors and the blocks built on it, reorganizing the chain to them if they have the most work.",
+	"reconsiderblock-blockhash": "The hash of the block to reconsider",
+
+	// SaveMempoolCmd help.
+	"savemempool--synopsis": "Writes the transactions in the mempool to a file in the data directory, from which they are loaded when the node starts.",
+
+	// SaveMempoolResult help.
+	"savemempoolresult-saved":    "Number of transactions saved",
+	"savemempoolresult-filename": "Path of the file the mempool was saved to",
+
 	// SearchRawTransactionsCmd help.
 	"searchrawtransactions--synopsis": "Returns raw data for transactions involving the passed address.\n" +
 		"Returned transactions are pulled from both the database, and transactions currently in the mempool.\n" +
@@ -712,6 +888,7 @@ var ResultTypes = map[string][]interface{}{
 		(*[]btcjson.GetAddedNodeInfoResult)(nil),
 	},
 	"getbestblock":     {(*btcjson.GetBestBlockResult)(nil)},
+	"getalgostats":     {(*btcjson.GetAlgoStatsResult)(nil)},
 	"getbestblockhash": {(*string)(nil)},
 	"getblock": {
 		(*string)(nil),
@@ -730,6 +907,7 @@ var ResultTypes = map[string][]interface{}{
 	"getblockchaininfo":  {(*btcjson.GetBlockChainInfoResult)(nil)},
 	"getcfilter":         {(*string)(nil)},
 	"getcfilterheader":   {(*string)(nil)},
+	"getchaintips":       {(*[]btcjson.GetChainTipsResult)(nil)},
 	"getconnectioncount": {(*int32)(nil)},
 	"getcurrentnet":      {(*uint32)(nil)},
 	"getdifficulty":      {(*float64)(nil)},
@@ -737,11 +915,23 @@ var ResultTypes = map[string][]interface{}{
 	"gethashespersec":    {(*float64)(nil)},
 	"getheaders":         {(*[]string)(nil)},
 	"getinfo":            {(*btcjson.InfoChainResult)(nil)},
-	"getmempoolinfo":     {(*btcjson.GetMempoolInfoResult)(nil)},
-	"getmininginfo":      {(*btcjson.GetMiningInfoResult)(nil)},
-	"getnettotals":       {(*btcjson.GetNetTotalsResult)(nil)},
-	"getnetworkhashps":   {(*int64)(nil)},
-	"getpeerinfo":        {(*[]btcjson.GetPeerInfoResult)(nil)},
+	"getmempoolancestors": {
+		(*[]string)(nil),
+		(*btcjson.GetMempoolEntryResult)(nil),
+	},
+	"getlanpeers": {(*[]btcjson.GetLANPeersResult)(nil)},
+	"getmempooldescendants": {
+		(*[]string)(nil),
+		(*btcjson.GetMempoolEntryResult)(nil),
+	},
+	"getmempoolentry":  {(*btcjson.GetMempoolEntryResult)(nil)},
+	"getmempoolinfo":   {(*btcjson.GetMempoolInfoResult)(nil)},
+	"getmininginfo":    {(*btcjson.GetMiningInfoResult)(nil)},
+	"getnettotals":     {(*btcjson.GetNetTotalsResult)(nil)},
+	"getnetworkinfo":   {(*btcjson.GetNetworkInfoResult)(nil)},
+	"getnetworkhashps": {(*int64)(nil)},
+	"getpeerinfo":      {(*[]btcjson.GetPeerInfoResult)(nil)},
+	"getpoolinfo":      {(*btcjson.GetPoolInfoResult)(nil)},
 	"getrawmempool": {
 		(*[]string)(nil),
 		(*btcjson.GetRawMempoolVerboseResult)(nil),
@@ -750,11 +940,16 @@ var ResultTypes = map[string][]interface{}{
 	"gettxout":          {(*btcjson.GetTxOutResult)(nil)},
 	"node":              nil,
 	"help":              {(*string)(nil), (*string)(nil)},
+	"invalidateblock":   nil,
+	"loadmempool":       {(*btcjson.LoadMempoolResult)(nil)},
 	"ping":              nil,
+	"preciousblock":     nil,
+	"reconsiderblock":   nil,
 	"searchrawtransactions": {
 		(*string)(nil),
 		(*[]btcjson.SearchRawTransactionsResult)(nil),
 	},
+	"savemempool":        {(*btcjson.SaveMempoolResult)(nil)},
 	"sendrawtransaction": {(*string)(nil)},
 	"setgenerate":        nil,
 	"stop":               {(*string)(nil)},
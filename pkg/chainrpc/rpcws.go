@@ -616,7 +616,9 @@ func (c *WSClient) ServiceRequest(r *ParsedRPCCmd) {
 	// standard command.
 	wsHandler, ok := WSHandlers[r.Method]
 	if ok {
+		start := time.Now()
 		result, e = wsHandler(c, r.Cmd)
+		rpcDuration.Since(r.Method, start)
 	} else {
 		result, e = c.Server.StandardCmdResult(r, nil)
 	}
@@ -179,6 +179,7 @@ type (
 		*peer.Peer
 		// The following variables must only be used atomically
 		FeeFilter      int64
+		SentFeeFilter  int64
 		ConnReq        *connmgr.ConnReq
 		Server         *Node
 		ContinueHash   *chainhash.Hash
@@ -226,6 +227,8 @@ const (
 	// when connecting to persistent peers. It is adjusted by the number of retries
 	// such that there is a retry backoff.
 	ConnectionRetryInterval = time.Minute
+	// FeeFilterInterval is how often peers are sent the minimum fee rate of the mempool if it has changed.
+	FeeFilterInterval = time.Minute
 )
 
 var (
@@ -429,6 +432,18 @@ func (n *Node) Start() {
 		n.WG.Add(1)
 		go n.UPNPUpdateThread()
 	}
+	n.WG.Add(1)
+	go n.FeeFilterHandler()
+	if n.Config.PersistMempool.True() {
+		n.WG.Add(1)
+		go func() {
+			// there is no saved mempool the first time the node is started
+			if _, _, _, e := n.LoadMempool(); e != nil && !os.IsNotExist(e) {
+				E.Ln("failed to load the mempool:", e)
+			}
+			n.WG.Done()
+		}()
+	}
 	if n.Config.DisableRPC.False() {
 		n.WG.Add(1)
 		// Start the rebroadcastHandler, which ensures user tx received by the RPC server are rebroadcast until being
@@ -481,6 +496,11 @@ func (n *Node) Stop() (e error) {
 			}
 		}
 	}
+	// Save the mempool so it can be loaded again when the node restarts.
+	if n.Config.PersistMempool.True() {
+		if _, e = n.SaveMempool(); E.Chk(e) {
+		}
+	}
 	// Save fee estimator state in the database.
 	if e = n.DB.Update(
 		func(tx database.Tx) (e error) {
@@ -650,6 +670,7 @@ func (n *Node) HandleBanPeerMsg(state *PeerState, sp *NodePeer) {
 	I.F("banned peer %n (%n) for %v", host, direction,
 		*n.Config.BanDuration)
 	state.Banned[host] = time.Now().Add(n.Config.BanDuration.V())
+	bans.Inc()
 }
 
 // HandleBroadcastMsg deals with broadcasting messages to peers. It is invoked from the peerHandler goroutine.
@@ -1374,6 +1395,43 @@ cleanup:
 	n.WG.Done()
 }
 
+// FeeFilterHandler periodically tells peers the minimum fee rate of transactions the mempool will accept with a
+// feefilter message, whenever it is different from the last one sent to them, so they do not relay transactions that
+// would be rejected.
+func (n *Node) FeeFilterHandler() {
+	ticker := time.NewTicker(FeeFilterInterval)
+out:
+	for {
+		select {
+		case <-ticker.C:
+			// transactions are not relayed until the chain is current, so the fee rate does not matter to peers
+			if !n.SyncManager.IsCurrent() {
+				continue
+			}
+			replyChan := make(chan []*NodePeer)
+			select {
+			case n.Query <- GetPeersMsg{Reply: replyChan}:
+			case <-n.Quit.Wait():
+				break out
+			}
+			minFee := int64(n.TxMemPool.MinFee())
+			for _, sp := range <-replyChan {
+				if sp.ProtocolVersion() < wire.FeeFilterVersion ||
+					atomic.LoadInt64(&sp.SentFeeFilter) == minFee {
+					continue
+				}
+				T.F("sending feefilter of %v to %v", amt.Amount(minFee), sp)
+				sp.QueueMessage(wire.NewMsgFeeFilter(minFee), nil)
+				atomic.StoreInt64(&sp.SentFeeFilter, minFee)
+			}
+		case <-n.Quit.Wait():
+			break out
+		}
+	}
+	ticker.Stop()
+	n.WG.Done()
+}
+
 // RelayTransactions generates and relays inventory vectors for all of the
 // passed transactions to all connected peers.
 func (n *Node) RelayTransactions(txns []*mempool.TxDesc) {
@@ -2809,6 +2867,11 @@ func NewNode(
 	if cx.Config.NoCFilters.True() {
 		services &^= wire.SFNodeCF
 	}
+	// A pruned node only serves the most recent blocks.
+	if cx.Config.Prune.V() != 0 {
+		services &^= wire.SFNodeNetwork
+		services |= wire.SFNodeNetworkLimited
+	}
 	aMgr := addrmgr.New(
 		cx.Config.DataDir.V()+string(os.PathSeparator)+cx.ActiveNet.Name,
 		Lookup(cx.StateCfg),
@@ -2912,8 +2975,24 @@ func NewNode(
 			s.ChainParams.Checkpoints, cx.StateCfg.AddedCheckpoints,
 		)
 	}
-	// Create a new block chain instance with the appropriate configuration.
 	var e error
+	// The blocks deleted from a pruned database can not be restored without downloading the chain again.
+	var pruned bool
+	if e = db.View(
+		func(tx database.Tx) (e error) {
+			pruned, e = tx.BeenPruned()
+			return e
+		},
+	); E.Chk(e) {
+		return nil, e
+	}
+	if pruned && cx.Config.Prune.V() == 0 {
+		return nil, errors.New(
+			"the block database has been pruned, pruning can only be disabled by deleting it and syncing the" +
+				" chain again",
+		)
+	}
+	// Create a new block chain instance with the appropriate configuration.
 	s.Chain, e = blockchain.New(
 		&blockchain.Config{
 			DB:           s.DB,
@@ -2924,6 +3003,8 @@ func NewNode(
 			SigCache:     s.SigCache,
 			IndexManager: indexManager,
 			HashCache:    s.HashCache,
+			PruneTarget:  uint64(cx.Config.Prune.V()) * 1024 * 1024,
+			AssumeValid:  cx.StateCfg.AssumeValid,
 		},
 	)
 	if e != nil {
@@ -2976,6 +3057,9 @@ func NewNode(
 			MaxSigOpCostPerTx:    blockchain.MaxBlockSigOpsCost / 4,
 			MinRelayTxFee:        cx.StateCfg.ActiveMinRelayTxFee,
 			MaxTxVersion:         2,
+			MaxPoolSize:          int64(cx.Config.MaxMempool.V()) * 1000000,
+			Expiry:               cx.Config.MempoolExpiry.V(),
+			RejectReplacement:    cx.Config.MempoolReplacement.False(),
 		},
 		ChainParams:   cx.ActiveNet,
 		FetchUtxoView: s.Chain.FetchUtxoView,
@@ -3154,6 +3238,9 @@ func NewNode(
 					Quit:            s.Quit,
 					StartController: s.StartController,
 					StopController:  s.StopController,
+					MempoolFile:     s.MempoolFile(),
+					SaveMempool:     s.SaveMempool,
+					LoadMempool:     s.LoadMempool,
 				}, cx.StateCfg, cx.Config,
 			)
 			if e != nil {
@@ -8,24 +8,28 @@ import (
 	"github.com/cybriq/p9/pkg/wire"
 )
 
-// Magic is the marker for packets containing a solution
-var Magic = []byte{'s', 'o', 'l', 1}
+// Magic is the marker for packets containing a solution. The last byte is the version of the encoding, which was
+// raised when the payout address was added so workers and controllers from before then ignore the other's solutions
+// instead of decoding them wrongly.
+var Magic = []byte{'s', 'o', 'l', 2}
 
 type Solution struct {
 	Nonce uint64
 	UUID  uint64
 	// *wire.Block
 	Bytes []byte
+	// Address is the payout address of the worker when submitting shares to a pool
+	Address string
 }
 
-// Encode a message for a solution
-func Encode(nonce uint64, uuid uint64, mb *wire.BlockHeader) []byte {
+// Encode a message for a solution, address is empty unless the worker is mining for a pool
+func Encode(nonce uint64, uuid uint64, address string, mb *wire.BlockHeader) []byte {
 	var buf []byte
 	wr := bytes.NewBuffer(buf)
 	var e error
 	if e = mb.Serialize(wr); E.Chk(e) {
 	}
-	s := Solution{Nonce: nonce, UUID: uuid, Bytes: wr.Bytes()} // Block: mb}
+	s := Solution{Nonce: nonce, UUID: uuid, Address: address, Bytes: wr.Bytes()} // Block: mb}
 	return gotiny.Marshal(&s)
 }
 
@@ -2,6 +2,7 @@ package templates
 
 import (
 	"errors"
+	"sync"
 	"time"
 
 	"github.com/cybriq/gotiny"
@@ -31,6 +32,8 @@ type Message struct {
 	Merkles   Merkles
 	txs       Txs
 	Timestamp time.Time
+	// ShareBits are the share targets for each version when the controller is running in pool mode
+	ShareBits Diffs
 }
 
 // SetTxs writes to the private, non-serialized transactions field
@@ -86,6 +89,7 @@ func (m *Message) Reconstruct(hdr *wire.BlockHeader) (mb *wire.Block, e error) {
 // RecentMessages keeps a buffer of four previously created messages so that
 // solutions found after a new template is created can be submitted
 type RecentMessages struct {
+	mx     sync.Mutex
 	msgs   [4]*Message
 	cursor int
 }
@@ -101,6 +105,8 @@ func NewRecentMessages() *RecentMessages {
 // position, and then advance it, back to zero if it exceeds the buffer length,
 // overwriting the first, and so on
 func (rm *RecentMessages) Add(msg *Message) {
+	rm.mx.Lock()
+	defer rm.mx.Unlock()
 	D.Ln("adding template with cursor", rm.cursor)
 	rm.msgs[rm.cursor] = msg
 	rm.cursor++
@@ -111,6 +117,8 @@ func (rm *RecentMessages) Add(msg *Message) {
 
 // Len returns the number of elements in the buffer
 func (rm *RecentMessages) Len() (o int) {
+	rm.mx.Lock()
+	defer rm.mx.Unlock()
 	for i := range rm.msgs {
 		if rm.msgs[i] != nil {
 			o++
@@ -119,8 +127,22 @@ func (rm *RecentMessages) Len() (o int) {
 	return
 }
 
+// Get returns the cached Message with the given nonce without removing it from the list
+func (rm *RecentMessages) Get(nonce uint64) *Message {
+	rm.mx.Lock()
+	defer rm.mx.Unlock()
+	for i := range rm.msgs {
+		if rm.msgs[i] != nil && rm.msgs[i].Nonce == nonce {
+			return rm.msgs[i]
+		}
+	}
+	return nil
+}
+
 // Find checks whether the given nonce matches any of the cached Message's and remove it from the list
 func (rm *RecentMessages) Find(nonce uint64) *Message {
+	rm.mx.Lock()
+	defer rm.mx.Unlock()
 	for i := range rm.msgs {
 		if rm.msgs[i] != nil {
 			I.Ln("recent message", i, rm.msgs[i].Nonce, nonce)
@@ -32,6 +32,8 @@ const (
 	BlockMaxWeightMin            = 4000
 	BlockMaxWeightMax            = blockchain.MaxBlockWeight - 4000
 	DefaultMaxOrphanTransactions = 100
+	DefaultMaxMempoolSize        = 300 // megabytes
+	DefaultMempoolExpiry         = time.Hour * 24 * 14
 	DefaultSigCacheMaxSize       = 100000
 	// DefaultBlockPrioritySize is the default size in bytes for high - priority / low-fee transactions. It is used to
 	// help determine which are allowed into the mempool and consequently affects their relay and inclusion when
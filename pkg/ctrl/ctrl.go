@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"math/rand"
 	"net"
+	"path/filepath"
 	"sync"
 	"time"
 
@@ -19,6 +20,7 @@ import (
 	"github.com/cybriq/p9/pkg/block"
 	"github.com/cybriq/p9/pkg/blockchain"
 	"github.com/cybriq/p9/pkg/btcaddr"
+	"github.com/cybriq/p9/pkg/btcjson"
 	"github.com/cybriq/p9/pkg/chainrpc"
 	"github.com/cybriq/p9/pkg/chainrpc/hashrate"
 	"github.com/cybriq/p9/pkg/chainrpc/job"
@@ -27,10 +29,11 @@ import (
 	"github.com/cybriq/p9/pkg/chainrpc/sol"
 	"github.com/cybriq/p9/pkg/chainrpc/templates"
 	"github.com/cybriq/p9/pkg/constant"
-	"github.com/cybriq/p9/pkg/fork"
 	"github.com/cybriq/p9/pkg/mining"
+	"github.com/cybriq/p9/pkg/pool"
 	rav "github.com/cybriq/p9/pkg/ring"
 	"github.com/cybriq/p9/pkg/rpcclient"
+	"github.com/cybriq/p9/pkg/stratum"
 	"github.com/cybriq/p9/pkg/transport"
 	"github.com/cybriq/p9/pkg/wire"
 	"github.com/cybriq/p9/pod/config"
@@ -53,11 +56,14 @@ type State struct {
 	blockUpdate       chan *block.Block
 	generator         *mining.BlkTmplGenerator
 	nextAddress       btcaddr.Address
+	payout            *payout
 	walletClient      *rpcclient.Client
 	msgBlockTemplates *templates.RecentMessages
 	templateShards    [][]byte
 	multiConn         *transport.Channel
-	otherNodes        map[uint64]*nodeSpec
+	streamServer      *transport.StreamServer
+	stratum           *stratum.Server
+	pool              *pool.Pool
 	hashSampleBuf     *rav.BufferUint64
 	hashCount         atomic.Uint64
 	lastNonce         int32
@@ -65,11 +71,6 @@ type State struct {
 	certs             []byte
 }
 
-type nodeSpec struct {
-	time.Time
-	addr string
-}
-
 // New creates a new controller
 func New(
 	syncing *atomic.Bool,
@@ -83,7 +84,6 @@ func New(
 	start, stop qu.C,
 ) (s *State, e error) {
 	quit := qu.T()
-	I.Ln("creating othernodes map")
 	I.Ln("getting configured TLS certificates")
 	certs := cfg.ReadCAFile()
 	s = &State{
@@ -92,7 +92,6 @@ func New(
 		connMgr:           connMgr,
 		stateCfg:          stateCfg,
 		mempoolUpdateChan: mempoolUpdateChan,
-		otherNodes:        make(map[uint64]*nodeSpec),
 		quit:              quit,
 		uuid:              uuid,
 		start:             start,
@@ -118,6 +117,81 @@ func New(
 		return
 	}
 	s.multiConn = mc
+	if listeners := cfg.MinerListeners.V(); len(listeners) > 0 {
+		I.Ln("starting miner stream server")
+		if s.streamServer, e = transport.NewStreamServer(
+			"controller",
+			s,
+			cfg.MulticastPass.Bytes(),
+			listeners,
+			handlersMulticast,
+			quit,
+		); E.Chk(e) {
+			return
+		}
+	}
+	if cfg.PoolMode.True() {
+		path := filepath.Join(cfg.DataDir.V(), s.generator.ChainParams.Name, "pool.db")
+		I.Ln("opening pool ledger", path)
+		if s.pool, e = pool.New(
+			path,
+			&pool.Config{
+				Window:     cfg.PoolWindow.V(),
+				ShareRatio: cfg.PoolShareRatio.V(),
+				Params:     s.generator.ChainParams,
+			},
+		); E.Chk(e) {
+			return
+		}
+	}
+	if listeners := cfg.StratumListeners.V(); len(listeners) > 0 && cfg.StratumPass.V() == "" {
+		// stratum sends the password in the clear, so it must not be the multicast password that encrypts the
+		// connections of kopach workers, and without one any miner could use the controller
+		W.Ln("not starting stratum server as no stratum password is set")
+	} else if len(listeners) > 0 {
+		I.Ln("starting stratum server")
+		scfg := &stratum.Config{
+			Listeners:  listeners,
+			Forks:      s.generator.ChainParams.Forks,
+			PowLimit:   s.generator.ChainParams.PowLimit,
+			Difficulty: cfg.StratumDifficulty.V(),
+			ShareTime:  cfg.StratumShareTime.V(),
+			Authorize: func(user, pass string) bool {
+				if pass != cfg.StratumPass.V() {
+					return false
+				}
+				// in pool mode the worker name is the address its shares are paid to
+				if s.pool != nil {
+					if _, e := btcaddr.Decode(user, s.generator.ChainParams); e != nil {
+						I.Ln("stratum worker", user, "is not a payout address:", e)
+						return false
+					}
+				}
+				return true
+			},
+			Submit: s.submitBlock,
+		}
+		if s.pool != nil {
+			scfg.Share = func(tpl *templates.Message, hdr *wire.BlockHeader, worker string) (e error) {
+				_, e = s.pool.CheckExtendedShare(tpl, hdr, worker)
+				return
+			}
+		}
+		if s.stratum, e = stratum.New(scfg, quit); E.Chk(e) {
+			return
+		}
+	}
+	if s.payout, e = newPayout(
+		stateCfg.ActiveMiningAddrs,
+		cfg.MiningXPub.V(),
+		s.generator.ChainParams,
+		filepath.Join(cfg.DataDir.V(), s.generator.ChainParams.Name, "payoutindex"),
+	); E.Chk(e) {
+		return
+	}
+	if s.payout != nil {
+		I.Ln("paying out to configured mining addresses, a wallet is not required")
+	}
 	go func() {
 		I.Ln("starting shutdown signal watcher")
 		select {
@@ -127,6 +201,10 @@ func New(
 		case <-s.quit:
 			I.Ln("received quit signal, breaking out of shutdown signal watcher")
 		}
+		if s.pool != nil {
+			if e := s.pool.Close(); E.Chk(e) {
+			}
+		}
 	}()
 	node.Chain.Subscribe(
 		func(n *blockchain.Notification) {
@@ -195,12 +273,27 @@ func (s *State) updateBlockTemplate() (e error) {
 	// D.S(blk)
 	s.doBlockUpdate(blk)
 	I.Ln("sending out templates...")
-	if e = s.multiConn.SendMany(job.Magic, s.templateShards); E.Chk(e) {
+	if e = s.sendMany(job.Magic, s.templateShards); E.Chk(e) {
 		return
 	}
 	return
 }
 
+// sendMany sends a message to the workers on the multicast channel, and to those
+// connected by stream if there is a stream server
+func (s *State) sendMany(magic []byte, shards [][]byte) (e error) {
+	if string(magic) == string(job.Magic) {
+		jobBroadcasts.Inc()
+	}
+	if e = s.multiConn.SendMany(magic, shards); E.Chk(e) {
+	}
+	if s.streamServer != nil {
+		if e = s.streamServer.SendMany(magic, shards); E.Chk(e) {
+		}
+	}
+	return
+}
+
 // Run must be started as a goroutine, central routing for the business of the
 // controller
 //
@@ -253,6 +346,10 @@ out:
 				// D.Ln("controller ticker running")
 				// s.Advertise()
 				// s.checkConnected()
+				if s.payout != nil {
+					I.Ln("payout addresses are configured, switching to running")
+					break pausing
+				}
 				if s.walletClient.Disconnected() {
 					I.Ln("wallet client is disconnected, retrying")
 					if e = s.startWallet(); e != nil { // T.Chk(e) {
@@ -290,7 +387,7 @@ out:
 					break
 				}
 				I.Ln("sending out templates...")
-				if e = s.multiConn.SendMany(
+				if e = s.sendMany(
 					job.Magic,
 					s.templateShards,
 				); E.Chk(e) {
@@ -302,7 +399,7 @@ out:
 					I.Ln("received new block update while running")
 					s.doBlockUpdate(bu)
 					I.Ln("sending out templates...")
-					if e = s.multiConn.SendMany(
+					if e = s.sendMany(
 						job.Magic,
 						s.templateShards,
 					); E.Chk(e) {
@@ -315,13 +412,13 @@ out:
 					break running
 				}
 				// I.Ln("resending current templates...")
-				if e = s.multiConn.SendMany(
+				if e = s.sendMany(
 					job.Magic,
 					s.templateShards,
 				); E.Chk(e) {
 					break
 				}
-				if s.walletClient.Disconnected() {
+				if s.payout == nil && s.walletClient.Disconnected() {
 					I.Ln("wallet client has disconnected, switching to pausing")
 					break running
 				}
@@ -393,30 +490,14 @@ func (s *State) checkConnected() (connected bool) {
 	// return
 }
 
-//
-// func (s *State) Advertise() {
-// 	if !*s.cfg.Discovery {
-// 		return
-// 	}
-// 	T.Ln("sending out advertisment")
-// 	var e error
-// 	if e = s.multiConn.SendMany(
-// 		p2padvt.Magic,
-// 		transport.GetShards(p2padvt.Get(s.uuid, s.cfg)),
-// 	); E.Chk(e) {
-// 	}
-// }
-
 func (s *State) doBlockUpdate(prev *block.Block) (e error) {
 	I.Ln("do block update")
 	if s.nextAddress == nil {
 		I.Ln("getting new address for templates")
-		// if s.nextAddress, e = s.GetNewAddressFromMiningAddrs(); T.Chk(e) {
-		if s.nextAddress, e = s.GetNewAddressFromWallet(); T.Chk(e) {
+		if s.nextAddress, e = s.GetNewAddress(); T.Chk(e) {
 			s.Stop()
 			return
 		}
-		// }
 	}
 	I.Ln("getting templates...", prev.WireBlock().Header.Timestamp)
 	var tpl *templates.Message
@@ -426,6 +507,9 @@ func (s *State) doBlockUpdate(prev *block.Block) (e error) {
 	}
 	// I.S(tpl)
 	s.msgBlockTemplates.Add(tpl)
+	if s.stratum != nil {
+		s.stratum.Update(tpl)
+	}
 	// I.Ln(tpl.Timestamp)
 	I.Ln("caching error corrected message shards...")
 	srl := tpl.Serialize()
@@ -453,16 +537,26 @@ func (s *State) GetMsgBlockTemplate(
 		Bits:      make(templates.Diffs),
 		Merkles:   make(templates.Merkles),
 	}
-	for next, curr, more := fork.AlgoVerIterator(mbt.Height); more(); next() {
+	if s.pool != nil {
+		mbt.ShareBits = make(templates.Diffs)
+	}
+	for next, curr, more := s.generator.ChainParams.Forks.AlgoVerIterator(mbt.Height); more(); next() {
 		// I.Ln("creating template for", curr())
 		var templateX *mining.BlockTemplate
 		if templateX, e = s.generator.NewBlockTemplate(
 			addr,
-			fork.GetAlgoName(curr(), mbt.Height),
+			s.generator.ChainParams.Forks.GetAlgoName(curr(), mbt.Height),
 		); D.Chk(e) || templateX == nil {
 		} else {
 			// I.S(templateX)
 			newB := templateX.Block
+			if s.pool != nil {
+				// the split changes the merkle root so it must be done before the header is copied
+				if e = s.pool.SplitCoinbase(newB); E.Chk(e) {
+					continue
+				}
+				mbt.ShareBits[curr()] = s.pool.ShareBits(newB.Header.Bits)
+			}
 			newH := newB.Header
 			mbt.Timestamp = newH.Timestamp
 			mbt.Bits[curr()] = newH.Bits
@@ -492,106 +586,20 @@ func (s *State) GetNewAddressFromWallet() (addr btcaddr.Address, e error) {
 	return
 }
 
-//
-// // GetNewAddressFromMiningAddrs tries to get an address from the mining
-// // addresses list in the configuration file
-// func (s *State) GetNewAddressFromMiningAddrs() (addr btcaddr.Address, e error) {
-// 	if s.cfg.MiningAddrs == nil {
-// 		e = errors.New("mining addresses is nil")
-// 		I.Ln(e)
-// 		return
-// 	}
-// 	if len(s.cfg.MiningAddrs.S()) < 1 {
-// 		e = errors.New("no mining addresses")
-// 		I.Ln(e)
-// 		return
-// 	}
-// 	// Choose a payment address at random.
-// 	rand.Seed(time.Now().UnixNano())
-// 	p2a := rand.Intn(len(s.cfg.MiningAddrs.S()))
-// 	addr = s.stateCfg.ActiveMiningAddrs[p2a]
-// 	// remove the address from the state
-// 	if p2a == 0 {
-// 		s.stateCfg.ActiveMiningAddrs = s.stateCfg.ActiveMiningAddrs[1:]
-// 	} else {
-// 		s.stateCfg.ActiveMiningAddrs = append(
-// 			s.stateCfg.ActiveMiningAddrs[:p2a],
-// 			s.stateCfg.ActiveMiningAddrs[p2a+1:]...,
-// 		)
-// 	}
-// 	// update the config
-// 	var ma cli.StringSlice
-// 	for i := range s.stateCfg.ActiveMiningAddrs {
-// 		ma = append(ma, s.stateCfg.ActiveMiningAddrs[i].String())
-// 	}
-// 	s.cfg.MiningAddrs.Set(ma)
-// 	podcfg.Save(s.cfg)
-// 	return
-// }
+// GetNewAddress returns the address the coinbase of the next block template pays to, taken from the configured mining
+// addresses or account extended public key if there are any, and from the wallet otherwise
+func (s *State) GetNewAddress() (addr btcaddr.Address, e error) {
+	if s.payout != nil {
+		return s.payout.address()
+	}
+	return s.GetNewAddressFromWallet()
+}
 
 var handlersMulticast = transport.Handlers{
-	string(sol.Magic): processSolMsg,
-	// string(p2padvt.Magic):  processAdvtMsg,
+	string(sol.Magic):      processSolMsg,
 	string(hashrate.Magic): processHashrateMsg,
 }
 
-func processAdvtMsg(
-	ctx interface{}, src net.Addr, dst string, b []byte,
-) (e error) {
-	I.Ln("processing advertisment message", src, dst)
-	s := ctx.(*State)
-	var j p2padvt.Advertisment
-	gotiny.Unmarshal(b, &j)
-	var uuid uint64
-	uuid = j.UUID
-	// I.Ln("uuid of advertisment", uuid, s.otherNodes)
-	if uuid == s.uuid {
-		I.Ln("ignoring own advertisment message")
-		return
-	}
-	if _, ok := s.otherNodes[uuid]; !ok {
-		// if we haven't already added it to the permanent peer list, we can add it now
-		I.Ln("connecting to lan peer with same PSK", j.IPs, uuid)
-		s.otherNodes[uuid] = &nodeSpec{}
-		s.otherNodes[uuid].Time = time.Now()
-		// try all IPs
-		// if s.cfg.AutoListen.True() {
-		// 	s.cfg.P2PConnect.Set(cli.StringSlice{})
-		// }
-		for addr := range j.IPs {
-			peerIP := net.JoinHostPort(addr, fmt.Sprint(j.P2P))
-			if e = s.connMgr.Connect(
-				peerIP,
-				true,
-			); E.Chk(e) {
-				continue
-			}
-			I.Ln("connected to peer via address", peerIP)
-			s.otherNodes[uuid].addr = peerIP
-			break
-		}
-		I.Ln("otherNodes", s.otherNodes)
-	} else {
-		// update last seen time for uuid for garbage collection of stale disconnected
-		// nodes
-		s.otherNodes[uuid].Time = time.Now()
-	}
-	// If we lose connection for more than 9 seconds we delete and if the node
-	// reappears it can be reconnected
-	for i := range s.otherNodes {
-		if time.Now().Sub(s.otherNodes[i].Time) > time.Second*6 {
-			// also remove from connection manager
-			if e = s.connMgr.RemoveByAddr(s.otherNodes[i].addr); E.Chk(e) {
-			}
-			I.Ln("deleting", s.otherNodes[i])
-			delete(s.otherNodes, i)
-		}
-	}
-	// on := int32(len(s.otherNodes))
-	// s.otherNodeCount.Store(on)
-	return
-}
-
 // Solutions submitted by workers
 func processSolMsg(
 	ctx interface{}, src net.Addr, dst string, b []byte,
@@ -600,11 +608,6 @@ func processSolMsg(
 	s := ctx.(*State)
 	var so sol.Solution
 	gotiny.Unmarshal(b, &so)
-	tpl := s.msgBlockTemplates.Find(so.Nonce)
-	if tpl == nil {
-		I.Ln("solution nonce", so.Nonce, "is not known by this controller")
-		return
-	}
 	if so.UUID != s.uuid {
 		I.Ln("solution is for another controller")
 		return
@@ -613,8 +616,28 @@ func processSolMsg(
 	if newHeader, e = so.Decode(); E.Chk(e) {
 		return
 	}
+	// in pool mode solutions carrying a payout address are shares, which are recorded and only submitted if they
+	// also meet the block target
+	if s.pool != nil && so.Address != "" {
+		tpl := s.msgBlockTemplates.Get(so.Nonce)
+		if tpl == nil {
+			I.Ln("share nonce", so.Nonce, "is not known by this controller")
+			return
+		}
+		var isBlock bool
+		if isBlock, e = s.pool.CheckShare(tpl, newHeader, so.Address); D.Chk(e) || !isBlock {
+			return
+		}
+	}
+	tpl := s.msgBlockTemplates.Find(so.Nonce)
+	if tpl == nil {
+		I.Ln("solution nonce", so.Nonce, "is not known by this controller")
+		solutions.Inc("unknown")
+		return
+	}
 	if newHeader.PrevBlock != tpl.PrevBlock {
 		I.Ln("blk submitted by kopach miner worker is stale")
+		solutions.Inc("stale")
 		return
 	}
 	var msgBlock *wire.Block
@@ -622,9 +645,16 @@ func processSolMsg(
 		I.Ln("failed to construct new header")
 		return
 	}
+	return s.submitBlock(tpl, msgBlock)
+}
 
+// submitBlock pauses the workers and submits a solved block built from one of
+// the controller's templates, from either a kopach worker or a Stratum miner
+func (s *State) submitBlock(
+	tpl *templates.Message, msgBlock *wire.Block,
+) (e error) {
 	I.Ln("sending pause to workers")
-	if e = s.multiConn.SendMany(
+	if e = s.sendMany(
 		pause.Magic,
 		transport.GetShards(p2padvt.Get(s.uuid, (s.cfg.P2PListeners.S())[0])),
 	); E.Chk(e) {
@@ -644,6 +674,7 @@ func processSolMsg(
 		// Anything other than a rule violation is an unexpected error, so log that
 		// error as an internal error.
 		if _, ok := e.(blockchain.RuleError); !ok {
+			solutions.Inc("error")
 			W.F(
 				"Unexpected error while processing blk submitted via kopach miner:",
 				e,
@@ -651,6 +682,7 @@ func processSolMsg(
 			return
 		} else {
 			W.Ln("blk submitted via kopach miner rejected:", e)
+			solutions.Inc("rejected")
 			if isOrphan {
 				W.Ln("blk is an orphan")
 				return
@@ -658,7 +690,16 @@ func processSolMsg(
 			return
 		}
 	}
+	if isOrphan {
+		solutions.Inc("orphan")
+	} else {
+		solutions.Inc("accepted")
+	}
 	I.Ln("the blk was accepted, new height", blk.Height())
+	if s.pool != nil {
+		if e = s.pool.BlockFound(blk); E.Chk(e) {
+		}
+	}
 	I.C(
 		func() string {
 			bmb := blk.WireBlock()
@@ -672,7 +713,7 @@ func processSolMsg(
 			}
 			prevTime := prevBlock.WireBlock().Header.Timestamp.Unix()
 			since := bmb.Header.Timestamp.Unix() - prevTime
-			bHash := bmb.BlockHashWithAlgos(blk.Height())
+			bHash := bmb.BlockHashWithAlgos(s.generator.ChainParams.Forks, blk.Height())
 			return fmt.Sprintf(
 				"new blk height %d %08x %s%10d %08x %v %s %ds since prev",
 				blk.Height(),
@@ -681,7 +722,7 @@ func processSolMsg(
 				bmb.Header.Timestamp.Unix(),
 				bmb.Header.Bits,
 				amt.Amount(coinbaseTx.Value),
-				fork.GetAlgoName(
+				s.generator.ChainParams.Forks.GetAlgoName(
 					bmb.Header.Version,
 					blk.Height(),
 				), since,
@@ -693,6 +734,15 @@ func processSolMsg(
 	return
 }
 
+// PoolInfo returns the share window, pending balances and recent blocks of the pool, or an error if the controller is
+// not running in pool mode
+func (s *State) PoolInfo(blocks int) (r *btcjson.GetPoolInfoResult, e error) {
+	if s.pool == nil {
+		return nil, errors.New("controller is not running in pool mode")
+	}
+	return s.pool.Info(blocks)
+}
+
 // hashrate reports from workers
 func processHashrateMsg(
 	ctx interface{}, src net.Addr, dst string, b []byte,
@@ -0,0 +1,32 @@
+package ctrl
+
+import (
+	"github.com/cybriq/p9/pkg/metrics"
+)
+
+var (
+	// jobBroadcasts counts the block templates sent out to the miners
+	jobBroadcasts = metrics.NewCounter(
+		"pod_controller_job_broadcasts_total", "number of times the controller has sent its block templates to miners",
+	)
+	// solutions counts the solved blocks received from miners by what became of them
+	solutions = metrics.NewCounterVec(
+		"pod_controller_solutions_total",
+		"solved blocks received from miners by result: accepted, orphan, rejected, stale, unknown or error",
+		"result",
+	)
+)
+
+// Metrics returns the metrics of the controller for exposition
+func (s *State) Metrics() []metrics.Collector {
+	return []metrics.Collector{
+		jobBroadcasts,
+		solutions,
+		metrics.NewCounterFunc(
+			"pod_controller_hashes_total", "number of hashes the miners of the controller have reported",
+			func() float64 {
+				return float64(s.hashCount.Load())
+			},
+		),
+	}
+}
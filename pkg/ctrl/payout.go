@@ -0,0 +1,114 @@
+package ctrl
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/cybriq/p9/pkg/btcaddr"
+	"github.com/cybriq/p9/pkg/chaincfg"
+	"github.com/cybriq/p9/pkg/util/hdkeychain"
+)
+
+// payout hands out the addresses the coinbases of block templates pay to when they are configured on the node, so a
+// controller can mine without a wallet and without any private keys. Addresses come from an account extended public
+// key if one is configured, deriving a fresh external address for each block, otherwise from the list of mining
+// addresses in turn.
+type payout struct {
+	sync.Mutex
+	addrs    []btcaddr.Address
+	next     int
+	external *hdkeychain.ExtendedKey
+	index    uint32
+	params   *chaincfg.Params
+	// indexFile stores the next derivation index so addresses are not reused across restarts
+	indexFile string
+}
+
+// newPayout creates a payout address source from the configured addresses and account extended public key. It returns
+// nil if neither is configured, in which case addresses must be requested from a wallet.
+func newPayout(
+	addrs []btcaddr.Address, xpub string, params *chaincfg.Params, indexFile string,
+) (p *payout, e error) {
+	if len(addrs) < 1 && xpub == "" {
+		return
+	}
+	p = &payout{addrs: addrs, params: params, indexFile: indexFile}
+	if xpub == "" {
+		return
+	}
+	var account *hdkeychain.ExtendedKey
+	if account, e = hdkeychain.NewKeyFromString(xpub); E.Chk(e) {
+		return nil, e
+	}
+	if !account.IsForNet(params) {
+		return nil, fmt.Errorf("mining extended public key is not for network %s", params.Name)
+	}
+	if account.IsPrivate() {
+		// only the public key is needed, so do not keep the private key in memory
+		W.Ln("mining extended key is private, only its public key will be used")
+		if account, e = account.Neuter(); E.Chk(e) {
+			return nil, e
+		}
+	}
+	if p.external, e = account.Child(0); E.Chk(e) {
+		return nil, e
+	}
+	if p.indexFile != "" {
+		var b []byte
+		if b, e = ioutil.ReadFile(p.indexFile); e != nil {
+			if !os.IsNotExist(e) {
+				E.Ln(e)
+				return nil, e
+			}
+			e = nil
+		} else {
+			var index uint64
+			if index, e = strconv.ParseUint(strings.TrimSpace(string(b)), 10, 32); E.Chk(e) {
+				return nil, e
+			}
+			p.index = uint32(index)
+		}
+	}
+	return
+}
+
+// address returns the address the coinbase of the next block should pay to.
+func (p *payout) address() (addr btcaddr.Address, e error) {
+	p.Lock()
+	defer p.Unlock()
+	if p.external == nil {
+		addr = p.addrs[p.next%len(p.addrs)]
+		p.next = (p.next + 1) % len(p.addrs)
+		return
+	}
+	for {
+		if p.index >= hdkeychain.HardenedKeyStart {
+			return nil, errors.New("mining extended public key has no more unhardened addresses")
+		}
+		index := p.index
+		p.index++
+		var child *hdkeychain.ExtendedKey
+		if child, e = p.external.Child(index); e == hdkeychain.ErrInvalidChild {
+			// the caller is expected to skip the rare indexes that do not derive to a usable key
+			continue
+		} else if E.Chk(e) {
+			return
+		}
+		if addr, e = child.Address(p.params); E.Chk(e) {
+			return
+		}
+		D.Ln("derived payout address", addr.EncodeAddress(), "at index", index)
+		break
+	}
+	if p.indexFile != "" {
+		// failing to store the index only risks reusing addresses after a restart, so the address is still used
+		if e := ioutil.WriteFile(p.indexFile, []byte(fmt.Sprintln(p.index)), 0600); E.Chk(e) {
+		}
+	}
+	return
+}
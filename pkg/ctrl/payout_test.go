@@ -0,0 +1,101 @@
+package ctrl
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+
+	"github.com/cybriq/p9/pkg/btcaddr"
+	"github.com/cybriq/p9/pkg/chaincfg"
+	"github.com/cybriq/p9/pkg/util/hdkeychain"
+)
+
+// TestPayoutXPub ensures a fresh external address of the account is paid to for each block, that the derivation
+// continues where it left off after a restart, and that keys for another network are rejected.
+func TestPayoutXPub(t *testing.T) {
+	params := &chaincfg.SimNetParams
+	account, e := hdkeychain.NewMaster(bytes.Repeat([]byte{0x42}, 32), params)
+	if e != nil {
+		t.Fatal(e)
+	}
+	external, e := account.Child(0)
+	if e != nil {
+		t.Fatal(e)
+	}
+	want := func(index uint32) string {
+		child, e := external.Child(index)
+		if e != nil {
+			t.Fatal(e)
+		}
+		addr, e := child.Address(params)
+		if e != nil {
+			t.Fatal(e)
+		}
+		return addr.EncodeAddress()
+	}
+	pub, e := account.Neuter()
+	if e != nil {
+		t.Fatal(e)
+	}
+	indexFile := filepath.Join(t.TempDir(), "payoutindex")
+	p, e := newPayout(nil, pub.String(), params, indexFile)
+	if e != nil {
+		t.Fatal(e)
+	}
+	for i := uint32(0); i < 3; i++ {
+		addr, e := p.address()
+		if e != nil {
+			t.Fatal(e)
+		}
+		if addr.EncodeAddress() != want(i) {
+			t.Fatalf("address %d: got %s, want %s", i, addr.EncodeAddress(), want(i))
+		}
+	}
+	// a private key is accepted but only its public key is used, and the index is read back from the file
+	if p, e = newPayout(nil, account.String(), params, indexFile); e != nil {
+		t.Fatal(e)
+	}
+	if p.external.IsPrivate() {
+		t.Fatal("private extended key was not neutered")
+	}
+	addr, e := p.address()
+	if e != nil {
+		t.Fatal(e)
+	}
+	if addr.EncodeAddress() != want(3) {
+		t.Fatalf("address after restart: got %s, want %s", addr.EncodeAddress(), want(3))
+	}
+	if _, e = newPayout(nil, pub.String(), &chaincfg.MainNetParams, ""); e == nil {
+		t.Fatal("extended key for another network was accepted")
+	}
+}
+
+// TestPayoutAddrs ensures the configured mining addresses are paid to in turn and that nothing is returned when no
+// payout is configured.
+func TestPayoutAddrs(t *testing.T) {
+	params := &chaincfg.SimNetParams
+	var addrs []btcaddr.Address
+	for i := byte(0); i < 3; i++ {
+		addr, e := btcaddr.NewPubKeyHash(bytes.Repeat([]byte{i}, 20), params)
+		if e != nil {
+			t.Fatal(e)
+		}
+		addrs = append(addrs, addr)
+	}
+	p, e := newPayout(addrs, "", params, "")
+	if e != nil {
+		t.Fatal(e)
+	}
+	for i := 0; i < 7; i++ {
+		addr, e := p.address()
+		if e != nil {
+			t.Fatal(e)
+		}
+		if addr != addrs[i%len(addrs)] {
+			t.Fatalf("address %d: got %s, want %s", i, addr.EncodeAddress(), addrs[i%len(addrs)].EncodeAddress())
+		}
+	}
+	if p, e = newPayout(nil, "", params, ""); e != nil || p != nil {
+		t.Fatalf("got payout %v and error %v without any configured", p, e)
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"sort"
 	"sync"
 
 	"github.com/cybriq/p9/pkg/chainhash"
@@ -145,6 +146,11 @@ type (
 		fileOffset   uint32
 		blockLen     uint32
 	}
+	// blockFileInfo is the number and size of a flat block file on disk.
+	blockFileInfo struct {
+		num  uint32
+		size int64
+	}
 )
 
 // deserializeBlockLoc deserializes the passed serialized block location information. This is data stored into the block
@@ -259,6 +265,26 @@ func (s *blockStore) deleteFile(fileNum uint32) (e error) {
 	return nil
 }
 
+// closeFile closes the read-only file handle for the passed flat file number if it is open, so the file can be deleted.
+func (s *blockStore) closeFile(fileNum uint32) {
+	s.obfMutex.Lock()
+	defer s.obfMutex.Unlock()
+	blockFile, ok := s.openBlockFiles[fileNum]
+	if !ok {
+		return
+	}
+	s.lruMutex.Lock()
+	s.openBlocksLRU.Remove(s.fileNumToLRUElem[fileNum])
+	delete(s.fileNumToLRUElem, fileNum)
+	s.lruMutex.Unlock()
+	// Close the file under the write lock for the file in case any readers are currently reading from it so it's not
+	// closed out from under them.
+	blockFile.Lock()
+	_ = blockFile.file.Close()
+	blockFile.Unlock()
+	delete(s.openBlockFiles, fileNum)
+}
+
 // blockFile attempts to return an existing file handle for the passed flat file
 // number if it is already open as well as marking it as most recently used. It
 // will also open the file when it's not already open subject to the rules
@@ -626,6 +652,29 @@ func (s *blockStore) handleRollback(oldBlockFileNum, oldBlockOffset uint32) {
 	}
 }
 
+// listBlockFiles returns the flat block files in the database directory ordered by file number. The numbers do not
+// start at zero once the oldest files have been pruned.
+func listBlockFiles(dbPath string) (files []blockFileInfo, e error) {
+	var entries []os.DirEntry
+	if entries, e = os.ReadDir(dbPath); e != nil {
+		return
+	}
+	for _, entry := range entries {
+		var num uint32
+		if _, e := fmt.Sscanf(entry.Name(), blockFilenameTemplate, &num); e != nil ||
+			entry.Name() != fmt.Sprintf(blockFilenameTemplate, num) {
+			continue
+		}
+		info, e := entry.Info()
+		if e != nil {
+			continue
+		}
+		files = append(files, blockFileInfo{num: num, size: info.Size()})
+	}
+	sort.Slice(files, func(i, j int) bool { return files[i].num < files[j].num })
+	return files, nil
+}
+
 // scanBlockFiles searches the database directory for all flat block files to find the end of the most recent file.
 //
 // This position is considered the current write cursor which is also stored in the metadata.
@@ -634,15 +683,12 @@ func (s *blockStore) handleRollback(oldBlockFileNum, oldBlockOffset uint32) {
 func scanBlockFiles(dbPath string) (int, uint32) {
 	lastFile := -1
 	fileLen := uint32(0)
-	for i := 0; ; i++ {
-		filePath := blockFilePath(dbPath, uint32(i))
-		st, e := os.Stat(filePath)
-		if e != nil {
-			T.Ln(e)
-			break
-		}
-		lastFile = i
-		fileLen = uint32(st.Size())
+	files, e := listBlockFiles(dbPath)
+	if e != nil {
+		T.Ln(e)
+	} else if len(files) > 0 {
+		lastFile = int(files[len(files)-1].num)
+		fileLen = uint32(files[len(files)-1].size)
 	}
 	T.F("Scan found latest block file #%d with length %d", lastFile, fileLen)
 	return lastFile, fileLen
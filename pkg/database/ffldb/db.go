@@ -60,6 +60,8 @@ var (
 	blockIdxBucketName = []byte("ffldb-blockidx")
 	// writeLocKeyName is the key used to store the current write file location.
 	writeLocKeyName = []byte("ffldb-writeloc")
+	// prunedKeyName is the key set once block files have been pruned.
+	prunedKeyName = []byte("ffldb-pruned")
 )
 
 // Common error strings.
@@ -898,6 +900,8 @@ type transaction struct {
 	// The pendingBlocks map is kept to allow quick lookups of pending data by block hash.
 	pendingBlocks    map[chainhash.Hash]int
 	pendingBlockData []pendingBlock
+	// Block files that need to be deleted on commit.
+	pendingPrune []uint32
 	// Keys that need to be stored or deleted on commit.
 	pendingKeys   *treap.Mutable
 	pendingRemove *treap.Mutable
@@ -1493,6 +1497,115 @@ func (tx *transaction) FetchBlockRegions(regions []database.BlockRegion) (
 	return blockRegions, nil
 }
 
+// PruneBlocks removes the oldest block files until the block files take no more than targetSize bytes, along with the
+// blocks stored in them from the block index, and returns the hashes of the blocks removed. Only whole files are
+// removed, and never the file holding the block to keep, any later file, or the file currently being written to. The
+// files are deleted from disk when the transaction is committed.
+//
+// Returns the following errors as required by the interface contract:
+//
+//   - ErrBlockNotFound if the block to keep does not exist
+//
+//   - ErrTxNotWritable if attempted against a read-only transaction
+//
+//   - ErrTxClosed if the transaction has already been closed
+//
+// This function is part of the database.Tx interface implementation.
+func (tx *transaction) PruneBlocks(targetSize uint64, keep *chainhash.Hash) (pruned []chainhash.Hash, e error) {
+	// Ensure transaction state is valid.
+	if e = tx.checkClosed(); E.Chk(e) {
+		return nil, e
+	}
+	// Ensure the transaction is writable.
+	if !tx.writable {
+		str := "prune blocks requires a writable database transaction"
+		return nil, makeDbErr(database.ErrTxNotWritable, str, nil)
+	}
+	// Blocks are appended to the files in the order they are stored, so the files before the one holding the block to
+	// keep only hold older blocks.
+	wc := tx.db.store.writeCursor
+	wc.RLock()
+	keepFile := wc.curFileNum
+	wc.RUnlock()
+	if _, pending := tx.pendingBlocks[*keep]; !pending {
+		var keepRow []byte
+		if keepRow, e = tx.fetchBlockRow(keep); E.Chk(e) {
+			return nil, e
+		}
+		if loc := deserializeBlockLoc(keepRow); loc.blockFileNum < keepFile {
+			keepFile = loc.blockFileNum
+		}
+	}
+	var files []blockFileInfo
+	if files, e = listBlockFiles(tx.db.store.basePath); E.Chk(e) {
+		return nil, makeDbErr(database.ErrDriverSpecific, e.Error(), e)
+	}
+	// Files already pruned by the transaction are only deleted on commit.
+	alreadyPruned := make(map[uint32]struct{}, len(tx.pendingPrune))
+	for _, fileNum := range tx.pendingPrune {
+		alreadyPruned[fileNum] = struct{}{}
+	}
+	var total uint64
+	for _, f := range files {
+		if _, ok := alreadyPruned[f.num]; !ok {
+			total += uint64(f.size)
+		}
+	}
+	prune := make(map[uint32]struct{})
+	for _, f := range files {
+		if total <= targetSize || f.num >= keepFile {
+			break
+		}
+		if _, ok := alreadyPruned[f.num]; ok {
+			continue
+		}
+		prune[f.num] = struct{}{}
+		tx.pendingPrune = append(tx.pendingPrune, f.num)
+		total -= uint64(f.size)
+	}
+	if len(prune) == 0 {
+		return nil, nil
+	}
+	// Remove the blocks stored in the pruned files from the block index.
+	if e = tx.blockIdxBucket.ForEach(
+		func(k, v []byte) error {
+			if _, ok := prune[deserializeBlockLoc(v).blockFileNum]; ok {
+				var hash chainhash.Hash
+				copy(hash[:], k)
+				pruned = append(pruned, hash)
+			}
+			return nil
+		},
+	); E.Chk(e) {
+		return nil, e
+	}
+	for i := range pruned {
+		if e = tx.blockIdxBucket.Delete(pruned[i][:]); E.Chk(e) {
+			return nil, e
+		}
+	}
+	if e = tx.metaBucket.Put(prunedKeyName, []byte{1}); E.Chk(e) {
+		return nil, e
+	}
+	D.F("pruning %d block files holding %d blocks", len(prune), len(pruned))
+	return pruned, nil
+}
+
+// BeenPruned returns whether block files have ever been pruned.
+//
+// Returns the following errors as required by the interface contract:
+//
+//   - ErrTxClosed if the transaction has already been closed
+//
+// This function is part of the database.Tx interface implementation.
+func (tx *transaction) BeenPruned() (bool, error) {
+	// Ensure transaction state is valid.
+	if e := tx.checkClosed(); E.Chk(e) {
+		return false, e
+	}
+	return tx.metaBucket.Get(prunedKeyName) != nil, nil
+}
+
 // close marks the transaction closed then releases any pending data, the underlying snapshot, the transaction read
 // lock, and the write lock when the transaction is writable.
 func (tx *transaction) close() {
@@ -1500,6 +1613,7 @@ func (tx *transaction) close() {
 	// Clear pending blocks that would have been written on commit.
 	tx.pendingBlocks = nil
 	tx.pendingBlockData = nil
+	tx.pendingPrune = nil
 	// Clear pending keys that would have been written or deleted on commit.
 	tx.pendingKeys = nil
 	tx.pendingRemove = nil
@@ -1563,7 +1677,29 @@ func (tx *transaction) writePendingAndCommit() (e error) {
 	// Atomically update the database cache.
 	//
 	// The cache automatically handles flushing to the underlying persistent storage database.
-	return tx.db.cache.commitTx(tx)
+	if e = tx.db.cache.commitTx(tx); E.Chk(e) {
+		return e
+	}
+	return tx.deletePrunedFiles()
+}
+
+// deletePrunedFiles deletes the block files pruned by the transaction once it has been committed.
+func (tx *transaction) deletePrunedFiles() (e error) {
+	if len(tx.pendingPrune) == 0 {
+		return nil
+	}
+	// Flush the removal of the pruned blocks from the block index first so an unclean shutdown can not leave the index
+	// referring to deleted files.
+	if e = tx.db.cache.flush(); E.Chk(e) {
+		return e
+	}
+	for _, fileNum := range tx.pendingPrune {
+		tx.db.store.closeFile(fileNum)
+		// A file that fails to be deleted only wastes space as no block refers to it any more.
+		if e := tx.db.store.deleteFileFunc(fileNum); E.Chk(e) {
+		}
+	}
+	return nil
 }
 
 // Commit commits all changes that have been made to the root metadata bucket and all of its sub-buckets to the database
@@ -0,0 +1,157 @@
+package ffldb
+
+// This file is part of the ffldb package rather than the ffldb_test package as it provides whitebox testing.
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/cybriq/p9/pkg/block"
+	"github.com/cybriq/p9/pkg/chainhash"
+	"github.com/cybriq/p9/pkg/database"
+	"github.com/cybriq/p9/pkg/wire"
+)
+
+// makeBlocks returns a chain of n blocks each holding a single transaction padded to about 1 KiB.
+func makeBlocks(n int) (blocks []*block.Block) {
+	var prev chainhash.Hash
+	for i := 0; i < n; i++ {
+		tx := wire.NewMsgTx(wire.TxVersion)
+		tx.AddTxIn(wire.NewTxIn(wire.NewOutPoint(&chainhash.Hash{}, uint32(i)), []byte{byte(i)}, nil))
+		tx.AddTxOut(wire.NewTxOut(int64(i), make([]byte, 1024)))
+		merkle := tx.TxHash()
+		msgBlock := wire.NewMsgBlock(wire.NewBlockHeader(1, &prev, &merkle, 0x207fffff, uint32(i)))
+		if e := msgBlock.AddTransaction(tx); E.Chk(e) {
+		}
+		b := block.NewBlock(msgBlock)
+		prev = *b.Hash()
+		blocks = append(blocks, b)
+	}
+	return
+}
+
+// TestPruneBlocks ensures pruning removes the oldest block files and their blocks once the transaction is committed,
+// keeps the requested block, and that the database can be reopened with the files missing.
+func TestPruneBlocks(t *testing.T) {
+	t.Parallel()
+	blocks := makeBlocks(64)
+	dbPath := filepath.Join(t.TempDir(), "ffldb-prune")
+	idb, e := openDB(dbPath, blockDataNet, true)
+	if e != nil {
+		t.Fatalf("openDB: unexpected error: %v", e)
+	}
+	// Use small block files so the test blocks are spread across several of them.
+	store := idb.(*db).store
+	store.maxBlockFileSize = 8192
+	if e = idb.Update(
+		func(tx database.Tx) (e error) {
+			for _, b := range blocks {
+				if e = tx.StoreBlock(b); E.Chk(e) {
+					return e
+				}
+			}
+			return nil
+		},
+	); e != nil {
+		t.Fatalf("StoreBlock: unexpected error: %v", e)
+	}
+	files, e := listBlockFiles(dbPath)
+	if e != nil {
+		t.Fatalf("listBlockFiles: unexpected error: %v", e)
+	}
+	if len(files) < 4 {
+		t.Fatalf("expected the blocks to be spread across at least 4 files, got %d", len(files))
+	}
+	keep := blocks[len(blocks)/2].Hash()
+	if e = idb.View(
+		func(tx database.Tx) (e error) {
+			_, e = tx.PruneBlocks(0, keep)
+			return e
+		},
+	); !checkDbError(t, "PruneBlocks on read-only tx", e, database.ErrTxNotWritable) {
+		return
+	}
+	var pruned []chainhash.Hash
+	if e = idb.Update(
+		func(tx database.Tx) (e error) {
+			var been bool
+			if been, e = tx.BeenPruned(); E.Chk(e) {
+				return e
+			}
+			if been {
+				t.Errorf("BeenPruned: database reported pruned before pruning")
+			}
+			// A target larger than the block files prunes nothing.
+			var none []chainhash.Hash
+			if none, e = tx.PruneBlocks(1<<32, keep); E.Chk(e) {
+				return e
+			}
+			if len(none) != 0 {
+				t.Errorf("PruneBlocks: pruned %d blocks with a target above the size of the files", len(none))
+			}
+			pruned, e = tx.PruneBlocks(0, keep)
+			return e
+		},
+	); e != nil {
+		t.Fatalf("PruneBlocks: unexpected error: %v", e)
+	}
+	if len(pruned) == 0 || len(pruned) >= len(blocks)/2+1 {
+		t.Fatalf("PruneBlocks: unexpected number of pruned blocks %d", len(pruned))
+	}
+	// The pruned blocks are the oldest ones and the block to keep and all later blocks remain.
+	after, e := listBlockFiles(dbPath)
+	if e != nil {
+		t.Fatalf("listBlockFiles: unexpected error: %v", e)
+	}
+	if len(after) >= len(files) || after[len(after)-1].num != files[len(files)-1].num {
+		t.Fatalf("unexpected block files after pruning %v, before %v", after, files)
+	}
+	checkBlocks := func(idb database.DB) {
+		if e := idb.View(
+			func(tx database.Tx) (e error) {
+				var been bool
+				if been, e = tx.BeenPruned(); E.Chk(e) {
+					return e
+				}
+				if !been {
+					t.Errorf("BeenPruned: database not reported pruned")
+				}
+				for i, b := range blocks {
+					has, e := tx.HasBlock(b.Hash())
+					if e != nil {
+						return e
+					}
+					if want := i >= len(pruned); has != want {
+						t.Errorf("HasBlock #%d: got %v, want %v", i, has, want)
+					}
+				}
+				_, e = tx.FetchBlock(keep)
+				return e
+			},
+		); e != nil {
+			t.Errorf("View: unexpected error: %v", e)
+		}
+	}
+	checkBlocks(idb)
+	// Reopen the database and ensure the remaining blocks are still available and new blocks can be stored.
+	if e = idb.Close(); e != nil {
+		t.Fatalf("Close: unexpected error: %v", e)
+	}
+	if idb, e = openDB(dbPath, blockDataNet, false); e != nil {
+		t.Fatalf("openDB: unexpected error: %v", e)
+	}
+	defer func() {
+		if e := idb.Close(); E.Chk(e) {
+		}
+	}()
+	checkBlocks(idb)
+	if e = idb.Update(
+		func(tx database.Tx) (e error) {
+			if e = tx.StoreBlock(blocks[0]); E.Chk(e) {
+				return e
+			}
+			return nil
+		},
+	); e != nil {
+		t.Fatalf("StoreBlock after reopen: unexpected error: %v", e)
+	}
+}
@@ -336,6 +336,27 @@ type Tx interface {
 	// after a transaction has ended results in undefined behavior. This constraint prevents additional data copies and
 	// allows support for memory-mapped database implementations.
 	FetchBlockRegions(regions []BlockRegion) ([][]byte, error)
+	// PruneBlocks removes the oldest stored blocks until the block storage takes no more than targetSize bytes and
+	// returns the hashes of the blocks removed. The block identified by keep and all blocks stored after it are never
+	// removed. Depending on the backend implementation blocks may only be removed in large groups, so the storage can
+	// remain above the target.
+	//
+	// The interface contract guarantees at least the following errors will be returned (other implementation-specific
+	// errors are possible):
+	//
+	//   - ErrBlockNotFound if the block to keep does not exist
+	//
+	//   - ErrTxNotWritable if attempted against a read-only transaction
+	//
+	//   - ErrTxClosed if the transaction has already been closed
+	PruneBlocks(targetSize uint64, keep *chainhash.Hash) ([]chainhash.Hash, error)
+	// BeenPruned returns whether blocks have ever been removed from the block storage by PruneBlocks.
+	//
+	// The interface contract guarantees at least the following errors will be returned (other implementation-specific
+	// errors are possible):
+	//
+	//   - ErrTxClosed if the transaction has already been closed
+	BeenPruned() (bool, error)
 	// Commit commits all changes that have been made to the metadata or block storage. Depending on the backend
 	// implementation this could be to a cache that is periodically synced to persistent storage or directly to
 	// persistent storage.
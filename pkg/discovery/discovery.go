@@ -0,0 +1,207 @@
+// Package discovery finds other nodes on the local network from the advertisments they multicast and connects to them
+// as persistent peers, so nodes in the same office or home sync blocks from each other instead of over the internet.
+//
+// Only nodes sharing the same multicast password can read each other's advertisments.
+package discovery
+
+import (
+	"fmt"
+	"net"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/cybriq/gotiny"
+
+	"github.com/cybriq/p9/pkg/btcjson"
+	"github.com/cybriq/p9/pkg/chainrpc/p2padvt"
+	"github.com/cybriq/p9/pkg/constant"
+	"github.com/cybriq/p9/pkg/qu"
+	"github.com/cybriq/p9/pkg/transport"
+)
+
+const (
+	// AdvertInterval is how often the node multicasts its advertisment
+	AdvertInterval = time.Second * 5
+	// PeerTimeout is how long a node is remembered after its last advertisment. When a node is forgotten the
+	// connection to it is dropped, and if it reappears it is connected to again.
+	PeerTimeout = AdvertInterval * 6
+)
+
+// ConnManager is the part of the connection manager of the node used to connect to the nodes that are found.
+type ConnManager interface {
+	Connect(addr string, permanent bool) error
+	RemoveByAddr(addr string) error
+}
+
+// lanPeer is a node found on the local network
+type lanPeer struct {
+	ips []string
+	// addr is the address of the P2P listener of the node
+	addr string
+	// outbound is set when this node connects to the other. Only the node with the lower UUID connects, so two nodes
+	// finding each other do not open two connections.
+	outbound  bool
+	firstSeen time.Time
+	lastSeen  time.Time
+}
+
+// Discovery advertises the node on the local network and connects to the other nodes advertising there.
+type Discovery struct {
+	sync.Mutex
+	uuid     uint64
+	listener string
+	connMgr  ConnManager
+	peers    map[uint64]*lanPeer
+	conn     *transport.Channel
+	quit     qu.C
+}
+
+var handlers = transport.Handlers{
+	string(p2padvt.Magic): processAdvtMsg,
+}
+
+// New creates a Discovery for the node with the given UUID and P2P listener, which advertises and listens on the
+// multicast channel encrypted with key.
+func New(uuid uint64, listener string, key []byte, connMgr ConnManager, quit qu.C) (d *Discovery, e error) {
+	d = newDiscovery(uuid, listener, connMgr, quit)
+	if d.conn, e = transport.NewBroadcastChannel(
+		"discovery",
+		d,
+		key,
+		transport.DefaultPort,
+		constant.MaxDatagramSize,
+		handlers,
+		quit,
+	); E.Chk(e) {
+		return nil, e
+	}
+	return
+}
+
+func newDiscovery(uuid uint64, listener string, connMgr ConnManager, quit qu.C) *Discovery {
+	return &Discovery{
+		uuid:     uuid,
+		listener: listener,
+		connMgr:  connMgr,
+		peers:    make(map[uint64]*lanPeer),
+		quit:     quit,
+	}
+}
+
+// Run advertises the node and forgets nodes that stopped advertising until quit is closed.
+func (d *Discovery) Run() {
+	ticker := time.NewTicker(AdvertInterval)
+	defer ticker.Stop()
+	d.advertise()
+	for {
+		select {
+		case <-ticker.C:
+			d.advertise()
+			d.expire(time.Now())
+		case <-d.quit.Wait():
+			return
+		}
+	}
+}
+
+func (d *Discovery) advertise() {
+	T.Ln("sending out advertisment")
+	if e := d.conn.SendMany(p2padvt.Magic, transport.GetShards(p2padvt.Get(d.uuid, d.listener))); E.Chk(e) {
+	}
+}
+
+func processAdvtMsg(ctx interface{}, src net.Addr, dst string, b []byte) (e error) {
+	d := ctx.(*Discovery)
+	var advt p2padvt.Advertisment
+	gotiny.Unmarshal(b, &advt)
+	d.seen(&advt, src, time.Now())
+	return
+}
+
+// seen records an advertisment received from src, connecting to the node that sent it if it is new and has a higher
+// UUID than this node.
+func (d *Discovery) seen(advt *p2padvt.Advertisment, src net.Addr, now time.Time) {
+	if advt.UUID == d.uuid {
+		return
+	}
+	d.Lock()
+	if p, ok := d.peers[advt.UUID]; ok {
+		p.lastSeen = now
+		d.Unlock()
+		return
+	}
+	p := &lanPeer{firstSeen: now, lastSeen: now, outbound: d.uuid < advt.UUID}
+	for ip := range advt.IPs {
+		p.ips = append(p.ips, ip)
+	}
+	sort.Strings(p.ips)
+	// the address the advertisment came from is known to be reachable from this node, unlike some of the addresses
+	// the node advertises
+	host, _, e := net.SplitHostPort(src.String())
+	if e != nil || host == "" {
+		if len(p.ips) == 0 {
+			// without a host the address would be the loopback of this node
+			D.Ln("ignoring advertisment from", src, "without an address to connect to")
+			d.Unlock()
+			return
+		}
+		host = p.ips[0]
+	}
+	p.addr = net.JoinHostPort(host, fmt.Sprint(advt.P2P))
+	d.peers[advt.UUID] = p
+	d.Unlock()
+	if !p.outbound {
+		I.Ln("found LAN peer", p.addr, "which will connect to this node")
+		return
+	}
+	I.Ln("connecting to LAN peer", p.addr)
+	if e = d.connMgr.Connect(p.addr, true); E.Chk(e) {
+		// forget the node so the next advertisment is another attempt
+		d.Lock()
+		delete(d.peers, advt.UUID)
+		d.Unlock()
+	}
+}
+
+// expire forgets the nodes that have not advertised for longer than PeerTimeout and drops the connections to them.
+func (d *Discovery) expire(now time.Time) {
+	var remove []string
+	d.Lock()
+	for uuid, p := range d.peers {
+		if now.Sub(p.lastSeen) <= PeerTimeout {
+			continue
+		}
+		I.Ln("LAN peer", p.addr, "stopped advertising")
+		if p.outbound {
+			remove = append(remove, p.addr)
+		}
+		delete(d.peers, uuid)
+	}
+	d.Unlock()
+	for _, addr := range remove {
+		if e := d.connMgr.RemoveByAddr(addr); D.Chk(e) {
+		}
+	}
+}
+
+// Peers returns the nodes currently advertising on the local network, sorted by address.
+func (d *Discovery) Peers() (peers []btcjson.GetLANPeersResult) {
+	d.Lock()
+	defer d.Unlock()
+	peers = make([]btcjson.GetLANPeersResult, 0, len(d.peers))
+	for uuid, p := range d.peers {
+		peers = append(
+			peers, btcjson.GetLANPeersResult{
+				UUID:      uuid,
+				Addr:      p.addr,
+				IPs:       p.ips,
+				Outbound:  p.outbound,
+				FirstSeen: p.firstSeen.Unix(),
+				LastSeen:  p.lastSeen.Unix(),
+			},
+		)
+	}
+	sort.Slice(peers, func(i, j int) bool { return peers[i].Addr < peers[j].Addr })
+	return
+}
@@ -0,0 +1,138 @@
+package discovery
+
+import (
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/cybriq/gotiny"
+
+	"github.com/cybriq/p9/pkg/chainrpc/p2padvt"
+	"github.com/cybriq/p9/pkg/qu"
+)
+
+type fakeConnMgr struct {
+	connected []string
+	removed   []string
+	fail      bool
+}
+
+func (f *fakeConnMgr) Connect(addr string, permanent bool) error {
+	if !permanent {
+		return errors.New("LAN peers must be persistent")
+	}
+	if f.fail {
+		return errors.New("connection refused")
+	}
+	f.connected = append(f.connected, addr)
+	return nil
+}
+
+func (f *fakeConnMgr) RemoveByAddr(addr string) error {
+	f.removed = append(f.removed, addr)
+	return nil
+}
+
+func advert(uuid uint64, ips ...string) *p2padvt.Advertisment {
+	a := &p2padvt.Advertisment{IPs: make(map[string]struct{}), P2P: 11047, UUID: uuid}
+	for _, ip := range ips {
+		a.IPs[ip] = struct{}{}
+	}
+	return a
+}
+
+// TestAdvertMessage ensures advertisments made by p2padvt are decoded by the discovery handler.
+func TestAdvertMessage(t *testing.T) {
+	cm := &fakeConnMgr{}
+	d := newDiscovery(1, "127.0.0.1:11047", cm, qu.T())
+	src := &net.UDPAddr{IP: net.IPv4(192, 168, 1, 20), Port: 1234}
+	if e := processAdvtMsg(d, src, "", p2padvt.Get(2, "127.0.0.1:11047")); e != nil {
+		t.Fatal(e)
+	}
+	peers := d.Peers()
+	if len(peers) != 1 || peers[0].UUID != 2 || peers[0].Addr != "192.168.1.20:11047" {
+		t.Fatalf("unexpected peers %+v", peers)
+	}
+	var a p2padvt.Advertisment
+	gotiny.Unmarshal(p2padvt.Get(2, "127.0.0.1:11047"), &a)
+	if a.UUID != 2 {
+		t.Fatalf("advertisment decoded with UUID %d", a.UUID)
+	}
+}
+
+// TestDiscovery ensures nodes found on the local network are connected to once, only by the node with the lower UUID,
+// and dropped when they stop advertising.
+func TestDiscovery(t *testing.T) {
+	cm := &fakeConnMgr{}
+	d := newDiscovery(5, "127.0.0.1:11047", cm, qu.T())
+	now := time.Now()
+	src := &net.UDPAddr{IP: net.IPv4(192, 168, 1, 20), Port: 1234}
+	d.seen(advert(5, "192.168.1.10"), src, now)
+	if len(d.Peers()) != 0 {
+		t.Fatal("own advertisment was recorded")
+	}
+	d.seen(advert(9, "10.0.0.2", "192.168.1.20"), src, now)
+	d.seen(advert(9, "10.0.0.2", "192.168.1.20"), src, now.Add(AdvertInterval))
+	if len(cm.connected) != 1 || cm.connected[0] != "192.168.1.20:11047" {
+		t.Fatalf("unexpected connections %v", cm.connected)
+	}
+	other := &net.UDPAddr{IP: net.IPv4(192, 168, 1, 30), Port: 1234}
+	d.seen(advert(3, "192.168.1.30"), other, now)
+	if len(cm.connected) != 1 {
+		t.Fatalf("connected to a node with a lower UUID %v", cm.connected)
+	}
+	peers := d.Peers()
+	if len(peers) != 2 || !peers[0].Outbound || peers[1].Outbound {
+		t.Fatalf("unexpected peers %+v", peers)
+	}
+	d.expire(now.Add(PeerTimeout + time.Second))
+	peers = d.Peers()
+	if len(peers) != 1 || peers[0].UUID != 9 {
+		t.Fatalf("unexpected peers after expiry %+v", peers)
+	}
+	if len(cm.removed) != 0 {
+		t.Fatalf("removed a connection this node did not make %v", cm.removed)
+	}
+	d.expire(now.Add(AdvertInterval + PeerTimeout + time.Second))
+	if len(d.Peers()) != 0 || len(cm.removed) != 1 || cm.removed[0] != "192.168.1.20:11047" {
+		t.Fatalf("expired peer was not removed, removed %v", cm.removed)
+	}
+}
+
+// TestDiscoveryRetry ensures a node that could not be connected to is tried again on its next advertisment.
+func TestDiscoveryRetry(t *testing.T) {
+	cm := &fakeConnMgr{fail: true}
+	d := newDiscovery(1, "127.0.0.1:11047", cm, qu.T())
+	src := &net.UDPAddr{IP: net.IPv4(192, 168, 1, 20), Port: 1234}
+	d.seen(advert(2, "192.168.1.20"), src, time.Now())
+	if len(d.Peers()) != 0 {
+		t.Fatal("node that could not be connected to was recorded")
+	}
+	cm.fail = false
+	d.seen(advert(2, "192.168.1.20"), src, time.Now())
+	if len(cm.connected) != 1 || len(d.Peers()) != 1 {
+		t.Fatalf("node was not connected to again, connections %v", cm.connected)
+	}
+}
+
+// badAddr is a source address without a port
+type badAddr struct{}
+
+func (badAddr) Network() string { return "udp" }
+func (badAddr) String() string  { return "unknown" }
+
+// TestDiscoveryNoAddress ensures a node is connected to at an address it advertises when the address an advertisment
+// came from cannot be used, and not at all if it advertises none.
+func TestDiscoveryNoAddress(t *testing.T) {
+	cm := &fakeConnMgr{}
+	d := newDiscovery(1, "127.0.0.1:11047", cm, qu.T())
+	d.seen(advert(2), badAddr{}, time.Now())
+	if len(cm.connected) != 0 || len(d.Peers()) != 0 {
+		t.Fatalf("node without an address was recorded, connections %v", cm.connected)
+	}
+	d.seen(advert(2, "192.168.1.20"), badAddr{}, time.Now())
+	if len(cm.connected) != 1 || cm.connected[0] != "192.168.1.20:11047" {
+		t.Fatalf("unexpected connections %v", cm.connected)
+	}
+}
@@ -3,14 +3,15 @@ package fork
 
 import (
 	"encoding/hex"
+	"encoding/json"
 	"fmt"
+	"io/ioutil"
 	"math/big"
 	"math/rand"
 	"sort"
 	"time"
 
 	"github.com/cybriq/p9/pkg/bits"
-	"github.com/cybriq/p9/pkg/helpers"
 )
 
 const (
@@ -20,22 +21,21 @@ const (
 
 // AlgoParams are the identifying block version number and their minimum target bits
 type AlgoParams struct {
-	Version         int32
-	MinBits         uint32
-	AlgoID          uint32
-	VersionInterval int
+	Version         int32  `json:"version"`
+	MinBits         uint32 `json:"minBits"`
+	AlgoID          uint32 `json:"algoID"`
+	VersionInterval int    `json:"versionInterval,omitempty"`
 }
 
 // HardForks is the details related to a hard fork, number, name and activation height
 type HardForks struct {
-	Number             int
-	ActivationHeight   int32
-	Name               string
-	Algos              map[string]AlgoParams
-	AlgoVers           map[int32]string
-	TargetTimePerBlock int32
-	AveragingInterval  int32
-	TestnetStart       int32
+	Number             int                   `json:"number"`
+	ActivationHeight   int32                 `json:"activationHeight"`
+	Name               string                `json:"name"`
+	Algos              map[string]AlgoParams `json:"algos"`
+	AlgoVers           map[int32]string      `json:"-"`
+	TargetTimePerBlock int32                 `json:"targetTimePerBlock"`
+	AveragingInterval  int32                 `json:"averagingInterval"`
 }
 
 type AlgoSpec struct {
@@ -56,80 +56,167 @@ func (a AlgoSpecs) Swap(i, j int) {
 	a[i], a[j] = a[j], a[i]
 }
 
-func init() {
-	ForkCalc()
+// Schedule is the hard fork schedule of a network, being the list of hard forks in order of activation along with the
+// lookup tables that are derived from them. Each network's chaincfg.Params carries its own Schedule.
+type Schedule struct {
+	// List is the list of existing hard forks and when they activate
+	List []HardForks `json:"forks"`
+	// AlgoSlices are the algorithms of each hard fork sorted from highest to lowest block version
+	AlgoSlices []AlgoSpecs `json:"-"`
+	// P9Average is the target average time between blocks of any version after the Plan 9 hard fork
+	P9Average    float64   `json:"-"`
+	algoVerSlice [][]int32 `json:"-"`
 }
 
-var done bool
+// NewSchedule checks a list of hard forks is consistent and derives the lookup tables used by the Schedule methods
+// from it. The chain code expects the Plan 9 hard fork at index 1, so there must be at least two entries. The first
+// entry must activate at height zero and each following entry must not activate before the one preceding it. Where
+// several activate at the same height the last of them is in force.
+func NewSchedule(list []HardForks) (s *Schedule, e error) {
+	if len(list) < 2 {
+		return nil, fmt.Errorf("hard fork schedule must have at least two entries, it has %d", len(list))
+	}
+	if list[0].ActivationHeight != 0 {
+		return nil, fmt.Errorf("first hard fork must activate at height 0")
+	}
+	s = &Schedule{List: make([]HardForks, len(list))}
+	for i := range list {
+		hf := list[i]
+		if hf.Number != i {
+			return nil, fmt.Errorf("hard fork %d '%s' has number %d", i, hf.Name, hf.Number)
+		}
+		if i > 0 && hf.ActivationHeight < list[i-1].ActivationHeight {
+			return nil, fmt.Errorf(
+				"hard fork %d '%s' activates at %d which is before the previous at %d",
+				i, hf.Name, hf.ActivationHeight, list[i-1].ActivationHeight,
+			)
+		}
+		if len(hf.Algos) < 1 {
+			return nil, fmt.Errorf("hard fork %d '%s' has no algorithms", i, hf.Name)
+		}
+		// copy the maps so the schedule can't be changed from outside
+		algos := make(map[string]AlgoParams, len(hf.Algos))
+		hf.AlgoVers = make(map[int32]string, len(hf.Algos))
+		specs := make(AlgoSpecs, 0, len(hf.Algos))
+		for name, params := range hf.Algos {
+			if _, ok := hf.AlgoVers[params.Version]; ok {
+				return nil, fmt.Errorf(
+					"hard fork %d '%s' has more than one algorithm with version %d", i, hf.Name, params.Version,
+				)
+			}
+			algos[name] = params
+			hf.AlgoVers[params.Version] = name
+			specs = append(specs, AlgoSpec{params.Version, name})
+		}
+		hf.Algos = algos
+		sort.Sort(specs)
+		s.List[i] = hf
+		s.AlgoSlices = append(s.AlgoSlices, specs)
+		av := make([]int32, 0, len(hf.AlgoVers))
+		for j := range hf.AlgoVers {
+			av = append(av, j)
+		}
+		s.algoVerSlice = append(s.algoVerSlice, av)
+	}
+	if e = s.calcP9Average(); e != nil {
+		return nil, e
+	}
+	return
+}
 
-func ForkCalc() {
-	if done {
-		I.Ln(helpers.Caller("called again", 1))
-		return
+// calcP9Average computes the average block interval that results from the version intervals of the Plan 9 algorithms
+func (s *Schedule) calcP9Average() (e error) {
+	p9 := s.List[1]
+	baseVersionName := s.AlgoSlices[1][0].Name
+	baseVersionInterval := float64(p9.Algos[baseVersionName].VersionInterval)
+	D.Ln(baseVersionName, baseVersionInterval)
+	for _, i := range s.AlgoSlices[1] {
+		vi := float64(p9.Algos[i.Name].VersionInterval)
+		if vi <= 0 {
+			return fmt.Errorf("algorithm %s of hard fork '%s' has no version interval", i.Name, p9.Name)
+		}
+		s.P9Average += baseVersionInterval / vi
 	}
-	done = true
-	T.Ln("running fork data init")
-	for i := range P9AlgosNumeric {
-		List[1].AlgoVers[i] = fmt.Sprintf(
-			"Div%d",
-			P9AlgosNumeric[i].VersionInterval,
-		)
+	s.P9Average = baseVersionInterval / s.P9Average
+	D.Ln(s.P9Average)
+	return
+}
+
+// mustSchedule is used to create the built in schedules which are known to be valid
+func mustSchedule(list []HardForks) *Schedule {
+	s, e := NewSchedule(list)
+	if e != nil {
+		panic(e)
 	}
-	for i, v := range P9AlgoVers {
-		List[1].Algos[v] = P9AlgosNumeric[i]
+	return s
+}
+
+// MainNetSchedule returns the hard fork schedule of the main network
+func MainNetSchedule() *Schedule {
+	return mustSchedule(DefaultList(MainNetPlan9Height))
+}
+
+// TestNetSchedule returns the hard fork schedule used by the test networks, which start on Plan 9 at the genesis block
+func TestNetSchedule() *Schedule {
+	return mustSchedule(DefaultList(0))
+}
+
+// LoadSchedule reads a hard fork schedule from a JSON file, for testing alternative parameters on private networks
+func LoadSchedule(filename string) (s *Schedule, e error) {
+	var b []byte
+	if b, e = ioutil.ReadFile(filename); E.Chk(e) {
+		return
 	}
-	AlgoSlices = append(AlgoSlices, AlgoSpecs{})
-	for i := range Algos {
-		AlgoSlices[0] = append(
-			AlgoSlices[0], AlgoSpec{
-				List[0].Algos[i].Version,
-				i,
-			},
-		)
+	var sched Schedule
+	if e = json.Unmarshal(b, &sched); E.Chk(e) {
+		return
 	}
-	AlgoSlices = append(AlgoSlices, AlgoSpecs{})
-	for i := range P9Algos {
-		AlgoSlices[1] = append(
-			AlgoSlices[1], AlgoSpec{
-				List[1].Algos[i].Version,
-				i,
-			},
-		)
+	return NewSchedule(sched.List)
+}
+
+// DefaultList returns the standard Halcyon Days and Plan 9 hard fork definitions with Plan 9 activating at the given
+// height
+func DefaultList(plan9Height int32) []HardForks {
+	return []HardForks{
+		{
+			Number:             0,
+			Name:               "Halcyon days",
+			ActivationHeight:   0,
+			Algos:              Algos,
+			TargetTimePerBlock: 300,
+			AveragingInterval:  10, // 50 minutes
+		},
+		{
+			Number:             1,
+			Name:               "Plan 9 from Crypto Space",
+			ActivationHeight:   plan9Height,
+			Algos:              P9Algos(),
+			TargetTimePerBlock: 36,
+			AveragingInterval:  3600,
+		},
 	}
-	sort.Sort(AlgoSlices[0])
-	sort.Sort(AlgoSlices[1])
-	D.Ln(P9AlgoVers)
-	baseVersionName := AlgoSlices[1][0].Name
-	baseVersionInterval := float64(P9Algos[baseVersionName].VersionInterval)
-	D.Ln(baseVersionName, baseVersionInterval)
-	P9Average = 0
-	for _, i := range AlgoSlices[1] {
-		vi := float64(P9Algos[i.Name].VersionInterval)
-		p9a := baseVersionInterval / vi
-		P9Average += p9a
-		// Tracef("P9Average %4.4f %4.4f %d %4.4f", p9a, P9Average, IntervalBase, vi)
+}
+
+// P9Algos returns the algorithm specifications after the hard fork, named by their version interval
+func P9Algos() (algos map[string]AlgoParams) {
+	algos = make(map[string]AlgoParams, len(P9AlgosNumeric))
+	for i := range P9AlgosNumeric {
+		algos[fmt.Sprintf("Div%d", P9AlgosNumeric[i].VersionInterval)] = P9AlgosNumeric[i]
 	}
-	D.Ln(P9Average)
-	P9Average = baseVersionInterval / P9Average
-	D.Ln(P9Average)
+	return
 }
 
 var (
-	AlgoSlices []AlgoSpecs
-	// AlgoVers is the lookup for pre hardfork
-	//
-	AlgoVers = map[int32]string{
-		2:   SHA256d,
-		514: Scrypt,
-	}
+	// MainNetPlan9Height is the height at which Plan 9 activates on the main network
+	MainNetPlan9Height int32 = 25000000
 	// Algos are the specifications identifying the algorithm used in the
-	// block proof
+	// block proof before the hard fork
 	Algos = map[string]AlgoParams{
-		AlgoVers[2]: {
+		SHA256d: {
 			Version: 2,
 			MinBits: MainPowLimitBits,
 		},
-		AlgoVers[514]: {
+		Scrypt: {
 			Version: 514,
 			MinBits: MainPowLimitBits,
 			AlgoID:  1,
@@ -153,41 +240,13 @@ var (
 		return *big.NewInt(0).SetBytes(mplb)
 	}()
 	p9PowLimitBits = bits.BigToCompact(&p9PowLimit)
-	// IsTestnet is set at startup here to be accessible to all other libraries
-	IsTestnet bool
-	// List is the list of existing hard forks and when they activate
-	List = []HardForks{
-		{
-			Number:             0,
-			Name:               "Halcyon days",
-			ActivationHeight:   0,
-			Algos:              Algos,
-			AlgoVers:           AlgoVers,
-			TargetTimePerBlock: 300,
-			AveragingInterval:  10, // 50 minutes
-			TestnetStart:       0,
-		},
-		{
-			Number:             1,
-			Name:               "Plan 9 from Crypto Space",
-			ActivationHeight:   25000000,
-			Algos:              P9Algos,
-			AlgoVers:           P9AlgoVers,
-			TargetTimePerBlock: 36,
-			AveragingInterval:  3600,
-			TestnetStart:       0,
-		},
-	}
-	// P9AlgoVers is the lookup for after 1st hardfork
-	P9AlgoVers = make(map[int32]string)
 
 	// DoublingSequence is the differential between different block versions and
 	// their relative block timing
 	DoublingSequence = []int{2, 4, 8, 16, 32, 64, 128, 256, 512}
 	IntervalDivisor  = 1
 	IntervalBase     = 9
-	// P9Algos is the algorithm specifications after the hard fork
-	P9Algos        = make(map[string]AlgoParams)
+	// P9AlgosNumeric is the algorithm specifications after the hard fork by block version
 	P9AlgosNumeric = map[int32]AlgoParams{
 		5: {
 			5, p9PowLimitBits, 0,
@@ -227,8 +286,6 @@ var (
 		}, // 23
 	}
 
-	P9Average float64
-
 	// SecondPowLimit is
 	SecondPowLimit = func() big.Int {
 		mplb, _ := hex.DecodeString(
@@ -248,19 +305,19 @@ var (
 )
 
 // GetAlgoID returns the 'algo_id' which in pre-hardfork is not the same as the block version number, but is afterwards
-func GetAlgoID(algoname string, height int32) uint32 {
-	if GetCurrent(height) > 1 {
-		return P9Algos[algoname].AlgoID
+func (s *Schedule) GetAlgoID(algoname string, height int32) uint32 {
+	if hf := s.GetCurrent(height); hf > 1 {
+		return s.List[hf].Algos[algoname].AlgoID
 	}
-	return Algos[algoname].AlgoID
+	return s.List[0].Algos[algoname].AlgoID
 }
 
 // GetAlgoName returns the string identifier of an algorithm depending on
 // hard fork activation status
-func GetAlgoName(algoVer int32, height int32) (name string) {
-	hf := GetCurrent(height)
+func (s *Schedule) GetAlgoName(algoVer int32, height int32) (name string) {
+	hf := s.GetCurrent(height)
 	var ok bool
-	name, ok = List[hf].AlgoVers[algoVer]
+	name, ok = s.List[hf].AlgoVers[algoVer]
 	if hf < 1 && !ok {
 		name = SHA256d
 	}
@@ -269,56 +326,43 @@ func GetAlgoName(algoVer int32, height int32) (name string) {
 }
 
 // GetRandomVersion returns a random version relevant to the current hard fork state and height
-func GetRandomVersion(height int32) int32 {
+func (s *Schedule) GetRandomVersion(height int32) int32 {
 	rand.Seed(time.Now().UnixNano())
-	return int32(rand.Intn(len(List[GetCurrent(height)].Algos)) + 5)
+	return int32(rand.Intn(len(s.List[s.GetCurrent(height)].Algos)) + 5)
 }
 
 // GetAlgoVer returns the version number for a given algorithm (by string name) at a given height. If "random" is given,
 // a random number is taken from the system secure random source (for randomised cpu mining)
-func GetAlgoVer(name string, height int32) (version int32) {
-	hf := GetCurrent(height)
-	n := AlgoSlices[hf][0].Name
+func (s *Schedule) GetAlgoVer(name string, height int32) (version int32) {
+	hf := s.GetCurrent(height)
+	n := s.AlgoSlices[hf][0].Name
 	// D.Ln("GetAlgoVer", name, height, hf, n)
-	if _, ok := List[hf].Algos[name]; ok {
+	if _, ok := s.List[hf].Algos[name]; ok {
 		n = name
 	}
-	version = List[hf].Algos[n].Version
+	version = s.List[hf].Algos[n].Version
 	return
 }
 
-var algoVerSlice [][]int32
-
-func GetAlgoVerSlice(height int32) (o []int32) {
-	hf := GetCurrent(height)
-	if algoVerSlice == nil {
-		algoVerSlice = make([][]int32, 0, len(List))
-		for i := range List {
-			av := make([]int32, 0, len(List[i].AlgoVers))
-			for j := range List[i].AlgoVers {
-				av = append(av, j)
-			}
-			algoVerSlice = append(algoVerSlice, av)
-		}
-	}
-	// D.S(algoVerSlice)
-	return algoVerSlice[hf]
+// GetAlgoVerSlice returns the block versions that are valid at a given height
+func (s *Schedule) GetAlgoVerSlice(height int32) (o []int32) {
+	return s.algoVerSlice[s.GetCurrent(height)]
 }
 
 // AlgoVerIterator returns a next and more function to use in a for loop to
 // iterate over block versions at current height
-func AlgoVerIterator(height int32) (
+func (s *Schedule) AlgoVerIterator(height int32) (
 	next func(), curr func() int32,
 	more func() bool,
 ) {
-	current := GetCurrent(height)
+	current := s.GetCurrent(height)
 	var cursor int32
-	length := int32(GetNumAlgos(height))
+	length := int32(s.GetNumAlgos(height))
 	var verNumbers []int32
-	for i := range List[current].AlgoVers {
+	for i := range s.List[current].AlgoVers {
 		verNumbers = append(
 			verNumbers,
-			List[current].Algos[List[current].AlgoVers[i]].Version,
+			s.List[current].Algos[s.List[current].AlgoVers[i]].Version,
 		)
 	}
 	curr = func() int32 {
@@ -337,73 +381,50 @@ func AlgoVerIterator(height int32) (
 }
 
 // GetAlgos returns the map of names and algorithm parameters
-func GetAlgos(height int32) (o map[string]AlgoParams) {
-	current := GetCurrent(height)
-	for i := range List {
-		if List[i].Number == current {
-			o = List[i].Algos
-			break
-		}
-	}
-	return
+func (s *Schedule) GetAlgos(height int32) (o map[string]AlgoParams) {
+	return s.List[s.GetCurrent(height)].Algos
 }
 
 // GetNumAlgos returns the number of algos at a given height
-func GetNumAlgos(height int32) (numAlgos int) {
-	current := GetCurrent(height)
-	for i := range List {
-		if List[i].Number == current {
-			numAlgos = len(List[i].Algos)
-			break
-		}
-	}
-	return
+func (s *Schedule) GetNumAlgos(height int32) (numAlgos int) {
+	return len(s.List[s.GetCurrent(height)].Algos)
 }
 
 // GetAveragingInterval returns the active block interval target based on hard fork status
-func GetAveragingInterval(height int32) (r int32) {
-	r = List[GetCurrent(height)].AveragingInterval
+func (s *Schedule) GetAveragingInterval(height int32) (r int32) {
+	r = s.List[s.GetCurrent(height)].AveragingInterval
 	return
 }
 
 // GetCurrent returns the hardfork number code
-func GetCurrent(height int32) (curr int) {
-	// F.Ln("istestnet", IsTestnet)
-	if IsTestnet {
-		for i := range List {
-			if height >= List[i].TestnetStart {
-				curr = i
-			}
-		}
-	} else {
-		for i := range List {
-			if height >= List[i].ActivationHeight {
-				curr = i
-			}
+func (s *Schedule) GetCurrent(height int32) (curr int) {
+	for i := range s.List {
+		if height >= s.List[i].ActivationHeight {
+			curr = i
 		}
 	}
 	return
 }
 
 // GetMinBits returns the minimum diff bits based on height and testnet
-func GetMinBits(algoname string, height int32) (mb uint32) {
-	curr := GetCurrent(height)
+func (s *Schedule) GetMinBits(algoname string, height int32) (mb uint32) {
+	curr := s.GetCurrent(height)
 	// F.Ln("GetMinBits", algoname, height, curr, List[curr].Algos)
-	mb = List[curr].Algos[algoname].MinBits
+	mb = s.List[curr].Algos[algoname].MinBits
 	// TraceF("minbits %08x, %d", mb, mb)
 	return
 }
 
 // GetMinDiff returns the minimum difficulty in uint256 form
-func GetMinDiff(algoname string, height int32) (md *big.Int) {
+func (s *Schedule) GetMinDiff(algoname string, height int32) (md *big.Int) {
 	// F.Ln("GetMinDiff", algoname)
-	minbits := GetMinBits(algoname, height)
+	minbits := s.GetMinBits(algoname, height)
 	// TraceF("mindiff minbits %08x", minbits)
 	return bits.CompactToBig(minbits)
 }
 
 // GetTargetTimePerBlock returns the active block interval target based on hard fork status
-func GetTargetTimePerBlock(height int32) (r int64) {
-	r = int64(List[GetCurrent(height)].TargetTimePerBlock)
+func (s *Schedule) GetTargetTimePerBlock(height int32) (r int64) {
+	r = int64(s.List[s.GetCurrent(height)].TargetTimePerBlock)
 	return
 }
@@ -0,0 +1,103 @@
+package fork
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestSchedules(t *testing.T) {
+	main, test := MainNetSchedule(), TestNetSchedule()
+	if main.GetCurrent(MainNetPlan9Height-1) != 0 || main.GetCurrent(MainNetPlan9Height) != 1 {
+		t.Errorf("mainnet should activate plan 9 at height %d", MainNetPlan9Height)
+	}
+	if test.GetCurrent(0) != 1 || test.GetCurrent(1) != 1 {
+		t.Errorf("test networks should be on plan 9 from the genesis block")
+	}
+	if len(test.GetAlgoVerSlice(1)) != len(P9AlgosNumeric) {
+		t.Errorf("expected %d block versions, got %d", len(P9AlgosNumeric), len(test.GetAlgoVerSlice(1)))
+	}
+	if test.GetAlgoName(5, 1) != "Div18" {
+		t.Errorf("expected version 5 to be Div18, got %s", test.GetAlgoName(5, 1))
+	}
+	if main.GetAlgoName(514, 1) != Scrypt || main.GetAlgoName(3, 1) != SHA256d {
+		t.Errorf("pre-hardfork versions should map to scrypt and sha256d")
+	}
+	if test.P9Average <= 0 || test.P9Average != main.P9Average {
+		t.Errorf("unexpected plan 9 average interval %f %f", test.P9Average, main.P9Average)
+	}
+}
+
+func TestNewScheduleInvalid(t *testing.T) {
+	list := DefaultList(100)
+	list[0].ActivationHeight = 1
+	if _, e := NewSchedule(list); e == nil {
+		t.Errorf("schedule not starting at height 0 was accepted")
+	}
+	list = DefaultList(100)
+	list[1].Number = 2
+	if _, e := NewSchedule(list); e == nil {
+		t.Errorf("schedule with misnumbered hard fork was accepted")
+	}
+	list = DefaultList(100)
+	p := list[1].Algos["Div18"]
+	p.Version = 6
+	list[1].Algos = P9Algos()
+	list[1].Algos["Div18"] = p
+	if _, e := NewSchedule(list); e == nil {
+		t.Errorf("schedule with duplicate block versions was accepted")
+	}
+	if _, e := NewSchedule(DefaultList(100)[:1]); e == nil {
+		t.Errorf("schedule without the Plan 9 hard fork was accepted")
+	}
+	if _, e := NewSchedule(nil); e == nil {
+		t.Errorf("empty schedule was accepted")
+	}
+}
+
+func TestLoadSchedule(t *testing.T) {
+	list := DefaultList(1000)
+	list[1].TargetTimePerBlock = 60
+	b, e := json.Marshal(Schedule{List: list})
+	if e != nil {
+		t.Fatal(e)
+	}
+	dir, e := ioutil.TempDir("", "forks")
+	if e != nil {
+		t.Fatal(e)
+	}
+	defer os.RemoveAll(dir)
+	filename := filepath.Join(dir, "forks.json")
+	if e = ioutil.WriteFile(filename, b, 0600); e != nil {
+		t.Fatal(e)
+	}
+	var s *Schedule
+	if s, e = LoadSchedule(filename); e != nil {
+		t.Fatal(e)
+	}
+	if s.GetCurrent(999) != 0 || s.GetCurrent(1000) != 1 {
+		t.Errorf("loaded schedule activates at the wrong height")
+	}
+	if s.GetTargetTimePerBlock(1000) != 60 {
+		t.Errorf("expected target time per block 60, got %d", s.GetTargetTimePerBlock(1000))
+	}
+	if s.GetAlgoVer("Div36", 1000) != 6 {
+		t.Errorf("expected Div36 to be version 6, got %d", s.GetAlgoVer("Div36", 1000))
+	}
+}
+
+func TestLoadScheduleOneEntry(t *testing.T) {
+	b, e := json.Marshal(Schedule{List: DefaultList(1000)[:1]})
+	if e != nil {
+		t.Fatal(e)
+	}
+	filename := filepath.Join(t.TempDir(), "forks.json")
+	if e = ioutil.WriteFile(filename, b, 0600); e != nil {
+		t.Fatal(e)
+	}
+	if _, e = LoadSchedule(filename); e == nil {
+		t.Errorf("schedule with only one hard fork was loaded")
+	}
+}
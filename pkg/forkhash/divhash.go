@@ -0,0 +1,160 @@
+package forkhash
+
+import (
+	"encoding/binary"
+	"math/big"
+	"runtime"
+	"sync"
+
+	"lukechampine.com/blake3"
+)
+
+// Hasher computes DivHash while reusing its integers and byte buffers between rounds and between calls, so after the
+// first few hashes it only allocates inside the big integer multiplication and division. A Hasher is not safe for
+// concurrent use, each goroutine hashing should have its own.
+type Hasher struct {
+	first, second, block big.Int
+	product, quotient    big.Int
+	remainder            big.Int
+	scratch              []byte
+	in, out              []byte
+}
+
+// NewHasher returns a Hasher ready for use
+func NewHasher() *Hasher {
+	return &Hasher{}
+}
+
+// grow returns b resized to n bytes, reallocating only when the capacity is too small
+func grow(b []byte, n int) []byte {
+	if cap(b) < n {
+		return make([]byte, n, n+n/4)
+	}
+	return b[:n]
+}
+
+// reverseInto writes the bytes of src in reverse order to dst, which must be at least as long as src
+func reverseInto(dst, src []byte) {
+	for i, j := 0, len(src)-1; j >= 0; i, j = i+1, j-1 {
+		dst[i] = src[j]
+	}
+}
+
+// Sum returns the DivHash of input with the given number of repetitions, being exactly the same value as the
+// original recursive definition. Unlike that one, the input is not modified.
+func (h *Hasher) Sum(input []byte, repetitions int) []byte {
+	if len(input) < 2 {
+		panic("DivHash may not be computed with less than two bytes of input")
+	}
+	h.in = grow(h.in, len(input))
+	copy(h.in, input)
+	for ; ; repetitions-- {
+		h.round()
+		if repetitions < 1 {
+			break
+		}
+		if len(h.out) < 2 {
+			panic("DivHash may not be computed with less than two bytes of input")
+		}
+		h.in, h.out = h.out, h.in
+	}
+	// After all repetitions are done, the very large bytes produced at the end are hashed and reversed.
+	sum := blake3.Sum256(h.out)
+	out := make([]byte, Len)
+	reverseInto(out, sum[:])
+	return out
+}
+
+// round performs one division step on h.in and writes the scrambled quotient to h.out. The halves and the divisor are
+// composed in the same way as the original, which reversed the halves of its input in place as it went:
+//
+//	first  = in + reverse(in[:half])
+//	second = reverse(in[:half]) + in[half:] + reverse(in[half:]), cut to the length of first
+//	block  = in[half:] + in[:half]
+func (h *Hasher) round() {
+	in := h.in
+	blockLen := len(in)
+	half := blockLen / 2
+	h.scratch = grow(h.scratch, blockLen+half)
+	s := h.scratch
+	copy(s, in)
+	reverseInto(s[blockLen:], in[:half])
+	h.first.SetBytes(s)
+	reverseInto(s, in[:half])
+	copy(s[half:], in[half:])
+	// only as much of the reversed second half as fits is appended
+	for i, j := blockLen, blockLen-1; i < len(s); i, j = i+1, j-1 {
+		s[i] = in[j]
+	}
+	h.second.SetBytes(s)
+	s = s[:blockLen]
+	copy(s, in[half:])
+	copy(s[blockLen-half:], in[:half])
+	h.block.SetBytes(s)
+	// the product of the squares of the halves is the square of their product
+	h.product.Mul(&h.first, &h.second)
+	h.product.Mul(&h.product, &h.product)
+	h.quotient.QuoRem(&h.product, &h.block, &h.remainder)
+	dl := (h.quotient.BitLen() + 7) / 8
+	// the quotient bytes are placed at the front of the output and hashed in place one 32 byte segment at a time
+	h.out = grow(h.out, dl)
+	ddd := h.quotient.FillBytes(h.out)
+	for i := 0; i < dl; i += 32 {
+		end := i + 32
+		if end > dl {
+			end = dl
+		}
+		segment := blake3.Sum256(ddd[i:end])
+		copy(ddd[i:end], segment[:])
+	}
+}
+
+var hasherPool = sync.Pool{
+	New: func() interface{} {
+		return NewHasher()
+	},
+}
+
+// Batch computes the DivHash of each of the inputs with the given repetitions using up to workers goroutines, each with
+// its own Hasher. The hashes are returned in the same order as the inputs. If workers is less than 1 the number of
+// CPUs is used.
+func Batch(inputs [][]byte, repetitions, workers int) (hashes [][]byte) {
+	hashes = make([][]byte, len(inputs))
+	if workers < 1 {
+		workers = runtime.NumCPU()
+	}
+	if workers > len(inputs) {
+		workers = len(inputs)
+	}
+	next := make(chan int, len(inputs))
+	for i := range inputs {
+		next <- i
+	}
+	close(next)
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for w := 0; w < workers; w++ {
+		go func() {
+			h := hasherPool.Get().(*Hasher)
+			for i := range next {
+				hashes[i] = h.Sum(inputs[i], repetitions)
+			}
+			hasherPool.Put(h)
+			wg.Done()
+		}()
+	}
+	wg.Wait()
+	return
+}
+
+// Nonces computes the DivHash of a serialized block header with each of the nonces written at offset as a little
+// endian uint32, as they are in a block header, concurrently using up to workers goroutines.
+func Nonces(header []byte, offset int, nonces []uint32, repetitions, workers int) (hashes [][]byte) {
+	inputs := make([][]byte, len(nonces))
+	for i := range nonces {
+		inputs[i] = make([]byte, len(header))
+		copy(inputs[i], header)
+		binary.LittleEndian.PutUint32(inputs[i][offset:], nonces[i])
+	}
+	return Batch(inputs, repetitions, workers)
+}
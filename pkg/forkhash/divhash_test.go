@@ -0,0 +1,184 @@
+package forkhash
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"math/big"
+	"math/rand"
+	"testing"
+)
+
+// reverseInPlace is the byte reversal used by the original DivHash, which modifies its argument
+func reverseInPlace(b []byte) []byte {
+	for i := 0; i < len(b)/2; i++ {
+		b[i], b[len(b)-i-1] = b[len(b)-i-1], b[i]
+	}
+	return b
+}
+
+// referenceDivHash is the original recursive implementation of DivHash, kept to check the optimised version against
+func referenceDivHash(blockBytes []byte, repetitions int) []byte {
+	if len(blockBytes) < 2 {
+		panic("DivHash may not be computed with less than two bytes of input")
+	}
+	blockLen := len(blockBytes)
+	firstHalf := make([]byte, blockLen+blockLen/2)
+	copy(firstHalf[:blockLen], blockBytes)
+	copy(firstHalf[blockLen:], reverseInPlace(blockBytes[:blockLen/2]))
+	secondHalf := make([]byte, blockLen+blockLen/2)
+	copy(secondHalf[:blockLen], blockBytes)
+	copy(secondHalf[blockLen:], reverseInPlace(blockBytes[blockLen/2:]))
+	reversedBlockInt := big.NewInt(0).SetBytes(reverseInPlace(blockBytes))
+	firstHalfInt := big.NewInt(0).SetBytes(firstHalf)
+	secondHalfInt := big.NewInt(0).SetBytes(secondHalf)
+	squareFirstHalf := firstHalfInt.Mul(firstHalfInt, firstHalfInt)
+	squareSecondHalf := secondHalfInt.Mul(secondHalfInt, secondHalfInt)
+	productOfSquares := firstHalfInt.Mul(squareFirstHalf, squareSecondHalf)
+	productDividedByBlockInt := productOfSquares.Div(productOfSquares, reversedBlockInt)
+	ddd := productDividedByBlockInt.Bytes()
+	dl := len(ddd)
+	dddLen, dddMod := dl/32, dl%32
+	if dddMod > 0 {
+		dddLen++
+	}
+	output := make([]byte, dddLen*32)
+	for i := 0; i < dddLen; i++ {
+		end := 32 * (i + 1)
+		if end > dl {
+			end = dl
+		}
+		segment := Blake3(ddd[32*i : end])
+		copy(output[32*i:32*(i+1)], segment)
+	}
+	output = output[:dl]
+	if repetitions > 0 {
+		return referenceDivHash(output, repetitions-1)
+	}
+	return reverseInPlace(Blake3(output))
+}
+
+// TestDivHashVectors checks DivHash against the golden vectors in data/divhash.json, which were generated by the
+// original implementation
+func TestDivHashVectors(t *testing.T) {
+	file, e := ioutil.ReadFile("data/divhash.json")
+	if e != nil {
+		t.Fatalf("TestDivHashVectors: %v", e)
+	}
+	var tests [][]interface{}
+	if e = json.Unmarshal(file, &tests); e != nil {
+		t.Fatalf("TestDivHashVectors couldn't unmarshal JSON: %v", e)
+	}
+	h := NewHasher()
+	for i, test := range tests {
+		if len(test) == 1 {
+			// comment
+			continue
+		}
+		if len(test) != 3 {
+			t.Fatalf("TestDivHashVectors: test #%d has wrong length", i)
+		}
+		input, e := hex.DecodeString(test[0].(string))
+		if e != nil {
+			t.Fatalf("TestDivHashVectors: test #%d input: %v", i, e)
+		}
+		reps := int(test[1].(float64))
+		expected, e := hex.DecodeString(test[2].(string))
+		if e != nil {
+			t.Fatalf("TestDivHashVectors: test #%d hash: %v", i, e)
+		}
+		orig := append([]byte{}, input...)
+		if got := h.Sum(input, reps); !bytes.Equal(got, expected) {
+			t.Errorf("TestDivHashVectors: test #%d got %x expected %x", i, got, expected)
+		}
+		if got := DivHash(input, reps); !bytes.Equal(got, expected) {
+			t.Errorf("TestDivHashVectors: test #%d DivHash got %x expected %x", i, got, expected)
+		}
+		if !bytes.Equal(input, orig) {
+			t.Errorf("TestDivHashVectors: test #%d input was modified", i)
+		}
+	}
+}
+
+// TestDivHashReference compares the optimised DivHash with the original on random inputs of many lengths
+func TestDivHashReference(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	h := NewHasher()
+	for i := 0; i < 200; i++ {
+		input := make([]byte, 2+rng.Intn(160))
+		rng.Read(input)
+		// make sure the divisor is never zero
+		input[len(input)/2] |= 1
+		reps := rng.Intn(3)
+		expected := referenceDivHash(append([]byte{}, input...), reps)
+		if got := h.Sum(input, reps); !bytes.Equal(got, expected) {
+			t.Fatalf("length %d reps %d: got %x expected %x", len(input), reps, got, expected)
+		}
+	}
+}
+
+func TestNonces(t *testing.T) {
+	header := make([]byte, 80)
+	rand.New(rand.NewSource(2)).Read(header)
+	nonces := []uint32{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+	hashes := Nonces(header, 76, nonces, 2, 4)
+	if len(hashes) != len(nonces) {
+		t.Fatalf("expected %d hashes, got %d", len(nonces), len(hashes))
+	}
+	for i := range nonces {
+		binary.LittleEndian.PutUint32(header[76:], nonces[i])
+		if expected := DivHash(header, 2); !bytes.Equal(hashes[i], expected) {
+			t.Errorf("nonce %d: got %x expected %x", nonces[i], hashes[i], expected)
+		}
+	}
+}
+
+// benchmarkHeader is a block header sized input
+var benchmarkHeader = func() []byte {
+	b := make([]byte, 80)
+	rand.New(rand.NewSource(3)).Read(b)
+	return b
+}()
+
+// Comparison of the original and optimised DivHash on a block header with 4 repetitions, as used for the proof of
+// work, and the batch hashing of nonces, which reports the time per nonce and scales with the number of CPUs:
+//
+// $ go test -run XXX -bench=Header -benchmem ./pkg/forkhash/.
+// goos: linux
+// goarch: amd64
+// pkg: github.com/cybriq/p9/pkg/forkhash
+// cpu: Intel(R) Xeon(R) Processor
+// BenchmarkReferenceDivHashHeader          8    141336937 ns/op    2600083 B/op    9823 allocs/op
+// BenchmarkDivHashHeader                   9    120662944 ns/op     580124 B/op      11 allocs/op
+// BenchmarkNoncesHeader                    9    121397553 ns/op     580240 B/op      12 allocs/op
+// PASS
+//
+// The benchmark machine had a single CPU so the batch shows no gain there.
+
+func BenchmarkReferenceDivHashHeader(b *testing.B) {
+	input := make([]byte, len(benchmarkHeader))
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		copy(input, benchmarkHeader)
+		referenceDivHash(input, 4)
+	}
+}
+
+func BenchmarkDivHashHeader(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		DivHash(benchmarkHeader, 4)
+	}
+}
+
+func BenchmarkNoncesHeader(b *testing.B) {
+	nonces := make([]uint32, b.N)
+	for i := range nonces {
+		nonces[i] = uint32(i)
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	Nonces(benchmarkHeader, 76, nonces, 4, 0)
+}
@@ -10,22 +10,35 @@ import (
 // GetCipher returns a GCM cipher given a password string. Note that this cipher must be renewed every 4gb of encrypted
 // data
 func GetCipher(password []byte) (gcm cipher.AEAD, e error) {
+	key := DeriveKey(password)
+	gcm, e = NewCipher(key)
+	for i := range key {
+		key[i] = 0
+	}
+	return
+}
+
+// DeriveKey returns the 32 byte key GetCipher derives from a password, for deriving further keys from, such as those of
+// a session
+func DeriveKey(password []byte) (key []byte) {
 	bytes := make([]byte, len(password))
-	rb := make([]byte, len(password))
 	copy(bytes, password)
-	copy(rb, password)
+	rb := reverse(bytes)
+	key = argon2.IDKey(rb, bytes, 1, 64*1024, 4, 32)
+	for i := range bytes {
+		bytes[i] = 0
+	}
+	return
+}
+
+// NewCipher returns a GCM cipher using a 32 byte key as is
+func NewCipher(key []byte) (gcm cipher.AEAD, e error) {
 	var c cipher.Block
-	rb = reverse(bytes)
-	ark := argon2.IDKey(rb, bytes, 1, 64*1024, 4, 32)
-	if c, e = aes.NewCipher(ark); E.Chk(e) {
+	if c, e = aes.NewCipher(key); E.Chk(e) {
 		return
 	}
 	if gcm, e = cipher.NewGCM(c); E.Chk(e) {
 	}
-	for i := range bytes {
-		bytes[i] = 0
-		rb[i] = 0
-	}
 	return
 }
 
@@ -0,0 +1,210 @@
+package mempool
+
+import (
+	"math"
+	"time"
+
+	"github.com/cybriq/p9/pkg/amt"
+	"github.com/cybriq/p9/pkg/chainhash"
+	"github.com/cybriq/p9/pkg/helpers"
+	"github.com/cybriq/p9/pkg/wire"
+)
+
+const (
+	// minFeeHalfLife is the time it takes the minimum fee rate for entering the pool to halve after it was raised to
+	// evict transactions, once a block has been connected. It is shorter while the pool is less than half full.
+	minFeeHalfLife = time.Hour * 12
+	// minFeeUpdateInterval is the minimum amount of time between updates of the decaying minimum fee rate.
+	minFeeUpdateInterval = time.Second * 10
+	// poolExpireScanInterval is the minimum amount of time in between scans of the main pool to evict expired
+	// transactions.
+	poolExpireScanInterval = time.Minute
+)
+
+// MinFee returns the minimum fee rate in DUO/kB a transaction must pay to be accepted into the pool, which is the
+// minimum relay fee unless transactions have recently been evicted to keep the pool within its maximum size. This
+// function is safe for concurrent access.
+func (mp *TxPool) MinFee() amt.Amount {
+	mp.mtx.Lock()
+	minFee := mp.minFee()
+	mp.mtx.Unlock()
+	if minFee < mp.cfg.Policy.MinRelayTxFee {
+		return mp.cfg.Policy.MinRelayTxFee
+	}
+	return minFee
+}
+
+// MaxSize returns the maximum total serialized size in bytes of the transactions in the main pool, or zero if there is
+// no limit. This function is safe for concurrent access.
+func (mp *TxPool) MaxSize() int64 {
+	return mp.cfg.Policy.MaxPoolSize
+}
+
+// BlockConnected lets the pool know a block has been connected to the main chain, which allows the minimum fee rate to
+// decay and evicts expired transactions. It should be called once the transactions in the block have been removed from
+// the pool. This function is safe for concurrent access.
+func (mp *TxPool) BlockConnected() {
+	mp.mtx.Lock()
+	mp.blockSinceMinFeeBump = true
+	mp.expireTransactions()
+	mp.mtx.Unlock()
+}
+
+// minFee is the internal function which implements the public MinFee, except it returns zero while the pool has not
+// had to evict transactions. This function MUST be called with the mempool lock held (for writes).
+func (mp *TxPool) minFee() amt.Amount {
+	if !mp.blockSinceMinFeeBump || mp.rollingMinFee == 0 {
+		return amt.Amount(math.Round(mp.rollingMinFee))
+	}
+	now := time.Now()
+	if elapsed := now.Sub(mp.lastMinFeeUpdate); elapsed > minFeeUpdateInterval {
+		// the fee rate falls faster the emptier the pool is so it does not stay high after a burst of transactions
+		halfLife := minFeeHalfLife
+		if mp.size < mp.cfg.Policy.MaxPoolSize/4 {
+			halfLife /= 4
+		} else if mp.size < mp.cfg.Policy.MaxPoolSize/2 {
+			halfLife /= 2
+		}
+		mp.rollingMinFee /= math.Pow(2, elapsed.Seconds()/halfLife.Seconds())
+		mp.lastMinFeeUpdate = now
+		if mp.rollingMinFee < float64(mp.cfg.Policy.MinRelayTxFee)/2 {
+			mp.rollingMinFee = 0
+			return 0
+		}
+	}
+	minFee := amt.Amount(math.Round(mp.rollingMinFee))
+	if minFee < mp.cfg.Policy.MinRelayTxFee {
+		minFee = mp.cfg.Policy.MinRelayTxFee
+	}
+	return minFee
+}
+
+// limitPoolSize evicts expired transactions, and then the transactions with the lowest fee rates until the pool is
+// within its maximum size. This function MUST be called with the mempool lock held (for writes).
+func (mp *TxPool) limitPoolSize() {
+	mp.expireTransactions()
+	if mp.cfg.Policy.MaxPoolSize <= 0 {
+		return
+	}
+	var evicted int
+	for mp.size > mp.cfg.Policy.MaxPoolSize && len(mp.evictions) > 0 {
+		// The transaction whose eviction with its descendants gives up the least fees for the space is at the top of
+		// the heap. A transaction paying a high fee rate is kept while a descendant paying less can be evicted on its
+		// own.
+		worst := mp.evictions[0]
+		worstRate := evictionFeeRate(worst)
+		// Transactions entering the pool must now pay more than the evicted ones, so they cannot be replaced by
+		// transactions paying the same fee rate over and over.
+		if minFee := worstRate + float64(mp.cfg.Policy.MinRelayTxFee); minFee > mp.rollingMinFee {
+			mp.rollingMinFee = minFee
+			mp.blockSinceMinFeeBump = false
+		}
+		before := len(mp.pool)
+		mp.removeTransaction(worst.Tx, true)
+		evicted += before - len(mp.pool)
+	}
+	if evicted > 0 {
+		D.F(
+			"evicted %d %s to keep the mempool within %d bytes (minimum fee rate: %v/kB)",
+			evicted,
+			helpers.PickNoun(evicted, "transaction", "transactions"),
+			mp.cfg.Policy.MaxPoolSize,
+			amt.Amount(math.Round(mp.rollingMinFee)),
+		)
+	}
+}
+
+// evictionFeeRate returns the fee rate in DUO/kB of a transaction and its descendants together, or of the transaction
+// alone if that is higher. This function MUST be called with the mempool lock held (for reads).
+func evictionFeeRate(txD *TxDesc) float64 {
+	rate := float64(txD.Fee) * 1000 / float64(GetTxVirtualSize(txD.Tx))
+	if packageRate := float64(txD.DescendantFees) * 1000 / float64(txD.DescendantSize); packageRate > rate {
+		return packageRate
+	}
+	return rate
+}
+
+// evictionHeap is a min-heap of the transactions in the main pool ordered by their eviction fee rate. The position of
+// each transaction is kept in it so the heap can be fixed when the descendant package of the transaction changes.
+type evictionHeap []*TxDesc
+
+// Len returns the number of transactions in the heap. It is part of the heap.Interface implementation.
+func (h evictionHeap) Len() int {
+	return len(h)
+}
+
+// Less returns whether the transaction at index i gives up less fees when evicted than the one at index j. It is part
+// of the heap.Interface implementation.
+func (h evictionHeap) Less(i, j int) bool {
+	return evictionFeeRate(h[i]) < evictionFeeRate(h[j])
+}
+
+// Swap swaps the transactions at the passed indices in the heap. It is part of the heap.Interface implementation.
+func (h evictionHeap) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+	h[i].evictIndex = i
+	h[j].evictIndex = j
+}
+
+// Push adds a transaction to the end of the heap. It is part of the heap.Interface implementation.
+func (h *evictionHeap) Push(x interface{}) {
+	txD := x.(*TxDesc)
+	txD.evictIndex = len(*h)
+	*h = append(*h, txD)
+}
+
+// Pop removes the transaction at the end of the heap. It is part of the heap.Interface implementation.
+func (h *evictionHeap) Pop() interface{} {
+	old := *h
+	n := len(old)
+	txD := old[n-1]
+	old[n-1] = nil
+	txD.evictIndex = -1
+	*h = old[:n-1]
+	return txD
+}
+
+// txDescendants adds the transactions in the main pool that spend the outputs of a transaction, and those that spend
+// theirs, to a set. This function MUST be called with the mempool lock held (for reads).
+func (mp *TxPool) txDescendants(txD *TxDesc, set map[chainhash.Hash]*TxDesc) {
+	prevOut := wire.OutPoint{Hash: *txD.Tx.Hash()}
+	for txOutIdx := range txD.Tx.MsgTx().TxOut {
+		prevOut.Index = uint32(txOutIdx)
+		redeemer, exists := mp.outpoints[prevOut]
+		if !exists {
+			continue
+		}
+		if _, found := set[*redeemer.Hash()]; found {
+			continue
+		}
+		if redeemerDesc, exists := mp.pool[*redeemer.Hash()]; exists {
+			set[*redeemer.Hash()] = redeemerDesc
+			mp.txDescendants(redeemerDesc, set)
+		}
+	}
+}
+
+// expireTransactions evicts the transactions that have been in the main pool for longer than the expiry time, along
+// with those that spend their outputs, when it is time to scan the pool. This function MUST be called with the mempool
+// lock held (for writes).
+func (mp *TxPool) expireTransactions() {
+	now := time.Now()
+	if mp.cfg.Policy.Expiry <= 0 || now.Before(mp.nextPoolExpireScan) {
+		return
+	}
+	mp.nextPoolExpireScan = now.Add(poolExpireScanInterval)
+	before := len(mp.pool)
+	for _, txD := range mp.pool {
+		if now.Sub(txD.Added) > mp.cfg.Policy.Expiry {
+			mp.removeTransaction(txD.Tx, true)
+		}
+	}
+	if expired := before - len(mp.pool); expired > 0 {
+		D.F(
+			"expired %d %s (remaining: %d)",
+			expired,
+			helpers.PickNoun(expired, "transaction", "transactions"),
+			len(mp.pool),
+		)
+	}
+}
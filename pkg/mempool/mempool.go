@@ -82,6 +82,16 @@ type Policy struct {
 	MaxSigOpCostPerTx int
 	// MinRelayTxFee defines the minimum transaction fee in DUO/kB to be considered a non-zero fee.
 	MinRelayTxFee amt.Amount
+	// MaxPoolSize is the maximum total serialized size in bytes of the transactions in the main pool. When it is
+	// exceeded the transactions paying the lowest fee rates are evicted along with those that spend their outputs, and
+	// the minimum fee rate for entering the pool is raised above theirs. Zero means there is no limit.
+	MaxPoolSize int64
+	// Expiry is how long a transaction can stay in the main pool before it is evicted. Zero means transactions never
+	// expire.
+	Expiry time.Duration
+	// RejectReplacement defines whether to reject transactions that conflict with transactions in the pool that
+	// signal replacement as described by BIP125, instead of replacing them when they pay a higher fee.
+	RejectReplacement bool
 }
 
 // Tag represents an identifier to use for tagging orphan transactions. The caller may choose any scheme it desires
@@ -93,6 +103,14 @@ type TxDesc struct {
 	mining.TxDesc
 	// StartingPriority is the priority of the transaction when it was added to the pool.
 	StartingPriority float64
+	// DescendantCount, DescendantSize and DescendantFees are the number of transactions, total virtual size and total
+	// fee of the package made up of the transaction and the transactions in the pool that spend its outputs, directly
+	// or through other transactions.
+	DescendantCount int64
+	DescendantSize  int64
+	DescendantFees  int64
+	// evictIndex is the position of the transaction in the eviction heap of the pool
+	evictIndex int
 }
 
 // TxPool is used as a source of transactions that need to be mined into blocks and relayed to other peers. It is safe
@@ -113,6 +131,21 @@ type TxPool struct {
 	// unconditional timer.
 	nextExpireScan time.Time
 	updateHook     func()
+	// size is the total serialized size of the transactions in the main pool.
+	size int64
+	// evictions holds the transactions in the main pool ordered by the fee rate evicting them gives up.
+	evictions evictionHeap
+	// rollingMinFee is the fee rate in DUO/kB that transactions must pay to enter the pool after it has been full. It
+	// is raised above the fee rate of the transactions evicted to make room, and decays once a block has been connected
+	// since it was last raised.
+	rollingMinFee        float64
+	lastMinFeeUpdate     time.Time
+	blockSinceMinFeeBump bool
+	// nextPoolExpireScan is the time after which the main pool will be scanned for expired transactions.
+	nextPoolExpireScan time.Time
+	// removed collects the transactions removed from the main pool while a replacement is being accepted, so they can
+	// be put back if the replacement is evicted to keep the pool within its maximum size.
+	removed map[chainhash.Hash]*TxDesc
 }
 
 // orphanTx is normal transaction that references an ancestor transaction that is not yet available. It also contains
@@ -153,6 +186,23 @@ func (mp *TxPool) Count() int {
 	return count
 }
 
+// Size returns the total serialized size in bytes of the transactions in the main pool. It does not include the orphan
+// pool. This function is safe for concurrent access.
+func (mp *TxPool) Size() (size int) {
+	mp.mtx.RLock()
+	size = int(mp.size)
+	mp.mtx.RUnlock()
+	return
+}
+
+// OrphanCount returns the number of transactions in the orphan pool. This function is safe for concurrent access.
+func (mp *TxPool) OrphanCount() int {
+	mp.mtx.RLock()
+	count := len(mp.orphans)
+	mp.mtx.RUnlock()
+	return count
+}
+
 // FetchTransaction returns the requested transaction from the transaction pool. This only fetches from the main
 // transaction pool and does not include orphans. This function is safe for concurrent access.
 func (mp *TxPool) FetchTransaction(txHash *chainhash.Hash) (*util.Tx, error) {
@@ -227,7 +277,10 @@ func (mp *TxPool) MiningDescs() []*mining.TxDesc {
 	descs := make([]*mining.TxDesc, len(mp.pool))
 	i := 0
 	for _, desc := range mp.pool {
-		descs[i] = &desc.TxDesc
+		// The package totals change as transactions are added and removed, so the
+		// caller gets a copy that is safe to read once the lock is released.
+		miningDesc := desc.TxDesc
+		descs[i] = &miningDesc
 		i++
 	}
 	mp.mtx.RUnlock()
@@ -299,6 +352,102 @@ func (mp *TxPool) ProcessTransaction(
 	return nil, e
 }
 
+// MempoolEntry returns the details of a transaction in the main pool as a json result. This function is safe for
+// concurrent access.
+func (mp *TxPool) MempoolEntry(hash *chainhash.Hash) (*btcjson.GetMempoolEntryResult, error) {
+	mp.mtx.RLock()
+	defer mp.mtx.RUnlock()
+	desc, exists := mp.pool[*hash]
+	if !exists {
+		return nil, fmt.Errorf("transaction is not in the pool")
+	}
+	return mp.mempoolEntry(desc, mp.cfg.BestHeight()), nil
+}
+
+// MempoolAncestors returns the details of the unconfirmed ancestors in the main pool of a transaction in the main
+// pool, keyed by their hashes. This function is safe for concurrent access.
+func (mp *TxPool) MempoolAncestors(hash *chainhash.Hash) (map[string]*btcjson.GetMempoolEntryResult, error) {
+	mp.mtx.RLock()
+	defer mp.mtx.RUnlock()
+	desc, exists := mp.pool[*hash]
+	if !exists {
+		return nil, fmt.Errorf("transaction is not in the pool")
+	}
+	ancestors := make(map[chainhash.Hash]*TxDesc)
+	mp.txAncestors(desc.Tx, ancestors)
+	return mp.mempoolEntries(ancestors), nil
+}
+
+// MempoolDescendants returns the details of the transactions in the main pool that spend the outputs of a transaction
+// in the main pool, directly or through other transactions, keyed by their hashes. This function is safe for
+// concurrent access.
+func (mp *TxPool) MempoolDescendants(hash *chainhash.Hash) (map[string]*btcjson.GetMempoolEntryResult, error) {
+	mp.mtx.RLock()
+	defer mp.mtx.RUnlock()
+	desc, exists := mp.pool[*hash]
+	if !exists {
+		return nil, fmt.Errorf("transaction is not in the pool")
+	}
+	descendants := make(map[chainhash.Hash]*TxDesc)
+	mp.txDescendants(desc, descendants)
+	return mp.mempoolEntries(descendants), nil
+}
+
+// mempoolEntries returns the details of a set of transactions in the main pool keyed by their hashes. This function
+// MUST be called with the mempool lock held (for reads).
+func (mp *TxPool) mempoolEntries(set map[chainhash.Hash]*TxDesc) map[string]*btcjson.GetMempoolEntryResult {
+	bestHeight := mp.cfg.BestHeight()
+	result := make(map[string]*btcjson.GetMempoolEntryResult, len(set))
+	for hash, desc := range set {
+		result[hash.String()] = mp.mempoolEntry(desc, bestHeight)
+	}
+	return result
+}
+
+// mempoolEntry returns the details of a transaction in the main pool as a json result. This function MUST be called
+// with the mempool lock held (for reads).
+func (mp *TxPool) mempoolEntry(desc *TxDesc, bestHeight int32) *btcjson.GetMempoolEntryResult {
+	tx := desc.Tx
+	// Use zero for the current priority if one or more of the input transactions can't be found for some reason.
+	var currentPriority float64
+	if utxos, e := mp.fetchInputUtxos(tx); e == nil {
+		currentPriority = mining.CalcPriority(tx.MsgTx(), utxos, bestHeight+1)
+	}
+	entry := &btcjson.GetMempoolEntryResult{
+		Size:             int32(tx.MsgTx().SerializeSize()),
+		VSize:            int32(GetTxVirtualSize(tx)),
+		Fee:              amt.Amount(desc.Fee).ToDUO(),
+		ModifiedFee:      amt.Amount(desc.Fee).ToDUO(),
+		Time:             desc.Added.Unix(),
+		Height:           int64(desc.Height),
+		StartingPriority: desc.StartingPriority,
+		CurrentPriority:  currentPriority,
+		DescendantCount:  desc.DescendantCount,
+		DescendantSize:   desc.DescendantSize,
+		DescendantFees:   amt.Amount(desc.DescendantFees).ToDUO(),
+		AncestorCount:    desc.AncestorCount,
+		AncestorSize:     desc.AncestorSize,
+		AncestorFees:     amt.Amount(desc.AncestorFees).ToDUO(),
+		PackageFeeRate:   amt.Amount(desc.AncestorFees * 1000 / desc.AncestorSize).ToDUO(),
+		Depends:          make([]string, 0),
+		SpentBy:          make([]string, 0),
+	}
+	for _, txIn := range tx.MsgTx().TxIn {
+		hash := &txIn.PreviousOutPoint.Hash
+		if mp.haveTransaction(hash) {
+			entry.Depends = append(entry.Depends, hash.String())
+		}
+	}
+	prevOut := wire.OutPoint{Hash: *tx.Hash()}
+	for txOutIdx := range tx.MsgTx().TxOut {
+		prevOut.Index = uint32(txOutIdx)
+		if redeemer, exists := mp.outpoints[prevOut]; exists {
+			entry.SpentBy = append(entry.SpentBy, redeemer.Hash().String())
+		}
+	}
+	return entry
+}
+
 // RawMempoolVerbose returns all of the entries in the mempool as a fully populated json result. This function is safe
 // for concurrent access.
 func (mp *TxPool) RawMempoolVerbose() map[string]*btcjson.GetRawMempoolVerboseResult {
@@ -328,6 +477,13 @@ func (mp *TxPool) RawMempoolVerbose() map[string]*btcjson.GetRawMempoolVerboseRe
 			StartingPriority: desc.StartingPriority,
 			CurrentPriority:  currentPriority,
 			Depends:          make([]string, 0),
+			AncestorCount:    desc.AncestorCount,
+			AncestorSize:     desc.AncestorSize,
+			AncestorFees:     amt.Amount(desc.AncestorFees).ToDUO(),
+			DescendantCount:  desc.DescendantCount,
+			DescendantSize:   desc.DescendantSize,
+			DescendantFees:   amt.Amount(desc.DescendantFees).ToDUO(),
+			PackageFeeRate:   amt.Amount(desc.AncestorFees * 1000 / desc.AncestorSize).ToDUO(),
 		}
 		for _, txIn := range tx.MsgTx().TxIn {
 			hash := &txIn.PreviousOutPoint.Hash
@@ -474,9 +630,11 @@ func (mp *TxPool) addTransaction(
 			height),
 	}
 	mp.pool[*tx.Hash()] = txD
+	mp.size += int64(tx.MsgTx().SerializeSize())
 	for _, txIn := range tx.MsgTx().TxIn {
 		mp.outpoints[txIn.PreviousOutPoint] = tx
 	}
+	mp.addToPackages(txD)
 	atomic.StoreInt64(&mp.lastUpdated, time.Now().Unix())
 	if mp.updateHook != nil {
 		mp.updateHook()
@@ -493,20 +651,27 @@ func (mp *TxPool) addTransaction(
 }
 
 // checkPoolDoubleSpend checks whether or not the passed transaction is attempting to spend coins already spent by other
-// transactions in the pool. Note it does not check for double spends against transactions already in the main chain.
-// This function MUST be called with the mempool lock held (for reads).
-func (mp *TxPool) checkPoolDoubleSpend(tx *util.Tx) (e error) {
+// transactions in the pool. Spending the same coins is allowed when every conflicting transaction signals that it can
+// be replaced as described by BIP125 and the replacement policy is enabled, in which case the transaction is reported
+// as a replacement and must still satisfy validateReplacement. Note it does not check for double spends against
+// transactions already in the main chain. This function MUST be called with the mempool lock held (for reads).
+func (mp *TxPool) checkPoolDoubleSpend(tx *util.Tx) (isReplacement bool, e error) {
+	cache := make(map[chainhash.Hash]bool)
 	for _, txIn := range tx.MsgTx().TxIn {
 		if txR, exists := mp.outpoints[txIn.PreviousOutPoint]; exists {
+			if !mp.cfg.Policy.RejectReplacement && mp.signalsReplacement(txR, cache) {
+				isReplacement = true
+				continue
+			}
 			str := fmt.Sprintf(
 				"output %v already spent by "+
 					"transaction %v in the memory pool",
 				txIn.PreviousOutPoint, txR.Hash(),
 			)
-			return txRuleError(wire.RejectDuplicate, str)
+			return false, txRuleError(wire.RejectDuplicate, str)
 		}
 	}
-	return nil
+	return isReplacement, nil
 }
 
 // fetchInputUtxos loads utxo details about the input transactions referenced by the passed transaction. First it loads
@@ -686,7 +851,7 @@ func (mp *TxPool) maybeAcceptTransaction(
 	// within the transaction pool itself. The transaction could still be double spending coins from the main chain at
 	// this point. There is a more in-depth check that happens later after fetching the referenced transaction inputs
 	// from the main chain which examines the actual spend data and prevents double spends.
-	e = mp.checkPoolDoubleSpend(tx)
+	isReplacement, e := mp.checkPoolDoubleSpend(tx)
 	if e != nil {
 		return nil, nil, e
 	}
@@ -814,6 +979,18 @@ func (mp *TxPool) maybeAcceptTransaction(
 		)
 		return nil, nil, txRuleError(wire.RejectInsufficientFee, str)
 	}
+	// While the pool is full, or has recently been, new transactions must pay at least the fee rate of those that
+	// were evicted to make room. Transactions from disconnected blocks are exempt from this as they were already mined.
+	if isNew {
+		if poolMinFee := calcMinRequiredTxRelayFee(serializedSize, mp.minFee()); poolMinFee > minFee &&
+			txFee < poolMinFee {
+			str := fmt.Sprintf(
+				"transaction %v has %d fees which is under the mempool minimum of %d",
+				txHash, txFee, poolMinFee,
+			)
+			return nil, nil, txRuleError(wire.RejectInsufficientFee, str)
+		}
+	}
 	// Require that free transactions have sufficient priority to be mined in the next block. Transactions which are
 	// being added back to the memory pool from blocks that have been disconnected during a reorg are exempted.
 	if isNew && !mp.cfg.Policy.DisableRelayPriority && txFee < minFee {
@@ -860,6 +1037,13 @@ func (mp *TxPool) maybeAcceptTransaction(
 			mp.cfg.Policy.FreeTxRelayLimit*10*1000,
 		)
 	}
+	// Limit the length of the chains of unconfirmed transactions that new transactions can build. Transactions from
+	// disconnected blocks are let back in regardless, as they were already mined once.
+	if isNew {
+		if e = mp.checkPackageLimits(tx); e != nil {
+			return nil, nil, e
+		}
+	}
 	// Verify crypto signatures for each input and reject the transaction if any don't verify.
 	e = blockchain.ValidateTransactionScripts(
 		b, tx, utxoView,
@@ -872,10 +1056,38 @@ func (mp *TxPool) maybeAcceptTransaction(
 		}
 		return nil, nil, e
 	}
+	// A transaction that conflicts with transactions in the pool that signal replacement must pay enough to replace
+	// them, and they are removed along with their descendants before it is added.
+	if isReplacement {
+		var evicted map[chainhash.Hash]*TxDesc
+		if evicted, e = mp.validateReplacement(tx, txFee); e != nil {
+			return nil, nil, e
+		}
+		D.F("transaction %v replaces %d transactions in the pool", txHash, len(evicted))
+		mp.removed = make(map[chainhash.Hash]*TxDesc)
+		for _, conflict := range mp.txConflicts(tx) {
+			mp.removeTransaction(conflict.Tx, true)
+		}
+	}
 	// Add to transaction pool.
 	txD := mp.addTransaction(utxoView, tx, bestHeight, txFee)
+	// Make room for the transaction if the pool is now too large, which may evict the transaction itself if it pays
+	// the lowest fee rate.
+	mp.limitPoolSize()
+	removed := mp.removed
+	mp.removed = nil
+	if !mp.isTransactionInPool(txHash) {
+		// The transactions a replacement that did not stay in the pool removed, and those evicted to make room for it,
+		// are put back so the pool is as it was before.
+		if removed != nil {
+			delete(removed, *txHash)
+			mp.restoreTransactions(removed)
+		}
+		str := fmt.Sprintf("transaction %v was not accepted because the mempool is full", txHash)
+		return nil, nil, txRuleError(wire.RejectInsufficientFee, str)
+	}
 	D.F(
-		"accepted transaction %v (pool size: %v) %s",
+		"accepted transaction %v (pool size: %v)",
 		txHash,
 		len(mp.pool),
 	)
@@ -1037,6 +1249,11 @@ func (mp *TxPool) removeTransaction(tx *util.Tx, removeRedeemers bool) {
 			delete(mp.outpoints, txIn.PreviousOutPoint)
 		}
 		delete(mp.pool, *txHash)
+		if mp.removed != nil {
+			mp.removed[*txHash] = txDesc
+		}
+		mp.size -= int64(txDesc.Tx.MsgTx().SerializeSize())
+		mp.removeFromPackages(txDesc)
 		atomic.StoreInt64(&mp.lastUpdated, time.Now().Unix())
 		if mp.updateHook != nil {
 			mp.updateHook()
@@ -1047,12 +1264,13 @@ func (mp *TxPool) removeTransaction(tx *util.Tx, removeRedeemers bool) {
 // New returns a new memory pool for validating and storing standalone transactions until they are mined into a block.
 func New(cfg *Config) *TxPool {
 	return &TxPool{
-		cfg:            *cfg,
-		pool:           make(map[chainhash.Hash]*TxDesc),
-		orphans:        make(map[chainhash.Hash]*orphanTx),
-		orphansByPrev:  make(map[wire.OutPoint]map[chainhash.Hash]*util.Tx),
-		nextExpireScan: time.Now().Add(orphanExpireScanInterval),
-		outpoints:      make(map[wire.OutPoint]*util.Tx),
-		updateHook:     cfg.UpdateHook,
+		cfg:                *cfg,
+		pool:               make(map[chainhash.Hash]*TxDesc),
+		orphans:            make(map[chainhash.Hash]*orphanTx),
+		orphansByPrev:      make(map[wire.OutPoint]map[chainhash.Hash]*util.Tx),
+		nextExpireScan:     time.Now().Add(orphanExpireScanInterval),
+		outpoints:          make(map[wire.OutPoint]*util.Tx),
+		updateHook:         cfg.UpdateHook,
+		nextPoolExpireScan: time.Now().Add(poolExpireScanInterval),
 	}
 }
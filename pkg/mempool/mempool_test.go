@@ -1,6 +1,7 @@
 package mempool
 
 import (
+	"bytes"
 	"encoding/hex"
 	"reflect"
 	"runtime"
@@ -222,6 +223,33 @@ func (p *poolHarness) CreateSignedTx(
 	return util.NewTx(tx), nil
 }
 
+// CreateFeeTx creates a new signed transaction that spends the provided input to the payment script associated with the
+// harness, less the given fee, with the given input sequence number.
+func (p *poolHarness) CreateFeeTx(input spendableOutput, fee amt.Amount, sequence uint32) (*util.Tx, error) {
+	tx := wire.NewMsgTx(wire.TxVersion)
+	tx.AddTxIn(
+		&wire.TxIn{
+			PreviousOutPoint: input.outPoint,
+			Sequence:         sequence,
+		},
+	)
+	tx.AddTxOut(
+		&wire.TxOut{
+			PkScript: p.payScript,
+			Value:    int64(input.amount - fee),
+		},
+	)
+	sigScript, e := txscript.SignatureScript(
+		tx, 0, p.payScript,
+		txscript.SigHashAll, p.signKey, true,
+	)
+	if e != nil {
+		return nil, e
+	}
+	tx.TxIn[0].SignatureScript = sigScript
+	return util.NewTx(tx), nil
+}
+
 // CreateTxChain creates a chain of zero-fee transactions (each subsequent transaction spends the entire amount from the
 // previous one) with the first one spending the provided outpoint. Each transaction spends the entire amount of the
 // previous one and as such does not include any fees.
@@ -856,3 +884,476 @@ func TestCheckSpend(t *testing.T) {
 		t.Fatalf("Unexpeced spend found in pool: %v", spend)
 	}
 }
+
+// TestPoolSizeLimit ensures that the transactions paying the lowest fee rates are evicted when the pool grows beyond its
+// maximum size, that the minimum fee rate is raised above theirs and decays after a block, and that transactions expire.
+func TestPoolSizeLimit(t *testing.T) {
+	t.Parallel()
+	harness, outputs, e := newPoolHarness(&chaincfg.MainNetParams)
+	if e != nil {
+		t.Fatalf("unable to create test pool: %v", e)
+	}
+	tc := &testContext{t, harness}
+	pool := harness.txPool
+	split, e := harness.CreateSignedTx(outputs[:1], 4)
+	if e != nil {
+		t.Fatalf("unable to create transaction: %v", e)
+	}
+	if _, e = pool.ProcessTransaction(nil, split, false, false, 0); e != nil {
+		t.Fatalf("ProcessTransaction: failed to accept tx: %v", e)
+	}
+	// Create transactions paying increasing fees on the outputs of the first one, which all have the same size.
+	var txs []*util.Tx
+	for i, fee := range []amt.Amount{1000, 5000, 20000, 30000} {
+		tx, e := harness.CreateFeeTx(txOutToSpendableOut(split, uint32(i)), fee, wire.MaxTxInSequenceNum)
+		if e != nil {
+			t.Fatalf("unable to create transaction: %v", e)
+		}
+		txs = append(txs, tx)
+	}
+	for _, tx := range txs[1:3] {
+		if _, e = pool.ProcessTransaction(nil, tx, false, false, 0); e != nil {
+			t.Fatalf("ProcessTransaction: failed to accept tx: %v", e)
+		}
+	}
+	// With room for one more transaction the one paying the lowest fee rate is evicted as soon as it is added, and the
+	// minimum fee rate is raised above it.
+	pool.cfg.Policy.MaxPoolSize = int64(pool.Size() + txs[0].MsgTx().SerializeSize() - 1)
+	if _, e = pool.ProcessTransaction(nil, txs[0], false, false, 0); e == nil {
+		t.Fatalf("ProcessTransaction: accepted a tx into a full pool")
+	}
+	testPoolMembership(tc, txs[0], false, false)
+	rate := amt.Amount(1000 * 1000 / GetTxVirtualSize(txs[0]))
+	if minFee := pool.MinFee(); minFee < rate+pool.cfg.Policy.MinRelayTxFee {
+		t.Fatalf("minimum fee rate %v was not raised above %v", minFee, rate)
+	}
+	// A transaction paying more than any other replaces the one paying the least, while the transaction it spends
+	// stays as it has more fees in the transactions spending it.
+	pool.cfg.Policy.MaxPoolSize = int64(pool.Size())
+	if _, e = pool.ProcessTransaction(nil, txs[3], false, false, 0); e != nil {
+		t.Fatalf("ProcessTransaction: failed to accept tx: %v", e)
+	}
+	testPoolMembership(tc, split, false, true)
+	testPoolMembership(tc, txs[1], false, false)
+	testPoolMembership(tc, txs[2], false, true)
+	testPoolMembership(tc, txs[3], false, true)
+	checkEvictions(t, pool)
+	// The minimum fee rate only decays once a block has been connected.
+	pool.mtx.Lock()
+	pool.lastMinFeeUpdate = time.Now().Add(-time.Hour * 24 * 30)
+	pool.mtx.Unlock()
+	if minFee := pool.MinFee(); minFee <= pool.cfg.Policy.MinRelayTxFee {
+		t.Fatalf("minimum fee rate decayed before a block was connected")
+	}
+	pool.BlockConnected()
+	if minFee := pool.MinFee(); minFee != pool.cfg.Policy.MinRelayTxFee {
+		t.Fatalf("minimum fee rate %v did not decay to the minimum relay fee", minFee)
+	}
+	// Expiring a transaction evicts the transactions spending it too.
+	pool.cfg.Policy.Expiry = time.Hour
+	pool.mtx.Lock()
+	pool.pool[*split.Hash()].Added = time.Now().Add(-time.Hour * 2)
+	pool.nextPoolExpireScan = time.Time{}
+	pool.mtx.Unlock()
+	pool.BlockConnected()
+	if pool.Count() != 0 || pool.Size() != 0 {
+		t.Fatalf("expected an empty pool, got %d transactions of %d bytes", pool.Count(), pool.Size())
+	}
+}
+
+// TestReplacement ensures that transactions signalling replacement, directly or through an unconfirmed ancestor, can be
+// replaced along with their descendants by a conflicting transaction paying enough to do so, and that other conflicting
+// transactions are rejected.
+func TestReplacement(t *testing.T) {
+	t.Parallel()
+	harness, outputs, e := newPoolHarness(&chaincfg.MainNetParams)
+	if e != nil {
+		t.Fatalf("unable to create test pool: %v", e)
+	}
+	tc := &testContext{t, harness}
+	pool := harness.txPool
+	split, e := harness.CreateSignedTx(outputs[:1], 3)
+	if e != nil {
+		t.Fatalf("unable to create transaction: %v", e)
+	}
+	if _, e = pool.ProcessTransaction(nil, split, false, false, 0); e != nil {
+		t.Fatalf("ProcessTransaction: failed to accept tx: %v", e)
+	}
+	createTx := func(input spendableOutput, fee amt.Amount, sequence uint32) *util.Tx {
+		tx, e := harness.CreateFeeTx(input, fee, sequence)
+		if e != nil {
+			t.Fatalf("unable to create transaction: %v", e)
+		}
+		return tx
+	}
+	accept := func(tx *util.Tx) {
+		if _, e := pool.ProcessTransaction(nil, tx, false, false, 0); e != nil {
+			t.Fatalf("ProcessTransaction: failed to accept tx: %v", e)
+		}
+	}
+	reject := func(tx *util.Tx, code wire.RejectCode) {
+		_, e := pool.ProcessTransaction(nil, tx, false, false, 0)
+		if e == nil {
+			t.Fatalf("ProcessTransaction: accepted tx %v", tx.Hash())
+		}
+		if gotCode, _ := extractRejectCode(e); gotCode != code {
+			t.Fatalf("ProcessTransaction: expected reject code %v, got %v: %v", code, gotCode, e)
+		}
+		testPoolMembership(tc, tx, false, false)
+	}
+	// A transaction that does not signal replacement cannot be replaced.
+	final := createTx(txOutToSpendableOut(split, 0), 1000, wire.MaxTxInSequenceNum)
+	accept(final)
+	reject(createTx(txOutToSpendableOut(split, 0), 50000, wire.MaxTxInSequenceNum), wire.RejectDuplicate)
+	// A signalling transaction and the one spending it can only be replaced by one paying more than both.
+	replaceable := createTx(txOutToSpendableOut(split, 1), 1000, wire.MaxReplaceableSequenceNum)
+	accept(replaceable)
+	child := createTx(txOutToSpendableOut(replaceable, 0), 1000, wire.MaxTxInSequenceNum)
+	accept(child)
+	reject(
+		createTx(txOutToSpendableOut(split, 1), 1500, wire.MaxTxInSequenceNum),
+		wire.RejectInsufficientFee,
+	)
+	replacement := createTx(txOutToSpendableOut(split, 1), 50000, wire.MaxTxInSequenceNum)
+	accept(replacement)
+	testPoolMembership(tc, replaceable, false, false)
+	testPoolMembership(tc, child, false, false)
+	testPoolMembership(tc, replacement, false, true)
+	// A transaction inherits the signal from its unconfirmed ancestors.
+	parent := createTx(txOutToSpendableOut(split, 2), 1000, wire.MaxReplaceableSequenceNum)
+	accept(parent)
+	child = createTx(txOutToSpendableOut(parent, 0), 1000, wire.MaxTxInSequenceNum)
+	accept(child)
+	replacement = createTx(txOutToSpendableOut(parent, 0), 50000, wire.MaxTxInSequenceNum)
+	accept(replacement)
+	testPoolMembership(tc, parent, false, true)
+	testPoolMembership(tc, child, false, false)
+	// No transaction can be replaced when replacement is disabled.
+	pool.cfg.Policy.RejectReplacement = true
+	reject(createTx(txOutToSpendableOut(split, 2), 90000, wire.MaxTxInSequenceNum), wire.RejectDuplicate)
+	testPoolMembership(tc, parent, false, true)
+}
+
+// TestReplacementEvicted ensures that when a replacement is evicted to keep a full pool within its maximum size, the
+// transactions it replaced are put back into the pool as they were.
+func TestReplacementEvicted(t *testing.T) {
+	t.Parallel()
+	harness, outputs, e := newPoolHarness(&chaincfg.MainNetParams)
+	if e != nil {
+		t.Fatalf("unable to create test pool: %v", e)
+	}
+	tc := &testContext{t, harness}
+	pool := harness.txPool
+	split, e := harness.CreateSignedTx(outputs[:1], 2)
+	if e != nil {
+		t.Fatalf("unable to create transaction: %v", e)
+	}
+	var txs []*util.Tx
+	for _, tx := range []struct {
+		input    spendableOutput
+		fee      amt.Amount
+		sequence uint32
+	}{
+		{txOutToSpendableOut(split, 0), 30000, wire.MaxTxInSequenceNum},
+		{txOutToSpendableOut(split, 1), 1000, wire.MaxReplaceableSequenceNum},
+	} {
+		created, e := harness.CreateFeeTx(tx.input, tx.fee, tx.sequence)
+		if e != nil {
+			t.Fatalf("unable to create transaction: %v", e)
+		}
+		txs = append(txs, created)
+	}
+	child, e := harness.CreateFeeTx(txOutToSpendableOut(txs[1], 0), 1000, wire.MaxTxInSequenceNum)
+	if e != nil {
+		t.Fatalf("unable to create transaction: %v", e)
+	}
+	for _, tx := range append([]*util.Tx{split}, append(txs, child)...) {
+		if _, e = pool.ProcessTransaction(nil, tx, false, false, 0); e != nil {
+			t.Fatalf("ProcessTransaction: failed to accept tx: %v", e)
+		}
+	}
+	// The replacement pays more than the transactions it replaces but less than the rest of the pool, and is larger
+	// than them, so the full pool evicts it as soon as it is added.
+	input := txOutToSpendableOut(split, 1)
+	msgTx := wire.NewMsgTx(wire.TxVersion)
+	msgTx.AddTxIn(&wire.TxIn{PreviousOutPoint: input.outPoint, Sequence: wire.MaxTxInSequenceNum})
+	const numOutputs = 10
+	for i := 0; i < numOutputs; i++ {
+		msgTx.AddTxOut(&wire.TxOut{PkScript: harness.payScript, Value: int64(input.amount-10000) / numOutputs})
+	}
+	if msgTx.TxIn[0].SignatureScript, e = txscript.SignatureScript(
+		msgTx, 0, harness.payScript, txscript.SigHashAll, harness.signKey, true,
+	); e != nil {
+		t.Fatalf("unable to sign transaction: %v", e)
+	}
+	replacement := util.NewTx(msgTx)
+	size, count := pool.Size(), pool.Count()
+	pool.cfg.Policy.MaxPoolSize = int64(size)
+	_, e = pool.ProcessTransaction(nil, replacement, false, false, 0)
+	if code, _ := extractRejectCode(e); code != wire.RejectInsufficientFee {
+		t.Fatalf("ProcessTransaction: expected the replacement to be rejected as the pool is full, got %v", e)
+	}
+	testPoolMembership(tc, replacement, false, false)
+	for _, tx := range append([]*util.Tx{split}, append(txs, child)...) {
+		testPoolMembership(tc, tx, false, true)
+	}
+	if pool.Size() != size || pool.Count() != count {
+		t.Fatalf(
+			"expected %d transactions of %d bytes, got %d of %d bytes", count, size, pool.Count(), pool.Size(),
+		)
+	}
+	pool.mtx.RLock()
+	splitDesc := pool.pool[*split.Hash()]
+	if splitDesc.DescendantCount != 4 || splitDesc.DescendantFees != 32000 {
+		t.Errorf(
+			"expected the restored descendant package of 4 transactions paying 32000, got %d paying %d",
+			splitDesc.DescendantCount, splitDesc.DescendantFees,
+		)
+	}
+	pool.mtx.RUnlock()
+	checkEvictions(t, pool)
+	// The transactions that were put back are in the pool, and the minimum fee rate is raised above the replacement.
+	if _, e = pool.ProcessTransaction(nil, child, false, false, 0); e == nil {
+		t.Fatalf("ProcessTransaction: accepted a transaction that was put back again")
+	}
+	if minFee, rate := pool.MinFee(), amt.Amount(10000*1000/GetTxVirtualSize(replacement)); minFee <= rate {
+		t.Fatalf("minimum fee rate %v was not raised above %v", minFee, rate)
+	}
+}
+
+// TestPackages ensures that the ancestor and descendant packages of the transactions in the pool are tracked as
+// transactions are added and removed, and that chains of unconfirmed transactions are limited in length.
+func TestPackages(t *testing.T) {
+	t.Parallel()
+	harness, outputs, e := newPoolHarness(&chaincfg.MainNetParams)
+	if e != nil {
+		t.Fatalf("unable to create test pool: %v", e)
+	}
+	tc := &testContext{t, harness}
+	pool := harness.txPool
+	split, e := harness.CreateSignedTx(outputs[:1], 2)
+	if e != nil {
+		t.Fatalf("unable to create transaction: %v", e)
+	}
+	// The split transaction is mined so that it does not count towards the chain built on it.
+	harness.chain.utxos.AddTxOuts(split, harness.chain.BestHeight()+1)
+	chainedTxns, e := harness.CreateTxChain(txOutToSpendableOut(split, 0), MaxAncestorCount+1)
+	if e != nil {
+		t.Fatalf("unable to create transaction chain: %v", e)
+	}
+	for _, tx := range chainedTxns[:MaxAncestorCount] {
+		if _, e = pool.ProcessTransaction(nil, tx, false, false, 0); e != nil {
+			t.Fatalf("ProcessTransaction: failed to accept tx: %v", e)
+		}
+	}
+	// The transaction that would have one ancestor too many is rejected.
+	tooLong := chainedTxns[MaxAncestorCount]
+	_, e = pool.ProcessTransaction(nil, tooLong, false, false, 0)
+	if code, _ := extractRejectCode(e); code != wire.RejectNonstandard {
+		t.Fatalf("ProcessTransaction: expected reject code %v, got %v: %v", wire.RejectNonstandard, code, e)
+	}
+	testPoolMembership(tc, tooLong, false, false)
+	first := pool.pool[*chainedTxns[0].Hash()]
+	last := pool.pool[*chainedTxns[MaxAncestorCount-1].Hash()]
+	if first.DescendantCount != MaxAncestorCount || first.AncestorCount != 1 {
+		t.Fatalf(
+			"first transaction has %d descendants and %d ancestors, want %d and 1",
+			first.DescendantCount, first.AncestorCount, MaxAncestorCount,
+		)
+	}
+	if last.AncestorCount != MaxAncestorCount || last.DescendantCount != 1 {
+		t.Fatalf(
+			"last transaction has %d ancestors and %d descendants, want %d and 1",
+			last.AncestorCount, last.DescendantCount, MaxAncestorCount,
+		)
+	}
+	// Once the first transaction is mined the chain can be extended again.
+	pool.RemoveTransaction(chainedTxns[0], false)
+	if last.AncestorCount != MaxAncestorCount-1 {
+		t.Fatalf("last transaction has %d ancestors, want %d", last.AncestorCount, MaxAncestorCount-1)
+	}
+	if _, e = pool.ProcessTransaction(nil, tooLong, false, false, 0); e != nil {
+		t.Fatalf("ProcessTransaction: failed to accept tx: %v", e)
+	}
+	// A child paying a high fee raises the package fee rate of its parent.
+	parent, e := harness.CreateFeeTx(txOutToSpendableOut(split, 1), 1000, wire.MaxTxInSequenceNum)
+	if e != nil {
+		t.Fatalf("unable to create transaction: %v", e)
+	}
+	child, e := harness.CreateFeeTx(txOutToSpendableOut(parent, 0), 20000, wire.MaxTxInSequenceNum)
+	if e != nil {
+		t.Fatalf("unable to create transaction: %v", e)
+	}
+	for _, tx := range []*util.Tx{parent, child} {
+		if _, e = pool.ProcessTransaction(nil, tx, false, false, 0); e != nil {
+			t.Fatalf("ProcessTransaction: failed to accept tx: %v", e)
+		}
+	}
+	checkEvictions(t, pool)
+	parentDesc, childDesc := pool.pool[*parent.Hash()], pool.pool[*child.Hash()]
+	if parentDesc.DescendantFees != 21000 || childDesc.AncestorFees != 21000 {
+		t.Fatalf(
+			"parent has descendant fees of %d and child ancestor fees of %d, want 21000",
+			parentDesc.DescendantFees, childDesc.AncestorFees,
+		)
+	}
+	wantSize := GetTxVirtualSize(parent) + GetTxVirtualSize(child)
+	if childDesc.AncestorSize != wantSize || parentDesc.DescendantSize != wantSize {
+		t.Fatalf(
+			"child has ancestor size %d and parent descendant size %d, want %d",
+			childDesc.AncestorSize, parentDesc.DescendantSize, wantSize,
+		)
+	}
+	// The relatives of the transactions are reported along with their details.
+	entry, e := pool.MempoolEntry(parent.Hash())
+	if e != nil {
+		t.Fatalf("MempoolEntry: %v", e)
+	}
+	if len(entry.SpentBy) != 1 || entry.SpentBy[0] != child.Hash().String() || entry.DescendantCount != 2 {
+		t.Fatalf("parent entry is spent by %v with %d descendants", entry.SpentBy, entry.DescendantCount)
+	}
+	ancestors, e := pool.MempoolAncestors(child.Hash())
+	if e != nil {
+		t.Fatalf("MempoolAncestors: %v", e)
+	}
+	if _, found := ancestors[parent.Hash().String()]; !found || len(ancestors) != 1 {
+		t.Fatalf("child has ancestors %v, want only the parent", ancestors)
+	}
+	descendants, e := pool.MempoolDescendants(parent.Hash())
+	if e != nil {
+		t.Fatalf("MempoolDescendants: %v", e)
+	}
+	if _, found := descendants[child.Hash().String()]; !found || len(descendants) != 1 {
+		t.Fatalf("parent has descendants %v, want only the child", descendants)
+	}
+	// Removing the child along with the parent leaves no trace in the packages of the rest of the pool.
+	pool.RemoveTransaction(parent, true)
+	testPoolMembership(tc, child, false, false)
+	if _, e = pool.MempoolEntry(child.Hash()); e == nil {
+		t.Fatalf("MempoolEntry: found removed transaction %v", child.Hash())
+	}
+	if remaining := pool.pool[*chainedTxns[1].Hash()]; remaining.DescendantCount != MaxAncestorCount {
+		t.Fatalf("first remaining transaction has %d descendants, want %d", remaining.DescendantCount, MaxAncestorCount)
+	}
+	checkEvictions(t, pool)
+}
+
+// TestEvictionOrder ensures that a transaction paying a low fee is kept when a transaction spending it raises the fee
+// rate of their package above that of another transaction, which is evicted instead.
+func TestEvictionOrder(t *testing.T) {
+	t.Parallel()
+	harness, outputs, e := newPoolHarness(&chaincfg.MainNetParams)
+	if e != nil {
+		t.Fatalf("unable to create test pool: %v", e)
+	}
+	tc := &testContext{t, harness}
+	pool := harness.txPool
+	split, e := harness.CreateSignedTx(outputs[:1], 2)
+	if e != nil {
+		t.Fatalf("unable to create transaction: %v", e)
+	}
+	harness.chain.utxos.AddTxOuts(split, harness.chain.BestHeight()+1)
+	parent, e := harness.CreateFeeTx(txOutToSpendableOut(split, 0), 1000, wire.MaxTxInSequenceNum)
+	if e != nil {
+		t.Fatalf("unable to create transaction: %v", e)
+	}
+	other, e := harness.CreateFeeTx(txOutToSpendableOut(split, 1), 5000, wire.MaxTxInSequenceNum)
+	if e != nil {
+		t.Fatalf("unable to create transaction: %v", e)
+	}
+	child, e := harness.CreateFeeTx(txOutToSpendableOut(parent, 0), 50000, wire.MaxTxInSequenceNum)
+	if e != nil {
+		t.Fatalf("unable to create transaction: %v", e)
+	}
+	for _, tx := range []*util.Tx{parent, other} {
+		if _, e = pool.ProcessTransaction(nil, tx, false, false, 0); e != nil {
+			t.Fatalf("ProcessTransaction: failed to accept tx: %v", e)
+		}
+	}
+	if top := pool.evictions[0].Tx; top != parent {
+		t.Fatalf("expected %v to be evicted first, got %v", parent.Hash(), top.Hash())
+	}
+	if _, e = pool.ProcessTransaction(nil, child, false, false, 0); e != nil {
+		t.Fatalf("ProcessTransaction: failed to accept tx: %v", e)
+	}
+	checkEvictions(t, pool)
+	if top := pool.evictions[0].Tx; top != other {
+		t.Fatalf("expected %v to be evicted first, got %v", other.Hash(), top.Hash())
+	}
+	pool.mtx.Lock()
+	pool.cfg.Policy.MaxPoolSize = pool.size - 1
+	pool.limitPoolSize()
+	pool.mtx.Unlock()
+	testPoolMembership(tc, other, false, false)
+	testPoolMembership(tc, parent, false, true)
+	testPoolMembership(tc, child, false, true)
+	checkEvictions(t, pool)
+}
+
+// checkEvictions ensures the eviction heap of a pool holds every transaction in the main pool at the position it has
+// recorded, and that no transaction gives up less fees when evicted than the one above it.
+func checkEvictions(t *testing.T, pool *TxPool) {
+	t.Helper()
+	pool.mtx.RLock()
+	defer pool.mtx.RUnlock()
+	if len(pool.evictions) != len(pool.pool) {
+		t.Fatalf("eviction heap has %d transactions, the pool has %d", len(pool.evictions), len(pool.pool))
+	}
+	for i, txD := range pool.evictions {
+		if pool.pool[*txD.Tx.Hash()] != txD || txD.evictIndex != i {
+			t.Fatalf("transaction %v at %d of the eviction heap is not in the pool at that position", txD.Tx.Hash(), i)
+		}
+		if parent := (i - 1) / 2; i > 0 && pool.evictions.Less(i, parent) {
+			t.Fatalf("transaction %v is evicted after the one above it in the eviction heap", txD.Tx.Hash())
+		}
+	}
+}
+
+// TestSaveLoad ensures that the transactions saved from a pool are loaded back into another with the times they were
+// added, regardless of the order they are loaded in, and that those already in the pool are reported.
+func TestSaveLoad(t *testing.T) {
+	t.Parallel()
+	harness, outputs, e := newPoolHarness(&chaincfg.MainNetParams)
+	if e != nil {
+		t.Fatalf("unable to create test pool: %v", e)
+	}
+	chainedTxns, e := harness.CreateTxChain(outputs[0], 3)
+	if e != nil {
+		t.Fatalf("unable to create transaction chain: %v", e)
+	}
+	for _, tx := range chainedTxns {
+		if _, e = harness.txPool.ProcessTransaction(nil, tx, false, false, 0); e != nil {
+			t.Fatalf("ProcessTransaction: failed to accept tx: %v", e)
+		}
+	}
+	// Make the last transaction in the chain look like it was added first, so it is saved before its parents.
+	harness.txPool.mtx.Lock()
+	harness.txPool.pool[*chainedTxns[2].Hash()].Added = time.Now().Add(-time.Hour)
+	harness.txPool.mtx.Unlock()
+	var buf bytes.Buffer
+	count, e := harness.txPool.Save(&buf)
+	if e != nil || count != len(chainedTxns) {
+		t.Fatalf("Save: saved %d transactions: %v", count, e)
+	}
+	saved := buf.Bytes()
+	pool := New(&harness.txPool.cfg)
+	accepted, present, failed, e := pool.Load(nil, bytes.NewReader(saved))
+	if e != nil {
+		t.Fatalf("Load: %v", e)
+	}
+	if len(accepted) != len(chainedTxns) || present != 0 || failed != 0 {
+		t.Fatalf("Load: accepted %d, present %d, failed %d", len(accepted), present, failed)
+	}
+	for _, tx := range chainedTxns {
+		want := harness.txPool.pool[*tx.Hash()].Added
+		if got := pool.pool[*tx.Hash()].Added; !got.Equal(want) {
+			t.Fatalf("transaction %v was added at %v, want %v", tx.Hash(), got, want)
+		}
+	}
+	if accepted, present, failed, e = pool.Load(nil, bytes.NewReader(saved)); e != nil ||
+		len(accepted) != 0 || present != len(chainedTxns) || failed != 0 {
+		t.Fatalf(
+			"Load: accepted %d, present %d, failed %d: %v", len(accepted), present, failed, e,
+		)
+	}
+}
@@ -0,0 +1,177 @@
+package mempool
+
+import (
+	"container/heap"
+	"fmt"
+
+	"github.com/cybriq/p9/pkg/chainhash"
+	"github.com/cybriq/p9/pkg/util"
+	"github.com/cybriq/p9/pkg/wire"
+)
+
+const (
+	// MaxAncestorCount is the maximum number of transactions in the pool, counting the transaction itself, in the
+	// package made up of a new transaction and its unconfirmed ancestors.
+	MaxAncestorCount = 25
+	// MaxAncestorSize is the maximum total virtual size of the ancestor package of a new transaction.
+	MaxAncestorSize = 101000
+	// MaxDescendantCount is the maximum number of transactions in the pool, counting the transaction itself, in the
+	// package made up of a transaction and its descendants.
+	MaxDescendantCount = 25
+	// MaxDescendantSize is the maximum total virtual size of the descendant package of a transaction in the pool.
+	MaxDescendantSize = 101000
+)
+
+// txAncestors adds the transactions in the main pool whose outputs a transaction spends, and those whose outputs they
+// spend, to a set. This function MUST be called with the mempool lock held (for reads).
+func (mp *TxPool) txAncestors(tx *util.Tx, set map[chainhash.Hash]*TxDesc) {
+	for _, txIn := range tx.MsgTx().TxIn {
+		parentHash := txIn.PreviousOutPoint.Hash
+		if _, found := set[parentHash]; found {
+			continue
+		}
+		if parentDesc, exists := mp.pool[parentHash]; exists {
+			set[parentHash] = parentDesc
+			mp.txAncestors(parentDesc.Tx, set)
+		}
+	}
+}
+
+// packageTotals returns the number of transactions, total virtual size and total fee of a transaction together with a
+// set of its ancestors or descendants.
+func packageTotals(txD *TxDesc, set map[chainhash.Hash]*TxDesc) (count, size, fees int64) {
+	count, size, fees = 1, GetTxVirtualSize(txD.Tx), txD.Fee
+	for _, relative := range set {
+		count++
+		size += GetTxVirtualSize(relative.Tx)
+		fees += relative.Fee
+	}
+	return
+}
+
+// checkPackageLimits checks that accepting a transaction would not make its ancestor package, or the descendant
+// package of any of its ancestors, exceed the maximum count and size, which bounds the work done to track packages
+// and keeps chains of unconfirmed transactions from growing without limit. This function MUST be called with the
+// mempool lock held (for reads).
+func (mp *TxPool) checkPackageLimits(tx *util.Tx) (e error) {
+	ancestors := make(map[chainhash.Hash]*TxDesc)
+	mp.txAncestors(tx, ancestors)
+	size := GetTxVirtualSize(tx)
+	count, ancestorSize := int64(len(ancestors))+1, size
+	for _, ancestor := range ancestors {
+		ancestorSize += GetTxVirtualSize(ancestor.Tx)
+	}
+	if count > MaxAncestorCount {
+		str := fmt.Sprintf(
+			"transaction %v has %d unconfirmed ancestors which is more than the maximum of %d",
+			tx.Hash(), count-1, MaxAncestorCount-1,
+		)
+		return txRuleError(wire.RejectNonstandard, str)
+	}
+	if ancestorSize > MaxAncestorSize {
+		str := fmt.Sprintf(
+			"transaction %v and its unconfirmed ancestors have a size of %d which is more than the maximum of %d",
+			tx.Hash(), ancestorSize, MaxAncestorSize,
+		)
+		return txRuleError(wire.RejectNonstandard, str)
+	}
+	for hash, ancestor := range ancestors {
+		if ancestor.DescendantCount+1 > MaxDescendantCount {
+			str := fmt.Sprintf(
+				"transaction %v would give ancestor %v more than the maximum of %d descendants",
+				tx.Hash(), hash, MaxDescendantCount-1,
+			)
+			return txRuleError(wire.RejectNonstandard, str)
+		}
+		if ancestor.DescendantSize+size > MaxDescendantSize {
+			str := fmt.Sprintf(
+				"transaction %v would make the descendants of ancestor %v larger than the maximum of %d",
+				tx.Hash(), hash, MaxDescendantSize,
+			)
+			return txRuleError(wire.RejectNonstandard, str)
+		}
+	}
+	return nil
+}
+
+// addToPackages sets the ancestor and descendant package totals of a transaction that has just been added to the pool
+// and updates those of its ancestors and descendants, along with the eviction order. Descendants can already be in the
+// pool when transactions from a disconnected block are added back, in which case the totals of the relatives are
+// recalculated, as they may have been related through other transactions before. This function MUST be called with the
+// mempool lock held (for writes).
+func (mp *TxPool) addToPackages(txD *TxDesc) {
+	ancestors := make(map[chainhash.Hash]*TxDesc)
+	mp.txAncestors(txD.Tx, ancestors)
+	descendants := make(map[chainhash.Hash]*TxDesc)
+	mp.txDescendants(txD, descendants)
+	txD.AncestorCount, txD.AncestorSize, txD.AncestorFees = packageTotals(txD, ancestors)
+	txD.DescendantCount, txD.DescendantSize, txD.DescendantFees = packageTotals(txD, descendants)
+	heap.Push(&mp.evictions, txD)
+	size := GetTxVirtualSize(txD.Tx)
+	for _, ancestor := range ancestors {
+		if len(descendants) > 0 {
+			mp.updateDescendantPackage(ancestor)
+		} else {
+			ancestor.DescendantCount++
+			ancestor.DescendantSize += size
+			ancestor.DescendantFees += txD.Fee
+		}
+		heap.Fix(&mp.evictions, ancestor.evictIndex)
+	}
+	for _, descendant := range descendants {
+		if len(ancestors) > 0 {
+			mp.updateAncestorPackage(descendant)
+			continue
+		}
+		descendant.AncestorCount++
+		descendant.AncestorSize += size
+		descendant.AncestorFees += txD.Fee
+	}
+}
+
+// removeFromPackages updates the package totals of the ancestors and descendants of a transaction that has just been
+// removed from the pool, along with the eviction order. This function MUST be called with the mempool lock held (for
+// writes).
+func (mp *TxPool) removeFromPackages(txD *TxDesc) {
+	ancestors := make(map[chainhash.Hash]*TxDesc)
+	mp.txAncestors(txD.Tx, ancestors)
+	descendants := make(map[chainhash.Hash]*TxDesc)
+	mp.txDescendants(txD, descendants)
+	heap.Remove(&mp.evictions, txD.evictIndex)
+	size := GetTxVirtualSize(txD.Tx)
+	for _, ancestor := range ancestors {
+		if len(descendants) > 0 {
+			mp.updateDescendantPackage(ancestor)
+		} else {
+			ancestor.DescendantCount--
+			ancestor.DescendantSize -= size
+			ancestor.DescendantFees -= txD.Fee
+		}
+		heap.Fix(&mp.evictions, ancestor.evictIndex)
+	}
+	for _, descendant := range descendants {
+		if len(ancestors) > 0 {
+			mp.updateAncestorPackage(descendant)
+			continue
+		}
+		descendant.AncestorCount--
+		descendant.AncestorSize -= size
+		descendant.AncestorFees -= txD.Fee
+	}
+}
+
+// updateAncestorPackage recalculates the ancestor package totals of a transaction in the pool. This function MUST be
+// called with the mempool lock held (for writes).
+func (mp *TxPool) updateAncestorPackage(txD *TxDesc) {
+	ancestors := make(map[chainhash.Hash]*TxDesc)
+	mp.txAncestors(txD.Tx, ancestors)
+	txD.AncestorCount, txD.AncestorSize, txD.AncestorFees = packageTotals(txD, ancestors)
+}
+
+// updateDescendantPackage recalculates the descendant package totals of a transaction in the pool. This function MUST
+// be called with the mempool lock held (for writes).
+func (mp *TxPool) updateDescendantPackage(txD *TxDesc) {
+	descendants := make(map[chainhash.Hash]*TxDesc)
+	mp.txDescendants(txD, descendants)
+	txD.DescendantCount, txD.DescendantSize, txD.DescendantFees = packageTotals(txD, descendants)
+}
@@ -0,0 +1,137 @@
+package mempool
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"sort"
+	"time"
+
+	"github.com/cybriq/p9/pkg/blockchain"
+	"github.com/cybriq/p9/pkg/util"
+	"github.com/cybriq/p9/pkg/wire"
+)
+
+// In case the format the pool is saved in changes, a version number is written first. A saved pool of another version
+// is not loaded.
+const poolSaveVersion = 1
+
+// savedTx is a transaction read back from a saved pool along with the time it was added to the pool and its fee.
+type savedTx struct {
+	tx    *util.Tx
+	added time.Time
+	fee   int64
+}
+
+// Save writes the transactions in the main pool, with the time each was added and its fee, so they can be loaded back
+// into the pool after a restart. It returns the number of transactions written. This function is safe for concurrent
+// access.
+func (mp *TxPool) Save(w io.Writer) (count int, e error) {
+	txDescs := mp.TxDescs()
+	// the parents of transactions were added before them, so writing them in that order means few of the
+	// transactions are orphans when they are loaded
+	sort.Slice(
+		txDescs, func(i, j int) bool {
+			return txDescs[i].Added.Before(txDescs[j].Added)
+		},
+	)
+	if e = binary.Write(w, binary.BigEndian, uint32(poolSaveVersion)); E.Chk(e) {
+		return
+	}
+	if e = binary.Write(w, binary.BigEndian, uint64(len(txDescs))); E.Chk(e) {
+		return
+	}
+	for _, txD := range txDescs {
+		if e = binary.Write(w, binary.BigEndian, txD.Added.UnixNano()); E.Chk(e) {
+			return
+		}
+		if e = binary.Write(w, binary.BigEndian, txD.Fee); E.Chk(e) {
+			return
+		}
+		if e = txD.Tx.MsgTx().Serialize(w); E.Chk(e) {
+			return
+		}
+		count++
+	}
+	return
+}
+
+// Load reads transactions written by Save and validates them again, adding those that are still valid to the main
+// pool with the time they were originally added. Transactions paying the highest fee rates are tried first, so they are
+// the ones kept if the pool is now smaller. It returns the accepted transactions along with the numbers of transactions
+// that were already in the pool and that failed validation or had expired. This function is safe for concurrent
+// access.
+func (mp *TxPool) Load(b *blockchain.BlockChain, r io.Reader) (
+	accepted []*TxDesc, present, failed int, e error,
+) {
+	var version uint32
+	if e = binary.Read(r, binary.BigEndian, &version); E.Chk(e) {
+		return
+	}
+	if version != poolSaveVersion {
+		e = fmt.Errorf("incorrect version: expected %d found %d", poolSaveVersion, version)
+		return
+	}
+	var count uint64
+	if e = binary.Read(r, binary.BigEndian, &count); E.Chk(e) {
+		return
+	}
+	var saved []savedTx
+	for i := uint64(0); i < count; i++ {
+		var added, fee int64
+		if e = binary.Read(r, binary.BigEndian, &added); E.Chk(e) {
+			return
+		}
+		if e = binary.Read(r, binary.BigEndian, &fee); E.Chk(e) {
+			return
+		}
+		msgTx := &wire.MsgTx{}
+		if e = msgTx.Deserialize(r); E.Chk(e) {
+			return
+		}
+		saved = append(saved, savedTx{tx: util.NewTx(msgTx), added: time.Unix(0, added), fee: fee})
+	}
+	sort.SliceStable(
+		saved, func(i, j int) bool {
+			return saved[i].fee*GetTxVirtualSize(saved[j].tx) > saved[j].fee*GetTxVirtualSize(saved[i].tx)
+		},
+	)
+	mp.mtx.Lock()
+	defer mp.mtx.Unlock()
+	now := time.Now()
+	var pending []savedTx
+	for _, st := range saved {
+		if mp.cfg.Policy.Expiry > 0 && now.Sub(st.added) > mp.cfg.Policy.Expiry {
+			failed++
+			continue
+		}
+		pending = append(pending, st)
+	}
+	// transactions whose parents have not been accepted yet are tried again until no more are accepted
+	for len(pending) > 0 {
+		var orphans []savedTx
+		for _, st := range pending {
+			if mp.isTransactionInPool(st.tx.Hash()) {
+				present++
+				continue
+			}
+			missing, txD, ee := mp.maybeAcceptTransaction(b, st.tx, true, false, true)
+			switch {
+			case ee != nil:
+				D.Ln("saved transaction", st.tx.Hash(), "was not accepted:", ee)
+				failed++
+			case len(missing) > 0:
+				orphans = append(orphans, st)
+			default:
+				txD.Added = st.added
+				accepted = append(accepted, txD)
+			}
+		}
+		if len(orphans) == len(pending) {
+			failed += len(orphans)
+			break
+		}
+		pending = orphans
+	}
+	return
+}
@@ -0,0 +1,173 @@
+package mempool
+
+import (
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/cybriq/p9/pkg/chainhash"
+	"github.com/cybriq/p9/pkg/util"
+	"github.com/cybriq/p9/pkg/wire"
+)
+
+// MaxReplacementEvictions is the maximum number of transactions, counting the descendants of the conflicting
+// transactions, that can be evicted from the pool by a single replacement transaction.
+const MaxReplacementEvictions = 100
+
+// signalsReplacement returns whether a transaction in the pool, or one that is being accepted, has opted in to being
+// replaced as described by BIP125. A transaction signals replacement if any of its inputs has a sequence number no
+// greater than wire.MaxReplaceableSequenceNum, and inherits the signal from any of its unconfirmed ancestors. The cache
+// holds the results for ancestors that have already been visited and may be nil. This function MUST be called with the
+// mempool lock held (for reads).
+func (mp *TxPool) signalsReplacement(tx *util.Tx, cache map[chainhash.Hash]bool) bool {
+	if cache == nil {
+		cache = make(map[chainhash.Hash]bool)
+	}
+	if signals, found := cache[*tx.Hash()]; found {
+		return signals
+	}
+	for _, txIn := range tx.MsgTx().TxIn {
+		if txIn.Sequence <= wire.MaxReplaceableSequenceNum {
+			cache[*tx.Hash()] = true
+			return true
+		}
+	}
+	// Mark the transaction before visiting its ancestors so that a transaction with several paths to the same ancestor
+	// is only examined once.
+	cache[*tx.Hash()] = false
+	for _, txIn := range tx.MsgTx().TxIn {
+		parent, exists := mp.pool[txIn.PreviousOutPoint.Hash]
+		if !exists {
+			continue
+		}
+		if mp.signalsReplacement(parent.Tx, cache) {
+			cache[*tx.Hash()] = true
+			return true
+		}
+	}
+	return false
+}
+
+// txConflicts returns the transactions in the main pool that spend any of the same outputs as the passed transaction.
+// This function MUST be called with the mempool lock held (for reads).
+func (mp *TxPool) txConflicts(tx *util.Tx) map[chainhash.Hash]*TxDesc {
+	conflicts := make(map[chainhash.Hash]*TxDesc)
+	for _, txIn := range tx.MsgTx().TxIn {
+		conflict, exists := mp.outpoints[txIn.PreviousOutPoint]
+		if !exists {
+			continue
+		}
+		if conflictDesc, exists := mp.pool[*conflict.Hash()]; exists {
+			conflicts[*conflict.Hash()] = conflictDesc
+		}
+	}
+	return conflicts
+}
+
+// validateReplacement checks whether a transaction that conflicts with transactions in the pool that signal
+// replacement satisfies the replacement rules of BIP125, and returns the set of transactions it would evict, which is
+// the conflicting transactions and all of their descendants. The replacement must not evict more than
+// MaxReplacementEvictions transactions, may only spend unconfirmed outputs that one of the conflicting transactions
+// also spends, must pay a higher fee rate than each transaction it evicts, and must pay at least their total fee plus
+// the minimum relay fee for its own size. This function MUST be called with the mempool lock held (for reads).
+func (mp *TxPool) validateReplacement(tx *util.Tx, txFee int64) (map[chainhash.Hash]*TxDesc, error) {
+	txHash := tx.Hash()
+	conflicts := mp.txConflicts(tx)
+	evicted := make(map[chainhash.Hash]*TxDesc, len(conflicts))
+	for hash, conflict := range conflicts {
+		evicted[hash] = conflict
+		mp.txDescendants(conflict, evicted)
+		if len(evicted) > MaxReplacementEvictions {
+			str := fmt.Sprintf(
+				"replacement transaction %v evicts more transactions than the maximum of %d",
+				txHash, MaxReplacementEvictions,
+			)
+			return nil, txRuleError(wire.RejectNonstandard, str)
+		}
+	}
+	// The replacement may not spend the outputs of a transaction it would evict, and may only spend unconfirmed
+	// outputs of transactions that one of the transactions it replaces also spends from, so that it cannot lower the
+	// fee rate of the package that gets mined by pulling in new low fee parents.
+	conflictParents := make(map[chainhash.Hash]struct{})
+	for _, conflict := range conflicts {
+		for _, txIn := range conflict.Tx.MsgTx().TxIn {
+			conflictParents[txIn.PreviousOutPoint.Hash] = struct{}{}
+		}
+	}
+	for _, txIn := range tx.MsgTx().TxIn {
+		parentHash := txIn.PreviousOutPoint.Hash
+		if _, exists := evicted[parentHash]; exists {
+			str := fmt.Sprintf(
+				"replacement transaction %v spends transaction %v that it would replace",
+				txHash, parentHash,
+			)
+			return nil, txRuleError(wire.RejectInvalid, str)
+		}
+		if _, exists := mp.pool[parentHash]; !exists {
+			continue
+		}
+		if _, exists := conflictParents[parentHash]; !exists {
+			str := fmt.Sprintf(
+				"replacement transaction %v spends new unconfirmed input %v",
+				txHash, txIn.PreviousOutPoint,
+			)
+			return nil, txRuleError(wire.RejectNonstandard, str)
+		}
+	}
+	serializedSize := GetTxVirtualSize(tx)
+	txFeeRate := float64(txFee) * 1000 / float64(serializedSize)
+	var evictedFees int64
+	for hash, txD := range evicted {
+		feeRate := float64(txD.Fee) * 1000 / float64(GetTxVirtualSize(txD.Tx))
+		if txFeeRate <= feeRate {
+			str := fmt.Sprintf(
+				"replacement transaction %v has a fee rate of %.0f which is not higher than the %.0f of "+
+					"transaction %v it would replace",
+				txHash, txFeeRate, feeRate, hash,
+			)
+			return nil, txRuleError(wire.RejectInsufficientFee, str)
+		}
+		evictedFees += txD.Fee
+	}
+	if minFee := evictedFees + calcMinRequiredTxRelayFee(
+		serializedSize, mp.cfg.Policy.MinRelayTxFee,
+	); txFee < minFee {
+		str := fmt.Sprintf(
+			"replacement transaction %v has %d fees which is under the required amount of %d",
+			txHash, txFee, minFee,
+		)
+		return nil, txRuleError(wire.RejectInsufficientFee, str)
+	}
+	return evicted, nil
+}
+
+// restoreTransactions puts transactions that were removed from the main pool back into it as they were, each after the
+// transactions in the set it spends. This function MUST be called with the mempool lock held (for writes).
+func (mp *TxPool) restoreTransactions(removed map[chainhash.Hash]*TxDesc) {
+	for len(removed) > 0 {
+	next:
+		for hash, txD := range removed {
+			for _, txIn := range txD.Tx.MsgTx().TxIn {
+				if _, waiting := removed[txIn.PreviousOutPoint.Hash]; waiting {
+					continue next
+				}
+			}
+			delete(removed, hash)
+			mp.pool[hash] = txD
+			mp.size += int64(txD.Tx.MsgTx().SerializeSize())
+			for _, txIn := range txD.Tx.MsgTx().TxIn {
+				mp.outpoints[txIn.PreviousOutPoint] = txD.Tx
+			}
+			mp.addToPackages(txD)
+			if mp.cfg.AddrIndex != nil {
+				if utxoView, e := mp.fetchInputUtxos(txD.Tx); !E.Chk(e) {
+					mp.cfg.AddrIndex.AddUnconfirmedTx(txD.Tx, utxoView)
+				}
+			}
+		}
+	}
+	atomic.StoreInt64(&mp.lastUpdated, time.Now().Unix())
+	if mp.updateHook != nil {
+		mp.updateHook()
+	}
+}
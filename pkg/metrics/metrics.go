@@ -0,0 +1,274 @@
+// Package metrics keeps counters, gauges and histograms of the operation of the node, wallet and miner and exposes them
+// over HTTP in the Prometheus text exposition format.
+package metrics
+
+import (
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"go.uber.org/atomic"
+)
+
+// The kinds of metric in the exposition format
+const (
+	KindCounter   = "counter"
+	KindGauge     = "gauge"
+	KindHistogram = "histogram"
+)
+
+// Collector is a metric that can be registered for exposition
+type Collector interface {
+	// Describe returns the name, help text and kind of the metric
+	Describe() (name, help, kind string)
+	// Collect calls emit for each sample of the metric. The suffix is appended to the name of the metric, and the
+	// labels are pairs of label names and values.
+	Collect(emit func(suffix string, labels []string, value float64))
+}
+
+type desc struct {
+	name, help, kind string
+}
+
+func (d desc) Describe() (name, help, kind string) {
+	return d.name, d.help, d.kind
+}
+
+// Counter is a value that only goes up
+type Counter struct {
+	desc
+	v atomic.Uint64
+}
+
+// NewCounter creates a counter
+func NewCounter(name, help string) *Counter {
+	return &Counter{desc: desc{name, help, KindCounter}}
+}
+
+// Inc adds one to the counter
+func (c *Counter) Inc() {
+	c.v.Inc()
+}
+
+// Add adds n to the counter
+func (c *Counter) Add(n uint64) {
+	c.v.Add(n)
+}
+
+// Value returns the current count
+func (c *Counter) Value() uint64 {
+	return c.v.Load()
+}
+
+func (c *Counter) Collect(emit func(suffix string, labels []string, value float64)) {
+	emit("", nil, float64(c.v.Load()))
+}
+
+// Gauge is a value that can go up and down
+type Gauge struct {
+	desc
+	v atomic.Float64
+}
+
+// NewGauge creates a gauge
+func NewGauge(name, help string) *Gauge {
+	return &Gauge{desc: desc{name, help, KindGauge}}
+}
+
+// Set the value of the gauge
+func (g *Gauge) Set(v float64) {
+	g.v.Store(v)
+}
+
+// Value returns the current value of the gauge
+func (g *Gauge) Value() float64 {
+	return g.v.Load()
+}
+
+func (g *Gauge) Collect(emit func(suffix string, labels []string, value float64)) {
+	emit("", nil, g.v.Load())
+}
+
+// Func is a metric whose value is read when it is collected, for values that are already kept elsewhere
+type Func struct {
+	desc
+	fn func() float64
+}
+
+// NewGaugeFunc creates a gauge that calls fn for its value
+func NewGaugeFunc(name, help string, fn func() float64) *Func {
+	return &Func{desc{name, help, KindGauge}, fn}
+}
+
+// NewCounterFunc creates a counter that calls fn for its value, which must only go up
+func NewCounterFunc(name, help string, fn func() float64) *Func {
+	return &Func{desc{name, help, KindCounter}, fn}
+}
+
+func (f *Func) Collect(emit func(suffix string, labels []string, value float64)) {
+	emit("", nil, f.fn())
+}
+
+// VecFunc is a metric with one label whose values are read when it is collected
+type VecFunc struct {
+	desc
+	label string
+	fn    func() map[string]float64
+}
+
+// NewGaugeVecFunc creates a gauge that calls fn for its value for each value of the label
+func NewGaugeVecFunc(name, help, label string, fn func() map[string]float64) *VecFunc {
+	return &VecFunc{desc{name, help, KindGauge}, label, fn}
+}
+
+// NewCounterVecFunc creates a counter that calls fn for its value for each value of the label
+func NewCounterVecFunc(name, help, label string, fn func() map[string]float64) *VecFunc {
+	return &VecFunc{desc{name, help, KindCounter}, label, fn}
+}
+
+func (v *VecFunc) Collect(emit func(suffix string, labels []string, value float64)) {
+	values := v.fn()
+	for _, lv := range sortedKeys(values) {
+		emit("", []string{v.label, lv}, values[lv])
+	}
+}
+
+// CounterVec is a set of counters distinguished by the value of a label
+type CounterVec struct {
+	desc
+	label    string
+	mx       sync.Mutex
+	counters map[string]*atomic.Uint64
+}
+
+// NewCounterVec creates a counter with one label
+func NewCounterVec(name, help, label string) *CounterVec {
+	return &CounterVec{
+		desc:     desc{name, help, KindCounter},
+		label:    label,
+		counters: make(map[string]*atomic.Uint64),
+	}
+}
+
+// Inc adds one to the counter for a label value
+func (c *CounterVec) Inc(labelValue string) {
+	c.Add(labelValue, 1)
+}
+
+// Add adds n to the counter for a label value
+func (c *CounterVec) Add(labelValue string, n uint64) {
+	c.mx.Lock()
+	v, ok := c.counters[labelValue]
+	if !ok {
+		v = atomic.NewUint64(0)
+		c.counters[labelValue] = v
+	}
+	c.mx.Unlock()
+	v.Add(n)
+}
+
+// Value returns the count for a label value
+func (c *CounterVec) Value(labelValue string) uint64 {
+	c.mx.Lock()
+	defer c.mx.Unlock()
+	if v, ok := c.counters[labelValue]; ok {
+		return v.Load()
+	}
+	return 0
+}
+
+func (c *CounterVec) Collect(emit func(suffix string, labels []string, value float64)) {
+	c.mx.Lock()
+	values := make(map[string]float64, len(c.counters))
+	for lv, v := range c.counters {
+		values[lv] = float64(v.Load())
+	}
+	c.mx.Unlock()
+	for _, lv := range sortedKeys(values) {
+		emit("", []string{c.label, lv}, values[lv])
+	}
+}
+
+// DurationBuckets are the upper bounds in seconds of the histogram buckets for the time taken by requests
+var DurationBuckets = []float64{.001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10}
+
+type histogram struct {
+	counts []uint64
+	count  uint64
+	sum    float64
+}
+
+// HistogramVec is a set of histograms distinguished by the value of a label
+type HistogramVec struct {
+	desc
+	label      string
+	buckets    []float64
+	mx         sync.Mutex
+	histograms map[string]*histogram
+}
+
+// NewHistogramVec creates a histogram with one label and the given bucket upper bounds, which must be in increasing
+// order
+func NewHistogramVec(name, help, label string, buckets []float64) *HistogramVec {
+	return &HistogramVec{
+		desc:       desc{name, help, KindHistogram},
+		label:      label,
+		buckets:    buckets,
+		histograms: make(map[string]*histogram),
+	}
+}
+
+// Observe adds a value to the histogram for a label value
+func (h *HistogramVec) Observe(labelValue string, v float64) {
+	h.mx.Lock()
+	defer h.mx.Unlock()
+	hg, ok := h.histograms[labelValue]
+	if !ok {
+		hg = &histogram{counts: make([]uint64, len(h.buckets))}
+		h.histograms[labelValue] = hg
+	}
+	// only the first bucket the value fits in is counted here, the counts are accumulated when collected
+	if i := sort.SearchFloat64s(h.buckets, v); i < len(h.buckets) {
+		hg.counts[i]++
+	}
+	hg.count++
+	hg.sum += v
+}
+
+// Since adds the seconds since start to the histogram for a label value
+func (h *HistogramVec) Since(labelValue string, start time.Time) {
+	h.Observe(labelValue, time.Since(start).Seconds())
+}
+
+func (h *HistogramVec) Collect(emit func(suffix string, labels []string, value float64)) {
+	h.mx.Lock()
+	histograms := make(map[string]histogram, len(h.histograms))
+	labelValues := make([]string, 0, len(h.histograms))
+	for lv, hg := range h.histograms {
+		histograms[lv] = histogram{append([]uint64(nil), hg.counts...), hg.count, hg.sum}
+		labelValues = append(labelValues, lv)
+	}
+	h.mx.Unlock()
+	sort.Strings(labelValues)
+	for _, lv := range labelValues {
+		hg := histograms[lv]
+		var cumulative uint64
+		for i, le := range h.buckets {
+			cumulative += hg.counts[i]
+			emit("_bucket", []string{h.label, lv, "le", formatFloat(le)}, float64(cumulative))
+		}
+		emit("_bucket", []string{h.label, lv, "le", formatFloat(math.Inf(1))}, float64(hg.count))
+		emit("_sum", []string{h.label, lv}, hg.sum)
+		emit("_count", []string{h.label, lv}, float64(hg.count))
+	}
+}
+
+func sortedKeys(m map[string]float64) (keys []string) {
+	keys = make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return
+}
@@ -0,0 +1,112 @@
+package metrics
+
+import (
+	"bytes"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/cybriq/p9/pkg/qu"
+)
+
+func TestExposition(t *testing.T) {
+	r := NewRegistry()
+	c := NewCounter("test_events_total", "events\nseen")
+	c.Add(3)
+	c.Inc()
+	g := NewGauge("test_height", "height")
+	g.Set(1.5)
+	cv := NewCounterVec("test_results_total", "results", "result")
+	cv.Inc("accepted")
+	cv.Inc(`re"jected`)
+	cv.Inc("accepted")
+	h := NewHistogramVec("test_duration_seconds", "duration", "method", []float64{.1, 1})
+	h.Observe("a", .05)
+	h.Observe("a", .5)
+	h.Observe("a", 5)
+	vf := NewGaugeVecFunc(
+		"test_rate", "rate", "thread", func() map[string]float64 {
+			return map[string]float64{"1": 20, "0": 10}
+		},
+	)
+	r.Register(c, g, cv, h, vf, NewGaugeFunc("test_func", "func", func() float64 { return 7 }))
+	var buf bytes.Buffer
+	if _, e := r.WriteTo(&buf); e != nil {
+		t.Fatal(e)
+	}
+	expected := `# HELP test_duration_seconds duration
+# TYPE test_duration_seconds histogram
+test_duration_seconds_bucket{method="a",le="0.1"} 1
+test_duration_seconds_bucket{method="a",le="1"} 2
+test_duration_seconds_bucket{method="a",le="+Inf"} 3
+test_duration_seconds_sum{method="a"} 5.55
+test_duration_seconds_count{method="a"} 3
+# HELP test_events_total events\nseen
+# TYPE test_events_total counter
+test_events_total 4
+# HELP test_func func
+# TYPE test_func gauge
+test_func 7
+# HELP test_height height
+# TYPE test_height gauge
+test_height 1.5
+# HELP test_rate rate
+# TYPE test_rate gauge
+test_rate{thread="0"} 10
+test_rate{thread="1"} 20
+# HELP test_results_total results
+# TYPE test_results_total counter
+test_results_total{result="accepted"} 2
+test_results_total{result="re\"jected"} 1
+`
+	if buf.String() != expected {
+		t.Fatalf("unexpected exposition:\n%s", buf.String())
+	}
+	// registering a metric with the same name replaces the old one
+	r.Register(NewGaugeFunc("test_func", "func", func() float64 { return 8 }))
+	r.Unregister("test_height")
+	buf.Reset()
+	if _, e := r.WriteTo(&buf); e != nil {
+		t.Fatal(e)
+	}
+	if !strings.Contains(buf.String(), "test_func 8\n") || strings.Contains(buf.String(), "test_height") {
+		t.Fatalf("metrics were not replaced and removed:\n%s", buf.String())
+	}
+}
+
+func TestServe(t *testing.T) {
+	ln, e := net.Listen("tcp", "127.0.0.1:0")
+	if e != nil {
+		t.Fatal(e)
+	}
+	addr := ln.Addr().String()
+	if e = ln.Close(); e != nil {
+		t.Fatal(e)
+	}
+	r := NewRegistry()
+	c := NewCounter("test_events_total", "events")
+	c.Inc()
+	r.Register(c)
+	quit := qu.T()
+	defer quit.Q()
+	if e = Serve(r, []string{addr}, quit); e != nil {
+		t.Fatal(e)
+	}
+	var resp *http.Response
+	if resp, e = http.Get("http://" + addr + "/metrics"); e != nil {
+		t.Fatal(e)
+	}
+	defer resp.Body.Close()
+	if resp.Header.Get("Content-Type") != ContentType {
+		t.Errorf("unexpected content type %s", resp.Header.Get("Content-Type"))
+	}
+	var body []byte
+	if body, e = io.ReadAll(resp.Body); e != nil {
+		t.Fatal(e)
+	}
+	if !strings.Contains(string(body), "test_events_total 1\n") {
+		t.Fatalf("metric missing from response:\n%s", body)
+	}
+}
@@ -0,0 +1,152 @@
+package metrics
+
+import (
+	"bufio"
+	"io"
+	"net"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cybriq/p9/pkg/qu"
+)
+
+// ContentType is the content type of the text exposition format
+const ContentType = "text/plain; version=0.0.4; charset=utf-8"
+
+// Registry is a set of metrics that are exposed together
+type Registry struct {
+	mx         sync.Mutex
+	collectors map[string]Collector
+}
+
+// NewRegistry creates an empty registry
+func NewRegistry() *Registry {
+	return &Registry{collectors: make(map[string]Collector)}
+}
+
+// Register adds metrics to the registry, replacing any already registered with the same name
+func (r *Registry) Register(collectors ...Collector) {
+	r.mx.Lock()
+	defer r.mx.Unlock()
+	for _, c := range collectors {
+		name, _, _ := c.Describe()
+		r.collectors[name] = c
+	}
+}
+
+// Unregister removes the metric with a name from the registry
+func (r *Registry) Unregister(name string) {
+	r.mx.Lock()
+	delete(r.collectors, name)
+	r.mx.Unlock()
+}
+
+// WriteTo writes the metrics of the registry ordered by name in the text exposition format
+func (r *Registry) WriteTo(w io.Writer) (n int64, e error) {
+	r.mx.Lock()
+	names := make([]string, 0, len(r.collectors))
+	collectors := make(map[string]Collector, len(r.collectors))
+	for name, c := range r.collectors {
+		names = append(names, name)
+		collectors[name] = c
+	}
+	r.mx.Unlock()
+	sort.Strings(names)
+	cw := &countWriter{w: bufio.NewWriter(w)}
+	for _, name := range names {
+		_, help, kind := collectors[name].Describe()
+		cw.write("# HELP ", name, " ", helpEscaper.Replace(help), "\n")
+		cw.write("# TYPE ", name, " ", kind, "\n")
+		collectors[name].Collect(
+			func(suffix string, labels []string, value float64) {
+				cw.write(name, suffix)
+				for i := 0; i+1 < len(labels); i += 2 {
+					sep := ","
+					if i == 0 {
+						sep = "{"
+					}
+					cw.write(sep, labels[i], `="`, labelEscaper.Replace(labels[i+1]), `"`)
+				}
+				if len(labels) > 1 {
+					cw.write("}")
+				}
+				cw.write(" ", formatFloat(value), "\n")
+			},
+		)
+	}
+	if cw.e == nil {
+		cw.e = cw.w.Flush()
+	}
+	return cw.n, cw.e
+}
+
+// ServeHTTP writes the metrics of the registry in response to a request
+func (r *Registry) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	w.Header().Set("Content-Type", ContentType)
+	if _, e := r.WriteTo(w); D.Chk(e) {
+	}
+}
+
+// Serve exposes the metrics of the registry at /metrics on each of the listeners until quit is closed
+func Serve(r *Registry, listeners []string, quit qu.C) (e error) {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", r)
+	var lns []net.Listener
+	for _, addr := range listeners {
+		var ln net.Listener
+		if ln, e = net.Listen("tcp", addr); E.Chk(e) {
+			for i := range lns {
+				if e := lns[i].Close(); E.Chk(e) {
+				}
+			}
+			return
+		}
+		lns = append(lns, ln)
+	}
+	for i := range lns {
+		server := &http.Server{Handler: mux, ReadHeaderTimeout: time.Second * 10}
+		I.Ln("metrics server listening on", lns[i].Addr())
+		go func(ln net.Listener) {
+			if e := server.Serve(ln); e != http.ErrServerClosed {
+				E.Ln("metrics server stopped:", e)
+			}
+		}(lns[i])
+		go func() {
+			<-quit.Wait()
+			if e := server.Close(); E.Chk(e) {
+			}
+		}()
+	}
+	return
+}
+
+var (
+	helpEscaper  = strings.NewReplacer(`\`, `\\`, "\n", `\n`)
+	labelEscaper = strings.NewReplacer(`\`, `\\`, "\n", `\n`, `"`, `\"`)
+)
+
+func formatFloat(v float64) string {
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
+
+// countWriter writes strings to a buffer until the first error, counting the bytes written
+type countWriter struct {
+	w *bufio.Writer
+	n int64
+	e error
+}
+
+func (c *countWriter) write(s ...string) {
+	for i := range s {
+		if c.e != nil {
+			return
+		}
+		var n int
+		n, c.e = c.w.WriteString(s[i])
+		c.n += int64(n)
+	}
+}
@@ -11,7 +11,6 @@ import (
 	block2 "github.com/cybriq/p9/pkg/block"
 	"github.com/cybriq/p9/pkg/btcaddr"
 	"github.com/cybriq/p9/pkg/chaincfg"
-	"github.com/cybriq/p9/pkg/fork"
 
 	"github.com/cybriq/p9/pkg/blockchain"
 	"github.com/cybriq/p9/pkg/chainhash"
@@ -46,6 +45,13 @@ type (
 		Fee int64
 		// FeePerKB is the fee the transaction pays in Satoshi per 1000 bytes.
 		FeePerKB int64
+		// AncestorCount is the number of transactions in the package made up of the
+		// transaction and its unconfirmed ancestors in the source pool.
+		AncestorCount int64
+		// AncestorSize is the total virtual size of the ancestor package.
+		AncestorSize int64
+		// AncestorFees is the total fee paid by the ancestor package.
+		AncestorFees int64
 	}
 	// TxSource represents a source of transactions to consider for inclusion in new
 	// blocks. The interface contract requires that all of these methods are safe
@@ -69,6 +75,10 @@ type (
 		fee      int64
 		priority float64
 		feePerKB int64
+		// packageFeePerKB is the highest fee rate of the ancestor packages the
+		// transaction is part of, which is the rate it is sorted by so that a child
+		// paying a high fee pulls its parents into the block with it.
+		packageFeePerKB int64
 		// dependsOn holds a map of transaction hashes which this one depends on.
 		//
 		// It will only be set when the transaction references other transactions in the
@@ -191,10 +201,10 @@ func txPQByPriority(pq *txPriorityQueue, i, j int) bool {
 func txPQByFee(pq *txPriorityQueue, i, j int) bool {
 	// Using > here so that pop gives the highest fee item as opposed to the lowest.
 	// Sort by fee first, then priority.
-	if pq.items[i].feePerKB == pq.items[j].feePerKB {
+	if pq.items[i].packageFeePerKB == pq.items[j].packageFeePerKB {
 		return pq.items[i].priority > pq.items[j].priority
 	}
-	return pq.items[i].feePerKB > pq.items[j].feePerKB
+	return pq.items[i].packageFeePerKB > pq.items[j].packageFeePerKB
 }
 
 // newTxPriorityQueue returns a new transaction priority queue that reserves the
@@ -316,6 +326,35 @@ func spendTransaction(
 	return nil
 }
 
+// raisePackageFeePerKB raises the package fee rate of the ancestors of a
+// transaction that are being considered for the block to the passed fee rate of
+// its ancestor package where it is higher than their own.
+func raisePackageFeePerKB(
+	prioItems map[chainhash.Hash]*txPrioItem, prioItem *txPrioItem,
+	feePerKB int64,
+) {
+	visited := make(map[chainhash.Hash]struct{})
+	pending := []*txPrioItem{prioItem}
+	for len(pending) > 0 {
+		item := pending[len(pending)-1]
+		pending = pending[:len(pending)-1]
+		for originHash := range item.dependsOn {
+			if _, seen := visited[originHash]; seen {
+				continue
+			}
+			visited[originHash] = struct{}{}
+			origin, exists := prioItems[originHash]
+			if !exists {
+				continue
+			}
+			if origin.packageFeePerKB < feePerKB {
+				origin.packageFeePerKB = feePerKB
+			}
+			pending = append(pending, origin)
+		}
+	}
+}
+
 // logSkippedDeps logs any dependencies which are also skipped as a result of
 // skipping a transaction while generating a block template at the trace level.
 func logSkippedDeps(tx *util.Tx, deps map[chainhash.Hash]*txPrioItem) {
@@ -454,8 +493,8 @@ func (g *BlkTmplGenerator) NewBlockTemplate(
 	best := g.Chain.BestSnapshot()
 	nextBlockHeight := best.Height + 1
 	// sanitise the version number
-	vers := fork.GetAlgoVer(algo, nextBlockHeight)
-	algo = fork.GetAlgoName(vers, nextBlockHeight)
+	vers := g.ChainParams.Forks.GetAlgoVer(algo, nextBlockHeight)
+	algo = g.ChainParams.Forks.GetAlgoName(vers, nextBlockHeight)
 	// Create a standard coinbase transaction paying to the provided address.
 	//
 	// NOTE: The coinbase value will be updated to include the fees from the
@@ -503,6 +542,10 @@ func (g *BlkTmplGenerator) NewBlockTemplate(
 	// transactions are now eligible for inclusion in the block once each
 	// transaction has been included.
 	dependers := make(map[chainhash.Hash]map[chainhash.Hash]*txPrioItem)
+	// prioItems holds the items for all of the transactions being considered, so
+	// that the fee rate of a package can be passed on to the ancestors it depends
+	// on.
+	prioItems := make(map[chainhash.Hash]*txPrioItem, len(sourceTxns))
 	// Create slices to hold the fees and number of signature operations for each of
 	// the selected transactions and add an entry for the coinbase. This allows the
 	// code below to simply append details about a transaction as it is selected for
@@ -600,17 +643,35 @@ mempoolLoop:
 		)
 		// Calculate the fee in Satoshi/kB.
 		prioItem.feePerKB = txDesc.FeePerKB
+		prioItem.packageFeePerKB = txDesc.FeePerKB
 		prioItem.fee = txDesc.Fee
-		// Add the transaction to the priority queue to mark it ready for inclusion in
-		// the block unless it has dependencies.
-		if prioItem.dependsOn == nil {
-			heap.Push(priorityQueue, prioItem)
-		}
+		prioItems[*tx.Hash()] = prioItem
 		// Merge the referenced outputs from the input transactions to this transaction
 		// into the block utxo view. This allows the code below to avoid a second
 		// lookup.
 		mergeUtxoView(blockUtxos, utxos)
 	}
+	// A transaction can only be mined along with its unconfirmed ancestors, so the
+	// ancestors are sorted by the fee rate of the whole package when it is higher
+	// than their own. This lets a child paying a high fee get a low fee parent mined
+	// sooner.
+	for _, txDesc := range sourceTxns {
+		prioItem, exists := prioItems[*txDesc.Tx.Hash()]
+		if !exists || prioItem.dependsOn == nil || txDesc.AncestorSize <= 0 {
+			continue
+		}
+		raisePackageFeePerKB(
+			prioItems, prioItem,
+			txDesc.AncestorFees*1000/txDesc.AncestorSize,
+		)
+	}
+	// Add the transactions to the priority queue to mark them ready for inclusion
+	// in the block unless they have dependencies.
+	for _, prioItem := range prioItems {
+		if prioItem.dependsOn == nil {
+			heap.Push(priorityQueue, prioItem)
+		}
+	}
 	// The starting block size is the size of the block header plus the max possible
 	// transaction count size, plus the size of the coinbase transaction.
 	blockWeight := uint32((blockHeaderOverhead) + blockchain.GetTransactionWeight(coinbaseTx))
@@ -708,14 +769,14 @@ mempoolLoop:
 		}
 		// Skip free transactions once the block is larger than the minimum block size.
 		if sortedByFee &&
-			prioItem.feePerKB < int64(g.Policy.TxMinFreeFee) &&
+			prioItem.packageFeePerKB < int64(g.Policy.TxMinFreeFee) &&
 			blockPlusTxWeight >= g.Policy.BlockMinWeight {
 			T.C(
 				func() string {
 					return fmt.Sprintf(
 						"skipping tx %v with feePerKB %v < TxMinFreeFee %v and block weight %v >= minBlockWeight %v",
 						tx.Hash(),
-						prioItem.feePerKB,
+						prioItem.packageFeePerKB,
 						g.Policy.TxMinFreeFee,
 						blockPlusTxWeight,
 						g.Policy.BlockMinWeight,
@@ -739,16 +800,16 @@ mempoolLoop:
 			)
 			sortedByFee = true
 			priorityQueue.SetLessFunc(txPQByFee)
-		}
-		// Put the transaction back into the priority queue and skip it so it is
-		// re-prioritized by fees if it won't fit into the high-priority section or the
-		// priority is too low. Otherwise this transaction will be the final one in the
-		// high-priority section, so just fall though to the code below so it is added
-		// now.
-		if blockPlusTxWeight > g.Policy.BlockPrioritySize ||
-			prioItem.priority < MinHighPriority.ToDUO() {
-			heap.Push(priorityQueue, prioItem)
-			continue
+			// Put the transaction back into the priority queue and skip it so it is
+			// re-prioritized by fees if it won't fit into the high-priority section or the
+			// priority is too low. Otherwise this transaction will be the final one in the
+			// high-priority section, so just fall though to the code below so it is added
+			// now.
+			if blockPlusTxWeight > g.Policy.BlockPrioritySize ||
+				prioItem.priority < MinHighPriority.ToDUO() {
+				heap.Push(priorityQueue, prioItem)
+				continue
+			}
 		}
 
 		// Ensure the transaction inputs pass all of the necessary preconditions before
@@ -858,7 +919,7 @@ mempoolLoop:
 	// per the chain consensus rules.
 	ts := medianAdjustedTime(best, g.TimeSource)
 	T.Ln("legacy ts", ts)
-	if fork.GetCurrent(nextBlockHeight) > 0 {
+	if g.ChainParams.Forks.GetCurrent(nextBlockHeight) > 0 {
 		ots := g.Chain.BestChain.NodeByHeight(best.Height).Header().Timestamp.Truncate(time.Second).Add(time.Second)
 		D.Ln("prev timestamp+1", ots)
 		tn := time.Now().Truncate(time.Second)
@@ -955,7 +1016,7 @@ func (g *BlkTmplGenerator) UpdateBlockTime(
 		var difficulty uint32
 		var e error
 		if difficulty, e = g.Chain.CalcNextRequiredDifficulty(
-			fork.GetAlgoName(
+			g.ChainParams.Forks.GetAlgoName(
 				msgBlock.Header.Version,
 				g.BestSnapshot().Height,
 			),
@@ -1002,6 +1063,55 @@ func (g *BlkTmplGenerator) UpdateExtraNonce(
 	return nil
 }
 
+// CoinbasePayout is an amount of the coinbase of a block paid to an address
+type CoinbasePayout struct {
+	Address btcaddr.Address
+	Amount  amt.Amount
+}
+
+// SplitCoinbase replaces the single output of the coinbase of a block template
+// with outputs paying each of the payouts. Whatever is left of the original
+// output's value stays with its address, and the merkle root is recalculated.
+func SplitCoinbase(msgBlock *wire.Block, payouts []CoinbasePayout) (e error) {
+	coinbase := msgBlock.Transactions[0]
+	if len(coinbase.TxOut) != 1 {
+		return fmt.Errorf(
+			"coinbase has %d outputs, can only split one",
+			len(coinbase.TxOut),
+		)
+	}
+	remainder := amt.Amount(coinbase.TxOut[0].Value)
+	txOuts := make([]*wire.TxOut, 0, len(payouts)+1)
+	for i := range payouts {
+		if payouts[i].Amount <= 0 {
+			continue
+		}
+		if payouts[i].Amount > remainder {
+			return fmt.Errorf(
+				"payouts total more than the coinbase value %v",
+				amt.Amount(coinbase.TxOut[0].Value),
+			)
+		}
+		remainder -= payouts[i].Amount
+		var pkScript []byte
+		if pkScript, e = txscript.PayToAddrScript(payouts[i].Address); E.Chk(e) {
+			return
+		}
+		txOuts = append(txOuts, wire.NewTxOut(int64(payouts[i].Amount), pkScript))
+	}
+	if remainder > 0 {
+		txOuts = append(
+			txOuts,
+			wire.NewTxOut(int64(remainder), coinbase.TxOut[0].PkScript),
+		)
+	}
+	coinbase.TxOut = txOuts
+	block := block2.NewBlock(msgBlock)
+	merkles := blockchain.BuildMerkleTreeStore(block.Transactions(), false)
+	msgBlock.Header.MerkleRoot = *merkles.GetRoot()
+	return
+}
+
 // BestSnapshot returns information about the current best chain block and
 // related state as of the current point in time using the chain instance
 // associated with the block template generator. The returned state must be
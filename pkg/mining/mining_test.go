@@ -0,0 +1,141 @@
+package mining
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	block2 "github.com/cybriq/p9/pkg/block"
+	"github.com/cybriq/p9/pkg/blockchain"
+	"github.com/cybriq/p9/pkg/chaincfg"
+	"github.com/cybriq/p9/pkg/chainhash"
+	"github.com/cybriq/p9/pkg/database"
+	_ "github.com/cybriq/p9/pkg/database/ffldb"
+	"github.com/cybriq/p9/pkg/txscript"
+	"github.com/cybriq/p9/pkg/util"
+	"github.com/cybriq/p9/pkg/wire"
+)
+
+// testTxSource is a transaction source holding a fixed set of transactions
+type testTxSource []*TxDesc
+
+func (s testTxSource) LastUpdated() time.Time { return time.Time{} }
+
+func (s testTxSource) MiningDescs() []*TxDesc { return s }
+
+func (s testTxSource) HaveTransaction(hash *chainhash.Hash) bool {
+	for _, txD := range s {
+		if *txD.Tx.Hash() == *hash {
+			return true
+		}
+	}
+	return false
+}
+
+// testGenerator returns a block template generator for a chain of n blocks, mined with the generator itself, on a copy
+// of the regression test network parameters with a coinbase maturity of one block. The coinbases of the blocks pay to
+// an anyone can spend script.
+func testGenerator(t *testing.T, txSource TxSource, n int) (g *BlkTmplGenerator, coinbases []*wire.MsgTx) {
+	params := chaincfg.RegressionTestParams
+	genesisHash := params.GenesisBlock.BlockHash()
+	params.GenesisHash = &genesisHash
+	params.CoinbaseMaturity = 1
+	db, e := database.Create("ffldb", filepath.Join(t.TempDir(), "mining"), params.Net)
+	if e != nil {
+		t.Fatalf("failed to create database: %v", e)
+	}
+	t.Cleanup(
+		func() {
+			if e := db.Close(); E.Chk(e) {
+			}
+		},
+	)
+	timeSource := blockchain.NewMedianTime()
+	sigCache := txscript.NewSigCache(1000)
+	chain, e := blockchain.New(
+		&blockchain.Config{
+			DB:          db,
+			ChainParams: &params,
+			TimeSource:  timeSource,
+			SigCache:    sigCache,
+		},
+	)
+	if e != nil {
+		t.Fatalf("failed to create chain: %v", e)
+	}
+	policy := &Policy{BlockMaxWeight: blockchain.MaxBlockWeight}
+	g = NewBlkTmplGenerator(policy, &params, testTxSource(nil), chain, timeSource, sigCache, txscript.NewHashCache(1000))
+	for i := 0; i < n; i++ {
+		var tpl *BlockTemplate
+		if tpl, e = g.NewBlockTemplate(nil, ""); e != nil {
+			t.Fatalf("failed to create template for block %d: %v", i+1, e)
+		}
+		blk := block2.NewBlock(tpl.Block)
+		blk.SetHeight(tpl.Height)
+		if _, _, e = chain.ProcessBlock(0, blk, blockchain.BFNoPoWCheck, tpl.Height); e != nil {
+			t.Fatalf("failed to process block %d: %v", i+1, e)
+		}
+		coinbases = append(coinbases, tpl.Block.Transactions[0])
+	}
+	g.TxSource = txSource
+	return
+}
+
+// spendTx returns a transaction spending the first output of prev to an anyone can spend script with a fee of fee
+func spendTx(prev *wire.MsgTx, fee int64) *util.Tx {
+	tx := wire.NewMsgTx(wire.TxVersion)
+	prevHash := prev.TxHash()
+	tx.AddTxIn(wire.NewTxIn(wire.NewOutPoint(&prevHash, 0), nil, nil))
+	tx.AddTxOut(wire.NewTxOut(prev.TxOut[0].Value-fee, []byte{txscript.OP_TRUE}))
+	return util.NewTx(tx)
+}
+
+// testTxDesc returns the descriptor of a transaction paying fee with the ancestors in the source pool given
+func testTxDesc(tx *util.Tx, fee int64, ancestors ...*TxDesc) *TxDesc {
+	size := int64(tx.MsgTx().SerializeSize())
+	txD := &TxDesc{
+		Tx:            tx,
+		Fee:           fee,
+		FeePerKB:      fee * 1000 / size,
+		AncestorCount: 1,
+		AncestorSize:  size,
+		AncestorFees:  fee,
+	}
+	for _, a := range ancestors {
+		txD.AncestorCount++
+		txD.AncestorSize += int64(a.Tx.MsgTx().SerializeSize())
+		txD.AncestorFees += a.Fee
+	}
+	return txD
+}
+
+// TestNewBlockTemplateCPFP ensures a parent paying a low fee is mined ahead of a transaction paying a higher fee rate
+// than it when its child pays enough for the package of the two to pay more, and that the child follows its parent.
+func TestNewBlockTemplateCPFP(t *testing.T) {
+	source := make(testTxSource, 0, 3)
+	g, coinbases := testGenerator(t, &source, 2)
+	parent := testTxDesc(spendTx(coinbases[0], 100), 100)
+	child := testTxDesc(spendTx(parent.Tx.MsgTx(), 100000), 100000, parent)
+	other := testTxDesc(spendTx(coinbases[1], 10000), 10000)
+	if !(parent.FeePerKB < other.FeePerKB && other.FeePerKB < child.AncestorFees*1000/child.AncestorSize) {
+		t.Fatalf(
+			"fee rates do not set up the test: parent %d, other %d, package %d",
+			parent.FeePerKB, other.FeePerKB, child.AncestorFees*1000/child.AncestorSize,
+		)
+	}
+	source = append(source, other, child, parent)
+	tpl, e := g.NewBlockTemplate(nil, "")
+	if e != nil {
+		t.Fatal(e)
+	}
+	txs := tpl.Block.Transactions
+	expected := []*TxDesc{parent, child, other}
+	if len(txs) != len(expected)+1 {
+		t.Fatalf("expected %d transactions in the template, got %d", len(expected)+1, len(txs))
+	}
+	for i, txD := range expected {
+		if txs[i+1].TxHash() != *txD.Tx.Hash() {
+			t.Errorf("transaction %d of the template is %v, expected %v", i+1, txs[i+1].TxHash(), txD.Tx.Hash())
+		}
+	}
+}
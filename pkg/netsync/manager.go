@@ -50,6 +50,9 @@ type (
 		headerList       *list.List
 		startHeader      *list.Element
 		nextCheckpoint   *chaincfg.Checkpoint
+		// assumeValid is the block the headers are downloaded up to once past the
+		// checkpoints, so the scripts of the blocks leading up to it are not checked.
+		assumeValid *chainhash.Hash
 		// An optional fee estimator.
 		feeEstimator *mempool.FeeEstimator
 	}
@@ -477,6 +480,51 @@ func (sm *SyncManager) findNextHeaderCheckpoint(height int32) *chaincfg.Checkpoi
 	return nextCheckpoint
 }
 
+// headersTarget returns the hash of the block the headers are downloaded up to
+// in headers-first mode, which is the next checkpoint, or the assumed valid
+// block once past the checkpoints.
+func (sm *SyncManager) headersTarget() *chainhash.Hash {
+	if sm.nextCheckpoint != nil {
+		return sm.nextCheckpoint.Hash
+	}
+	return sm.assumeValid
+}
+
+// assumeValidHeadersNeeded returns whether the headers leading up to the assumed
+// valid block are still to be downloaded, which is the case until the block is
+// known. A known assumed valid block that is not on the main chain does not
+// cause any scripts to be skipped.
+func (sm *SyncManager) assumeValidHeadersNeeded() bool {
+	if sm.assumeValid == nil ||
+		sm.chainParams == &chaincfg.RegressionTestParams {
+		return false
+	}
+	have, e := sm.chain.HaveBlock(sm.assumeValid)
+	if E.Chk(e) {
+		return false
+	}
+	return !have
+}
+
+// assumeValidNotFound switches to normal mode when the chain of the sync peer
+// does not lead to the assumed valid block, so the scripts of all the blocks
+// received from it are checked.
+func (sm *SyncManager) assumeValidNotFound(peer *peerpkg.Peer) {
+	W.F(
+		"the chain of peer %s does not include the assumed valid block %s -- checking all scripts",
+		peer, sm.assumeValid,
+	)
+	sm.headersFirstMode = false
+	sm.headerList.Init()
+	sm.startHeader = nil
+	locator, e := sm.chain.LatestBlockLocator()
+	if E.Chk(e) {
+		return
+	}
+	if e = peer.PushGetBlocksMsg(locator, &zeroHash); E.Chk(e) {
+	}
+}
+
 // handleBlockMsg handles block messages from all peers.
 func (sm *SyncManager) handleBlockMsg(workerNumber uint32, bmsg *blockMsg) {
 	pp := bmsg.peer
@@ -511,9 +559,10 @@ func (sm *SyncManager) handleBlockMsg(workerNumber uint32, bmsg *blockMsg) {
 	// When in headers-first mode, if the block matches the hash of the first header
 	// in the list of headers that are being fetched, it's eligible for less
 	// validation since the headers have already been verified to link together and
-	// are valid up to the next checkpoint. Also, remove the list entry for all
-	// blocks except the checkpoint since it is needed to verify the next round of
-	// headers links properly.
+	// are valid up to the next checkpoint, or lead up to the assumed valid block so
+	// only its scripts are not checked. Also, remove the list entry for all blocks
+	// except the checkpoint since it is needed to verify the next round of headers
+	// links properly.
 	isCheckpointBlock := false
 	behaviorFlags := blockchain.BFNone
 	if sm.headersFirstMode {
@@ -521,8 +570,12 @@ func (sm *SyncManager) handleBlockMsg(workerNumber uint32, bmsg *blockMsg) {
 		if firstNodeEl != nil {
 			firstNode := firstNodeEl.Value.(*headerNode)
 			if blockHash.IsEqual(firstNode.hash) {
-				behaviorFlags |= blockchain.BFFastAdd
-				if firstNode.hash.IsEqual(sm.nextCheckpoint.Hash) {
+				if sm.nextCheckpoint != nil {
+					behaviorFlags |= blockchain.BFFastAdd
+				} else {
+					behaviorFlags |= blockchain.BFAssumeValid
+				}
+				if firstNode.hash.IsEqual(sm.headersTarget()) {
 					isCheckpointBlock = true
 				} else {
 					sm.headerList.Remove(firstNodeEl)
@@ -663,6 +716,17 @@ func (sm *SyncManager) handleBlockMsg(workerNumber uint32, bmsg *blockMsg) {
 		}
 		return
 	}
+	// This is headers-first mode and the block is the assumed valid block, so
+	// there are no more headers to download.
+	if sm.nextCheckpoint == nil {
+		I.Ln("reached the assumed valid block -- switching to normal mode")
+		sm.headersFirstMode = false
+		sm.headerList.Init()
+		locator := blockchain.BlockLocator([]*chainhash.Hash{blockHash})
+		if e = pp.PushGetBlocksMsg(locator, &zeroHash); E.Chk(e) {
+		}
+		return
+	}
 	// This is headers-first mode and the block is a checkpoint. When there is a
 	// next checkpoint, get the next round of headers by asking for headers starting
 	// from the block after this one up to the next checkpoint.
@@ -685,6 +749,19 @@ func (sm *SyncManager) handleBlockMsg(workerNumber uint32, bmsg *blockMsg) {
 		)
 		return
 	}
+	// After the final checkpoint, get the headers up to the assumed valid block when
+	// it is later.
+	if sm.assumeValidHeadersNeeded() {
+		locator := blockchain.BlockLocator([]*chainhash.Hash{prevHash})
+		if e = pp.PushGetHeadersMsg(locator, sm.assumeValid); E.Chk(e) {
+			return
+		}
+		I.F(
+			"downloading headers for blocks from %d up to the assumed valid block %s from peer %s",
+			prevHeight+1, sm.assumeValid, sm.syncPeer.Addr(),
+		)
+		return
+	}
 	// This is headers-first mode, the block is a checkpoint, and there are no more
 	// checkpoints, so switch to normal mode by requesting blocks from the block
 	// after this one up to the end of the chain (zero hash).
@@ -744,6 +821,7 @@ func (sm *SyncManager) handleBlockchainNotification(notification *blockchain.Not
 			acceptedTxs := sm.txMemPool.ProcessOrphans(sm.chain, tx)
 			sm.peerNotifier.AnnounceNewTransactions(acceptedTxs)
 		}
+		sm.txMemPool.BlockConnected()
 		// Register block with the fee estimator, if it exists.
 		if sm.feeEstimator != nil {
 			e := sm.feeEstimator.RegisterBlock(block)
@@ -842,8 +920,13 @@ func (sm *SyncManager) handleHeadersMsg(hmsg *headersMsg) {
 		peer.Disconnect()
 		return
 	}
-	// Nothing to do for an empty headers message.
+	// Nothing to do for an empty headers message, unless the headers are being
+	// downloaded up to the assumed valid block, in which case the chain of the peer
+	// ends without it.
 	if numHeaders == 0 {
+		if sm.nextCheckpoint == nil {
+			sm.assumeValidNotFound(peer)
+		}
 		return
 	}
 	// Process all of the received headers ensuring each one connects to the
@@ -881,6 +964,20 @@ func (sm *SyncManager) handleHeadersMsg(hmsg *headersMsg) {
 			peer.Disconnect()
 			return
 		}
+		// Past the checkpoints the headers are downloaded up to the assumed valid
+		// block, whose height is not known in advance.
+		if sm.nextCheckpoint == nil {
+			if node.hash.IsEqual(sm.assumeValid) {
+				receivedCheckpoint = true
+				I.F(
+					"downloaded block headers up to the assumed valid block at height %d/hash %s",
+					node.height,
+					node.hash,
+				)
+				break
+			}
+			continue
+		}
 		// Verify the header at the next checkpoint height matches.
 		if node.height == sm.nextCheckpoint.Height {
 			if node.hash.IsEqual(sm.nextCheckpoint.Hash) {
@@ -920,10 +1017,16 @@ func (sm *SyncManager) handleHeadersMsg(hmsg *headersMsg) {
 		sm.fetchHeaderBlocks()
 		return
 	}
+	// A short batch of headers without the assumed valid block means the chain of
+	// the peer does not lead to it.
+	if sm.nextCheckpoint == nil && numHeaders < wire.MaxBlockHeadersPerMsg {
+		sm.assumeValidNotFound(peer)
+		return
+	}
 	// This header is not a checkpoint, so request the next batch of headers
 	// starting from the latest known header and ending with the next checkpoint.
 	locator := blockchain.BlockLocator([]*chainhash.Hash{finalHash})
-	e := peer.PushGetHeadersMsg(locator, sm.nextCheckpoint.Hash)
+	e := peer.PushGetHeadersMsg(locator, sm.headersTarget())
 	if e != nil {
 		E.F(
 			"failed to send getheaders message to peer %s: %v", peer,
@@ -1266,18 +1369,13 @@ func (sm *SyncManager) isSyncCandidate(peer *peerpkg.Peer) bool {
 		if host != "127.0.0.1" && host != "localhost" {
 			return false
 		}
-		// } else {
-		// // The peer is not a candidate for sync if it's not a full node. Additionally, if the segwit soft-fork package
-		// // has activated, then the peer must also be upgraded.
-		// segwitActive, e := sm.chain.IsDeploymentActive(chaincfg.DeploymentSegwit)
-		// if e != nil  {
-		// 			// 	Error("unable to query for segwit soft-fork state:", e)
-		// }
-		// nodeServices := peer.Services()
-		// if nodeServices&wire.SFNodeNetwork != wire.SFNodeNetwork ||
-		// 	(segwitActive && !peer.IsWitnessEnabled()) {
-		// 	return false
-		// }
+	} else {
+		// The peer is not a candidate for sync if it's not a full node. Peers that only keep recent blocks are
+		// candidates here, startSync skips them while they can't serve the blocks this node is missing.
+		services := peer.Services()
+		if services&(wire.SFNodeNetwork|wire.SFNodeNetworkLimited) == 0 {
+			return false
+		}
 	}
 	// Candidate if all checks passed.
 	return true
@@ -1309,10 +1407,11 @@ func (sm *SyncManager) resetHeaderState(
 	sm.headersFirstMode = false
 	sm.headerList.Init()
 	sm.startHeader = nil
-	// When there is a next checkpoint, add an entry for the latest known block into
-	// the header pool. This allows the next downloaded header to prove it links to
-	// the chain properly.
-	if sm.nextCheckpoint != nil {
+	// When there is a next checkpoint or an assumed valid block to download the
+	// headers up to, add an entry for the latest known block into the header pool.
+	// This allows the next downloaded header to prove it links to the chain
+	// properly.
+	if sm.nextCheckpoint != nil || sm.assumeValidHeadersNeeded() {
 		node := headerNode{height: newestHeight, hash: newestHash}
 		sm.headerList.PushBack(&node)
 	}
@@ -1357,6 +1456,13 @@ func (sm *SyncManager) startSync() {
 			// state.syncCandidate = false
 			continue
 		}
+		// A pruned peer only has its most recent blocks, so it can't be synced from until this node is within reach
+		// of its tip.
+		if peer.Services()&wire.SFNodeNetwork != wire.SFNodeNetwork &&
+			best.Height+blockchain.PruneKeepBlocks < peer.LastBlock() {
+			D.Ln("peer", peer, "is pruned and too far ahead to sync from, skipping")
+			continue
+		}
 		// TODO(davec): Use a better algorithm to choose the best peer. For now, just pick the first available candidate.
 		bestPeer = peer
 	}
@@ -1405,6 +1511,20 @@ func (sm *SyncManager) startSync() {
 				sm.nextCheckpoint.Height,
 				bestPeer.Addr(),
 			)
+		} else if sm.nextCheckpoint == nil && sm.assumeValidHeadersNeeded() {
+			// Past the checkpoints the headers are downloaded up to the assumed valid
+			// block the same way, so only the scripts of the blocks leading up to it are
+			// not checked.
+			sm.resetHeaderState(&best.Hash, best.Height)
+			if e = bestPeer.PushGetHeadersMsg(locator, sm.assumeValid); E.Chk(e) {
+			}
+			sm.headersFirstMode = true
+			I.F(
+				"downloading headers for blocks from %d up to the assumed valid block %s from peer %s",
+				best.Height+1,
+				sm.assumeValid,
+				bestPeer.Addr(),
+			)
 		} else {
 			e := bestPeer.PushGetBlocksMsg(locator, &zeroHash)
 			if e != nil {
@@ -1444,6 +1564,10 @@ func New(config *Config) (*SyncManager, error) {
 	} else {
 		I.Ln("checkpoints are disabled")
 	}
+	sm.assumeValid = sm.chain.AssumeValid()
+	if sm.nextCheckpoint == nil && sm.assumeValidHeadersNeeded() {
+		sm.resetHeaderState(&best.Hash, best.Height)
+	}
 	sm.chain.Subscribe(sm.handleBlockchainNotification)
 	return &sm, nil
 }
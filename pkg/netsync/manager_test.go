@@ -0,0 +1,163 @@
+package netsync
+
+import (
+	"container/list"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/cybriq/p9/pkg/blockchain"
+	"github.com/cybriq/p9/pkg/chaincfg"
+	"github.com/cybriq/p9/pkg/chainhash"
+	"github.com/cybriq/p9/pkg/database"
+	_ "github.com/cybriq/p9/pkg/database/ffldb"
+	peerpkg "github.com/cybriq/p9/pkg/peer"
+	"github.com/cybriq/p9/pkg/txscript"
+	"github.com/cybriq/p9/pkg/wire"
+)
+
+// assumeValidSetup returns a sync manager in headers-first mode downloading the headers up to an assumed valid block
+// that is not known yet, with an unconnected sync peer.
+func assumeValidSetup(t *testing.T, assumeValid *chainhash.Hash) (sm *SyncManager, peer *peerpkg.Peer) {
+	// a copy of the simulation test network parameters with the genesis hash matching the genesis block
+	params := chaincfg.SimNetParams
+	genesisHash := params.GenesisBlock.BlockHash()
+	params.GenesisHash = &genesisHash
+	db, e := database.Create("ffldb", filepath.Join(t.TempDir(), "netsync"), params.Net)
+	if e != nil {
+		t.Fatalf("failed to create database: %v", e)
+	}
+	t.Cleanup(
+		func() {
+			if e := db.Close(); E.Chk(e) {
+			}
+		},
+	)
+	chain, e := blockchain.New(
+		&blockchain.Config{
+			DB:          db,
+			ChainParams: &params,
+			TimeSource:  blockchain.NewMedianTime(),
+			SigCache:    txscript.NewSigCache(1000),
+			AssumeValid: assumeValid,
+		},
+	)
+	if e != nil {
+		t.Fatalf("failed to create chain: %v", e)
+	}
+	if peer, e = peerpkg.NewOutboundPeer(&peerpkg.Config{ChainParams: &params}, "127.0.0.1:11047"); e != nil {
+		t.Fatalf("failed to create peer: %v", e)
+	}
+	sm = &SyncManager{
+		chain:           chain,
+		chainParams:     &params,
+		requestedBlocks: make(map[chainhash.Hash]struct{}),
+		peerStates: map[*peerpkg.Peer]*peerSyncState{
+			peer: {
+				syncCandidate:   true,
+				requestedTxns:   make(map[chainhash.Hash]struct{}),
+				requestedBlocks: make(map[chainhash.Hash]struct{}),
+			},
+		},
+		progressLogger: newBlockProgressLogger("processed"),
+		headerList:     list.New(),
+		assumeValid:    chain.AssumeValid(),
+		syncPeer:       peer,
+	}
+	best := chain.BestSnapshot()
+	sm.resetHeaderState(&best.Hash, best.Height)
+	if sm.headerList.Len() != 1 {
+		t.Fatalf("expected the header list to hold the best block, got %d entries", sm.headerList.Len())
+	}
+	sm.headersFirstMode = true
+	return
+}
+
+// makeHeaders returns n headers building on the genesis block of the chain of the sync manager
+func makeHeaders(sm *SyncManager, n int) (msg *wire.MsgHeaders) {
+	msg = wire.NewMsgHeaders()
+	prev := sm.chain.BestSnapshot().Hash
+	for i := 0; i < n; i++ {
+		header := wire.NewBlockHeader(1, &prev, &chainhash.Hash{}, 0x207fffff, uint32(i))
+		header.Timestamp = time.Unix(int64(i), 0)
+		if e := msg.AddBlockHeader(header); E.Chk(e) {
+		}
+		prev = header.BlockHash()
+	}
+	return
+}
+
+// TestAssumeValidHeaders ensures the headers are only downloaded up to an assumed valid block that is not known yet.
+func TestAssumeValidHeaders(t *testing.T) {
+	unknown := chainhash.Hash{1}
+	sm, _ := assumeValidSetup(t, &unknown)
+	if !sm.assumeValidHeadersNeeded() {
+		t.Errorf("the headers up to an unknown assumed valid block are not needed")
+	}
+	if !sm.headersTarget().IsEqual(&unknown) {
+		t.Errorf("the headers target is not the assumed valid block after the checkpoints")
+	}
+	checkpoint := chaincfg.Checkpoint{Height: 10, Hash: &chainhash.Hash{2}}
+	sm.nextCheckpoint = &checkpoint
+	if !sm.headersTarget().IsEqual(checkpoint.Hash) {
+		t.Errorf("the headers target is not the next checkpoint")
+	}
+	// a known assumed valid block, no assumed valid block, or regression test mode need no headers
+	sm.assumeValid = &sm.chain.BestSnapshot().Hash
+	if sm.assumeValidHeadersNeeded() {
+		t.Errorf("the headers up to a known assumed valid block are needed")
+	}
+	sm.assumeValid = nil
+	if sm.assumeValidHeadersNeeded() {
+		t.Errorf("the headers are needed without an assumed valid block")
+	}
+	sm.assumeValid = &unknown
+	sm.chainParams = &chaincfg.RegressionTestParams
+	if sm.assumeValidHeadersNeeded() {
+		t.Errorf("the headers up to the assumed valid block are needed in regression test mode")
+	}
+}
+
+// TestAssumeValidNotFound ensures the sync manager leaves headers-first mode when the chain of the sync peer ends
+// without the assumed valid block, and only then.
+func TestAssumeValidNotFound(t *testing.T) {
+	unknown := chainhash.Hash{1}
+	tests := []struct {
+		name       string
+		numHeaders int
+		// assumed is the index of the header that is the assumed valid block, or -1 for none of them
+		assumed int
+		// headersFirst is whether the sync manager is expected to still be in headers-first mode
+		headersFirst bool
+		// requested is the number of blocks expected to have been requested
+		requested int
+	}{
+		{"empty batch", 0, -1, false, 0},
+		{"short batch without the assumed block", 10, -1, false, 0},
+		{"full batch without the assumed block", wire.MaxBlockHeadersPerMsg, -1, true, 0},
+		{"batch with the assumed block", 10, 5, true, 6},
+	}
+	for _, test := range tests {
+		sm, peer := assumeValidSetup(t, &unknown)
+		msg := makeHeaders(sm, test.numHeaders)
+		if test.assumed >= 0 {
+			assumed := msg.Headers[test.assumed].BlockHash()
+			sm.assumeValid = &assumed
+		}
+		sm.handleHeadersMsg(&headersMsg{headers: msg, peer: peer})
+		if sm.headersFirstMode != test.headersFirst {
+			t.Errorf("%s: expected headers-first mode %v", test.name, test.headersFirst)
+		}
+		if !test.headersFirst && (sm.headerList.Len() != 0 || sm.startHeader != nil) {
+			t.Errorf("%s: the header state was not reset when leaving headers-first mode", test.name)
+		}
+		if test.headersFirst && test.requested == 0 && sm.headerList.Len() != test.numHeaders+1 {
+			t.Errorf(
+				"%s: expected %d headers in the list, got %d", test.name, test.numHeaders+1, sm.headerList.Len(),
+			)
+		}
+		if len(sm.requestedBlocks) != test.requested {
+			t.Errorf("%s: expected %d blocks requested, got %d", test.name, test.requested, len(sm.requestedBlocks))
+		}
+	}
+}
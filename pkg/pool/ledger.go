@@ -0,0 +1,204 @@
+package pool
+
+import (
+	"bytes"
+	"encoding/binary"
+	"time"
+
+	"github.com/cybriq/gotiny"
+	bolt "go.etcd.io/bbolt"
+
+	"github.com/cybriq/p9/pkg/amt"
+	"github.com/cybriq/p9/pkg/chainhash"
+)
+
+var (
+	sharesBucket = []byte("shares")
+	blocksBucket = []byte("blocks")
+	paidBucket   = []byte("paid")
+)
+
+// Share is a proof of work below the share target of a template, submitted by a miner to be paid to its address
+type Share struct {
+	Address string
+	Height  int32
+	Version int32
+	Hash    chainhash.Hash
+	Time    time.Time
+}
+
+// Payout is an amount of a block reward paid to an address
+type Payout struct {
+	Address string
+	Amount  amt.Amount
+}
+
+// Block is a block found by the pool and the payouts in its coinbase
+type Block struct {
+	Height  int32
+	Hash    chainhash.Hash
+	Time    time.Time
+	Payouts []Payout
+}
+
+// Ledger keeps the shares submitted to the pool, the blocks it has found and the total paid to each address in a bbolt
+// database. Shares are keyed by a sequence number so the most recent ones can be read from the end of their bucket.
+type Ledger struct {
+	db *bolt.DB
+}
+
+// OpenLedger opens the ledger database at path, creating it if it does not exist
+func OpenLedger(path string) (l *Ledger, e error) {
+	var db *bolt.DB
+	if db, e = bolt.Open(path, 0600, &bolt.Options{Timeout: time.Second}); E.Chk(e) {
+		return
+	}
+	if e = db.Update(
+		func(tx *bolt.Tx) (e error) {
+			for _, b := range [][]byte{sharesBucket, blocksBucket, paidBucket} {
+				if _, e = tx.CreateBucketIfNotExists(b); E.Chk(e) {
+					return
+				}
+			}
+			return
+		},
+	); E.Chk(e) {
+		if e := db.Close(); E.Chk(e) {
+		}
+		return
+	}
+	return &Ledger{db: db}, nil
+}
+
+// Close the ledger database
+func (l *Ledger) Close() error {
+	return l.db.Close()
+}
+
+func uint64Key(n uint64) []byte {
+	k := make([]byte, 8)
+	binary.BigEndian.PutUint64(k, n)
+	return k
+}
+
+// AddShare appends a share to the ledger
+func (l *Ledger) AddShare(s *Share) (e error) {
+	return l.db.Update(
+		func(tx *bolt.Tx) (e error) {
+			b := tx.Bucket(sharesBucket)
+			var seq uint64
+			if seq, e = b.NextSequence(); E.Chk(e) {
+				return
+			}
+			return b.Put(uint64Key(seq), gotiny.Marshal(s))
+		},
+	)
+}
+
+// Window returns up to n of the most recent shares, newest first
+func (l *Ledger) Window(n int) (shares []Share, e error) {
+	e = l.db.View(
+		func(tx *bolt.Tx) (e error) {
+			c := tx.Bucket(sharesBucket).Cursor()
+			for k, v := c.Last(); k != nil && len(shares) < n; k, v = c.Prev() {
+				var s Share
+				gotiny.Unmarshal(v, &s)
+				shares = append(shares, s)
+			}
+			return
+		},
+	)
+	return
+}
+
+// Prune deletes all but the most recent keep shares
+func (l *Ledger) Prune(keep int) (e error) {
+	return l.db.Update(
+		func(tx *bolt.Tx) (e error) {
+			b := tx.Bucket(sharesBucket)
+			c := b.Cursor()
+			k, _ := c.Last()
+			for i := 0; i < keep && k != nil; i++ {
+				k, _ = c.Prev()
+			}
+			if k == nil {
+				return
+			}
+			// keys are collected first as deleting under a cursor moves it
+			last := append([]byte{}, k...)
+			var keys [][]byte
+			for k, _ = c.First(); k != nil && bytes.Compare(k, last) <= 0; k, _ = c.Next() {
+				keys = append(keys, append([]byte{}, k...))
+			}
+			for _, k := range keys {
+				if e = b.Delete(k); E.Chk(e) {
+					return
+				}
+			}
+			return
+		},
+	)
+}
+
+// AddBlock records a block found by the pool and adds its payouts to the totals paid to each address. A block that is
+// already recorded is left as it is so its payouts are only counted once.
+func (l *Ledger) AddBlock(blk *Block) (e error) {
+	return l.db.Update(
+		func(tx *bolt.Tx) (e error) {
+			blocks := tx.Bucket(blocksBucket)
+			key := append(uint64Key(uint64(blk.Height)), blk.Hash[:]...)
+			if blocks.Get(key) != nil {
+				return
+			}
+			if e = blocks.Put(key, gotiny.Marshal(blk)); E.Chk(e) {
+				return
+			}
+			paid := tx.Bucket(paidBucket)
+			for _, p := range blk.Payouts {
+				var total uint64
+				if v := paid.Get([]byte(p.Address)); len(v) == 8 {
+					total = binary.BigEndian.Uint64(v)
+				}
+				total += uint64(p.Amount)
+				if e = paid.Put([]byte(p.Address), uint64Key(total)); E.Chk(e) {
+					return
+				}
+			}
+			return
+		},
+	)
+}
+
+// Blocks returns up to n of the blocks found by the pool, highest first
+func (l *Ledger) Blocks(n int) (blocks []Block, e error) {
+	e = l.db.View(
+		func(tx *bolt.Tx) (e error) {
+			c := tx.Bucket(blocksBucket).Cursor()
+			for k, v := c.Last(); k != nil && len(blocks) < n; k, v = c.Prev() {
+				var blk Block
+				gotiny.Unmarshal(v, &blk)
+				blocks = append(blocks, blk)
+			}
+			return
+		},
+	)
+	return
+}
+
+// Paid returns the total amount paid to each address in the coinbases of blocks found by the pool
+func (l *Ledger) Paid() (paid map[string]amt.Amount, e error) {
+	paid = make(map[string]amt.Amount)
+	e = l.db.View(
+		func(tx *bolt.Tx) (e error) {
+			return tx.Bucket(paidBucket).ForEach(
+				func(k, v []byte) (e error) {
+					if len(v) == 8 {
+						paid[string(k)] = amt.Amount(binary.BigEndian.Uint64(v))
+					}
+					return
+				},
+			)
+		},
+	)
+	return
+}
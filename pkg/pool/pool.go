@@ -0,0 +1,285 @@
+package pool
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/cybriq/p9/pkg/amt"
+	"github.com/cybriq/p9/pkg/bits"
+	"github.com/cybriq/p9/pkg/block"
+	"github.com/cybriq/p9/pkg/blockchain"
+	"github.com/cybriq/p9/pkg/btcaddr"
+	"github.com/cybriq/p9/pkg/btcjson"
+	"github.com/cybriq/p9/pkg/chaincfg"
+	"github.com/cybriq/p9/pkg/chainhash"
+	"github.com/cybriq/p9/pkg/chainrpc/templates"
+	"github.com/cybriq/p9/pkg/mining"
+	"github.com/cybriq/p9/pkg/txrules"
+	"github.com/cybriq/p9/pkg/txscript"
+	"github.com/cybriq/p9/pkg/wire"
+)
+
+const (
+	// DefaultWindow is the number of most recent shares block rewards are split between by default
+	DefaultWindow = 1024
+	// DefaultShareRatio is how many times easier than a block a share is by default
+	DefaultShareRatio = 64
+	// DefaultMaxPayouts is the most outputs a coinbase is split into by default, which keeps it well inside the block
+	// size and standard transaction size limits
+	DefaultMaxPayouts = 1000
+	// maxFutureTime is how far past the current time the timestamp of a share may be
+	maxFutureTime = time.Hour * 2
+)
+
+// Config is the configuration of a pool
+type Config struct {
+	// Window is the number of most recent shares a block reward is split between
+	Window int
+	// ShareRatio is how many times larger than the block target the share target is
+	ShareRatio int
+	// MaxPayouts is the most addresses a coinbase is split between
+	MaxPayouts int
+	// Params are the parameters of the chain being mined
+	Params *chaincfg.Params
+}
+
+// Pool keeps the accounts of a controller running in pool mode. Workers submit shares, which are proofs of work below a
+// target ShareRatio times easier than that of a block, paid to their address. When building a template the coinbase
+// is split between the addresses of the most recent Window shares, so whoever finds a block everyone is paid for their
+// recent work.
+type Pool struct {
+	sync.Mutex
+	cfg       *Config
+	ledger    *Ledger
+	seen      map[chainhash.Hash]struct{}
+	prevBlock chainhash.Hash
+	reward    amt.Amount
+	added     int
+}
+
+// New creates a pool keeping its ledger in the database at path
+func New(path string, cfg *Config) (p *Pool, e error) {
+	if cfg.Window < 1 {
+		cfg.Window = DefaultWindow
+	}
+	if cfg.ShareRatio < 1 {
+		cfg.ShareRatio = DefaultShareRatio
+	}
+	if cfg.MaxPayouts < 1 {
+		cfg.MaxPayouts = DefaultMaxPayouts
+	}
+	var l *Ledger
+	if l, e = OpenLedger(path); E.Chk(e) {
+		return
+	}
+	p = &Pool{
+		cfg:    cfg,
+		ledger: l,
+		seen:   make(map[chainhash.Hash]struct{}),
+	}
+	return
+}
+
+// Close the pool's ledger
+func (p *Pool) Close() error {
+	return p.ledger.Close()
+}
+
+// ShareBits returns the compact share target for a block target
+func (p *Pool) ShareBits(blockBits uint32) uint32 {
+	target := bits.CompactToBig(blockBits)
+	target.Mul(target, big.NewInt(int64(p.cfg.ShareRatio)))
+	if target.Cmp(p.cfg.Params.PowLimit) > 0 {
+		target.Set(p.cfg.Params.PowLimit)
+	}
+	return bits.BigToCompact(target)
+}
+
+// SplitCoinbase divides the coinbase of a block template between the addresses in the current share window. Anything
+// not paid to them, which is all of it while there are no shares, stays with the address the template pays. So does
+// any payout that would be a dust output, and the smallest payouts when there are more than MaxPayouts.
+func (p *Pool) SplitCoinbase(msgBlock *wire.Block) (e error) {
+	var shares []Share
+	if shares, e = p.ledger.Window(p.cfg.Window); E.Chk(e) {
+		return
+	}
+	reward := amt.Amount(msgBlock.Transactions[0].TxOut[0].Value)
+	p.Lock()
+	p.reward = reward
+	p.Unlock()
+	payouts, _ := Split(shares, reward)
+	cbp := make([]mining.CoinbasePayout, 0, len(payouts))
+	for i := range payouts {
+		var addr btcaddr.Address
+		if addr, e = btcaddr.Decode(payouts[i].Address, p.cfg.Params); E.Chk(e) {
+			continue
+		}
+		var pkScript []byte
+		if pkScript, e = txscript.PayToAddrScript(addr); E.Chk(e) {
+			continue
+		}
+		if txrules.IsDustOutput(wire.NewTxOut(int64(payouts[i].Amount), pkScript), txrules.DefaultRelayFeePerKb) {
+			continue
+		}
+		cbp = append(cbp, mining.CoinbasePayout{Address: addr, Amount: payouts[i].Amount})
+	}
+	if len(cbp) > p.cfg.MaxPayouts {
+		// the largest payouts are kept, in the order of their addresses as they were before
+		sort.SliceStable(cbp, func(i, j int) bool { return cbp[i].Amount > cbp[j].Amount })
+		cbp = cbp[:p.cfg.MaxPayouts]
+		sort.Slice(
+			cbp, func(i, j int) bool {
+				return cbp[i].Address.EncodeAddress() < cbp[j].Address.EncodeAddress()
+			},
+		)
+	}
+	return mining.SplitCoinbase(msgBlock, cbp)
+}
+
+// CheckShare validates a share submitted for a template and records it in the ledger. The header must be for the
+// template, meet the share target of its version and not have been submitted before. If it also meets the block
+// target isBlock is true.
+func (p *Pool) CheckShare(tpl *templates.Message, hdr *wire.BlockHeader, address string) (isBlock bool, e error) {
+	if hdr.MerkleRoot != tpl.Merkles[hdr.Version] {
+		return false, errors.New("share merkle root does not match the template")
+	}
+	return p.CheckExtendedShare(tpl, hdr, address)
+}
+
+// CheckExtendedShare is CheckShare for a header whose coinbase the miner extended with an extranonce of its own, as
+// Stratum miners do, so its merkle root is not the one of the template. The caller must have computed the merkle root
+// from the extended coinbase and the other transactions of the template.
+func (p *Pool) CheckExtendedShare(tpl *templates.Message, hdr *wire.BlockHeader, address string) (
+	isBlock bool, e error,
+) {
+	if _, e = btcaddr.Decode(address, p.cfg.Params); e != nil {
+		return false, fmt.Errorf("invalid payout address %q: %v", address, e)
+	}
+	shareBits, ok := tpl.ShareBits[hdr.Version]
+	switch {
+	case !ok:
+		return false, fmt.Errorf("template has no share target for version %d", hdr.Version)
+	case hdr.PrevBlock != tpl.PrevBlock:
+		return false, errors.New("share is for a different previous block")
+	case hdr.Bits != tpl.Bits[hdr.Version]:
+		return false, errors.New("share bits do not match the template")
+	case hdr.Timestamp.Before(tpl.Timestamp) || hdr.Timestamp.After(time.Now().Add(maxFutureTime)):
+		return false, errors.New("share timestamp is out of range")
+	}
+	hash := hdr.BlockHashWithAlgos(p.cfg.Params.Forks, tpl.Height)
+	hashNum := blockchain.HashToBig(&hash)
+	if hashNum.Cmp(bits.CompactToBig(shareBits)) > 0 {
+		return false, errors.New("share does not meet the share target")
+	}
+	p.Lock()
+	if tpl.PrevBlock != p.prevBlock {
+		p.prevBlock = tpl.PrevBlock
+		p.seen = make(map[chainhash.Hash]struct{})
+	}
+	if _, ok = p.seen[hash]; ok {
+		p.Unlock()
+		return false, errors.New("duplicate share")
+	}
+	p.seen[hash] = struct{}{}
+	p.added++
+	prune := p.added%p.cfg.Window == 0
+	p.Unlock()
+	if e = p.ledger.AddShare(
+		&Share{
+			Address: address,
+			Height:  tpl.Height,
+			Version: hdr.Version,
+			Hash:    hash,
+			Time:    time.Now(),
+		},
+	); E.Chk(e) {
+		return
+	}
+	// twice the window is kept so the ledger shows some history
+	if prune {
+		if e = p.ledger.Prune(p.cfg.Window * 2); E.Chk(e) {
+		}
+	}
+	D.Ln("accepted share from", address, "at height", tpl.Height, hash)
+	return hashNum.Cmp(bits.CompactToBig(hdr.Bits)) <= 0, nil
+}
+
+// BlockFound records a block the pool has found in the ledger, with the payouts in its coinbase
+func (p *Pool) BlockFound(blk *block.Block) (e error) {
+	coinbase := blk.WireBlock().Transactions[0]
+	rec := &Block{Height: blk.Height(), Hash: *blk.Hash(), Time: time.Now()}
+	for _, out := range coinbase.TxOut {
+		var addrs []btcaddr.Address
+		if _, addrs, _, e = txscript.ExtractPkScriptAddrs(out.PkScript, p.cfg.Params); E.Chk(e) || len(addrs) != 1 {
+			continue
+		}
+		rec.Payouts = append(rec.Payouts, Payout{Address: addrs[0].EncodeAddress(), Amount: amt.Amount(out.Value)})
+	}
+	return p.ledger.AddBlock(rec)
+}
+
+// Info returns the current share window, what each address in it would be paid if a block was found now, and the
+// most recent blocks found by the pool
+func (p *Pool) Info(blocks int) (r *btcjson.GetPoolInfoResult, e error) {
+	var shares []Share
+	if shares, e = p.ledger.Window(p.cfg.Window); E.Chk(e) {
+		return
+	}
+	var paid map[string]amt.Amount
+	if paid, e = p.ledger.Paid(); E.Chk(e) {
+		return
+	}
+	var found []Block
+	if found, e = p.ledger.Blocks(blocks); E.Chk(e) {
+		return
+	}
+	p.Lock()
+	reward := p.reward
+	p.Unlock()
+	r = &btcjson.GetPoolInfoResult{
+		Window:     p.cfg.Window,
+		ShareRatio: p.cfg.ShareRatio,
+		Shares:     len(shares),
+		Reward:     reward.ToDUO(),
+		Miners:     []btcjson.PoolMinerResult{},
+		Blocks:     []btcjson.PoolBlockResult{},
+	}
+	miners := make(map[string]*btcjson.PoolMinerResult)
+	for i := range shares {
+		m, ok := miners[shares[i].Address]
+		if !ok {
+			m = &btcjson.PoolMinerResult{Address: shares[i].Address}
+			miners[shares[i].Address] = m
+		}
+		m.Shares++
+		if t := shares[i].Time.Unix(); t > m.LastShare {
+			m.LastShare = t
+		}
+	}
+	pending, _ := Split(shares, reward)
+	for i := range pending {
+		miners[pending[i].Address].Pending = pending[i].Amount.ToDUO()
+	}
+	for a := range miners {
+		miners[a].Paid = paid[a].ToDUO()
+		r.Miners = append(r.Miners, *miners[a])
+	}
+	sort.Slice(r.Miners, func(i, j int) bool { return r.Miners[i].Address < r.Miners[j].Address })
+	for i := range found {
+		b := btcjson.PoolBlockResult{
+			Height:  found[i].Height,
+			Hash:    found[i].Hash.String(),
+			Time:    found[i].Time.Unix(),
+			Payouts: make(map[string]float64),
+		}
+		for _, po := range found[i].Payouts {
+			b.Payouts[po.Address] += po.Amount.ToDUO()
+		}
+		r.Blocks = append(r.Blocks, b)
+	}
+	return
+}
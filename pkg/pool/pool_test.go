@@ -0,0 +1,270 @@
+package pool
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/cybriq/p9/pkg/amt"
+	"github.com/cybriq/p9/pkg/bits"
+	"github.com/cybriq/p9/pkg/blockchain"
+	"github.com/cybriq/p9/pkg/btcaddr"
+	"github.com/cybriq/p9/pkg/chaincfg"
+	"github.com/cybriq/p9/pkg/chainhash"
+	"github.com/cybriq/p9/pkg/chainrpc/templates"
+	"github.com/cybriq/p9/pkg/txscript"
+	"github.com/cybriq/p9/pkg/util"
+	"github.com/cybriq/p9/pkg/wire"
+)
+
+var params = &chaincfg.RegressionTestParams
+
+func testAddress(t *testing.T, b byte) string {
+	h := make([]byte, 20)
+	h[0] = b
+	addr, e := btcaddr.NewPubKeyHash(h, params)
+	if e != nil {
+		t.Fatal(e)
+	}
+	return addr.EncodeAddress()
+}
+
+func testPool(t *testing.T, window, ratio int) *Pool {
+	p, e := New(filepath.Join(t.TempDir(), "pool.db"), &Config{Window: window, ShareRatio: ratio, Params: params})
+	if e != nil {
+		t.Fatal(e)
+	}
+	t.Cleanup(func() { _ = p.Close() })
+	return p
+}
+
+// testBlock returns a block with a coinbase paying the whole reward to an anyone can spend script
+func testBlock(reward int64) *wire.Block {
+	coinbase := wire.NewMsgTx(1)
+	coinbase.AddTxIn(
+		&wire.TxIn{
+			PreviousOutPoint: *wire.NewOutPoint(&chainhash.Hash{}, wire.MaxPrevOutIndex),
+			SignatureScript:  []byte{1, 1, 0},
+			Sequence:         wire.MaxTxInSequenceNum,
+		},
+	)
+	coinbase.AddTxOut(wire.NewTxOut(reward, []byte{0x51}))
+	return &wire.Block{Header: wire.BlockHeader{Version: 5, Bits: 0x207fffff}, Transactions: []*wire.MsgTx{coinbase}}
+}
+
+func TestSplit(t *testing.T) {
+	a, b, c := testAddress(t, 1), testAddress(t, 2), testAddress(t, 3)
+	shares := []Share{{Address: b}, {Address: a}, {Address: b}, {Address: c}, {Address: b}, {Address: a}}
+	payouts, remainder := Split(shares, 1000)
+	expected := map[string]amt.Amount{a: 333, b: 500, c: 166}
+	if len(payouts) != 3 {
+		t.Fatalf("expected 3 payouts, got %v", payouts)
+	}
+	total := remainder
+	for i := range payouts {
+		if i > 0 && payouts[i-1].Address >= payouts[i].Address {
+			t.Errorf("payouts are not sorted by address")
+		}
+		if payouts[i].Amount != expected[payouts[i].Address] {
+			t.Errorf("expected %v for %s, got %v", expected[payouts[i].Address], payouts[i].Address, payouts[i].Amount)
+		}
+		total += payouts[i].Amount
+	}
+	if total != 1000 || remainder != 1 {
+		t.Errorf("payouts and remainder %v do not add up to the reward", remainder)
+	}
+	if payouts, remainder = Split(nil, 1000); payouts != nil || remainder != 1000 {
+		t.Errorf("expected the whole reward to be left over without shares")
+	}
+	// large rewards over large windows must not overflow
+	shares = make([]Share, 100000)
+	for i := range shares {
+		shares[i].Address = a
+	}
+	if payouts, _ = Split(shares, amt.Amount(21e6*1e8)); payouts[0].Amount != amt.Amount(21e6*1e8) {
+		t.Errorf("expected the whole reward, got %v", payouts[0].Amount)
+	}
+}
+
+func TestLedger(t *testing.T) {
+	l, e := OpenLedger(filepath.Join(t.TempDir(), "ledger.db"))
+	if e != nil {
+		t.Fatal(e)
+	}
+	defer func() { _ = l.Close() }()
+	for i := 0; i < 10; i++ {
+		if e = l.AddShare(&Share{Address: "a", Height: int32(i)}); e != nil {
+			t.Fatal(e)
+		}
+	}
+	var shares []Share
+	if shares, e = l.Window(4); e != nil || len(shares) != 4 || shares[0].Height != 9 || shares[3].Height != 6 {
+		t.Fatalf("unexpected window %v %v", shares, e)
+	}
+	if e = l.Prune(3); e != nil {
+		t.Fatal(e)
+	}
+	if shares, e = l.Window(10); e != nil || len(shares) != 3 || shares[2].Height != 7 {
+		t.Fatalf("unexpected window after pruning %v %v", shares, e)
+	}
+	for h := int32(1); h <= 3; h++ {
+		if e = l.AddBlock(&Block{Height: h, Payouts: []Payout{{"a", 10}, {"b", amt.Amount(h)}}}); e != nil {
+			t.Fatal(e)
+		}
+	}
+	// a block recorded again, as when it is resubmitted, must not be paid twice
+	if e = l.AddBlock(&Block{Height: 3, Payouts: []Payout{{"a", 10}, {"b", 3}}}); e != nil {
+		t.Fatal(e)
+	}
+	var blocks []Block
+	if blocks, e = l.Blocks(2); e != nil || len(blocks) != 2 || blocks[0].Height != 3 {
+		t.Fatalf("unexpected blocks %v %v", blocks, e)
+	}
+	var paid map[string]amt.Amount
+	if paid, e = l.Paid(); e != nil || paid["a"] != 30 || paid["b"] != 6 {
+		t.Fatalf("unexpected paid totals %v %v", paid, e)
+	}
+}
+
+func TestPool(t *testing.T) {
+	// shares are twice as easy as the block, which caps them at the pow limit so every hash is a share and about
+	// half of them are blocks
+	p := testPool(t, 8, 2)
+	a, b := testAddress(t, 1), testAddress(t, 2)
+	const reward = 100000000
+	mb := testBlock(reward)
+	if e := p.SplitCoinbase(mb); e != nil || len(mb.Transactions[0].TxOut) != 1 {
+		t.Fatalf("expected the coinbase to be unchanged without shares: %v", e)
+	}
+	tpl := &templates.Message{
+		Height:    1,
+		PrevBlock: mb.Header.PrevBlock,
+		Bits:      templates.Diffs{5: mb.Header.Bits},
+		Merkles:   templates.Merkles{5: mb.Header.MerkleRoot},
+		ShareBits: templates.Diffs{5: p.ShareBits(mb.Header.Bits)},
+		Timestamp: time.Unix(time.Now().Unix()-60, 0),
+	}
+	hdr := tpl.GenBlockHeader(5)
+	if _, e := p.CheckShare(tpl, hdr, "nonsense"); e == nil {
+		t.Errorf("expected share with invalid address to be rejected")
+	}
+	var shares, blocks int
+	for nonce := uint32(0); shares < 6 || blocks < 1; nonce++ {
+		if nonce > 64 {
+			t.Fatal("did not find enough shares and blocks")
+		}
+		hdr.Nonce = nonce
+		addr := a
+		if nonce%3 == 0 {
+			addr = b
+		}
+		isBlock, e := p.CheckShare(tpl, hdr, addr)
+		if e != nil {
+			t.Fatalf("share was rejected: %v", e)
+		}
+		hash := hdr.BlockHashWithAlgos(params.Forks, tpl.Height)
+		if isBlock != (blockchain.HashToBig(&hash).Cmp(bits.CompactToBig(hdr.Bits)) <= 0) {
+			t.Errorf("share block status is wrong")
+		}
+		if _, e = p.CheckShare(tpl, hdr, addr); e == nil {
+			t.Errorf("expected duplicate share to be rejected")
+		}
+		shares++
+		if isBlock {
+			blocks++
+		}
+	}
+	bad := *hdr
+	bad.MerkleRoot = chainhash.Hash{1}
+	if _, e := p.CheckShare(tpl, &bad, a); e == nil {
+		t.Errorf("expected share with wrong merkle root to be rejected")
+	}
+	window, _ := p.ledger.Window(p.cfg.Window)
+	expected, _ := Split(window, reward)
+	if e := p.SplitCoinbase(mb); e != nil {
+		t.Fatal(e)
+	}
+	outs := mb.Transactions[0].TxOut
+	if len(outs) != len(expected)+1 {
+		t.Fatalf("expected %d coinbase outputs, got %d", len(expected)+1, len(outs))
+	}
+	// the payouts come first and the remainder stays with the original script in the last output
+	var total int64
+	for i := range outs {
+		total += outs[i].Value
+		if i == len(expected) {
+			if outs[i].PkScript[0] != 0x51 {
+				t.Errorf("remainder is not paid to the template address")
+			}
+			continue
+		}
+		_, addrs, _, e := txscript.ExtractPkScriptAddrs(outs[i].PkScript, params)
+		if e != nil || len(addrs) != 1 || addrs[0].EncodeAddress() != expected[i].Address ||
+			outs[i].Value != int64(expected[i].Amount) {
+			t.Errorf("coinbase output %d does not pay %v", i, expected[i])
+		}
+	}
+	if total != reward {
+		t.Errorf("coinbase outputs add up to %d", total)
+	}
+	if mb.Header.MerkleRoot != *blockchain.BuildMerkleTreeStore(
+		[]*util.Tx{util.NewTx(mb.Transactions[0])}, false,
+	).GetRoot() {
+		t.Errorf("merkle root was not updated")
+	}
+	info, e := p.Info(10)
+	if e != nil || info.Shares != len(window) || len(info.Miners) != 2 || info.Reward != amt.Amount(reward).ToDUO() {
+		t.Fatalf("unexpected pool info %v %v", info, e)
+	}
+	for _, m := range info.Miners {
+		for _, po := range expected {
+			if po.Address == m.Address && m.Pending != po.Amount.ToDUO() {
+				t.Errorf("expected %s to have %v pending, got %v", m.Address, po.Amount.ToDUO(), m.Pending)
+			}
+		}
+	}
+}
+
+// TestSplitCoinbaseLimits ensures payouts that would be dust, and the smallest payouts over the output limit, are left
+// to the template's address.
+func TestSplitCoinbaseLimits(t *testing.T) {
+	p := testPool(t, 100, 2)
+	p.cfg.MaxPayouts = 3
+	// a has 50 shares, b 30, c 15, d 4 and e 1 of the window
+	counts := []int{50, 30, 15, 4, 1}
+	addrs := make([]string, len(counts))
+	for i := range counts {
+		addrs[i] = testAddress(t, byte(i+1))
+		for j := 0; j < counts[i]; j++ {
+			if e := p.ledger.AddShare(&Share{Address: addrs[i]}); e != nil {
+				t.Fatal(e)
+			}
+		}
+	}
+	// with a reward of 50000 e is paid 500, which is under the dust threshold, and d is paid 2000, which is over the
+	// limit of three payouts
+	mb := testBlock(50000)
+	if e := p.SplitCoinbase(mb); e != nil {
+		t.Fatal(e)
+	}
+	outs := mb.Transactions[0].TxOut
+	expected := []int64{25000, 15000, 7500, 2500}
+	if len(outs) != len(expected) {
+		t.Fatalf("expected %d coinbase outputs, got %d", len(expected), len(outs))
+	}
+	for i := range outs {
+		if outs[i].Value != expected[i] {
+			t.Errorf("coinbase output %d pays %d, expected %d", i, outs[i].Value, expected[i])
+		}
+		if i == len(outs)-1 {
+			if outs[i].PkScript[0] != 0x51 {
+				t.Errorf("left over payouts are not paid to the template address")
+			}
+			continue
+		}
+		_, paid, _, e := txscript.ExtractPkScriptAddrs(outs[i].PkScript, params)
+		if e != nil || len(paid) != 1 || paid[0].EncodeAddress() != addrs[i] {
+			t.Errorf("coinbase output %d does not pay %s", i, addrs[i])
+		}
+	}
+}
@@ -0,0 +1,41 @@
+package pool
+
+import (
+	"sort"
+
+	"github.com/cybriq/p9/pkg/amt"
+)
+
+// Split divides a block reward between the addresses of a window of shares in proportion to the number of shares each
+// submitted, which is pay per last N shares when the window is the most recent shares. All shares count the same, as
+// the share target of every block version is the same multiple of its block target.
+//
+// Payouts are sorted by address and rounded down, and the remainder, of less than one satoshi per address, is returned
+// separately.
+func Split(shares []Share, reward amt.Amount) (payouts []Payout, remainder amt.Amount) {
+	remainder = reward
+	if len(shares) < 1 || reward <= 0 {
+		return
+	}
+	counts := make(map[string]int64)
+	for i := range shares {
+		counts[shares[i].Address]++
+	}
+	addresses := make([]string, 0, len(counts))
+	for a := range counts {
+		addresses = append(addresses, a)
+	}
+	sort.Strings(addresses)
+	total := int64(len(shares))
+	for _, a := range addresses {
+		amount := amt.Amount(int64(reward) / total * counts[a])
+		// the division is done in two parts to avoid overflowing with large rewards and windows
+		amount += amt.Amount(int64(reward) % total * counts[a] / total)
+		if amount < 1 {
+			continue
+		}
+		payouts = append(payouts, Payout{Address: a, Amount: amount})
+		remainder -= amount
+	}
+	return
+}
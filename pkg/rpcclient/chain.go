@@ -379,6 +379,119 @@ func (c *Client) GetBlockHeaderVerbose(blockHash *chainhash.Hash) (
 	return c.GetBlockHeaderVerboseAsync(blockHash).Receive()
 }
 
+// FutureGetMempoolRelativesResult is a future promise to deliver the result of a GetMempoolAncestorsAsync or
+// GetMempoolDescendantsAsync RPC invocation (or an applicable error).
+type FutureGetMempoolRelativesResult chan *response
+
+// Receive waits for the response promised by the future and returns the hashes of the relatives in the memory pool of
+// the transaction.
+func (r FutureGetMempoolRelativesResult) Receive() ([]*chainhash.Hash, error) {
+	res, e := receiveFuture(r)
+	if e != nil {
+		return nil, e
+	}
+	// Unmarshal the result as an array of strings.
+	var txHashStrs []string
+	e = js.Unmarshal(res, &txHashStrs)
+	if e != nil {
+		return nil, e
+	}
+	txHashes := make([]*chainhash.Hash, 0, len(txHashStrs))
+	for _, hashStr := range txHashStrs {
+		txHash, e := chainhash.NewHashFromStr(hashStr)
+		if e != nil {
+			return nil, e
+		}
+		txHashes = append(txHashes, txHash)
+	}
+	return txHashes, nil
+}
+
+// FutureGetMempoolRelativesVerboseResult is a future promise to deliver the result of a GetMempoolAncestorsVerboseAsync
+// or GetMempoolDescendantsVerboseAsync RPC invocation (or an applicable error).
+type FutureGetMempoolRelativesVerboseResult chan *response
+
+// Receive waits for the response promised by the future and returns a map of the hashes of the relatives in the memory
+// pool of the transaction to a data structure with information about them.
+func (r FutureGetMempoolRelativesVerboseResult) Receive() (
+	map[string]btcjson.GetMempoolEntryResult,
+	error,
+) {
+	res, e := receiveFuture(r)
+	if e != nil {
+		return nil, e
+	}
+	var relatives map[string]btcjson.GetMempoolEntryResult
+	e = js.Unmarshal(res, &relatives)
+	if e != nil {
+		return nil, e
+	}
+	return relatives, nil
+}
+
+// GetMempoolAncestorsAsync returns an instance of a type that can be used to get the result of the RPC at some future
+// time by invoking the Receive function on the returned instance. See GetMempoolAncestors for the blocking version and
+// more details.
+func (c *Client) GetMempoolAncestorsAsync(txHash string) FutureGetMempoolRelativesResult {
+	cmd := btcjson.NewGetMempoolAncestorsCmd(txHash, btcjson.Bool(false))
+	return c.sendCmd(cmd)
+}
+
+// GetMempoolAncestors returns the hashes of the unconfirmed ancestors in the memory pool of a transaction in the memory
+// pool. See GetMempoolAncestorsVerbose to retrieve data structures with information about them instead.
+func (c *Client) GetMempoolAncestors(txHash string) ([]*chainhash.Hash, error) {
+	return c.GetMempoolAncestorsAsync(txHash).Receive()
+}
+
+// GetMempoolAncestorsVerboseAsync returns an instance of a type that can be used to get the result of the RPC at some
+// future time by invoking the Receive function on the returned instance. See GetMempoolAncestorsVerbose for the
+// blocking version and more details.
+func (c *Client) GetMempoolAncestorsVerboseAsync(txHash string) FutureGetMempoolRelativesVerboseResult {
+	cmd := btcjson.NewGetMempoolAncestorsCmd(txHash, btcjson.Bool(true))
+	return c.sendCmd(cmd)
+}
+
+// GetMempoolAncestorsVerbose returns a map of the hashes of the unconfirmed ancestors in the memory pool of a
+// transaction in the memory pool to a data structure with information about them.
+func (c *Client) GetMempoolAncestorsVerbose(txHash string) (
+	map[string]btcjson.GetMempoolEntryResult,
+	error,
+) {
+	return c.GetMempoolAncestorsVerboseAsync(txHash).Receive()
+}
+
+// GetMempoolDescendantsAsync returns an instance of a type that can be used to get the result of the RPC at some future
+// time by invoking the Receive function on the returned instance. See GetMempoolDescendants for the blocking version
+// and more details.
+func (c *Client) GetMempoolDescendantsAsync(txHash string) FutureGetMempoolRelativesResult {
+	cmd := btcjson.NewGetMempoolDescendantsCmd(txHash, btcjson.Bool(false))
+	return c.sendCmd(cmd)
+}
+
+// GetMempoolDescendants returns the hashes of the transactions in the memory pool that spend the outputs of a
+// transaction in the memory pool, directly or through other transactions. See GetMempoolDescendantsVerbose to retrieve
+// data structures with information about them instead.
+func (c *Client) GetMempoolDescendants(txHash string) ([]*chainhash.Hash, error) {
+	return c.GetMempoolDescendantsAsync(txHash).Receive()
+}
+
+// GetMempoolDescendantsVerboseAsync returns an instance of a type that can be used to get the result of the RPC at
+// some future time by invoking the Receive function on the returned instance. See GetMempoolDescendantsVerbose for the
+// blocking version and more details.
+func (c *Client) GetMempoolDescendantsVerboseAsync(txHash string) FutureGetMempoolRelativesVerboseResult {
+	cmd := btcjson.NewGetMempoolDescendantsCmd(txHash, btcjson.Bool(true))
+	return c.sendCmd(cmd)
+}
+
+// GetMempoolDescendantsVerbose returns a map of the hashes of the descendants in the memory pool of a transaction in
+// the memory pool to a data structure with information about them.
+func (c *Client) GetMempoolDescendantsVerbose(txHash string) (
+	map[string]btcjson.GetMempoolEntryResult,
+	error,
+) {
+	return c.GetMempoolDescendantsVerboseAsync(txHash).Receive()
+}
+
 // FutureGetMempoolEntryResult is a future promise to deliver the result of a GetMempoolEntryAsync RPC invocation (or an
 // applicable error).
 type FutureGetMempoolEntryResult chan *response
@@ -500,6 +613,66 @@ func (c *Client) GetRawMempoolVerbose() (
 	return c.GetRawMempoolVerboseAsync().Receive()
 }
 
+// FutureSaveMempoolResult is a future promise to deliver the result of a SaveMempoolAsync RPC invocation (or an applicable
+// error).
+type FutureSaveMempoolResult chan *response
+
+// Receive waits for the response promised by the future and returns the number of transactions saved and the file they were
+// saved to.
+func (r FutureSaveMempoolResult) Receive() (*btcjson.SaveMempoolResult, error) {
+	res, e := receiveFuture(r)
+	if e != nil {
+		return nil, e
+	}
+	var result btcjson.SaveMempoolResult
+	if e = js.Unmarshal(res, &result); E.Chk(e) {
+		return nil, e
+	}
+	return &result, nil
+}
+
+// SaveMempoolAsync returns an instance of a type that can be used to get the result of the RPC at some future time by
+// invoking the Receive function on the returned instance. See SaveMempool for the blocking version and more details.
+func (c *Client) SaveMempoolAsync() FutureSaveMempoolResult {
+	cmd := btcjson.NewSaveMempoolCmd()
+	return c.sendCmd(cmd)
+}
+
+// SaveMempool writes the transactions in the mempool of the server to a file it loads them from when it starts.
+func (c *Client) SaveMempool() (*btcjson.SaveMempoolResult, error) {
+	return c.SaveMempoolAsync().Receive()
+}
+
+// FutureLoadMempoolResult is a future promise to deliver the result of a LoadMempoolAsync RPC invocation (or an applicable
+// error).
+type FutureLoadMempoolResult chan *response
+
+// Receive waits for the response promised by the future and returns the numbers of transactions accepted into the mempool,
+// already in it and rejected.
+func (r FutureLoadMempoolResult) Receive() (*btcjson.LoadMempoolResult, error) {
+	res, e := receiveFuture(r)
+	if e != nil {
+		return nil, e
+	}
+	var result btcjson.LoadMempoolResult
+	if e = js.Unmarshal(res, &result); E.Chk(e) {
+		return nil, e
+	}
+	return &result, nil
+}
+
+// LoadMempoolAsync returns an instance of a type that can be used to get the result of the RPC at some future time by
+// invoking the Receive function on the returned instance. See LoadMempool for the blocking version and more details.
+func (c *Client) LoadMempoolAsync() FutureLoadMempoolResult {
+	cmd := btcjson.NewLoadMempoolCmd()
+	return c.sendCmd(cmd)
+}
+
+// LoadMempool adds the transactions in the saved mempool file of the server that are still valid to its mempool.
+func (c *Client) LoadMempool() (*btcjson.LoadMempoolResult, error) {
+	return c.LoadMempoolAsync().Receive()
+}
+
 // FutureEstimateFeeResult is a future promise to deliver the result of a EstimateFeeAsync RPC invocation (or an
 // applicable error).
 type FutureEstimateFeeResult chan *response
@@ -713,6 +886,89 @@ func (c *Client) InvalidateBlock(blockHash *chainhash.Hash) (e error) {
 	return c.InvalidateBlockAsync(blockHash).Receive()
 }
 
+// FutureGetChainTipsResult is a future promise to deliver the result of a GetChainTipsAsync RPC invocation (or an
+// applicable error).
+type FutureGetChainTipsResult chan *response
+
+// Receive waits for the response promised by the future and returns the tips of the chains known to the server.
+func (r FutureGetChainTipsResult) Receive() (tips []btcjson.GetChainTipsResult, e error) {
+	var res []byte
+	if res, e = receiveFuture(r); E.Chk(e) {
+		return
+	}
+	if e = js.Unmarshal(res, &tips); E.Chk(e) {
+	}
+	return
+}
+
+// GetChainTipsAsync returns an instance of a type that can be used to get the result of the RPC at some future time
+// by invoking the Receive function on the returned instance. See GetChainTips for the blocking version and more
+// details.
+func (c *Client) GetChainTipsAsync() FutureGetChainTipsResult {
+	cmd := btcjson.NewGetChainTipsCmd()
+	return c.sendCmd(cmd)
+}
+
+// GetChainTips returns every block known to the server that no other known block builds on, including the tip of the
+// main chain.
+func (c *Client) GetChainTips() ([]btcjson.GetChainTipsResult, error) {
+	return c.GetChainTipsAsync().Receive()
+}
+
+// FutureReconsiderBlockResult is a future promise to deliver the result of a ReconsiderBlockAsync RPC invocation (or an
+// applicable error).
+type FutureReconsiderBlockResult chan *response
+
+// Receive waits for the response promised by the future and returns an error if the block could not be reconsidered.
+func (r FutureReconsiderBlockResult) Receive() (e error) {
+	_, e = receiveFuture(r)
+	return e
+}
+
+// ReconsiderBlockAsync returns an instance of a type that can be used to get the result of the RPC at some future time
+// by invoking the Receive function on the returned instance. See ReconsiderBlock for the blocking version and more
+// details.
+func (c *Client) ReconsiderBlockAsync(blockHash *chainhash.Hash) FutureReconsiderBlockResult {
+	hash := ""
+	if blockHash != nil {
+		hash = blockHash.String()
+	}
+	cmd := btcjson.NewReconsiderBlockCmd(hash)
+	return c.sendCmd(cmd)
+}
+
+// ReconsiderBlock removes the invalid marks from a block, its ancestors and the blocks built on it.
+func (c *Client) ReconsiderBlock(blockHash *chainhash.Hash) (e error) {
+	return c.ReconsiderBlockAsync(blockHash).Receive()
+}
+
+// FuturePreciousBlockResult is a future promise to deliver the result of a PreciousBlockAsync RPC invocation (or an
+// applicable error).
+type FuturePreciousBlockResult chan *response
+
+// Receive waits for the response promised by the future and returns an error if the block could not be preferred.
+func (r FuturePreciousBlockResult) Receive() (e error) {
+	_, e = receiveFuture(r)
+	return e
+}
+
+// PreciousBlockAsync returns an instance of a type that can be used to get the result of the RPC at some future time
+// by invoking the Receive function on the returned instance. See PreciousBlock for the blocking version and more
+// details.
+func (c *Client) PreciousBlockAsync(blockHash *chainhash.Hash) FuturePreciousBlockResult {
+	hash := ""
+	if blockHash != nil {
+		hash = blockHash.String()
+	}
+	cmd := btcjson.NewPreciousBlockCmd(hash)
+	return c.sendCmd(cmd)
+}
+
+// PreciousBlock prefers a block over a competing tip with equal work.
+func (c *Client) PreciousBlock(blockHash *chainhash.Hash) (e error) {
+	return c.PreciousBlockAsync(blockHash).Receive()
+}
+
 // FutureGetCFilterResult is a future promise to deliver the result of a GetCFilterAsync RPC invocation (or an
 // applicable error).
 type FutureGetCFilterResult chan *response
@@ -174,6 +174,77 @@ func (c *Client) GetMiningInfo() (*btcjson.GetMiningInfoResult, error) {
 	return c.GetMiningInfoAsync().Receive()
 }
 
+// FutureGetAlgoStatsResult is a future promise to deliver the result of a GetAlgoStatsAsync RPC invocation (or an
+// applicable error).
+type FutureGetAlgoStatsResult chan *response
+
+// Receive waits for the response promised by the future and returns the recent activity of each block version.
+func (r FutureGetAlgoStatsResult) Receive() (
+	*btcjson.GetAlgoStatsResult,
+	error,
+) {
+	res, e := receiveFuture(r)
+	if e != nil {
+		return nil, e
+	}
+	// Unmarshal result as a getalgostats result object.
+	var statsResult btcjson.GetAlgoStatsResult
+	e = js.Unmarshal(res, &statsResult)
+	if e != nil {
+		return nil, e
+	}
+	return &statsResult, nil
+}
+
+// GetAlgoStatsAsync returns an instance of a type that can be used to get the result of the RPC at some future time by
+// invoking the Receive function on the returned instance. See GetAlgoStats for the blocking version and more details.
+func (c *Client) GetAlgoStatsAsync(blocks int) FutureGetAlgoStatsResult {
+	cmd := btcjson.NewGetAlgoStatsCmd(&blocks)
+	return c.sendCmd(cmd)
+}
+
+// GetAlgoStats returns the target, block intervals, share of the given number of most recent blocks and estimated
+// hashrate of each block version of the current hard fork.
+func (c *Client) GetAlgoStats(blocks int) (*btcjson.GetAlgoStatsResult, error) {
+	return c.GetAlgoStatsAsync(blocks).Receive()
+}
+
+// FutureGetPoolInfoResult is a future promise to deliver the result of a GetPoolInfoAsync RPC invocation (or an
+// applicable error).
+type FutureGetPoolInfoResult chan *response
+
+// Receive waits for the response promised by the future and returns the share window, pending balances and recent
+// blocks of the pool.
+func (r FutureGetPoolInfoResult) Receive() (
+	*btcjson.GetPoolInfoResult,
+	error,
+) {
+	res, e := receiveFuture(r)
+	if e != nil {
+		return nil, e
+	}
+	// Unmarshal result as a getpoolinfo result object.
+	var infoResult btcjson.GetPoolInfoResult
+	e = js.Unmarshal(res, &infoResult)
+	if e != nil {
+		return nil, e
+	}
+	return &infoResult, nil
+}
+
+// GetPoolInfoAsync returns an instance of a type that can be used to get the result of the RPC at some future time by
+// invoking the Receive function on the returned instance. See GetPoolInfo for the blocking version and more details.
+func (c *Client) GetPoolInfoAsync(blocks int) FutureGetPoolInfoResult {
+	cmd := btcjson.NewGetPoolInfoCmd(&blocks)
+	return c.sendCmd(cmd)
+}
+
+// GetPoolInfo returns the share window, pending balances and up to the given number of most recent blocks found by a
+// controller running in pool mode.
+func (c *Client) GetPoolInfo(blocks int) (*btcjson.GetPoolInfoResult, error) {
+	return c.GetPoolInfoAsync(blocks).Receive()
+}
+
 // FutureGetNetworkHashPS is a future promise to deliver the result of a GetNetworkHashPSAsync RPC invocation (or an
 // applicable error).
 type FutureGetNetworkHashPS chan *response
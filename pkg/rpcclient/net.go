@@ -245,6 +245,79 @@ func (c *Client) GetPeerInfo() ([]btcjson.GetPeerInfoResult, error) {
 	return c.GetPeerInfoAsync().Receive()
 }
 
+// FutureGetLANPeersResult is a future promise to deliver the result of a GetLANPeersAsync RPC invocation (or an
+// applicable error).
+type FutureGetLANPeersResult chan *response
+
+// Receive waits for the response promised by the future and returns the nodes found on the local network.
+func (r FutureGetLANPeersResult) Receive() (
+	[]btcjson.GetLANPeersResult, error,
+) {
+	res, e := receiveFuture(r)
+	if e != nil {
+		return nil, e
+	}
+	var peers []btcjson.GetLANPeersResult
+	e = js.Unmarshal(res, &peers)
+	if e != nil {
+		return nil, e
+	}
+	return peers, nil
+}
+
+// GetLANPeersAsync returns an instance of a type that can be used to get the result of the RPC at some future time by
+// invoking the Receive function on the returned instance.
+//
+// See GetLANPeers for the blocking version and more details.
+func (c *Client) GetLANPeersAsync() FutureGetLANPeersResult {
+	cmd := btcjson.NewGetLANPeersCmd()
+	return c.sendCmd(cmd)
+}
+
+// GetLANPeers returns the nodes the server found on the local network from their advertisments.
+//
+// NOTE: This is a pod extension and requires LAN peer discovery to be enabled on the server.
+func (c *Client) GetLANPeers() ([]btcjson.GetLANPeersResult, error) {
+	return c.GetLANPeersAsync().Receive()
+}
+
+// FutureGetNetworkInfoResult is a future promise to deliver the result of a GetNetworkInfoAsync RPC invocation (or an
+// applicable error).
+type FutureGetNetworkInfoResult chan *response
+
+// Receive waits for the response promised by the future and returns information about the node's connection to the
+// network.
+func (r FutureGetNetworkInfoResult) Receive() (
+	*btcjson.GetNetworkInfoResult, error,
+) {
+	res, e := receiveFuture(r)
+	if e != nil {
+		return nil, e
+	}
+	// Unmarshal result as a getnetworkinfo result object.
+	var info btcjson.GetNetworkInfoResult
+	e = js.Unmarshal(res, &info)
+	if e != nil {
+		return nil, e
+	}
+	return &info, nil
+}
+
+// GetNetworkInfoAsync returns an instance of a type that can be used to get the result of the RPC at some future time
+// by invoking the Receive function on the returned instance.
+//
+// See GetNetworkInfo for the blocking version and more details.
+func (c *Client) GetNetworkInfoAsync() FutureGetNetworkInfoResult {
+	cmd := btcjson.NewGetNetworkInfoCmd()
+	return c.sendCmd(cmd)
+}
+
+// GetNetworkInfo returns information about the node's connection to the network, such as its services, relay fee,
+// reachable networks and local addresses.
+func (c *Client) GetNetworkInfo() (*btcjson.GetNetworkInfoResult, error) {
+	return c.GetNetworkInfoAsync().Receive()
+}
+
 // FutureGetNetTotalsResult is a future promise to deliver the result of a GetNetTotalsAsync RPC invocation (or an
 // applicable error).
 type FutureGetNetTotalsResult chan *response
@@ -447,6 +447,51 @@ func (c *Client) SetTxFee(fee amt.Amount) (e error) {
 	return c.SetTxFeeAsync(fee).Receive()
 }
 
+// FutureBumpFeeResult is a future promise to deliver the result of a BumpFeeAsync RPC invocation (or an applicable
+// error).
+type FutureBumpFeeResult chan *response
+
+// Receive waits for the response promised by the future and returns the hash of the replacement transaction and the
+// fees paid by it and the transaction it replaces.
+func (r FutureBumpFeeResult) Receive() (*btcjson.BumpFeeResult, error) {
+	res, e := receiveFuture(r)
+	if e != nil {
+		return nil, e
+	}
+	var result btcjson.BumpFeeResult
+	e = js.Unmarshal(res, &result)
+	if e != nil {
+		return nil, e
+	}
+	return &result, nil
+}
+
+// BumpFeeAsync returns an instance of a type that can be used to get the result of the RPC at some future time by
+// invoking the Receive function on the returned instance.
+//
+// See BumpFee for the blocking version and more details.
+func (c *Client) BumpFeeAsync(txHash *chainhash.Hash, feeRate *amt.Amount) FutureBumpFeeResult {
+	hash := ""
+	if txHash != nil {
+		hash = txHash.String()
+	}
+	var rate *float64
+	if feeRate != nil {
+		r := feeRate.ToDUO()
+		rate = &r
+	}
+	cmd := btcjson.NewBumpFeeCmd(hash, rate)
+	return c.sendCmd(cmd)
+}
+
+// BumpFee replaces an unconfirmed wallet transaction that signals replacement (BIP125) with one paying the given fee
+// rate per kB, or the fee rate of the original plus the relay fee if it is nil.
+//
+// NOTE: This function requires to the wallet to be unlocked. See the WalletPassphrase function for more details.
+func (c *Client) BumpFee(txHash *chainhash.Hash, feeRate *amt.Amount) (*btcjson.BumpFeeResult, error) {
+	return c.BumpFeeAsync(txHash, feeRate).Receive()
+}
+
 // FutureSendToAddressResult is a future promise to deliver the result of a SendToAddressAsync RPC invocation (or an
 // applicable error).
 type FutureSendToAddressResult chan *response
@@ -475,7 +520,7 @@ func (c *Client) SendToAddressAsync(
 	address btcaddr.Address, amount amt.Amount,
 ) FutureSendToAddressResult {
 	addr := address.EncodeAddress()
-	cmd := btcjson.NewSendToAddressCmd(addr, amount.ToDUO(), nil, nil)
+	cmd := btcjson.NewSendToAddressCmd(addr, amount.ToDUO(), nil, nil, nil)
 	return c.sendCmd(cmd)
 }
 
@@ -505,7 +550,7 @@ func (c *Client) SendToAddressCommentAsync(
 	addr := address.EncodeAddress()
 	cmd := btcjson.NewSendToAddressCmd(
 		addr, amount.ToDUO(), &comment,
-		&commentTo,
+		&commentTo, nil,
 	)
 	return c.sendCmd(cmd)
 }
@@ -683,7 +728,7 @@ func (c *Client) SendManyAsync(
 	for addr, amount := range amounts {
 		convertedAmounts[addr.EncodeAddress()] = amount.ToDUO()
 	}
-	cmd := btcjson.NewSendManyCmd(fromAccount, convertedAmounts, nil, nil)
+	cmd := btcjson.NewSendManyCmd(fromAccount, convertedAmounts, nil, nil, nil)
 	return c.sendCmd(cmd)
 }
 
@@ -715,7 +760,7 @@ func (c *Client) SendManyMinConfAsync(
 	}
 	cmd := btcjson.NewSendManyCmd(
 		fromAccount, convertedAmounts,
-		&minConfirms, nil,
+		&minConfirms, nil, nil,
 	)
 	return c.sendCmd(cmd)
 }
@@ -749,7 +794,7 @@ func (c *Client) SendManyCommentAsync(
 	}
 	cmd := btcjson.NewSendManyCmd(
 		fromAccount, convertedAmounts,
-		&minConfirms, &comment,
+		&minConfirms, &comment, nil,
 	)
 	return c.sendCmd(cmd)
 }
@@ -2274,12 +2319,304 @@ func (c *Client) GetInfo() (*btcjson.InfoWalletResult, error) {
 	return c.GetInfoAsync().Receive()
 }
 
+// FutureGetWalletInfoResult is a future promise to deliver the result of a GetWalletInfoAsync RPC invocation (or an
+// applicable error).
+type FutureGetWalletInfoResult chan *response
+
+// Receive waits for the response promised by the future and returns the balances, lock state and sync state of the
+// wallet.
+func (r FutureGetWalletInfoResult) Receive() (*btcjson.GetWalletInfoResult, error) {
+	res, e := receiveFuture(r)
+	if e != nil {
+		return nil, e
+	}
+	// Unmarshal result as a getwalletinfo result object.
+	var infoRes btcjson.GetWalletInfoResult
+	e = js.Unmarshal(res, &infoRes)
+	if e != nil {
+		return nil, e
+	}
+	return &infoRes, nil
+}
+
+// GetWalletInfoAsync returns an instance of a type that can be used to get the result of the RPC at some future time
+// by invoking the Receive function on the returned instance.
+//
+// See GetWalletInfo for the blocking version and more details.
+func (c *Client) GetWalletInfoAsync() FutureGetWalletInfoResult {
+	cmd := btcjson.NewGetWalletInfoCmd()
+	return c.sendCmd(cmd)
+}
+
+// GetWalletInfo returns the balances, lock state and sync state of the wallet.
+func (c *Client) GetWalletInfo() (*btcjson.GetWalletInfoResult, error) {
+	return c.GetWalletInfoAsync().Receive()
+}
+
+// FutureListAddressGroupingsResult is a future promise to deliver the result of a ListAddressGroupingsAsync RPC
+// invocation (or an applicable error).
+type FutureListAddressGroupingsResult chan *response
+
+// Receive waits for the response promised by the future and returns the wallet addresses grouped by common
+// ownership.
+func (r FutureListAddressGroupingsResult) Receive() ([][]btcjson.AddressGroupingResult, error) {
+	res, e := receiveFuture(r)
+	if e != nil {
+		return nil, e
+	}
+	// Unmarshal result as an array of arrays of address grouping result objects.
+	var groupings [][]btcjson.AddressGroupingResult
+	e = js.Unmarshal(res, &groupings)
+	if e != nil {
+		return nil, e
+	}
+	return groupings, nil
+}
+
+// ListAddressGroupingsAsync returns an instance of a type that can be used to get the result of the RPC at some
+// future time by invoking the Receive function on the returned instance.
+//
+// See ListAddressGroupings for the blocking version and more details.
+func (c *Client) ListAddressGroupingsAsync() FutureListAddressGroupingsResult {
+	cmd := btcjson.NewListAddressGroupingsCmd()
+	return c.sendCmd(cmd)
+}
+
+// ListAddressGroupings returns the wallet addresses grouped by the common ownership made public by spending them
+// together as transaction inputs or receiving change.
+func (c *Client) ListAddressGroupings() ([][]btcjson.AddressGroupingResult, error) {
+	return c.ListAddressGroupingsAsync().Receive()
+}
+
+// FutureBackupWalletResult is a future promise to deliver the result of a BackupWalletAsync RPC invocation (or an
+// applicable error).
+type FutureBackupWalletResult chan *response
+
+// Receive waits for the response promised by the future and returns the result of backing up the wallet.
+func (r FutureBackupWalletResult) Receive() (e error) {
+	_, e = receiveFuture(r)
+	return e
+}
+
+// BackupWalletAsync returns an instance of a type that can be used to get the result of the RPC at some future time
+// by invoking the Receive function on the returned instance.
+//
+// See BackupWallet for the blocking version and more details.
+func (c *Client) BackupWalletAsync(destination string) FutureBackupWalletResult {
+	cmd := btcjson.NewBackupWalletCmd(destination)
+	return c.sendCmd(cmd)
+}
+
+// BackupWallet writes a copy of the wallet database to destination on the host of the wallet server.
+func (c *Client) BackupWallet(destination string) (e error) {
+	return c.BackupWalletAsync(destination).Receive()
+}
+
+// FutureDumpWalletResult is a future promise to deliver the result of a DumpWalletAsync RPC invocation (or an
+// applicable error).
+type FutureDumpWalletResult chan *response
+
+// Receive waits for the response promised by the future and returns the path of the written wallet dump.
+func (r FutureDumpWalletResult) Receive() (string, error) {
+	res, e := receiveFuture(r)
+	if e != nil {
+		return "", e
+	}
+	// Unmarshal result as a dumpwallet result object.
+	var dumpRes btcjson.DumpWalletResult
+	e = js.Unmarshal(res, &dumpRes)
+	if e != nil {
+		return "", e
+	}
+	return dumpRes.Filename, nil
+}
+
+// DumpWalletAsync returns an instance of a type that can be used to get the result of the RPC at some future time by
+// invoking the Receive function on the returned instance.
+//
+// See DumpWallet for the blocking version and more details.
+func (c *Client) DumpWalletAsync(filename string) FutureDumpWalletResult {
+	cmd := btcjson.NewDumpWalletCmd(filename)
+	return c.sendCmd(cmd)
+}
+
+// DumpWallet writes all private keys of the wallet to filename on the host of the wallet server and returns its
+// absolute path.
+//
+// NOTE: This function requires to the wallet to be unlocked. See the WalletPassphrase function for more details.
+func (c *Client) DumpWallet(filename string) (string, error) {
+	return c.DumpWalletAsync(filename).Receive()
+}
+
+// FutureImportWalletResult is a future promise to deliver the result of an ImportWalletAsync RPC invocation (or an
+// applicable error).
+type FutureImportWalletResult chan *response
+
+// Receive waits for the response promised by the future and returns the result of importing the wallet dump.
+func (r FutureImportWalletResult) Receive() (e error) {
+	_, e = receiveFuture(r)
+	return e
+}
+
+// ImportWalletAsync returns an instance of a type that can be used to get the result of the RPC at some future time
+// by invoking the Receive function on the returned instance.
+//
+// See ImportWallet for the blocking version and more details.
+func (c *Client) ImportWalletAsync(filename string) FutureImportWalletResult {
+	cmd := btcjson.NewImportWalletCmd(filename)
+	return c.sendCmd(cmd)
+}
+
+// ImportWallet imports the private keys of the wallet dump at filename on the host of the wallet server.
+//
+// NOTE: This function requires to the wallet to be unlocked. See the WalletPassphrase function for more details.
+func (c *Client) ImportWallet(filename string) (e error) {
+	return c.ImportWalletAsync(filename).Receive()
+}
+
+// FutureSetLabelResult is a future promise to deliver the result of a SetLabelAsync RPC invocation (or an applicable
+// error).
+type FutureSetLabelResult chan *response
+
+// Receive waits for the response promised by the future and returns the result of setting the label.
+func (r FutureSetLabelResult) Receive() (e error) {
+	_, e = receiveFuture(r)
+	return e
+}
+
+// SetLabelAsync returns an instance of a type that can be used to get the result of the RPC at some future time by
+// invoking the Receive function on the returned instance.
+//
+// See SetLabel for the blocking version and more details.
+func (c *Client) SetLabelAsync(target, label string) FutureSetLabelResult {
+	cmd := btcjson.NewSetLabelCmd(target, label)
+	return c.sendCmd(cmd)
+}
+
+// SetLabel sets the label of an address or of the wallet transaction with the hash target, or removes it if the label
+// is empty.
+func (c *Client) SetLabel(target, label string) (e error) {
+	return c.SetLabelAsync(target, label).Receive()
+}
+
+// FutureGetAddressesByLabelResult is a future promise to deliver the result of a GetAddressesByLabelAsync RPC
+// invocation (or an applicable error).
+type FutureGetAddressesByLabelResult chan *response
+
+// Receive waits for the response promised by the future and returns the addresses carrying the label mapped to their
+// purpose.
+func (r FutureGetAddressesByLabelResult) Receive() (map[string]btcjson.AddressPurposeResult, error) {
+	res, e := receiveFuture(r)
+	if e != nil {
+		return nil, e
+	}
+	// Unmarshal result as an object of addresses and their purpose.
+	var addrs map[string]btcjson.AddressPurposeResult
+	e = js.Unmarshal(res, &addrs)
+	if e != nil {
+		return nil, e
+	}
+	return addrs, nil
+}
+
+// GetAddressesByLabelAsync returns an instance of a type that can be used to get the result of the RPC at some future
+// time by invoking the Receive function on the returned instance.
+//
+// See GetAddressesByLabel for the blocking version and more details.
+func (c *Client) GetAddressesByLabelAsync(label string) FutureGetAddressesByLabelResult {
+	cmd := btcjson.NewGetAddressesByLabelCmd(label)
+	return c.sendCmd(cmd)
+}
+
+// GetAddressesByLabel returns the addresses carrying the label, mapped to whether they are wallet addresses labelled
+// to receive payments or other addresses labelled to send payments to.
+func (c *Client) GetAddressesByLabel(label string) (map[string]btcjson.AddressPurposeResult, error) {
+	return c.GetAddressesByLabelAsync(label).Receive()
+}
+
+// FutureListLabelsResult is a future promise to deliver the result of a ListLabelsAsync RPC invocation (or an
+// applicable error).
+type FutureListLabelsResult chan *response
+
+// Receive waits for the response promised by the future and returns the labels.
+func (r FutureListLabelsResult) Receive() ([]string, error) {
+	res, e := receiveFuture(r)
+	if e != nil {
+		return nil, e
+	}
+	// Unmarshal result as an array of strings.
+	var labels []string
+	e = js.Unmarshal(res, &labels)
+	if e != nil {
+		return nil, e
+	}
+	return labels, nil
+}
+
+// ListLabelsAsync returns an instance of a type that can be used to get the result of the RPC at some future time by
+// invoking the Receive function on the returned instance.
+//
+// See ListLabels for the blocking version and more details.
+func (c *Client) ListLabelsAsync() FutureListLabelsResult {
+	cmd := btcjson.NewListLabelsCmd(nil)
+	return c.sendCmd(cmd)
+}
+
+// ListLabels returns the sorted labels of addresses.
+func (c *Client) ListLabels() ([]string, error) {
+	return c.ListLabelsAsync().Receive()
+}
+
+// ListLabelsPurposeAsync returns an instance of a type that can be used to get the result of the RPC at some future
+// time by invoking the Receive function on the returned instance.
+//
+// See ListLabelsPurpose for the blocking version and more details.
+func (c *Client) ListLabelsPurposeAsync(purpose string) FutureListLabelsResult {
+	cmd := btcjson.NewListLabelsCmd(&purpose)
+	return c.sendCmd(cmd)
+}
+
+// ListLabelsPurpose returns the sorted labels of wallet addresses if purpose is "receive" or of other addresses if it
+// is "send".
+func (c *Client) ListLabelsPurpose(purpose string) ([]string, error) {
+	return c.ListLabelsPurposeAsync(purpose).Receive()
+}
+
+// FutureListAddressLabelsResult is a future promise to deliver the result of a ListAddressLabelsAsync RPC invocation
+// (or an applicable error).
+type FutureListAddressLabelsResult chan *response
+
+// Receive waits for the response promised by the future and returns the labelled addresses mapped to their label and
+// purpose.
+func (r FutureListAddressLabelsResult) Receive() (map[string]btcjson.AddressLabelResult, error) {
+	res, e := receiveFuture(r)
+	if e != nil {
+		return nil, e
+	}
+	// Unmarshal result as an object of addresses and their label and purpose.
+	var addrs map[string]btcjson.AddressLabelResult
+	e = js.Unmarshal(res, &addrs)
+	if e != nil {
+		return nil, e
+	}
+	return addrs, nil
+}
+
+// ListAddressLabelsAsync returns an instance of a type that can be used to get the result of the RPC at some future
+// time by invoking the Receive function on the returned instance.
+//
+// See ListAddressLabels for the blocking version and more details.
+func (c *Client) ListAddressLabelsAsync() FutureListAddressLabelsResult {
+	cmd := btcjson.NewListAddressLabelsCmd()
+	return c.sendCmd(cmd)
+}
+
+// ListAddressLabels returns every labelled address mapped to its label and to whether it is a wallet address labelled
+// to receive payments or another address labelled to send payments to.
+func (c *Client) ListAddressLabels() (map[string]btcjson.AddressLabelResult, error) {
+	return c.ListAddressLabelsAsync().Receive()
+}
+
 // TODO(davec): Implement
-//  backupwallet (NYI in btcwallet)
 //  encryptwallet (Won't be supported by btcwallet since it's always encrypted)
-//  getwalletinfo (NYI in btcwallet or json)
-//  listaddressgroupings (NYI in btcwallet)
 //  listreceivedbyaccount (NYI in btcwallet)
-//  DUMP
-//  importwallet (NYI in btcwallet)
-//  dumpwallet (NYI in btcwallet)
@@ -10,6 +10,17 @@ var helpDescsEnUS = map[string]string{
 	"addmultisigaddress-keys":      "Pubkeys and/or pay-to-pubkey-hash addresses to partially control the multisig address",
 	"addmultisigaddress-nrequired": "The number of signatures required to redeem outputs paid to this address",
 	"addmultisigaddress--result0":  "The imported pay-to-script-hash address",
+	// BackupWalletCmd help.
+	"backupwallet--synopsis":   "Writes a consistent copy of the wallet database to a file while the wallet keeps running.",
+	"backupwallet-destination": "The file to write the copy to, or a directory to write wallet.db into",
+	// BumpFeeCmd help.
+	"bumpfee--synopsis": "Replaces an unconfirmed wallet transaction that signals replacement (BIP125) with one paying a higher fee, spending the same inputs and reducing its change.",
+	"bumpfee-txid":      "The hash of the transaction to replace",
+	"bumpfee-feerate":   "The fee rate per kB valued in bitcoin to pay, which defaults to the fee rate of the transaction plus the relay fee",
+	// BumpFeeResult help.
+	"bumpfeeresult-txid":    "The hash of the replacement transaction",
+	"bumpfeeresult-origfee": "The fee paid by the replaced transaction valued in bitcoin",
+	"bumpfeeresult-fee":     "The fee paid by the replacement transaction valued in bitcoin",
 	// CreateMultisigCmd help.
 	"createmultisig--synopsis": "Generate a multisig address and redeem script.",
 	"createmultisig-keys":      "Pubkeys and/or pay-to-pubkey-hash addresses to partially control the multisig address",
@@ -21,6 +32,11 @@ var helpDescsEnUS = map[string]string{
 	"dumpprivkey--synopsis": "Returns the private key in WIF encoding that controls some wallet address.",
 	"dumpprivkey-address":   "The address to return a private key for",
 	"dumpprivkey--result0":  "The WIF-encoded private key",
+	// DumpWalletCmd help.
+	"dumpwallet--synopsis": "Writes all private keys of the wallet to a new file in a human readable format, along with their accounts, addresses and HD derivation paths.",
+	"dumpwallet-filename":  "The file to write the keys to, which must not exist yet",
+	// DumpWalletResult help.
+	"dumpwalletresult-filename": "The absolute path of the written file",
 	// GetAccountCmd help.
 	"getaccount--synopsis": "DEPRECATED -- Lookup the account name that some wallet address belongs to.",
 	"getaccount-address":   "The address to query the account for",
@@ -34,6 +50,12 @@ var helpDescsEnUS = map[string]string{
 	"getaddressesbyaccount--synopsis": "DEPRECATED -- Returns all addresses strings controlled by a single account.",
 	"getaddressesbyaccount-account":   "Account name to fetch addresses for",
 	"getaddressesbyaccount--result0":  "All addresses controlled by 'account'",
+	// GetAddressesByLabelCmd help.
+	"getaddressesbylabel--synopsis":       "Returns the addresses carrying a label, which may be wallet addresses or addresses that are paid to.",
+	"getaddressesbylabel-label":           "The label to return the addresses of",
+	"getaddressesbylabel--result0--desc":  "JSON object with addresses as keys and their purpose as values",
+	"getaddressesbylabel--result0--key":   "The address",
+	"getaddressesbylabel--result0--value": `{"purpose": "receive" for wallet addresses or "send" for all other addresses}`,
 	// GetBalanceCmd help.
 	"getbalance--synopsis":   "Calculates and returns the balance of one or all accounts.",
 	"getbalance-minconf":     "Minimum number of block confirmations required before an unspent output's value is included in the balance",
@@ -67,6 +89,20 @@ var helpDescsEnUS = map[string]string{
 	"infowalletresult-unlocked_until":  "Unset",
 	"infowalletresult-keypoolsize":     "Unset",
 	"infowalletresult-keypoololdest":   "Unset",
+	// GetWalletInfoCmd help.
+	"getwalletinfo--synopsis": "Returns a JSON object describing the balances, lock state and sync state of the wallet.",
+	// GetWalletInfoResult help.
+	"getwalletinforesult-walletname":          "The file name of the wallet database",
+	"getwalletinforesult-walletversion":       "The version of the address manager database",
+	"getwalletinforesult-balance":             "The spendable balance with at least one block confirmation valued in bitcoin",
+	"getwalletinforesult-unconfirmed_balance": "The balance of unconfirmed outputs valued in bitcoin",
+	"getwalletinforesult-immature_balance":    "The balance of coinbase outputs that have not matured valued in bitcoin",
+	"getwalletinforesult-txcount":             "The number of transactions in the wallet",
+	"getwalletinforesult-unlocked":            "Whether the wallet is unlocked",
+	"getwalletinforesult-paytxfee":            "The fee rate per kB used for authored transactions valued in bitcoin",
+	"getwalletinforesult-syncedheight":        "The height of the last block the wallet is synced to",
+	"getwalletinforesult-syncedhash":          "The hash of the last block the wallet is synced to",
+	"getwalletinforesult-birthday":            "The wallet birthday as a unix timestamp, before which no wallet transactions exist",
 	// GetNewAddressCmd help.
 	"getnewaddress--synopsis": "Generates and returns a new payment address.",
 	"getnewaddress-account":   "DEPRECATED -- Account name the new address will belong to (default=\"default\")",
@@ -122,6 +158,9 @@ var helpDescsEnUS = map[string]string{
 	"importprivkey-privkey":   "The WIF-encoded private key",
 	"importprivkey-label":     "Unused (must be unset or 'imported')",
 	"importprivkey-rescan":    "Rescan the blockchain (since the genesis block) for outputs controlled by the imported key",
+	// ImportWalletCmd help.
+	"importwallet--synopsis": "Imports all private keys of a wallet dump written by dumpwallet to the 'imported' account and rescans the blockchain (since the genesis block) for their outputs.",
+	"importwallet-filename":  "The wallet dump file to import",
 	// KeypoolRefillCmd help.
 	"keypoolrefill--synopsis": "DEPRECATED -- This request does nothing since no keypool is maintained.",
 	"keypoolrefill-newsize":   "Unused",
@@ -131,6 +170,21 @@ var helpDescsEnUS = map[string]string{
 	"listaccounts--result0--desc":  "JSON object with account names as keys and bitcoin amounts as values",
 	"listaccounts--result0--key":   "The account name",
 	"listaccounts--result0--value": "The account balance valued in bitcoin",
+	// ListAddressGroupingsCmd help.
+	"listaddressgroupings--synopsis": "Returns the wallet addresses grouped by common ownership made public by spending them together as transaction inputs or receiving change.",
+	// AddressGroupingResult help.
+	"addressgroupingresult-address": "The payment address",
+	"addressgroupingresult-amount":  "The unspent balance of the address valued in bitcoin",
+	"addressgroupingresult-account": "The account the address belongs to",
+	// ListAddressLabelsCmd help.
+	"listaddresslabels--synopsis":       "Returns every address labelled with setlabel along with its label and purpose.",
+	"listaddresslabels--result0--desc":  "JSON object with addresses as keys and their label and purpose as values",
+	"listaddresslabels--result0--key":   "The address",
+	"listaddresslabels--result0--value": `{"label": the label of the address, "purpose": "receive" for wallet addresses or "send" for all other addresses}`,
+	// ListLabelsCmd help.
+	"listlabels--synopsis": "Returns the sorted labels of addresses set with setlabel.",
+	"listlabels-purpose":   `Only return labels of wallet addresses with "receive" or of other addresses with "send"`,
+	"listlabels--result0":  "The labels",
 	// ListLockUnspentCmd help.
 	"listlockunspent--synopsis": "Returns a JSON array of outpoints marked as locked (with lockunspent) for this wallet session.",
 	// TransactionInput help.
@@ -186,7 +240,8 @@ var helpDescsEnUS = map[string]string{
 	"listtransactionsresult-time":               "The earliest Unix time this transaction was known to exist",
 	"listtransactionsresult-timereceived":       "The earliest Unix time this transaction was known to exist",
 	"listtransactionsresult-involveswatchonly":  "Unset",
-	"listtransactionsresult-comment":            "Unset",
+	"listtransactionsresult-label":              "The label of the address set with setlabel",
+	"listtransactionsresult-comment":            "The label of the transaction set with setlabel",
 	"listtransactionsresult-otheraccount":       "Unset",
 	"listtransactionsresult-trusted":            "Unset",
 	"listtransactionsresult-bip125-replaceable": "Unset",
@@ -240,16 +295,22 @@ var helpDescsEnUS = map[string]string{
 	"sendmany-amounts--value": "Amount to send to the payment address valued in bitcoin",
 	"sendmany-minconf":        "Minimum number of block confirmations required before a transaction output is eligible to be spent",
 	"sendmany-comment":        "Unused",
+	"sendmany-replaceable":    "Signal that the transaction can be replaced by one paying a higher fee (BIP125)",
 	"sendmany--result0":       "The transaction hash of the sent transaction",
 	// SendToAddressCmd help.
 	"sendtoaddress--synopsis": "Authors, signs, and sends a transaction that outputs some amount to a payment address.\n" +
 		"Unlike sendfrom, outputs are always chosen from the default account.\n" +
 		"A change output is automatically included to send extra output value back to the original account.",
-	"sendtoaddress-address":   "Address to pay",
-	"sendtoaddress-amount":    "Amount to send to the payment address valued in bitcoin",
-	"sendtoaddress-comment":   "Unused",
-	"sendtoaddress-commentto": "Unused",
-	"sendtoaddress--result0":  "The transaction hash of the sent transaction",
+	"sendtoaddress-address":     "Address to pay",
+	"sendtoaddress-amount":      "Amount to send to the payment address valued in bitcoin",
+	"sendtoaddress-comment":     "Unused",
+	"sendtoaddress-commentto":   "Unused",
+	"sendtoaddress-replaceable": "Signal that the transaction can be replaced by one paying a higher fee (BIP125)",
+	"sendtoaddress--result0":    "The transaction hash of the sent transaction",
+	// SetLabelCmd help.
+	"setlabel--synopsis": "Sets the label of an address or of a wallet transaction, or removes it if the label is empty. Labels are stored in the wallet database.",
+	"setlabel-target":    "The address, which does not need to belong to the wallet, or the hash of the wallet transaction to label",
+	"setlabel-label":     "The label",
 	// SetTxFeeCmd help.
 	"settxfee--synopsis": "Modify the increment used each time more fee is required for an authored transaction.",
 	"settxfee-amount":    "The new fee increment valued in bitcoin",
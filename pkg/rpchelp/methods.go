@@ -22,11 +22,18 @@ var Methods = []struct {
 	ResultTypes []interface{}
 }{
 	{"addmultisigaddress", returnsString},
+	{"backupwallet", nil},
+	{"bumpfee", []interface{}{(*btcjson.BumpFeeResult)(nil)}},
 	{"createmultisig", []interface{}{(*btcjson.CreateMultiSigResult)(nil)}},
 	{"dumpprivkey", returnsString},
+	{"dumpwallet", []interface{}{(*btcjson.DumpWalletResult)(nil)}},
 	{"getaccount", returnsString},
 	{"getaccountaddress", returnsString},
 	{"getaddressesbyaccount", returnsStringArray},
+	{
+		"getaddressesbylabel",
+		[]interface{}{(*map[string]btcjson.AddressPurposeResult)(nil)},
+	},
 	{"getbalance", append(returnsNumber, returnsNumber[0])},
 	{"getbestblockhash", returnsString},
 	{"getblockcount", returnsNumber},
@@ -36,10 +43,21 @@ var Methods = []struct {
 	{"getreceivedbyaccount", returnsNumber},
 	{"getreceivedbyaddress", returnsNumber},
 	{"gettransaction", []interface{}{(*btcjson.GetTransactionResult)(nil)}},
+	{"getwalletinfo", []interface{}{(*btcjson.GetWalletInfoResult)(nil)}},
 	{"help", append(returnsString, returnsString[0])},
 	{"importprivkey", nil},
+	{"importwallet", nil},
 	{"keypoolrefill", nil},
 	{"listaccounts", []interface{}{(*map[string]float64)(nil)}},
+	{
+		"listaddressgroupings",
+		[]interface{}{(*[][]btcjson.AddressGroupingResult)(nil)},
+	},
+	{
+		"listaddresslabels",
+		[]interface{}{(*map[string]btcjson.AddressLabelResult)(nil)},
+	},
+	{"listlabels", returnsStringArray},
 	{"listlockunspent", []interface{}{(*[]btcjson.TransactionInput)(nil)}},
 	{
 		"listreceivedbyaccount",
@@ -56,6 +74,7 @@ var Methods = []struct {
 	{"sendfrom", returnsString},
 	{"sendmany", returnsString},
 	{"sendtoaddress", returnsString},
+	{"setlabel", nil},
 	{"settxfee", returnsBool},
 	{"signmessage", returnsString},
 	{
@@ -0,0 +1,75 @@
+package simdiff
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// WriteCSV writes one line per simulated block with the block version, target bits and interval since the previous
+// block of the same version
+func (r *Result) WriteCSV(w io.Writer) (e error) {
+	cw := csv.NewWriter(w)
+	if e = cw.Write(
+		[]string{"height", "time", "version", "algo", "bits", "interval", "adjustment"},
+	); E.Chk(e) {
+		return
+	}
+	for _, b := range r.Blocks {
+		if e = cw.Write(
+			[]string{
+				strconv.FormatInt(int64(b.Height), 10),
+				strconv.FormatInt(b.Time, 10),
+				strconv.FormatInt(int64(b.Version), 10),
+				b.Algo,
+				fmt.Sprintf("%08x", b.Bits),
+				strconv.FormatInt(b.Interval, 10),
+				strconv.FormatFloat(b.Adjustment, 'f', 6, 64),
+			},
+		); E.Chk(e) {
+			return
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// WriteSummaryCSV writes one line per block version with the interval and convergence statistics of the run
+func (r *Result) WriteSummaryCSV(w io.Writer) (e error) {
+	cw := csv.NewWriter(w)
+	if e = cw.Write(
+		[]string{
+			"version", "algo", "blocks", "target", "mean", "stddev", "ratio", "finalbits", "convergedat",
+		},
+	); E.Chk(e) {
+		return
+	}
+	for _, v := range r.Versions {
+		if e = cw.Write(
+			[]string{
+				strconv.FormatInt(int64(v.Version), 10),
+				v.Algo,
+				strconv.Itoa(v.Blocks),
+				strconv.FormatFloat(v.TargetInterval, 'f', 2, 64),
+				strconv.FormatFloat(v.MeanInterval, 'f', 2, 64),
+				strconv.FormatFloat(v.StdDevInterval, 'f', 2, 64),
+				strconv.FormatFloat(v.Ratio, 'f', 4, 64),
+				fmt.Sprintf("%08x", v.FinalBits),
+				strconv.FormatInt(int64(v.ConvergedAt), 10),
+			},
+		); E.Chk(e) {
+			return
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// WriteJSON writes the complete result including every block as indented JSON
+func (r *Result) WriteJSON(w io.Writer) (e error) {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "\t")
+	return enc.Encode(r)
+}
@@ -0,0 +1,89 @@
+package simdiff
+
+import (
+	"sort"
+)
+
+// Hashrate returns the number of hashes per second being spent on the given block version at a point in the run,
+// where progress is the elapsed simulated time divided by the expected duration of the run (0 at the start and 1 at
+// the end if the chain is running on target)
+type Hashrate func(version int32, progress float64) float64
+
+// Scenario is a named synthetic hashrate schedule to run the difficulty adjustment against
+type Scenario struct {
+	Name        string
+	Description string
+	Hashrate    Hashrate
+}
+
+const (
+	// stepFactor is the multiplier applied to hashrate in the step scenarios
+	stepFactor = 10
+	// hitAndRunFactor is how much the cloud miner multiplies the hashrate of the algorithm it attacks
+	hitAndRunFactor = 50
+	// hitAndRunVersion is the block version that is targeted by the cloud miner
+	hitAndRunVersion = 7
+	// abandonedVersion is the block version that all miners stop mining in the abandon scenario
+	abandonedVersion = 13
+)
+
+// Scenarios is the set of built in hashrate scenarios
+var Scenarios = map[string]Scenario{
+	"steady": {
+		Name:        "steady",
+		Description: "constant and equal hashrate on every algorithm",
+		Hashrate: func(version int32, progress float64) float64 {
+			return 1
+		},
+	},
+	"stepup": {
+		Name:        "stepup",
+		Description: "hashrate on every algorithm increases tenfold halfway through the run",
+		Hashrate: func(version int32, progress float64) float64 {
+			if progress >= 0.5 {
+				return stepFactor
+			}
+			return 1
+		},
+	},
+	"stepdown": {
+		Name:        "stepdown",
+		Description: "hashrate on every algorithm falls to a tenth halfway through the run",
+		Hashrate: func(version int32, progress float64) float64 {
+			if progress >= 0.5 {
+				return 1.0 / stepFactor
+			}
+			return 1
+		},
+	},
+	"hitandrun": {
+		Name: "hitandrun",
+		Description: "a cloud miner adds fifty times the hashrate to one algorithm from 40% to 60% of the run and" +
+			" then leaves",
+		Hashrate: func(version int32, progress float64) float64 {
+			if version == hitAndRunVersion && progress >= 0.4 && progress < 0.6 {
+				return hitAndRunFactor
+			}
+			return 1
+		},
+	},
+	"abandon": {
+		Name:        "abandon",
+		Description: "all miners stop mining one algorithm after 30% of the run",
+		Hashrate: func(version int32, progress float64) float64 {
+			if version == abandonedVersion && progress >= 0.3 {
+				return 0
+			}
+			return 1
+		},
+	},
+}
+
+// ScenarioNames returns the names of the built in scenarios in alphabetical order
+func ScenarioNames() (names []string) {
+	for i := range Scenarios {
+		names = append(names, i)
+	}
+	sort.Strings(names)
+	return
+}
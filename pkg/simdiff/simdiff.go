@@ -0,0 +1,257 @@
+// Package simdiff replays the Plan 9 multi-algorithm difficulty adjustment against a synthetic chain of block nodes
+// mined by simulated hashrate, so the algorithm intervals and the adjustment can be tuned before the fork activates.
+package simdiff
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"math/rand"
+	"sort"
+	"time"
+
+	"github.com/cybriq/p9/pkg/bits"
+	"github.com/cybriq/p9/pkg/blockchain"
+	"github.com/cybriq/p9/pkg/chaincfg"
+	"github.com/cybriq/p9/pkg/fork"
+	"github.com/cybriq/p9/pkg/wire"
+)
+
+const (
+	// DefaultBlocks is the number of blocks mined in a run when none is specified
+	DefaultBlocks = 2000
+	// DefaultWindow is the number of same-version intervals averaged to test for convergence
+	DefaultWindow = 9
+	// DefaultTolerance is the fraction of the target interval the windowed average must stay within to be converged
+	DefaultTolerance = 0.25
+	// startTime is the timestamp of the simulated genesis block
+	startTime = 1600000000
+)
+
+// Config is the parameters for a simulation run
+type Config struct {
+	// Forks is the hard fork schedule whose Plan 9 parameters are simulated. Plan 9 must be active from the genesis
+	// block, and if none is given the schedule of the test networks is used.
+	Forks     *fork.Schedule
+	Scenario  Scenario
+	Blocks    int
+	Seed      int64
+	Window    int
+	Tolerance float64
+}
+
+// Block is the record of one block mined in the simulation
+type Block struct {
+	Height     int32   `json:"height"`
+	Time       int64   `json:"time"`
+	Version    int32   `json:"version"`
+	Algo       string  `json:"algo"`
+	Bits       uint32  `json:"bits"`
+	Interval   int64   `json:"interval"`
+	Adjustment float64 `json:"adjustment"`
+}
+
+// VersionStats is the summary of the blocks of one version found in a simulation run
+type VersionStats struct {
+	Version        int32   `json:"version"`
+	Algo           string  `json:"algo"`
+	Blocks         int     `json:"blocks"`
+	TargetInterval float64 `json:"targetInterval"`
+	MeanInterval   float64 `json:"meanInterval"`
+	StdDevInterval float64 `json:"stdDevInterval"`
+	Ratio          float64 `json:"ratio"`
+	FinalBits      uint32  `json:"finalBits"`
+	// ConvergedAt is the height after which the windowed average interval stayed within tolerance of the target, or
+	// -1 if it never settled
+	ConvergedAt int32 `json:"convergedAt"`
+}
+
+// Result is the output of a simulation run
+type Result struct {
+	Scenario       string         `json:"scenario"`
+	Seed           int64          `json:"seed"`
+	TargetInterval float64        `json:"targetInterval"`
+	MeanInterval   float64        `json:"meanInterval"`
+	Versions       []VersionStats `json:"versions"`
+	Blocks         []Block        `json:"blocks"`
+}
+
+// algo is a block version mined in the simulation
+type algo struct {
+	name     string
+	version  int32
+	interval int
+}
+
+var oneLsh256 = new(big.Float).SetInt(new(big.Int).Lsh(big.NewInt(1), 256))
+
+// Run mines a synthetic Plan 9 chain with the hashrate schedule of the scenario, computing the difficulty of each
+// block with the same code the chain uses, and returns the blocks found and per-version statistics
+func Run(cfg Config) (r *Result, e error) {
+	if cfg.Scenario.Hashrate == nil {
+		return nil, fmt.Errorf("scenario '%s' has no hashrate schedule", cfg.Scenario.Name)
+	}
+	if cfg.Blocks <= 0 {
+		cfg.Blocks = DefaultBlocks
+	}
+	if cfg.Window <= 0 {
+		cfg.Window = DefaultWindow
+	}
+	if cfg.Tolerance <= 0 {
+		cfg.Tolerance = DefaultTolerance
+	}
+	if cfg.Forks == nil {
+		cfg.Forks = fork.TestNetSchedule()
+	}
+	if len(cfg.Forks.List) < 2 || cfg.Forks.List[1].ActivationHeight != 0 {
+		return nil, fmt.Errorf("hard fork schedule must have plan 9 active from the genesis block")
+	}
+	algos := getAlgos(cfg.Forks)
+	rng := rand.New(rand.NewSource(cfg.Seed))
+	// the expected duration of the run is used to express the scenario schedule as a fraction of the run
+	span := float64(cfg.Blocks) * cfg.Forks.P9Average
+	genesis := wire.BlockHeader{
+		Version:   algos[0].version,
+		Timestamp: time.Unix(startTime, 0),
+		Bits:      fork.SecondPowLimitBits,
+	}
+	tip := blockchain.NewBlockNode(&genesis, nil)
+	prevHash := genesis.BlockHash()
+	// the targets are computed by the same controller the node uses, on a chain that has only the hard fork schedule
+	params := chaincfg.SimNetParams
+	params.Forks = cfg.Forks
+	chain := blockchain.NewDifficultyChain(&params)
+	clock := float64(startTime)
+	lastOfVersion := make(map[int32]int64)
+	lastBits := make(map[int32]uint32)
+	r = &Result{
+		Scenario:       cfg.Scenario.Name,
+		Seed:           cfg.Seed,
+		TargetInterval: cfg.Forks.P9Average,
+	}
+	for height := int32(1); height <= int32(cfg.Blocks); height++ {
+		progress := (clock - startTime) / span
+		var diffs blockchain.Diffs
+		if diffs, e = chain.CalcNextRequiredDifficultyPlan9Controller(tip); E.Chk(e) {
+			return
+		}
+		best := math.Inf(1)
+		var winner Block
+		for _, a := range algos {
+			newBits := diffs[a.version]
+			oldBits, ok := lastBits[a.version]
+			if !ok {
+				oldBits = fork.SecondPowLimitBits
+			}
+			adjustment, _ := new(big.Float).Quo(
+				new(big.Float).SetInt(bits.CompactToBig(newBits)),
+				new(big.Float).SetInt(bits.CompactToBig(oldBits)),
+			).Float64()
+			hashrate := cfg.Scenario.Hashrate(a.version, progress)
+			if hashrate <= 0 {
+				continue
+			}
+			// the time to find a block is exponentially distributed with a rate of the hashrate times the chance of
+			// a single hash being under the target
+			chance, _ := new(big.Float).Quo(
+				new(big.Float).SetInt(bits.CompactToBig(newBits)), oneLsh256,
+			).Float64()
+			found := rng.ExpFloat64() / (hashrate * chance)
+			if found < best {
+				best = found
+				winner = Block{
+					Height:     height,
+					Version:    a.version,
+					Algo:       a.name,
+					Bits:       newBits,
+					Adjustment: adjustment,
+				}
+			}
+		}
+		if math.IsInf(best, 1) {
+			return nil, fmt.Errorf(
+				"no hashrate on any algorithm at height %d in scenario '%s'", height, cfg.Scenario.Name,
+			)
+		}
+		clock += best
+		winner.Time = int64(clock)
+		if last, ok := lastOfVersion[winner.Version]; ok {
+			winner.Interval = winner.Time - last
+		}
+		lastOfVersion[winner.Version] = winner.Time
+		lastBits[winner.Version] = winner.Bits
+		header := wire.BlockHeader{
+			Version:   winner.Version,
+			PrevBlock: prevHash,
+			Timestamp: time.Unix(winner.Time, 0),
+			Bits:      winner.Bits,
+			Nonce:     uint32(height),
+		}
+		tip = blockchain.NewBlockNode(&header, tip)
+		prevHash = header.BlockHash()
+		r.Blocks = append(r.Blocks, winner)
+	}
+	r.MeanInterval = (clock - startTime) / float64(cfg.Blocks)
+	for _, a := range algos {
+		r.Versions = append(r.Versions, versionStats(a, r.Blocks, cfg.Window, cfg.Tolerance))
+	}
+	return
+}
+
+// getAlgos returns the Plan 9 block versions in ascending version order
+func getAlgos(forks *fork.Schedule) (algos []algo) {
+	for name, params := range forks.List[1].Algos {
+		algos = append(algos, algo{name: name, version: params.Version, interval: params.VersionInterval})
+	}
+	sort.Slice(algos, func(i, j int) bool { return algos[i].version < algos[j].version })
+	return
+}
+
+// versionStats computes the interval statistics and convergence point for one block version
+func versionStats(a algo, blocks []Block, window int, tolerance float64) (vs VersionStats) {
+	vs = VersionStats{
+		Version:        a.version,
+		Algo:           a.name,
+		TargetInterval: float64(a.interval),
+		ConvergedAt:    -1,
+	}
+	var intervals []float64
+	var heights []int32
+	for i := range blocks {
+		if blocks[i].Version != a.version {
+			continue
+		}
+		vs.Blocks++
+		vs.FinalBits = blocks[i].Bits
+		if vs.Blocks > 1 {
+			intervals = append(intervals, float64(blocks[i].Interval))
+			heights = append(heights, blocks[i].Height)
+		}
+	}
+	if len(intervals) == 0 {
+		return
+	}
+	var sum float64
+	for _, x := range intervals {
+		sum += x
+	}
+	vs.MeanInterval = sum / float64(len(intervals))
+	var variance float64
+	for _, x := range intervals {
+		variance += (x - vs.MeanInterval) * (x - vs.MeanInterval)
+	}
+	vs.StdDevInterval = math.Sqrt(variance / float64(len(intervals)))
+	vs.Ratio = vs.MeanInterval / vs.TargetInterval
+	// walk back from the end of the run while the windowed average stays within tolerance of the target
+	for i := len(intervals) - 1; i >= window-1; i-- {
+		var windowSum float64
+		for _, x := range intervals[i-window+1 : i+1] {
+			windowSum += x
+		}
+		if math.Abs(windowSum/float64(window)/vs.TargetInterval-1) > tolerance {
+			break
+		}
+		vs.ConvergedAt = heights[i]
+	}
+	return
+}
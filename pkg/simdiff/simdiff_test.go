@@ -0,0 +1,70 @@
+package simdiff
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestRunDeterministic(t *testing.T) {
+	cfg := Config{Scenario: Scenarios["steady"], Blocks: 300, Seed: 1}
+	a, e := Run(cfg)
+	if e != nil {
+		t.Fatal(e)
+	}
+	b, e := Run(cfg)
+	if e != nil {
+		t.Fatal(e)
+	}
+	if len(a.Blocks) != 300 || len(b.Blocks) != 300 {
+		t.Fatalf("expected 300 blocks, got %d and %d", len(a.Blocks), len(b.Blocks))
+	}
+	for i := range a.Blocks {
+		if a.Blocks[i] != b.Blocks[i] {
+			t.Fatalf("runs with the same seed diverged at height %d", a.Blocks[i].Height)
+		}
+	}
+	if len(a.Versions) != 9 {
+		t.Fatalf("expected statistics for 9 versions, got %d", len(a.Versions))
+	}
+}
+
+func TestRunAbandon(t *testing.T) {
+	r, e := Run(Config{Scenario: Scenarios["abandon"], Blocks: 1000, Seed: 2})
+	if e != nil {
+		t.Fatal(e)
+	}
+	// no block of the abandoned version can be found after it is abandoned, so the last one must be early in the run
+	var last int32
+	for _, b := range r.Blocks {
+		if b.Version == abandonedVersion {
+			last = b.Height
+		}
+	}
+	if last > r.Blocks[len(r.Blocks)-1].Height/2 {
+		t.Errorf("abandoned version was still being mined at height %d", last)
+	}
+}
+
+func TestWriteCSV(t *testing.T) {
+	r, e := Run(Config{Scenario: Scenarios["stepup"], Blocks: 50, Seed: 3})
+	if e != nil {
+		t.Fatal(e)
+	}
+	var buf bytes.Buffer
+	if e = r.WriteCSV(&buf); e != nil {
+		t.Fatal(e)
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 51 {
+		t.Errorf("expected header and 50 lines, got %d lines", len(lines))
+	}
+	buf.Reset()
+	if e = r.WriteSummaryCSV(&buf); e != nil {
+		t.Fatal(e)
+	}
+	lines = strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 10 {
+		t.Errorf("expected header and 9 lines, got %d lines", len(lines))
+	}
+}
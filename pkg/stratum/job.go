@@ -0,0 +1,222 @@
+package stratum
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+
+	"github.com/cybriq/p9/pkg/bits"
+	"github.com/cybriq/p9/pkg/blockchain"
+	"github.com/cybriq/p9/pkg/chainhash"
+	"github.com/cybriq/p9/pkg/chainrpc/templates"
+	"github.com/cybriq/p9/pkg/mining"
+	"github.com/cybriq/p9/pkg/txscript"
+	"github.com/cybriq/p9/pkg/wire"
+)
+
+const (
+	// ExtraNonce1Size is the number of bytes of extranonce assigned to each connection by the server
+	ExtraNonce1Size = 4
+	// ExtraNonce2Size is the number of bytes of extranonce the miner rolls itself
+	ExtraNonce2Size = 4
+)
+
+// Job is the Stratum form of one block version of a templates.Message. Plan 9 templates carry a separate merkle root
+// and transaction set for each block version, since each version is a different proof of work algorithm with its own
+// difficulty, so every version becomes its own job, identified by the template nonce and the version.
+//
+// The coinbase of the template is rebuilt with a fixed size extranonce in its signature script, after the block
+// height, and split around it into the two halves sent to miners.
+type Job struct {
+	ID       string
+	Template *templates.Message
+	Version  int32
+	Bits     uint32
+	Coinb1   []byte
+	Coinb2   []byte
+	Branch   []chainhash.Hash
+	txs      []*wire.MsgTx
+	target   *big.Int
+	// shareTarget is the target of shares credited by a pool, nil if the template is not for one
+	shareTarget *big.Int
+}
+
+// JobID returns the Stratum job ID of a block version of a template
+func JobID(nonce uint64, version int32) string {
+	return fmt.Sprintf("%016x%08x", nonce, uint32(version))
+}
+
+// ParseJobID returns the template nonce and block version encoded in a job ID
+func ParseJobID(id string) (nonce uint64, version int32, e error) {
+	if len(id) != 24 {
+		e = fmt.Errorf("job ID %q has the wrong length", id)
+		return
+	}
+	if nonce, e = strconv.ParseUint(id[:16], 16, 64); e != nil {
+		return
+	}
+	var v uint64
+	if v, e = strconv.ParseUint(id[16:], 16, 32); e != nil {
+		return
+	}
+	version = int32(v)
+	return
+}
+
+// NewJob creates the job for the given block version of a template
+func NewJob(tpl *templates.Message, version int32) (j *Job, e error) {
+	txs := tpl.GetTxs()[version]
+	if len(txs) < 1 {
+		e = fmt.Errorf("template has no transactions for version %d", version)
+		return
+	}
+	j = &Job{
+		ID:       JobID(tpl.Nonce, version),
+		Template: tpl,
+		Version:  version,
+		Bits:     tpl.Bits[version],
+		target:   bits.CompactToBig(tpl.Bits[version]),
+		txs:      txs,
+	}
+	if shareBits, ok := tpl.ShareBits[version]; ok {
+		j.shareTarget = bits.CompactToBig(shareBits)
+	}
+	var height, script []byte
+	if height, e = txscript.NewScriptBuilder().AddInt64(int64(tpl.Height)).Script(); E.Chk(e) {
+		return
+	}
+	if script, e = txscript.NewScriptBuilder().AddInt64(int64(tpl.Height)).
+		AddData(make([]byte, ExtraNonce1Size+ExtraNonce2Size)).
+		AddData([]byte(mining.CoinbaseFlags)).
+		Script(); E.Chk(e) {
+		return
+	}
+	if len(script) > blockchain.MaxCoinbaseScriptLen {
+		e = fmt.Errorf("coinbase script length %d exceeds %d", len(script), blockchain.MaxCoinbaseScriptLen)
+		return
+	}
+	coinbase := txs[0].Copy()
+	if len(coinbase.TxIn) != 1 {
+		e = errors.New("template coinbase does not have exactly one input")
+		return
+	}
+	coinbase.TxIn[0].SignatureScript = script
+	var buf bytes.Buffer
+	if e = coinbase.SerializeNoWitness(&buf); E.Chk(e) {
+		return
+	}
+	// the signature script follows the version, input count and previous outpoint, and its length, and the extranonce
+	// follows the height and the push opcode of the extranonce
+	split := 4 + wire.VarIntSerializeSize(1) + 36 + wire.VarIntSerializeSize(uint64(len(script))) + len(height) + 1
+	serialized := buf.Bytes()
+	j.Coinb1 = serialized[:split]
+	j.Coinb2 = serialized[split+ExtraNonce1Size+ExtraNonce2Size:]
+	hashes := make([]chainhash.Hash, len(txs)-1)
+	for i := range hashes {
+		hashes[i] = txs[i+1].TxHash()
+	}
+	j.Branch = MerkleBranch(hashes)
+	return
+}
+
+// MerkleBranch returns the hashes needed to compute the merkle root from the hash of the coinbase, given the hashes of
+// the other transactions in the block
+func MerkleBranch(hashes []chainhash.Hash) (branch []chainhash.Hash) {
+	// the first slot of each level is the coinbase side of the tree, which the miner computes
+	level := make([]*chainhash.Hash, len(hashes)+1)
+	for i := range hashes {
+		level[i+1] = &hashes[i]
+	}
+	for len(level) > 1 {
+		branch = append(branch, *level[1])
+		if len(level)%2 != 0 {
+			level = append(level, level[len(level)-1])
+		}
+		next := []*chainhash.Hash{nil}
+		for i := 2; i < len(level); i += 2 {
+			next = append(next, blockchain.HashMerkleBranches(level[i], level[i+1]))
+		}
+		level = next
+	}
+	return
+}
+
+// MerkleRoot computes the merkle root from the hash of the coinbase and a merkle branch
+func MerkleRoot(coinbase chainhash.Hash, branch []chainhash.Hash) chainhash.Hash {
+	root := &coinbase
+	for i := range branch {
+		root = blockchain.HashMerkleBranches(root, &branch[i])
+	}
+	return *root
+}
+
+// Coinbase returns the coinbase transaction with the given extranonces
+func (j *Job) Coinbase(extraNonce1, extraNonce2 []byte) (tx *wire.MsgTx, e error) {
+	tx = &wire.MsgTx{}
+	if e = tx.Deserialize(bytes.NewReader(j.coinbaseBytes(extraNonce1, extraNonce2))); E.Chk(e) {
+		return nil, e
+	}
+	return
+}
+
+func (j *Job) coinbaseBytes(extraNonce1, extraNonce2 []byte) []byte {
+	b := make([]byte, 0, len(j.Coinb1)+len(extraNonce1)+len(extraNonce2)+len(j.Coinb2))
+	b = append(b, j.Coinb1...)
+	b = append(b, extraNonce1...)
+	b = append(b, extraNonce2...)
+	return append(b, j.Coinb2...)
+}
+
+// Header returns the block header a miner hashes for the given extranonces, time and nonce
+func (j *Job) Header(extraNonce1, extraNonce2 []byte, nTime, nonce uint32) *wire.BlockHeader {
+	hdr := j.Template.GenBlockHeader(j.Version)
+	hdr.MerkleRoot = MerkleRoot(chainhash.DoubleHashH(j.coinbaseBytes(extraNonce1, extraNonce2)), j.Branch)
+	hdr.Timestamp = timeFromUnix(nTime)
+	hdr.Nonce = nonce
+	return hdr
+}
+
+// Block assembles the block for a header solved with the given extranonces
+func (j *Job) Block(hdr *wire.BlockHeader, extraNonce1, extraNonce2 []byte) (mb *wire.Block, e error) {
+	var coinbase *wire.MsgTx
+	if coinbase, e = j.Coinbase(extraNonce1, extraNonce2); E.Chk(e) {
+		return
+	}
+	txs := make([]*wire.MsgTx, len(j.txs))
+	txs[0] = coinbase
+	copy(txs[1:], j.txs[1:])
+	mb = &wire.Block{Header: *hdr, Transactions: txs}
+	return
+}
+
+// Notify returns the parameters of the mining.notify message for the job
+func (j *Job) Notify(clean bool) []interface{} {
+	branch := make([]string, len(j.Branch))
+	for i := range j.Branch {
+		branch[i] = hex.EncodeToString(j.Branch[i][:])
+	}
+	return []interface{}{
+		j.ID,
+		hex.EncodeToString(swapWords(j.Template.PrevBlock[:])),
+		hex.EncodeToString(j.Coinb1),
+		hex.EncodeToString(j.Coinb2),
+		branch,
+		fmt.Sprintf("%08x", uint32(j.Version)),
+		fmt.Sprintf("%08x", j.Bits),
+		fmt.Sprintf("%08x", uint32(j.Template.Timestamp.Unix())),
+		clean,
+	}
+}
+
+// swapWords returns a copy of b with the bytes of each 32 bit word reversed, which is how Stratum sends the previous
+// block hash
+func swapWords(b []byte) []byte {
+	out := make([]byte, len(b))
+	for i := 0; i+4 <= len(b); i += 4 {
+		out[i], out[i+1], out[i+2], out[i+3] = b[i+3], b[i+2], b[i+1], b[i]
+	}
+	return out
+}
@@ -0,0 +1,99 @@
+package stratum
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Stratum v1 methods handled by the server, and the notifications it sends
+const (
+	MethodSubscribe           = "mining.subscribe"
+	MethodExtranonceSubscribe = "mining.extranonce.subscribe"
+	MethodAuthorize           = "mining.authorize"
+	MethodConfigure           = "mining.configure"
+	MethodSubmit              = "mining.submit"
+	MethodNotify              = "mining.notify"
+	MethodSetDifficulty       = "mining.set_difficulty"
+)
+
+// Error codes returned to miners, as used by the common Stratum v1 pool implementations
+const (
+	ErrOther         = 20
+	ErrJobNotFound   = 21
+	ErrDuplicate     = 22
+	ErrLowDifficulty = 23
+	ErrUnauthorized  = 24
+	ErrNotSubscribed = 25
+)
+
+// Request is a JSON-RPC request sent by a miner. Each one is a single line terminated by a newline.
+type Request struct {
+	ID     interface{}   `json:"id"`
+	Method string        `json:"method"`
+	Params []interface{} `json:"params"`
+}
+
+// Response is the reply to a Request, carrying the same ID
+type Response struct {
+	ID     interface{} `json:"id"`
+	Result interface{} `json:"result"`
+	Error  *Error      `json:"error"`
+}
+
+// Notification is a request sent by the server that expects no reply, its ID is always null
+type Notification struct {
+	ID     interface{}   `json:"id"`
+	Method string        `json:"method"`
+	Params []interface{} `json:"params"`
+}
+
+// Error is a Stratum error, which is encoded as the array [code, message, traceback]
+type Error struct {
+	Code    int
+	Message string
+}
+
+// NewError creates a Stratum error with a formatted message
+func NewError(code int, format string, a ...interface{}) *Error {
+	return &Error{Code: code, Message: fmt.Sprintf(format, a...)}
+}
+
+// Error implements the error interface
+func (e *Error) Error() string {
+	return fmt.Sprintf("stratum error %d: %s", e.Code, e.Message)
+}
+
+// MarshalJSON encodes the error in the array form miners expect
+func (e *Error) MarshalJSON() ([]byte, error) {
+	return json.Marshal([]interface{}{e.Code, e.Message, nil})
+}
+
+// UnmarshalJSON decodes the array form of an error
+func (e *Error) UnmarshalJSON(b []byte) (err error) {
+	var a []interface{}
+	if err = json.Unmarshal(b, &a); err != nil {
+		return
+	}
+	if len(a) < 2 {
+		return fmt.Errorf("stratum error has %d elements, expected at least 2", len(a))
+	}
+	code, ok := a[0].(float64)
+	if !ok {
+		return fmt.Errorf("stratum error code is %T, not a number", a[0])
+	}
+	e.Code = int(code)
+	e.Message, _ = a[1].(string)
+	return
+}
+
+// stringParam returns the string parameter at index i
+func stringParam(params []interface{}, i int) (s string, e *Error) {
+	if i >= len(params) {
+		return "", NewError(ErrOther, "missing parameter %d", i)
+	}
+	var ok bool
+	if s, ok = params[i].(string); !ok {
+		return "", NewError(ErrOther, "parameter %d is not a string", i)
+	}
+	return
+}
@@ -0,0 +1,466 @@
+package stratum
+
+import (
+	"bufio"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"go.uber.org/atomic"
+
+	"github.com/cybriq/p9/pkg/blockchain"
+	"github.com/cybriq/p9/pkg/chainhash"
+	"github.com/cybriq/p9/pkg/chainrpc/templates"
+	"github.com/cybriq/p9/pkg/fork"
+	"github.com/cybriq/p9/pkg/qu"
+	"github.com/cybriq/p9/pkg/wire"
+)
+
+const (
+	// DefaultShareTime is the share interval vardiff aims for when none is configured
+	DefaultShareTime = time.Second * 10
+	// maxTemplates is the number of templates building on the same block whose jobs are still accepted, the same as
+	// the number of recent messages kept for kopach workers
+	maxTemplates = 4
+	// maxFutureTime is how far past the current time the ntime of a share may be
+	maxFutureTime = time.Hour * 2
+	// maxLineLength bounds the size of a request from a miner
+	maxLineLength = 16384
+	// writeTimeout is how long a write to a miner may block before the connection is dropped
+	writeTimeout = time.Second * 10
+)
+
+// Config is the configuration of a Stratum server
+type Config struct {
+	// Listeners are the addresses the server accepts miners on
+	Listeners []string
+	// Forks is the hard fork schedule used to hash submitted headers
+	Forks *fork.Schedule
+	// PowLimit is the target of a share of difficulty one
+	PowLimit *big.Int
+	// Difficulty is the share difficulty new connections start at
+	Difficulty float64
+	// ShareTime is the interval between shares vardiff aims for
+	ShareTime time.Duration
+	// Authorize checks the credentials of a worker, if nil all workers are accepted
+	Authorize func(user, pass string) bool
+	// Submit is called with a block that meets the target of the template it was built from
+	Submit func(tpl *templates.Message, mb *wire.Block) error
+	// Share, if it is set, is called with the header of each share that meets the pool share target of the template it
+	// was built from and the worker that submitted it, to credit the worker for it
+	Share func(tpl *templates.Message, hdr *wire.BlockHeader, worker string) error
+}
+
+// Server is a Stratum v1 server handing out jobs built from block templates
+type Server struct {
+	sync.Mutex
+	cfg         *Config
+	listeners   []net.Listener
+	clients     map[uint32]*client
+	jobs        map[string]*Job
+	current     []*Job
+	recent      [][]string
+	prevBlock   chainhash.Hash
+	round       int
+	extraNonce1 atomic.Uint32
+	quit        qu.C
+}
+
+// client is the state of a miner connection
+type client struct {
+	id          uint32
+	conn        net.Conn
+	extraNonce1 []byte
+	writeMx     sync.Mutex
+	enc         *json.Encoder
+	// the following are guarded by the server mutex
+	subscribed bool
+	workers    map[string]struct{}
+	vardiff    *VarDiff
+	job        *Job
+	shares     map[string]struct{}
+}
+
+// New creates a Stratum server listening on the configured addresses, which runs until quit is closed
+func New(cfg *Config, quit qu.C) (s *Server, e error) {
+	if cfg.ShareTime <= 0 {
+		cfg.ShareTime = DefaultShareTime
+	}
+	s = &Server{
+		cfg:     cfg,
+		clients: make(map[uint32]*client),
+		jobs:    make(map[string]*Job),
+		quit:    quit,
+	}
+	for _, addr := range cfg.Listeners {
+		var l net.Listener
+		if l, e = net.Listen("tcp", addr); E.Chk(e) {
+			s.closeListeners()
+			return nil, e
+		}
+		I.Ln("stratum server listening on", l.Addr())
+		s.listeners = append(s.listeners, l)
+	}
+	for _, l := range s.listeners {
+		go s.accept(l)
+	}
+	go s.run()
+	return
+}
+
+// Addrs returns the addresses the server is listening on
+func (s *Server) Addrs() (addrs []net.Addr) {
+	for _, l := range s.listeners {
+		addrs = append(addrs, l.Addr())
+	}
+	return
+}
+
+func (s *Server) closeListeners() {
+	for _, l := range s.listeners {
+		if e := l.Close(); E.Chk(e) {
+		}
+	}
+}
+
+// run retargets idle connections and shuts down the server when quit is closed
+func (s *Server) run() {
+	ticker := time.NewTicker(s.cfg.ShareTime)
+	defer ticker.Stop()
+out:
+	for {
+		select {
+		case <-ticker.C:
+			s.retargetIdle()
+		case <-s.quit.Wait():
+			break out
+		}
+	}
+	s.closeListeners()
+	s.Lock()
+	for _, c := range s.clients {
+		if e := c.conn.Close(); D.Chk(e) {
+		}
+	}
+	s.Unlock()
+}
+
+func (s *Server) accept(l net.Listener) {
+	for {
+		conn, e := l.Accept()
+		if e != nil {
+			select {
+			case <-s.quit.Wait():
+			default:
+				E.Ln("stratum listener stopped:", e)
+			}
+			return
+		}
+		go s.handle(conn)
+	}
+}
+
+// Update builds jobs from a new template and sends them to the connected miners. Miners are spread across the block
+// versions of the template, and rotate between them with each update.
+func (s *Server) Update(tpl *templates.Message) {
+	versions := make([]int, 0, len(tpl.GetTxs()))
+	for v := range tpl.GetTxs() {
+		versions = append(versions, int(v))
+	}
+	sort.Ints(versions)
+	jobs := make([]*Job, 0, len(versions))
+	for _, v := range versions {
+		j, e := NewJob(tpl, int32(v))
+		if E.Chk(e) {
+			continue
+		}
+		jobs = append(jobs, j)
+	}
+	if len(jobs) < 1 {
+		W.Ln("template has no usable block versions")
+		return
+	}
+	s.Lock()
+	clean := tpl.PrevBlock != s.prevBlock
+	if clean {
+		s.jobs = make(map[string]*Job)
+		s.recent = nil
+		s.prevBlock = tpl.PrevBlock
+		for _, c := range s.clients {
+			c.shares = make(map[string]struct{})
+		}
+	}
+	ids := make([]string, len(jobs))
+	for i, j := range jobs {
+		s.jobs[j.ID] = j
+		ids[i] = j.ID
+	}
+	s.recent = append(s.recent, ids)
+	if len(s.recent) > maxTemplates {
+		for _, id := range s.recent[0] {
+			delete(s.jobs, id)
+		}
+		s.recent = s.recent[1:]
+	}
+	s.current = jobs
+	s.round++
+	var sends []*client
+	for _, c := range s.clients {
+		if len(c.workers) > 0 {
+			c.job = s.jobFor(c)
+			sends = append(sends, c)
+		}
+	}
+	s.Unlock()
+	D.Ln("sending", len(jobs), "stratum jobs at height", tpl.Height, "to", len(sends), "miners")
+	for _, c := range sends {
+		s.notify(c, clean)
+	}
+}
+
+// jobFor picks the job for a client, the server must be locked
+func (s *Server) jobFor(c *client) *Job {
+	if len(s.current) < 1 {
+		return nil
+	}
+	return s.current[(int(c.id)+s.round)%len(s.current)]
+}
+
+// notify sends the current difficulty and job of a client
+func (s *Server) notify(c *client, clean bool) {
+	s.Lock()
+	j := c.job
+	d := c.vardiff.Difficulty()
+	s.Unlock()
+	c.send(&Notification{Method: MethodSetDifficulty, Params: []interface{}{d}})
+	if j != nil {
+		c.send(&Notification{Method: MethodNotify, Params: j.Notify(clean)})
+	}
+}
+
+func (s *Server) retargetIdle() {
+	now := time.Now()
+	var changed []*client
+	s.Lock()
+	for _, c := range s.clients {
+		if len(c.workers) > 0 && c.vardiff.Retarget(now) {
+			changed = append(changed, c)
+		}
+	}
+	s.Unlock()
+	for _, c := range changed {
+		s.notify(c, false)
+	}
+}
+
+func (c *client) send(v interface{}) {
+	c.writeMx.Lock()
+	defer c.writeMx.Unlock()
+	if e := c.conn.SetWriteDeadline(time.Now().Add(writeTimeout)); D.Chk(e) {
+	}
+	if e := c.enc.Encode(v); D.Chk(e) {
+		// the reader will see the connection is closed and clean up
+		if e = c.conn.Close(); D.Chk(e) {
+		}
+	}
+}
+
+// handle reads and answers the requests of a miner until it disconnects
+func (s *Server) handle(conn net.Conn) {
+	id := s.extraNonce1.Inc()
+	c := &client{
+		id:          id,
+		conn:        conn,
+		extraNonce1: make([]byte, ExtraNonce1Size),
+		enc:         json.NewEncoder(conn),
+		workers:     make(map[string]struct{}),
+		vardiff:     NewVarDiff(s.cfg.Difficulty, s.cfg.ShareTime, time.Now()),
+		shares:      make(map[string]struct{}),
+	}
+	binary.BigEndian.PutUint32(c.extraNonce1, id)
+	s.Lock()
+	s.clients[id] = c
+	s.Unlock()
+	D.Ln("stratum miner connected from", conn.RemoteAddr())
+	defer func() {
+		s.Lock()
+		delete(s.clients, id)
+		s.Unlock()
+		if e := conn.Close(); D.Chk(e) {
+		}
+		D.Ln("stratum miner disconnected", conn.RemoteAddr())
+	}()
+	scanner := bufio.NewScanner(conn)
+	scanner.Buffer(make([]byte, 1024), maxLineLength)
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(line) < 1 {
+			continue
+		}
+		var req Request
+		if e := json.Unmarshal(line, &req); e != nil {
+			D.Ln("invalid stratum request from", conn.RemoteAddr(), e)
+			return
+		}
+		var after func()
+		res := &Response{ID: req.ID}
+		switch req.Method {
+		case MethodSubscribe:
+			res.Result, res.Error = s.subscribe(c)
+		case MethodExtranonceSubscribe:
+			// the extranonce of a connection never changes
+			res.Result = true
+		case MethodConfigure:
+			// no extensions are supported, version rolling cannot be as the version selects the algorithm
+			res.Result = map[string]interface{}{}
+		case MethodAuthorize:
+			res.Result, res.Error = s.authorize(c, req.Params)
+			if res.Error == nil {
+				after = func() { s.notify(c, true) }
+			}
+		case MethodSubmit:
+			var retarget bool
+			res.Result, retarget, res.Error = s.submit(c, req.Params)
+			if retarget {
+				after = func() { s.notify(c, false) }
+			}
+		default:
+			res.Error = NewError(ErrOther, "unknown method %q", req.Method)
+		}
+		if res.Error != nil {
+			res.Result = false
+		}
+		c.send(res)
+		if after != nil {
+			after()
+		}
+	}
+	if e := scanner.Err(); D.Chk(e) {
+	}
+}
+
+func (s *Server) subscribe(c *client) (result interface{}, e *Error) {
+	s.Lock()
+	c.subscribed = true
+	s.Unlock()
+	sub := fmt.Sprintf("%08x", c.id)
+	return []interface{}{
+		[][]string{{MethodSetDifficulty, sub}, {MethodNotify, sub}},
+		hex.EncodeToString(c.extraNonce1),
+		ExtraNonce2Size,
+	}, nil
+}
+
+func (s *Server) authorize(c *client, params []interface{}) (result interface{}, e *Error) {
+	var user, pass string
+	if user, e = stringParam(params, 0); e != nil {
+		return
+	}
+	// the password is optional in the protocol
+	pass, _ = stringParam(params, 1)
+	s.Lock()
+	defer s.Unlock()
+	if !c.subscribed {
+		return nil, NewError(ErrNotSubscribed, "not subscribed")
+	}
+	if s.cfg.Authorize != nil && !s.cfg.Authorize(user, pass) {
+		I.Ln("stratum worker", user, "from", c.conn.RemoteAddr(), "failed to authorize")
+		return nil, NewError(ErrUnauthorized, "unauthorized worker")
+	}
+	c.workers[user] = struct{}{}
+	if c.job == nil {
+		c.job = s.jobFor(c)
+	}
+	return true, nil
+}
+
+// submit checks a share and submits it as a block if it meets the block target. The second return value is true if
+// the difficulty of the connection changed.
+func (s *Server) submit(c *client, params []interface{}) (result interface{}, retarget bool, e *Error) {
+	var p [5]string
+	for i := range p {
+		if p[i], e = stringParam(params, i); e != nil {
+			return
+		}
+	}
+	worker, jobID := p[0], p[1]
+	extraNonce2, err := hex.DecodeString(p[2])
+	if err != nil || len(extraNonce2) != ExtraNonce2Size {
+		return nil, false, NewError(ErrOther, "extranonce2 must be %d hex encoded bytes", ExtraNonce2Size)
+	}
+	nTime, err := strconv.ParseUint(p[3], 16, 32)
+	if err != nil {
+		return nil, false, NewError(ErrOther, "invalid ntime")
+	}
+	nonce, err := strconv.ParseUint(p[4], 16, 32)
+	if err != nil {
+		return nil, false, NewError(ErrOther, "invalid nonce")
+	}
+	key := jobID + p[2] + p[3] + p[4]
+	s.Lock()
+	if !c.subscribed {
+		s.Unlock()
+		return nil, false, NewError(ErrNotSubscribed, "not subscribed")
+	}
+	if _, ok := c.workers[worker]; !ok {
+		s.Unlock()
+		return nil, false, NewError(ErrUnauthorized, "unauthorized worker")
+	}
+	j := s.jobs[jobID]
+	if j == nil {
+		s.Unlock()
+		return nil, false, NewError(ErrJobNotFound, "job not found")
+	}
+	if _, ok := c.shares[key]; ok {
+		s.Unlock()
+		return nil, false, NewError(ErrDuplicate, "duplicate share")
+	}
+	target := Target(s.cfg.PowLimit, c.vardiff.Minimum())
+	s.Unlock()
+	if int64(nTime) < j.Template.Timestamp.Unix() ||
+		timeFromUnix(uint32(nTime)).After(time.Now().Add(maxFutureTime)) {
+		return nil, false, NewError(ErrOther, "ntime out of range")
+	}
+	hdr := j.Header(c.extraNonce1, extraNonce2, uint32(nTime), uint32(nonce))
+	hash := hdr.BlockHashWithAlgos(s.cfg.Forks, j.Template.Height)
+	hashNum := blockchain.HashToBig(&hash)
+	if hashNum.Cmp(target) > 0 {
+		return nil, false, NewError(ErrLowDifficulty, "low difficulty share")
+	}
+	s.Lock()
+	c.shares[key] = struct{}{}
+	retarget = c.vardiff.Share(time.Now())
+	s.Unlock()
+	T.Ln("accepted share from", worker, "for job", jobID, hash)
+	// a share that meets the pool share target is credited before a block it may be is submitted, so that it is in the
+	// share window the next template's coinbase is split between
+	var credit error
+	if s.cfg.Share != nil && j.shareTarget != nil && hashNum.Cmp(j.shareTarget) <= 0 {
+		credit = s.cfg.Share(j.Template, hdr, worker)
+	}
+	if hashNum.Cmp(j.target) <= 0 {
+		I.Ln("stratum worker", worker, "found a block at height", j.Template.Height, hash)
+		mb, err := j.Block(hdr, c.extraNonce1, extraNonce2)
+		if err != nil {
+			return nil, retarget, NewError(ErrOther, "failed to assemble block: %v", err)
+		}
+		if err = s.cfg.Submit(j.Template, mb); E.Chk(err) {
+			// the share was still valid work
+		}
+	}
+	if credit != nil {
+		return nil, retarget, NewError(ErrOther, "share was not credited: %v", credit)
+	}
+	return true, retarget, nil
+}
+
+func timeFromUnix(t uint32) time.Time {
+	return time.Unix(int64(t), 0)
+}
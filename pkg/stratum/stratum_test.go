@@ -0,0 +1,454 @@
+package stratum
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/cybriq/p9/pkg/blockchain"
+	"github.com/cybriq/p9/pkg/btcaddr"
+	"github.com/cybriq/p9/pkg/chaincfg"
+	"github.com/cybriq/p9/pkg/chainhash"
+	"github.com/cybriq/p9/pkg/chainrpc/templates"
+	"github.com/cybriq/p9/pkg/fork"
+	"github.com/cybriq/p9/pkg/pool"
+	"github.com/cybriq/p9/pkg/qu"
+	"github.com/cybriq/p9/pkg/util"
+	"github.com/cybriq/p9/pkg/wire"
+)
+
+// maxTarget is the largest possible target, with which every hash is a share of difficulty one
+var maxTarget = new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 256), big.NewInt(1))
+
+func merkleRoot(txs []*wire.MsgTx) chainhash.Hash {
+	utxs := make([]*util.Tx, len(txs))
+	for i := range txs {
+		utxs[i] = util.NewTx(txs[i])
+	}
+	return *blockchain.BuildMerkleTreeStore(utxs, false).GetRoot()
+}
+
+// testTxs returns a coinbase followed by n other transactions
+func testTxs(n int) (txs []*wire.MsgTx) {
+	coinbase := wire.NewMsgTx(1)
+	coinbase.AddTxIn(
+		&wire.TxIn{
+			PreviousOutPoint: *wire.NewOutPoint(&chainhash.Hash{}, wire.MaxPrevOutIndex),
+			SignatureScript:  []byte{1, 1, 0},
+			Sequence:         wire.MaxTxInSequenceNum,
+		},
+	)
+	coinbase.AddTxOut(wire.NewTxOut(5000000000, []byte{0x51}))
+	txs = append(txs, coinbase)
+	for i := 0; i < n; i++ {
+		tx := wire.NewMsgTx(1)
+		prev := chainhash.DoubleHashH([]byte{byte(i)})
+		tx.AddTxIn(wire.NewTxIn(wire.NewOutPoint(&prev, 0), []byte{0x51}, nil))
+		tx.AddTxOut(wire.NewTxOut(int64(1000*(i+1)), []byte{0x51}))
+		txs = append(txs, tx)
+	}
+	return
+}
+
+// testTemplate returns a template for height 1 with blocks for the first two plan 9 versions, whose target half of
+// all hashes meet
+func testTemplate(nonce uint64, prev byte) *templates.Message {
+	tpl := &templates.Message{
+		Nonce:     nonce,
+		Height:    1,
+		PrevBlock: chainhash.Hash{prev},
+		Bits:      make(templates.Diffs),
+		Merkles:   make(templates.Merkles),
+		Timestamp: time.Unix(time.Now().Unix()-60, 0),
+	}
+	for i, v := range []int32{5, 6} {
+		txs := testTxs(i + 2)
+		tpl.Bits[v] = 0x207fffff
+		tpl.Merkles[v] = merkleRoot(txs)
+		tpl.SetTxs(v, txs)
+	}
+	return tpl
+}
+
+func TestJobID(t *testing.T) {
+	id := JobID(0x0123456789abcdef, 13)
+	nonce, version, e := ParseJobID(id)
+	if e != nil {
+		t.Fatal(e)
+	}
+	if nonce != 0x0123456789abcdef || version != 13 {
+		t.Errorf("job ID %s decoded to %x %d", id, nonce, version)
+	}
+	if _, _, e = ParseJobID("abc"); e == nil {
+		t.Errorf("short job ID was accepted")
+	}
+}
+
+func TestMerkleBranch(t *testing.T) {
+	for n := 0; n < 10; n++ {
+		txs := testTxs(n)
+		hashes := make([]chainhash.Hash, n)
+		for i := range hashes {
+			hashes[i] = txs[i+1].TxHash()
+		}
+		if root := MerkleRoot(txs[0].TxHash(), MerkleBranch(hashes)); root != merkleRoot(txs) {
+			t.Errorf("%d transactions: merkle root from branch %s expected %s", n+1, root, merkleRoot(txs))
+		}
+	}
+}
+
+func TestJob(t *testing.T) {
+	tpl := testTemplate(1, 1)
+	j, e := NewJob(tpl, 6)
+	if e != nil {
+		t.Fatal(e)
+	}
+	en1, en2 := []byte{1, 2, 3, 4}, []byte{5, 6, 7, 8}
+	hdr := j.Header(en1, en2, uint32(tpl.Timestamp.Unix()), 42)
+	mb, e := j.Block(hdr, en1, en2)
+	if e != nil {
+		t.Fatal(e)
+	}
+	if len(mb.Transactions) != len(tpl.GetTxs()[6]) {
+		t.Fatalf("block has %d transactions, expected %d", len(mb.Transactions), len(tpl.GetTxs()[6]))
+	}
+	if hdr.MerkleRoot != merkleRoot(mb.Transactions) {
+		t.Errorf("header merkle root does not match the block transactions")
+	}
+	if hdr.Version != 6 || hdr.Bits != tpl.Bits[6] || hdr.PrevBlock != tpl.PrevBlock || hdr.Nonce != 42 {
+		t.Errorf("header does not match the template: %v", hdr)
+	}
+	if !bytes.Contains(mb.Transactions[0].TxIn[0].SignatureScript, append(en1, en2...)) {
+		t.Errorf("coinbase script %x does not contain the extranonces", mb.Transactions[0].TxIn[0].SignatureScript)
+	}
+	if _, e = NewJob(tpl, 7); e == nil {
+		t.Errorf("job was created for a version the template does not have")
+	}
+	params := j.Notify(true)
+	prev, _ := hex.DecodeString(params[1].(string))
+	if !bytes.Equal(swapWords(prev), tpl.PrevBlock[:]) {
+		t.Errorf("previous block hash was not sent word swapped")
+	}
+	if params[5] != "00000006" || params[6] != "207fffff" {
+		t.Errorf("unexpected version and bits %v %v", params[5], params[6])
+	}
+}
+
+func TestVarDiff(t *testing.T) {
+	now := time.Now()
+	v := NewVarDiff(16, time.Second*10, now)
+	// shares twice as fast as wanted double the difficulty
+	for i := 0; i < RetargetShares-1; i++ {
+		if v.Share(now.Add(time.Duration(i+1) * time.Second * 5)) {
+			t.Fatalf("difficulty changed before enough shares")
+		}
+	}
+	now = now.Add(RetargetShares * time.Second * 5)
+	if !v.Share(now) || v.Difficulty() != 32 {
+		t.Fatalf("expected difficulty 32, got %f", v.Difficulty())
+	}
+	if v.Minimum() != 16 {
+		t.Errorf("shares at the previous difficulty should still be accepted")
+	}
+	// shares close to the wanted rate leave it alone
+	for i := 0; i < RetargetShares; i++ {
+		now = now.Add(time.Second * 11)
+		if v.Share(now) {
+			t.Fatalf("difficulty changed when shares were on time")
+		}
+	}
+	if v.Difficulty() != 32 || v.Minimum() != 32 {
+		t.Errorf("expected difficulty 32, got %f minimum %f", v.Difficulty(), v.Minimum())
+	}
+	// no shares at all cut it to a quarter
+	if !v.Retarget(now.Add(RetargetShares*time.Second*10)) || v.Difficulty() != 8 {
+		t.Errorf("expected difficulty 8 after no shares, got %f", v.Difficulty())
+	}
+	if Target(maxTarget, 1).Cmp(maxTarget) != 0 {
+		t.Errorf("difficulty one should have the difficulty one target")
+	}
+	if Target(maxTarget, 0.5).Cmp(maxTarget) != 0 {
+		t.Errorf("target was not capped to 256 bits")
+	}
+}
+
+// testClient is a minimal Stratum miner
+type testClient struct {
+	t             *testing.T
+	conn          net.Conn
+	r             *bufio.Reader
+	id            int
+	notifications []*Request
+}
+
+func dial(t *testing.T, s *Server) *testClient {
+	conn, e := net.Dial("tcp", s.Addrs()[0].String())
+	if e != nil {
+		t.Fatal(e)
+	}
+	if e = conn.SetDeadline(time.Now().Add(time.Minute)); e != nil {
+		t.Fatal(e)
+	}
+	return &testClient{t: t, conn: conn, r: bufio.NewReader(conn)}
+}
+
+// call sends a request and returns its response, storing any notifications that arrive before it
+func (c *testClient) call(method string, params ...interface{}) (result interface{}, e *Error) {
+	c.id++
+	b, err := json.Marshal(&Request{ID: c.id, Method: method, Params: params})
+	if err != nil {
+		c.t.Fatal(err)
+	}
+	if _, err = c.conn.Write(append(b, '\n')); err != nil {
+		c.t.Fatal(err)
+	}
+	for {
+		line, err := c.r.ReadBytes('\n')
+		if err != nil {
+			c.t.Fatal(err)
+		}
+		var msg struct {
+			Request
+			Result interface{} `json:"result"`
+			Error  *Error      `json:"error"`
+		}
+		if err = json.Unmarshal(line, &msg); err != nil {
+			c.t.Fatal(err)
+		}
+		if msg.Method != "" {
+			req := msg.Request
+			c.notifications = append(c.notifications, &req)
+			continue
+		}
+		if id, ok := msg.ID.(float64); !ok || int(id) != c.id {
+			c.t.Fatalf("response has ID %v, expected %d", msg.ID, c.id)
+		}
+		return msg.Result, msg.Error
+	}
+}
+
+// next returns the next notification with the given method
+func (c *testClient) next(method string) *Request {
+	for len(c.notifications) < 1 {
+		line, err := c.r.ReadBytes('\n')
+		if err != nil {
+			c.t.Fatal(err)
+		}
+		var req Request
+		if err = json.Unmarshal(line, &req); err != nil {
+			c.t.Fatal(err)
+		}
+		c.notifications = append(c.notifications, &req)
+	}
+	n := c.notifications[0]
+	c.notifications = c.notifications[1:]
+	if n.Method != method {
+		c.t.Fatalf("expected %s, got %s", method, n.Method)
+	}
+	return n
+}
+
+func expectError(t *testing.T, what string, e *Error, code int) {
+	if e == nil || e.Code != code {
+		t.Errorf("%s: expected error %d, got %v", what, code, e)
+	}
+}
+
+func TestServer(t *testing.T) {
+	quit := qu.T()
+	defer quit.Q()
+	blocks := make(chan *wire.Block, 16)
+	forks := fork.TestNetSchedule()
+	s, e := New(
+		&Config{
+			Listeners:  []string{"127.0.0.1:0"},
+			Forks:      forks,
+			PowLimit:   maxTarget,
+			Difficulty: 1,
+			Authorize: func(user, pass string) bool {
+				return pass == "pa55word"
+			},
+			Submit: func(tpl *templates.Message, mb *wire.Block) error {
+				blocks <- mb
+				return nil
+			},
+		}, quit,
+	)
+	if e != nil {
+		t.Fatal(e)
+	}
+	tpl := testTemplate(7, 1)
+	s.Update(tpl)
+	c := dial(t, s)
+	defer c.conn.Close()
+	result, err := c.call(MethodAuthorize, "worker", "pa55word")
+	expectError(t, "authorize before subscribe", err, ErrNotSubscribed)
+	if result, err = c.call(MethodSubscribe, "test/1.0"); err != nil {
+		t.Fatal(err)
+	}
+	sub := result.([]interface{})
+	en1, _ := hex.DecodeString(sub[1].(string))
+	if len(en1) != ExtraNonce1Size || int(sub[2].(float64)) != ExtraNonce2Size {
+		t.Fatalf("unexpected subscription result %v", sub)
+	}
+	_, err = c.call(MethodAuthorize, "worker", "wrong")
+	expectError(t, "wrong password", err, ErrUnauthorized)
+	if result, err = c.call(MethodAuthorize, "worker", "pa55word"); err != nil || result != true {
+		t.Fatalf("authorize failed: %v %v", result, err)
+	}
+	if d := c.next(MethodSetDifficulty).Params[0]; d != 1.0 {
+		t.Errorf("expected difficulty 1, got %v", d)
+	}
+	notify := c.next(MethodNotify).Params
+	jobID := notify[0].(string)
+	_, version, e := ParseJobID(jobID)
+	if e != nil || (version != 5 && version != 6) || notify[8] != true {
+		t.Fatalf("unexpected job %v", notify)
+	}
+	nTime := notify[7].(string)
+	en2 := "00000001"
+	_, err = c.call(MethodSubmit, "other", jobID, en2, nTime, "00000000")
+	expectError(t, "unauthorized worker", err, ErrUnauthorized)
+	_, err = c.call(MethodSubmit, "worker", JobID(8, 5), en2, nTime, "00000000")
+	expectError(t, "unknown job", err, ErrJobNotFound)
+	_, err = c.call(MethodSubmit, "worker", jobID, "01", nTime, "00000000")
+	expectError(t, "short extranonce2", err, ErrOther)
+	// as every hash is a share and half of them are blocks, a block is found within a few nonces
+	var mb *wire.Block
+	for nonce := 0; mb == nil; nonce++ {
+		if nonce > 32 {
+			t.Fatal("no block was found")
+		}
+		n := fmt.Sprintf("%08x", nonce)
+		if result, err = c.call(MethodSubmit, "worker", jobID, en2, nTime, n); err != nil || result != true {
+			t.Fatalf("share was rejected: %v", err)
+		}
+		_, err = c.call(MethodSubmit, "worker", jobID, en2, nTime, n)
+		expectError(t, "duplicate share", err, ErrDuplicate)
+		select {
+		case mb = <-blocks:
+		default:
+		}
+	}
+	if mb.Header.Version != version || mb.Header.PrevBlock != tpl.PrevBlock {
+		t.Errorf("block header does not match the job: %v", mb.Header)
+	}
+	if mb.Header.MerkleRoot != merkleRoot(mb.Transactions) {
+		t.Errorf("block merkle root does not match its transactions")
+	}
+	hash := mb.Header.BlockHashWithAlgos(forks, tpl.Height)
+	if blockchain.HashToBig(&hash).Cmp(big.NewInt(0).Lsh(big.NewInt(0x7fffff), 8*(0x20-3))) > 0 {
+		t.Errorf("submitted block does not meet its target")
+	}
+	// a template for a new block replaces the jobs
+	s.Update(testTemplate(9, 2))
+	c.next(MethodSetDifficulty)
+	if notify = c.next(MethodNotify).Params; notify[8] != true {
+		t.Errorf("jobs for a new block should be clean")
+	}
+	_, err = c.call(MethodSubmit, "worker", jobID, en2, nTime, "ffffffff")
+	expectError(t, "stale job", err, ErrJobNotFound)
+}
+
+func TestLowDifficultyShare(t *testing.T) {
+	quit := qu.T()
+	defer quit.Q()
+	s, e := New(
+		&Config{
+			Listeners:  []string{"127.0.0.1:0"},
+			Forks:      fork.TestNetSchedule(),
+			PowLimit:   maxTarget,
+			Difficulty: 1e60,
+			Submit: func(tpl *templates.Message, mb *wire.Block) error {
+				t.Errorf("block was submitted for a low difficulty share")
+				return nil
+			},
+		}, quit,
+	)
+	if e != nil {
+		t.Fatal(e)
+	}
+	s.Update(testTemplate(3, 1))
+	c := dial(t, s)
+	defer c.conn.Close()
+	if _, err := c.call(MethodSubscribe); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.call(MethodAuthorize, "worker", ""); err != nil {
+		t.Fatal(err)
+	}
+	c.next(MethodSetDifficulty)
+	notify := c.next(MethodNotify).Params
+	_, err := c.call(MethodSubmit, "worker", notify[0], "00000000", notify[7], "00000000")
+	expectError(t, "low difficulty share", err, ErrLowDifficulty)
+}
+
+// TestPoolShare ensures that in pool mode shares of Stratum workers are recorded in the pool's ledger for the address
+// they authorized with.
+func TestPoolShare(t *testing.T) {
+	quit := qu.T()
+	defer quit.Q()
+	params := &chaincfg.RegressionTestParams
+	p, e := pool.New(filepath.Join(t.TempDir(), "pool.db"), &pool.Config{Window: 8, ShareRatio: 2, Params: params})
+	if e != nil {
+		t.Fatal(e)
+	}
+	defer p.Close()
+	h := make([]byte, 20)
+	h[0] = 1
+	addr, e := btcaddr.NewPubKeyHash(h, params)
+	if e != nil {
+		t.Fatal(e)
+	}
+	s, e := New(
+		&Config{
+			Listeners:  []string{"127.0.0.1:0"},
+			Forks:      params.Forks,
+			PowLimit:   maxTarget,
+			Difficulty: 1,
+			Submit: func(tpl *templates.Message, mb *wire.Block) error {
+				return nil
+			},
+			Share: func(tpl *templates.Message, hdr *wire.BlockHeader, worker string) (e error) {
+				_, e = p.CheckExtendedShare(tpl, hdr, worker)
+				return
+			},
+		}, quit,
+	)
+	if e != nil {
+		t.Fatal(e)
+	}
+	// the share target is capped at the pow limit, so every hash is a share
+	tpl := testTemplate(5, 1)
+	tpl.ShareBits = templates.Diffs{5: p.ShareBits(tpl.Bits[5]), 6: p.ShareBits(tpl.Bits[6])}
+	s.Update(tpl)
+	c := dial(t, s)
+	defer c.conn.Close()
+	if _, err := c.call(MethodSubscribe); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.call(MethodAuthorize, "nonsense", ""); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.call(MethodAuthorize, addr.EncodeAddress(), ""); err != nil {
+		t.Fatal(err)
+	}
+	c.next(MethodSetDifficulty)
+	notify := c.next(MethodNotify).Params
+	_, err := c.call(MethodSubmit, "nonsense", notify[0], "00000000", notify[7], "00000000")
+	expectError(t, "share of a worker that is not an address", err, ErrOther)
+	if _, err = c.call(MethodSubmit, addr.EncodeAddress(), notify[0], "00000000", notify[7], "00000001"); err != nil {
+		t.Fatalf("share was rejected: %v", err)
+	}
+	info, e := p.Info(1)
+	if e != nil || info.Shares != 1 || len(info.Miners) != 1 || info.Miners[0].Address != addr.EncodeAddress() {
+		t.Fatalf("share is not in the ledger: %v %v", info, e)
+	}
+}
@@ -0,0 +1,105 @@
+package stratum
+
+import (
+	"math/big"
+	"time"
+)
+
+const (
+	// RetargetShares is the number of shares after which the difficulty of a connection is reconsidered
+	RetargetShares = 6
+	// MinDifficulty is the lowest share difficulty the server will set
+	MinDifficulty = 1.0 / (1 << 32)
+	// maxAdjust limits how far one retarget can move the difficulty in either direction
+	maxAdjust = 4.0
+	// variance is how far the share interval may drift from the target before the difficulty is changed
+	variance = 0.3
+)
+
+// VarDiff adjusts the share difficulty of a connection so that it submits a share roughly every ShareTime. The
+// difficulty is reconsidered after RetargetShares shares, or if that many share intervals pass with fewer shares.
+//
+// Shares at the difficulty from before the last change are still accepted, as the miner may have been working on them
+// when it was told the new one.
+type VarDiff struct {
+	ShareTime  time.Duration
+	difficulty float64
+	previous   float64
+	shares     int
+	since      time.Time
+}
+
+// NewVarDiff creates a VarDiff starting at the given difficulty
+func NewVarDiff(difficulty float64, shareTime time.Duration, now time.Time) *VarDiff {
+	if difficulty < MinDifficulty {
+		difficulty = MinDifficulty
+	}
+	return &VarDiff{
+		ShareTime:  shareTime,
+		difficulty: difficulty,
+		previous:   difficulty,
+		since:      now,
+	}
+}
+
+// Difficulty returns the current share difficulty
+func (v *VarDiff) Difficulty() float64 {
+	return v.difficulty
+}
+
+// Minimum returns the lowest difficulty a share is currently accepted at
+func (v *VarDiff) Minimum() float64 {
+	if v.previous < v.difficulty {
+		return v.previous
+	}
+	return v.difficulty
+}
+
+// Share records an accepted share and returns true if the difficulty changed as a result
+func (v *VarDiff) Share(now time.Time) bool {
+	v.shares++
+	return v.Retarget(now)
+}
+
+// Retarget reconsiders the difficulty if enough shares or time have accumulated since the last retarget, and returns
+// true if it was changed
+func (v *VarDiff) Retarget(now time.Time) bool {
+	elapsed := now.Sub(v.since)
+	if v.shares < RetargetShares && elapsed < v.ShareTime*RetargetShares {
+		return false
+	}
+	ratio := 1 / maxAdjust
+	if v.shares > 0 {
+		ratio = float64(v.ShareTime) * float64(v.shares) / float64(elapsed)
+	}
+	v.shares = 0
+	v.since = now
+	if ratio > 1-variance && ratio < 1+variance {
+		v.previous = v.difficulty
+		return false
+	}
+	if ratio > maxAdjust {
+		ratio = maxAdjust
+	} else if ratio < 1/maxAdjust {
+		ratio = 1 / maxAdjust
+	}
+	next := v.difficulty * ratio
+	if next < MinDifficulty {
+		next = MinDifficulty
+	}
+	if next == v.difficulty {
+		return false
+	}
+	v.previous, v.difficulty = v.difficulty, next
+	return true
+}
+
+// Target returns the share target for a difficulty, being the difficulty one target divided by the difficulty
+func Target(diff1 *big.Int, difficulty float64) *big.Int {
+	t, _ := new(big.Float).Quo(new(big.Float).SetInt(diff1), big.NewFloat(difficulty)).Int(nil)
+	if t.BitLen() > 256 {
+		t.Lsh(big.NewInt(1), 256)
+		t.Sub(t, big.NewInt(1))
+	}
+	return t
+}
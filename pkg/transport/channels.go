@@ -1,7 +1,7 @@
 package transport
 
 import (
-	"crypto/cipher"
+	"encoding/binary"
 	"errors"
 	"fmt"
 	"net"
@@ -49,10 +49,13 @@ type (
 		firstSender     *string
 		lastSent        *time.Time
 		MaxDatagramSize int
-		receiveCiph     cipher.AEAD
-		Receiver        *net.UDPConn
-		sendCiph        cipher.AEAD
-		Sender          *net.UDPConn
+		// broadcast is set for a channel on the multicast address, which answers handshakes to everyone
+		broadcast bool
+		guard     *replayGuard
+		Receiver  *net.UDPConn
+		session   *sendSession
+		Sender    *net.UDPConn
+		quit      qu.C
 	}
 )
 
@@ -61,12 +64,17 @@ type (
 func (c *Channel) SetDestination(dst string) (e error) {
 	D.Ln("sending to", dst)
 	if c.Sender, e = NewSender(dst, c.MaxDatagramSize); E.Chk(e) {
+		return
+	}
+	if !c.broadcast {
+		go c.serveJoins(c.Sender)
 	}
 	return
 }
 
-// Send fires off some data through the configured multicast's outbound.
-func (c *Channel) Send(magic []byte, nonce []byte, data []byte) (
+// Send fires off some data through the configured multicast's outbound as a
+// shard of the given message.
+func (c *Channel) Send(magic []byte, msgID uint64, data []byte) (
 	n int, e error,
 ) {
 	if len(data) == 0 {
@@ -75,13 +83,8 @@ func (c *Channel) Send(magic []byte, nonce []byte, data []byte) (
 		return
 	}
 	var msg []byte
-	if msg, e = EncryptMessage(
-		c.Creator,
-		c.sendCiph,
-		magic,
-		nonce,
-		data,
-	); E.Chk(e) {
+	if msg, e = c.session.seal(magic, msgID, data); E.Chk(e) {
+		return
 	}
 	n, e = c.Sender.Write(msg)
 	// D.Ln(msg)
@@ -91,21 +94,116 @@ func (c *Channel) Send(magic []byte, nonce []byte, data []byte) (
 // SendMany sends a BufIter of shards as produced by GetShards
 func (c *Channel) SendMany(magic []byte, b [][]byte) (e error) {
 	D.Ln("magic", string(magic), helpers.Caller("sending from", 1))
-	var nonce []byte
-	if nonce, e = GetNonce(c.sendCiph); E.Chk(e) {
-	} else {
-		for i := 0; i < len(b); i++ {
-			// D.Ln(i)
-			// D.Ln("segment length", len(b[i]))
-			if _, e = c.Send(magic, nonce, b[i]); E.Chk(e) {
-				// debug.PrintStack()
-			}
+	msgID := c.session.nextMessage()
+	for i := 0; i < len(b); i++ {
+		// D.Ln(i)
+		// D.Ln("segment length", len(b[i]))
+		if _, e = c.Send(magic, msgID, b[i]); E.Chk(e) {
+			// debug.PrintStack()
 		}
-		// T.Ln(c.Creator, "sent packets", string(magic), hex.EncodeToString(nonce), c.Sender.LocalAddr(), c.Sender.RemoteAddr())
 	}
 	return
 }
 
+// setKey starts the session the channel sends with and the replay protection
+// for what it receives, from the pre shared key, which is zeroed afterwards
+func (c *Channel) setKey(key []byte) (e error) {
+	master := gcm.DeriveKey(key)
+	for i := range key {
+		key[i] = 0
+	}
+	if c.session, e = newSendSession(master); E.Chk(e) {
+		return
+	}
+	c.guard = newReplayGuard(master)
+	return
+}
+
+// announce the session the channel sends with when it starts and every AnnounceInterval after, so receivers join it
+// before anything is sent on it
+func (c *Channel) announce() {
+	ticker := time.NewTicker(AnnounceInterval)
+	defer ticker.Stop()
+	for {
+		if _, e := c.Sender.Write(c.session.announce()); e != nil {
+			T.Ln("announcing session failed:", e)
+		}
+		select {
+		case <-ticker.C:
+		case <-c.quit.Wait():
+			return
+		}
+	}
+}
+
+// handshake handles a packet that sets up the sessions of a channel
+func (c *Channel) handshake(packet []byte, src net.Addr) {
+	var reply []byte
+	var e error
+	switch string(packet[:4]) {
+	case announceMagicText:
+		reply, e = c.guard.announced(packet, time.Now())
+	case joinMagicText:
+		reply, e = c.session.accept(packet)
+	case acceptMagicText:
+		e = c.guard.accepted(packet, time.Now())
+	}
+	if e != nil {
+		T.Ln("dropped handshake from", src, e)
+		return
+	}
+	if reply != nil {
+		c.reply(reply, src)
+	}
+}
+
+// reply sends a handshake packet to where the packet it answers came from, which on a broadcast channel is everyone
+func (c *Channel) reply(packet []byte, src net.Addr) {
+	var e error
+	if c.broadcast {
+		if c.Sender != nil {
+			_, e = c.Sender.Write(packet)
+		}
+	} else if addr, ok := src.(*net.UDPAddr); ok && c.Receiver != nil {
+		_, e = c.Receiver.WriteToUDP(packet, addr)
+	}
+	if e != nil {
+		T.Ln("handshake reply to", src, "failed:", e)
+	}
+}
+
+// serveJoins answers the requests to join the session of a unicast channel, which receivers send back to the address
+// the packets they received came from
+func (c *Channel) serveJoins(conn *net.UDPConn) {
+	buffer := make([]byte, c.MaxDatagramSize)
+	for {
+		n, e := conn.Read(buffer)
+		if e != nil {
+			// reads fail while nothing is listening at the destination, which is not a reason to stop
+			if strings.Contains(e.Error(), "use of closed network connection") {
+				return
+			}
+			select {
+			case <-c.quit.Wait():
+				return
+			case <-time.After(joinRetry):
+			}
+			continue
+		}
+		if n < 4 || string(buffer[:4]) != joinMagicText {
+			continue
+		}
+		var accept []byte
+		if accept, e = c.session.accept(buffer[:n]); e != nil {
+			T.Ln("dropped request to join from", conn.RemoteAddr(), e)
+			continue
+		}
+		if _, e = conn.Write(accept); e != nil {
+			T.Ln("answering request to join failed:", e)
+		}
+	}
+}
+
 // Close the multicast
 func (c *Channel) Close() (e error) {
 	// if e = c.Sender.Close(); E.Chk(e) {
@@ -124,7 +222,8 @@ func GetShards(data []byte) (shards [][]byte) {
 	return
 }
 
-// NewUnicastChannel sets up a listener and sender for a specified destination
+// NewUnicastChannel sets up a listener and sender for a specified destination.
+// Either address may be empty for a channel that only sends or only receives.
 func NewUnicastChannel(
 	creator string, ctx interface{}, key []byte, sender, receiver string,
 	maxDatagramSize int,
@@ -135,28 +234,33 @@ func NewUnicastChannel(
 		MaxDatagramSize: maxDatagramSize,
 		buffers:         make(map[string]*MsgBuffer),
 		context:         ctx,
+		Ready:           qu.T(),
+		quit:            quit,
 	}
 	var magics []string
-	bytes := make([]byte, len(key))
-	copy(bytes, key)
 	for i := range handlers {
 		magics = append(magics, i)
 	}
-	if channel.sendCiph, e = gcm.GetCipher(bytes); E.Chk(e) {
-	}
-	copy(bytes, key)
-	if channel.receiveCiph, e = gcm.GetCipher(bytes); E.Chk(e) {
+	if e = channel.setKey(key); E.Chk(e) {
+		return
 	}
-	for i := range bytes {
-		bytes[i] = 0
-		key[i] = 0
+	if receiver != "" {
+		if channel.Receiver, e = Listen(
+			receiver, channel, maxDatagramSize,
+			handlers, quit,
+		); E.Chk(e) {
+			return
+		}
 	}
-	if channel.Receiver, e = Listen(
-		receiver, channel, maxDatagramSize,
-		handlers, quit,
-	); E.Chk(e) {
+	if sender != "" {
+		if channel.Sender, e = NewSender(sender, maxDatagramSize); E.Chk(e) {
+			return
+		}
+		go channel.serveJoins(channel.Sender)
 	}
-	if channel.Sender, e = NewSender(sender, maxDatagramSize); E.Chk(e) {
+	channel.Ready.Q()
+	if channel.Sender != nil {
+		go channel.announce()
 	}
 	D.Ln(
 		"starting unicast multicast:", channel.Creator, sender,
@@ -221,20 +325,12 @@ func NewBroadcastChannel(
 		buffers:         make(map[string]*MsgBuffer),
 		context:         ctx,
 		Ready:           qu.T(),
+		broadcast:       true,
+		quit:            quit,
 	}
-	bytes := make([]byte, len(key))
-	copy(bytes, key)
-	if channel.sendCiph, e = gcm.GetCipher(bytes); E.Chk(e) {
-		panic(e)
-	}
-	copy(bytes, key)
-	if channel.receiveCiph, e = gcm.GetCipher(bytes); E.Chk(e) {
+	if e = channel.setKey(key); E.Chk(e) {
 		panic(e)
 	}
-	for i := range bytes {
-		key[i] = 0
-		bytes[i] = 0
-	}
 	if channel.Receiver, e = ListenBroadcast(
 		port, channel, maxDatagramSize,
 		handlers, quit,
@@ -243,6 +339,9 @@ func NewBroadcastChannel(
 	if channel.Sender, e = NewBroadcaster(port, maxDatagramSize); E.Chk(e) {
 	}
 	channel.Ready.Q()
+	if channel.Sender != nil {
+		go channel.announce()
+	}
 	return
 }
 
@@ -327,24 +426,47 @@ out:
 			case success:
 			}
 		}
+		if numBytes < 4 {
+			continue
+		}
 		// Filter messages by magic, if there is no match in the map the packet is
 		// ignored
 		magic := string(buffer[:4])
+		switch magic {
+		case announceMagicText:
+			// a channel that handles nothing has no use for the sessions of others
+			if len(handlers) > 0 {
+				channel.handshake(buffer[:numBytes], src)
+			}
+			continue
+		case joinMagicText, acceptMagicText:
+			channel.handshake(buffer[:numBytes], src)
+			continue
+		}
 		if handler, ok := handlers[magic]; ok {
 			if channel.lastSent != nil && channel.firstSender != nil {
 				*channel.lastSent = time.Now()
 			}
-			msg := buffer[:numBytes]
-			nL := channel.receiveCiph.NonceSize()
-			nonceBytes := msg[4 : 4+nL]
-			nonce := string(nonceBytes)
+			// packets that are not authentic, have been received before or
+			// are too old are dropped, and those of a session that has not been
+			// joined yet prompt a request to join it
+			var session [sessionIDSize]byte
+			var msgID uint64
 			var shard []byte
-			if shard, e = channel.receiveCiph.Open(
-				nil, nonceBytes,
-				msg[4+len(nonceBytes):], nil,
+			if session, msgID, shard, e = channel.guard.open(
+				buffer[:numBytes], time.Now(),
 			); e != nil {
+				if e == errUnknownSession {
+					var join []byte
+					if join, e = channel.guard.join(session, time.Now()); e == nil && join != nil {
+						channel.reply(join, src)
+					}
+				}
 				continue
 			}
+			var id [8]byte
+			binary.BigEndian.PutUint64(id[:], msgID)
+			nonce := string(session[:]) + string(id[:])
 			// D.Ln("read", numBytes, "from", src, e, hex.EncodeToString(msg))
 			if bn, ok := channel.buffers[nonce]; ok {
 				if !bn.Decoded {
@@ -1,3 +1,6 @@
 // Package transport provides a listener and sender channel for unicast and multicast UDP IPv4 short message chat
-// protocol with a pre shared key, forward error correction facilities with a nice friendly declaration syntax
+// protocol with a pre shared key, forward error correction facilities with a nice friendly declaration syntax.
+//
+// Receivers join the session of each sender with a handshake authenticated by the pre shared key, which gives them the
+// session key sealed with a key from an ephemeral key exchange, and packets are protected against replay.
 package transport
@@ -0,0 +1,422 @@
+package transport
+
+import (
+	"bytes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"io"
+	"sync"
+	"time"
+
+	"golang.org/x/crypto/curve25519"
+
+	"github.com/cybriq/p9/pkg/gcm"
+)
+
+// Every sender on a channel starts a session with a random ID and a random key, and announces the session, with a MAC
+// made with the pre shared key, when it starts and every AnnounceInterval after. A receiver that does not know a
+// session it hears an announcement or a packet from asks to join it, sending an ephemeral X25519 public key along with
+// a MAC made with the pre shared key. The sender answers with its own ephemeral public key and the sequence number of
+// the next packet it will send, and seals the session key with a key derived from the pre shared key, both public keys
+// and the secret they share. Only a receiver that holds both the pre shared key and the private key of its request can
+// open the answer, so the session key cannot be learned from recording the handshake even by someone holding the pre
+// shared key, and an answer cannot be replayed as the public key of each request is used once.
+//
+// Each packet a sender sends carries the session ID, a sequence number counting the packets of the session and the ID
+// of the message the packet is a shard of. These are authenticated along with the shard, which is encrypted with a key
+// derived from the session key and the sequence number divided by RekeyInterval, so the key changes long before the
+// GCM limit is reached and the sequence number can be used as the nonce.
+//
+// Receivers keep the highest sequence number of each session along with which of the ReplayWindow packets before it
+// have arrived, and drop any packet that has been received before or is older than that. A receiver only accepts the
+// packets of a session from the sequence number given in the answer to its request, so nothing sent before it joined
+// can be replayed to it, and it does not depend on the clocks of the machines on a channel. Packets of a session are
+// dropped until the receiver has joined it. The replay state of a session that has sent nothing for sessionExpiry is
+// forgotten, and the session is joined again, from its current sequence number, if it is heard from again.
+const (
+	sessionIDSize = 8
+	keySize       = 32
+	macSize       = sha256.Size
+	// headerSize is the size of the magic, session ID, sequence number and message ID at the start of a packet
+	headerSize = 4 + sessionIDSize + 8 + 8
+	// announceSize is the size of the magic, session ID and MAC of an announcement
+	announceSize = 4 + sessionIDSize + macSize
+	// joinSize is the size of the magic, session ID, public key and MAC of a request to join a session
+	joinSize = 4 + sessionIDSize + keySize + macSize
+	// acceptHeaderSize is the size of the magic, session ID, public keys and sequence number of an answer to a request,
+	// which are followed by the sealed session key
+	acceptHeaderSize = 4 + sessionIDSize + keySize*2 + 8
+	// ReplayWindow is how many packets older than the newest received from a session may still arrive
+	ReplayWindow = 64
+	// RekeyInterval is the number of packets a session sends with each key
+	RekeyInterval = 1 << 18
+	// AnnounceInterval is how often a sender announces its session
+	AnnounceInterval = time.Second * 10
+	// joinRetry is how long a receiver waits for an answer before asking to join a session again
+	joinRetry = time.Second
+	// maxPending is how many sessions a receiver may be waiting to join at once
+	maxPending = 64
+	// sessionExpiry is how long the replay state of a session is kept since its last packet
+	sessionExpiry = time.Minute * 30
+	// sweepInterval is how often expired sessions and requests are looked for
+	sweepInterval     = time.Minute
+	announceMagicText = "anno"
+	joinMagicText     = "join"
+	acceptMagicText   = "acpt"
+)
+
+var (
+	announceMagic = []byte(announceMagicText)
+	joinMagic     = []byte(joinMagicText)
+	acceptMagic   = []byte(acceptMagicText)
+)
+
+var (
+	errShortPacket    = errors.New("packet is too short")
+	errReplayed       = errors.New("packet has already been received or is too old")
+	errUnknownSession = errors.New("packet is from a session that has not been joined")
+	errHandshake      = errors.New("handshake packet is not authentic")
+	errOtherSession   = errors.New("request is to join another session")
+	errNotJoining     = errors.New("answer is to a request that was not made")
+)
+
+// epochKey derives the key a session uses for the packets of an epoch
+func epochKey(key []byte, session []byte, epoch uint64) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte("multicast"))
+	mac.Write(session)
+	var e [8]byte
+	binary.BigEndian.PutUint64(e[:], epoch)
+	mac.Write(e[:])
+	return mac.Sum(nil)
+}
+
+// handshakeMAC authenticates an announcement or a request to join a session with the pre shared key
+func handshakeMAC(master []byte, packet []byte) []byte {
+	mac := hmac.New(sha256.New, master)
+	mac.Write(packet)
+	return mac.Sum(nil)
+}
+
+// acceptKey derives the key the session key is sealed with in the answer to a request to join a session, from the pre
+// shared key, the header of the answer, which holds both public keys, and the secret they share
+func acceptKey(master []byte, header []byte, shared []byte) []byte {
+	mac := hmac.New(sha256.New, master)
+	mac.Write([]byte("accept"))
+	mac.Write(header)
+	mac.Write(shared)
+	return mac.Sum(nil)
+}
+
+// ephemeralKey returns a new X25519 private key and its public key
+func ephemeralKey() (priv, pub []byte, e error) {
+	priv = make([]byte, keySize)
+	if _, e = io.ReadFull(rand.Reader, priv); E.Chk(e) {
+		return
+	}
+	pub, e = curve25519.X25519(priv, curve25519.Basepoint)
+	return
+}
+
+// sendSession seals the packets sent on a channel
+type sendSession struct {
+	sync.Mutex
+	master []byte
+	id     [sessionIDSize]byte
+	key    []byte
+	seq    uint64
+	msg    uint64
+	epoch  uint64
+	ciph   cipher.AEAD
+}
+
+func newSendSession(master []byte) (s *sendSession, e error) {
+	s = &sendSession{master: master, key: make([]byte, keySize)}
+	if _, e = io.ReadFull(rand.Reader, s.id[:]); E.Chk(e) {
+		return
+	}
+	if _, e = io.ReadFull(rand.Reader, s.key); E.Chk(e) {
+	}
+	return
+}
+
+// nextMessage returns the ID for the next message to be sent
+func (s *sendSession) nextMessage() (msg uint64) {
+	s.Lock()
+	msg = s.msg
+	s.msg++
+	s.Unlock()
+	return
+}
+
+// seal a shard of a message into a packet
+func (s *sendSession) seal(magic []byte, msg uint64, data []byte) (packet []byte, e error) {
+	s.Lock()
+	defer s.Unlock()
+	seq := s.seq
+	if epoch := seq / RekeyInterval; s.ciph == nil || epoch != s.epoch {
+		if s.ciph, e = gcm.NewCipher(epochKey(s.key, s.id[:], epoch)); E.Chk(e) {
+			return
+		}
+		s.epoch = epoch
+	}
+	s.seq++
+	packet = make([]byte, headerSize, headerSize+len(data)+s.ciph.Overhead())
+	copy(packet, magic[:4])
+	copy(packet[4:], s.id[:])
+	binary.BigEndian.PutUint64(packet[4+sessionIDSize:], seq)
+	binary.BigEndian.PutUint64(packet[12+sessionIDSize:], msg)
+	packet = s.ciph.Seal(packet, counterNonce(s.ciph, seq), data, packet[:headerSize])
+	return
+}
+
+// announce returns a packet that tells receivers that have not joined the session to do so
+func (s *sendSession) announce() (packet []byte) {
+	packet = make([]byte, 4+sessionIDSize, announceSize)
+	copy(packet, announceMagic)
+	copy(packet[4:], s.id[:])
+	return append(packet, handshakeMAC(s.master, packet)...)
+}
+
+// accept answers a request to join the session with the session key and the sequence number of the next packet
+func (s *sendSession) accept(join []byte) (packet []byte, e error) {
+	if len(join) != joinSize {
+		e = errShortPacket
+		return
+	}
+	if !bytes.Equal(join[4:4+sessionIDSize], s.id[:]) {
+		e = errOtherSession
+		return
+	}
+	if !hmac.Equal(join[joinSize-macSize:], handshakeMAC(s.master, join[:joinSize-macSize])) {
+		e = errHandshake
+		return
+	}
+	var priv, pub, shared []byte
+	if priv, pub, e = ephemeralKey(); E.Chk(e) {
+		return
+	}
+	if shared, e = curve25519.X25519(priv, join[4+sessionIDSize:4+sessionIDSize+keySize]); e != nil {
+		return
+	}
+	s.Lock()
+	seq := s.seq
+	s.Unlock()
+	packet = make([]byte, acceptHeaderSize, acceptHeaderSize+keySize+16)
+	copy(packet, acceptMagic)
+	// the session ID and public key of the request
+	copy(packet[4:], join[4:4+sessionIDSize+keySize])
+	copy(packet[4+sessionIDSize+keySize:], pub)
+	binary.BigEndian.PutUint64(packet[4+sessionIDSize+keySize*2:], seq)
+	var ciph cipher.AEAD
+	if ciph, e = gcm.NewCipher(acceptKey(s.master, packet, shared)); E.Chk(e) {
+		return
+	}
+	// the key is only used once so the nonce can be zero
+	packet = ciph.Seal(packet, counterNonce(ciph, 0), s.key, packet)
+	return
+}
+
+// recvSession is the replay state of a session being received
+type recvSession struct {
+	key     []byte
+	last    time.Time
+	highest uint64
+	// bit n of window is set if the packet n before highest has been received
+	window uint64
+	epoch  uint64
+	ciph   cipher.AEAD
+}
+
+// check returns whether a packet with a sequence number has not been received before and is within the window
+func (r *recvSession) check(seq uint64) bool {
+	if seq > r.highest {
+		return true
+	}
+	behind := r.highest - seq
+	return behind < ReplayWindow && r.window&(1<<behind) == 0
+}
+
+// mark a sequence number as received
+func (r *recvSession) mark(seq uint64) {
+	if seq > r.highest {
+		if shift := seq - r.highest; shift < ReplayWindow {
+			r.window = r.window<<shift | 1
+		} else {
+			r.window = 1
+		}
+		r.highest = seq
+		return
+	}
+	r.window |= 1 << (r.highest - seq)
+}
+
+// pendingJoin is a request to join a session that has not been answered
+type pendingJoin struct {
+	priv, pub []byte
+	sent      time.Time
+}
+
+// replayGuard joins the sessions of the senders on a channel and opens the packets they send, dropping those that are
+// replayed or not authentic
+type replayGuard struct {
+	master    []byte
+	sessions  map[[sessionIDSize]byte]*recvSession
+	pending   map[[sessionIDSize]byte]*pendingJoin
+	lastSweep time.Time
+}
+
+func newReplayGuard(master []byte) *replayGuard {
+	return &replayGuard{
+		master:   master,
+		sessions: make(map[[sessionIDSize]byte]*recvSession),
+		pending:  make(map[[sessionIDSize]byte]*pendingJoin),
+	}
+}
+
+// open a packet, returning the session and message it is part of and the shard it contains. Packets of a session that
+// has not been joined return errUnknownSession along with the session, which can then be asked to join.
+func (g *replayGuard) open(packet []byte, now time.Time) (
+	session [sessionIDSize]byte, msg uint64, data []byte, e error,
+) {
+	if len(packet) < headerSize {
+		e = errShortPacket
+		return
+	}
+	copy(session[:], packet[4:])
+	seq := binary.BigEndian.Uint64(packet[4+sessionIDSize:])
+	msg = binary.BigEndian.Uint64(packet[12+sessionIDSize:])
+	rs, known := g.sessions[session]
+	if !known {
+		e = errUnknownSession
+		return
+	}
+	if !rs.check(seq) {
+		e = errReplayed
+		return
+	}
+	epoch := seq / RekeyInterval
+	var ciph cipher.AEAD
+	if rs.ciph != nil && rs.epoch == epoch {
+		ciph = rs.ciph
+	} else if ciph, e = gcm.NewCipher(epochKey(rs.key, session[:], epoch)); E.Chk(e) {
+		return
+	}
+	// nothing is recorded about a packet until it is authentic
+	if data, e = ciph.Open(nil, counterNonce(ciph, seq), packet[headerSize:], packet[:headerSize]); e != nil {
+		return
+	}
+	rs.mark(seq)
+	rs.last = now
+	if rs.ciph == nil || epoch > rs.epoch {
+		rs.epoch, rs.ciph = epoch, ciph
+	}
+	return
+}
+
+// join returns a request to join a session, or nil if the session has been joined or was asked to join less than
+// joinRetry ago
+func (g *replayGuard) join(session [sessionIDSize]byte, now time.Time) (packet []byte, e error) {
+	g.sweep(now)
+	if _, known := g.sessions[session]; known {
+		return
+	}
+	pj, asked := g.pending[session]
+	if asked && now.Sub(pj.sent) < joinRetry {
+		return
+	}
+	if !asked {
+		if len(g.pending) >= maxPending {
+			return
+		}
+		pj = &pendingJoin{}
+		if pj.priv, pj.pub, e = ephemeralKey(); E.Chk(e) {
+			return
+		}
+		g.pending[session] = pj
+	}
+	// a request that is asked again keeps its key so an answer to the earlier one can still be opened
+	pj.sent = now
+	packet = make([]byte, 4+sessionIDSize, joinSize)
+	copy(packet, joinMagic)
+	copy(packet[4:], session[:])
+	packet = append(packet, pj.pub...)
+	return append(packet, handshakeMAC(g.master, packet)...), nil
+}
+
+// announced handles the announcement of a session, returning a request to join it if it has not been joined
+func (g *replayGuard) announced(packet []byte, now time.Time) (join []byte, e error) {
+	if len(packet) != announceSize {
+		e = errShortPacket
+		return
+	}
+	if !hmac.Equal(packet[announceSize-macSize:], handshakeMAC(g.master, packet[:announceSize-macSize])) {
+		e = errHandshake
+		return
+	}
+	var session [sessionIDSize]byte
+	copy(session[:], packet[4:])
+	return g.join(session, now)
+}
+
+// accepted handles the answer to a request to join a session, after which the packets of the session from the sequence
+// number in the answer are accepted
+func (g *replayGuard) accepted(packet []byte, now time.Time) (e error) {
+	if len(packet) <= acceptHeaderSize {
+		e = errShortPacket
+		return
+	}
+	var session [sessionIDSize]byte
+	copy(session[:], packet[4:])
+	pj, asked := g.pending[session]
+	if !asked || !bytes.Equal(packet[4+sessionIDSize:4+sessionIDSize+keySize], pj.pub) {
+		e = errNotJoining
+		return
+	}
+	var shared []byte
+	if shared, e = curve25519.X25519(pj.priv, packet[4+sessionIDSize+keySize:4+sessionIDSize+keySize*2]); e != nil {
+		return
+	}
+	var ciph cipher.AEAD
+	if ciph, e = gcm.NewCipher(acceptKey(g.master, packet[:acceptHeaderSize], shared)); E.Chk(e) {
+		return
+	}
+	var key []byte
+	if key, e = ciph.Open(
+		nil, counterNonce(ciph, 0), packet[acceptHeaderSize:], packet[:acceptHeaderSize],
+	); e != nil {
+		e = errHandshake
+		return
+	}
+	delete(g.pending, session)
+	// every packet before the sequence number in the answer counts as received
+	g.sessions[session] = &recvSession{
+		key:     key,
+		last:    now,
+		highest: binary.BigEndian.Uint64(packet[4+sessionIDSize+keySize*2:]),
+		window:  ^uint64(1),
+	}
+	return
+}
+
+// sweep forgets sessions that have not sent anything for sessionExpiry and requests that have not been answered
+func (g *replayGuard) sweep(now time.Time) {
+	if now.Sub(g.lastSweep) < sweepInterval {
+		return
+	}
+	g.lastSweep = now
+	for id, rs := range g.sessions {
+		if now.Sub(rs.last) > sessionExpiry {
+			delete(g.sessions, id)
+		}
+	}
+	for id, pj := range g.pending {
+		if now.Sub(pj.sent) > sweepInterval {
+			delete(g.pending, id)
+		}
+	}
+}
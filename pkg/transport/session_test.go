@@ -0,0 +1,356 @@
+package transport
+
+import (
+	"bytes"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/cybriq/p9/pkg/gcm"
+	"github.com/cybriq/p9/pkg/qu"
+)
+
+func testSessions(t *testing.T) (*sendSession, *replayGuard) {
+	master := gcm.DeriveKey([]byte("password"))
+	s, e := newSendSession(master)
+	if e != nil {
+		t.Fatal(e)
+	}
+	g := newReplayGuard(master)
+	joined(t, s, g, time.Now())
+	return s, g
+}
+
+// joined has a receiver join a session from its announcement
+func joined(t *testing.T, s *sendSession, g *replayGuard, now time.Time) {
+	join, e := g.announced(s.announce(), now)
+	if e != nil || join == nil {
+		t.Fatalf("announcement did not prompt a request to join: %v", e)
+	}
+	var accept []byte
+	if accept, e = s.accept(join); e != nil {
+		t.Fatal(e)
+	}
+	if e = g.accepted(accept, now); e != nil {
+		t.Fatal(e)
+	}
+}
+
+func seal(t *testing.T, s *sendSession, data string) []byte {
+	packet, e := s.seal([]byte("job!"), s.nextMessage(), []byte(data))
+	if e != nil {
+		t.Fatal(e)
+	}
+	return packet
+}
+
+func TestReplay(t *testing.T) {
+	s, g := testSessions(t)
+	now := time.Now()
+	packets := make([][]byte, ReplayWindow+3)
+	for i := range packets {
+		packets[i] = seal(t, s, "data")
+	}
+	// the second packet arrives first, then the first one out of order
+	for _, i := range []int{1, 0} {
+		if _, _, data, e := g.open(packets[i], now); e != nil || string(data) != "data" {
+			t.Fatalf("packet %d was not accepted: %v", i, e)
+		}
+	}
+	for _, i := range []int{0, 1} {
+		if _, _, _, e := g.open(packets[i], now); e != errReplayed {
+			t.Errorf("replayed packet %d was accepted: %v", i, e)
+		}
+	}
+	// once the last packet is received the third is too far behind it to be accepted, though it was never received
+	if _, _, _, e := g.open(packets[ReplayWindow+2], now); e != nil {
+		t.Fatal(e)
+	}
+	if _, _, _, e := g.open(packets[2], now); e != errReplayed {
+		t.Errorf("packet older than the replay window was accepted: %v", e)
+	}
+	if _, _, _, e := g.open(packets[3], now); e != nil {
+		t.Errorf("packet within the replay window was rejected: %v", e)
+	}
+}
+
+func TestHandshake(t *testing.T) {
+	master := gcm.DeriveKey([]byte("password"))
+	s, e := newSendSession(master)
+	if e != nil {
+		t.Fatal(e)
+	}
+	g := newReplayGuard(master)
+	now := time.Now()
+	early := seal(t, s, "data")
+	session, _, _, e := g.open(early, now)
+	if e != errUnknownSession {
+		t.Fatalf("packet of a session that was not joined was accepted: %v", e)
+	}
+	join, e := g.join(session, now)
+	if e != nil || join == nil {
+		t.Fatalf("no request to join the session: %v", e)
+	}
+	// the request is not repeated until it has had time to be answered
+	if again, _ := g.join(session, now); again != nil {
+		t.Errorf("request to join was repeated straight away")
+	}
+	accept, e := s.accept(join)
+	if e != nil {
+		t.Fatal(e)
+	}
+	if e = g.accepted(accept, now); e != nil {
+		t.Fatal(e)
+	}
+	// what was sent before the answer is not accepted, what is sent after it is
+	if _, _, _, e = g.open(early, now); e != errReplayed {
+		t.Errorf("packet sent before the session was joined was accepted: %v", e)
+	}
+	if _, _, data, e := g.open(seal(t, s, "data"), now); e != nil || string(data) != "data" {
+		t.Fatalf("packet sent after the session was joined was not accepted: %v", e)
+	}
+	// the answer cannot be replayed once it has been used
+	if e = g.accepted(accept, now); e != errNotJoining {
+		t.Errorf("replayed answer was accepted: %v", e)
+	}
+	// a receiver that has just started joins from the current sequence number, so nothing sent before can be replayed
+	// to it, without depending on the time
+	packet := seal(t, s, "data")
+	restarted := newReplayGuard(master)
+	joined(t, s, restarted, now)
+	for _, p := range [][]byte{early, packet} {
+		if _, _, _, e = restarted.open(p, now); e != errReplayed {
+			t.Errorf("packet sent before the receiver joined was accepted: %v", e)
+		}
+	}
+	// the replay state of a session is forgotten after it goes quiet, and it is joined again from where it is up to
+	later := now.Add(sessionExpiry + time.Minute)
+	restarted.sweep(later)
+	if len(restarted.sessions) != 0 {
+		t.Fatalf("expired session was not forgotten")
+	}
+	if _, _, _, e = restarted.open(packet, later); e != errUnknownSession {
+		t.Errorf("packet of a forgotten session was accepted: %v", e)
+	}
+	joined(t, s, restarted, later)
+	if _, _, _, e = restarted.open(packet, later); e != errReplayed {
+		t.Errorf("packet sent before the session was joined again was accepted: %v", e)
+	}
+}
+
+func TestForgedHandshake(t *testing.T) {
+	master := gcm.DeriveKey([]byte("password"))
+	wrong := gcm.DeriveKey([]byte("wrong password"))
+	s, e := newSendSession(master)
+	if e != nil {
+		t.Fatal(e)
+	}
+	g := newReplayGuard(master)
+	now := time.Now()
+	// announcements and requests made without the pre shared key are ignored
+	forger, e := newSendSession(wrong)
+	if e != nil {
+		t.Fatal(e)
+	}
+	if _, e = g.announced(forger.announce(), now); e != errHandshake {
+		t.Errorf("forged announcement was accepted: %v", e)
+	}
+	forgedJoin, e := newReplayGuard(wrong).join(s.id, now)
+	if e != nil {
+		t.Fatal(e)
+	}
+	if _, e = s.accept(forgedJoin); e != errHandshake {
+		t.Errorf("forged request to join was answered: %v", e)
+	}
+	join, e := g.join(s.id, now)
+	if e != nil {
+		t.Fatal(e)
+	}
+	// an answer to the request made without the pre shared key does not join the session, so nothing sealed with the
+	// key it gives is accepted
+	forger.id = s.id
+	forged := append([]byte{}, join[:joinSize-macSize]...)
+	forged = append(forged, handshakeMAC(wrong, forged)...)
+	accept, e := forger.accept(forged)
+	if e != nil {
+		t.Fatal(e)
+	}
+	if e = g.accepted(accept, now); e != errHandshake {
+		t.Errorf("forged answer was accepted: %v", e)
+	}
+	if _, _, _, e = g.open(seal(t, forger, "data"), now); e != errUnknownSession {
+		t.Errorf("packet of a forged session was accepted: %v", e)
+	}
+	// nor does an answer that has been altered, or one to the request of another receiver
+	if accept, e = s.accept(join); e != nil {
+		t.Fatal(e)
+	}
+	tampered := append([]byte{}, accept...)
+	tampered[acceptHeaderSize-1]--
+	if e = g.accepted(tampered, now); e != errHandshake {
+		t.Errorf("altered answer was accepted: %v", e)
+	}
+	other := newReplayGuard(master)
+	otherJoin, e := other.join(s.id, now)
+	if e != nil {
+		t.Fatal(e)
+	}
+	otherAccept, e := s.accept(otherJoin)
+	if e != nil {
+		t.Fatal(e)
+	}
+	if e = g.accepted(otherAccept, now); e != errNotJoining {
+		t.Errorf("answer to another receiver was accepted: %v", e)
+	}
+	if len(g.sessions) != 0 {
+		t.Fatalf("forged handshakes joined a session")
+	}
+	// the genuine answer still joins the session
+	if e = g.accepted(accept, now); e != nil {
+		t.Fatalf("genuine answer was rejected after forgeries: %v", e)
+	}
+	if _, _, data, e := g.open(seal(t, s, "data"), now); e != nil || string(data) != "data" {
+		t.Errorf("packet was not accepted after joining: %v", e)
+	}
+}
+
+func TestReplayAuthentication(t *testing.T) {
+	s, g := testSessions(t)
+	now := time.Now()
+	packet := seal(t, s, "data")
+	// the header is authenticated, so a packet cannot be moved to another sequence number or message
+	tampered := append([]byte{}, packet...)
+	tampered[4+sessionIDSize+7]++
+	if _, _, _, e := g.open(tampered, now); e == nil {
+		t.Errorf("packet with altered header was accepted")
+	}
+	// a packet of the session sealed with any other key is rejected
+	wrong, e := newSendSession(gcm.DeriveKey([]byte("wrong password")))
+	if e != nil {
+		t.Fatal(e)
+	}
+	wrong.id = s.id
+	wrong.seq = s.seq + 1
+	if _, _, _, e = g.open(seal(t, wrong, "data"), now); e == nil {
+		t.Errorf("packet with the wrong key was accepted")
+	}
+	if _, _, _, e = g.open(packet, now); e != nil {
+		t.Errorf("authentic packet was rejected after forgeries: %v", e)
+	}
+}
+
+func TestRekey(t *testing.T) {
+	s, g := testSessions(t)
+	now := time.Now()
+	// the receiver joined at the first packet, so the packets skipped count as sent
+	s.seq = RekeyInterval - 2
+	var packets [][]byte
+	for i := 0; i < 4; i++ {
+		packets = append(packets, seal(t, s, "same data"))
+	}
+	if s.epoch != 1 {
+		t.Fatalf("session did not change key after %d packets", RekeyInterval)
+	}
+	// the last packet of the first key arrives after the first of the second
+	for _, i := range []int{0, 2, 1, 3} {
+		if _, _, data, e := g.open(packets[i], now); e != nil || string(data) != "same data" {
+			t.Fatalf("packet %d was not accepted across the key change: %v", i, e)
+		}
+	}
+	// each epoch has its own key so the nonce counter can start over
+	k0, k1 := epochKey(s.key, s.id[:], 0), epochKey(s.key, s.id[:], 1)
+	if bytes.Equal(k0, k1) {
+		t.Fatalf("epochs have the same key")
+	}
+}
+
+func TestChannelReplay(t *testing.T) {
+	quit := qu.T()
+	defer quit.Q()
+	received := make(chan []byte, 8)
+	handlers := Handlers{
+		"sol!": func(ctx interface{}, src net.Addr, dst string, b []byte) (e error) {
+			received <- b
+			return
+		},
+	}
+	receiver, e := NewUnicastChannel("receiver", nil, []byte("password"), "", "127.0.0.1:0", 8192, handlers, quit)
+	if e != nil {
+		t.Fatal(e)
+	}
+	receiverAddr := receiver.Receiver.LocalAddr().(*net.UDPAddr)
+	// the sender sends through a proxy which passes the handshake both ways and captures the packets of the messages so
+	// they can be replayed to the receiver
+	proxy, e := net.ListenUDP("udp", &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1)})
+	if e != nil {
+		t.Fatal(e)
+	}
+	defer proxy.Close()
+	captured := make(chan []byte, 64)
+	go func() {
+		var senderAddr *net.UDPAddr
+		buf := make([]byte, 8192)
+		for {
+			n, src, e := proxy.ReadFromUDP(buf)
+			if e != nil {
+				return
+			}
+			packet := append([]byte{}, buf[:n]...)
+			if src.String() == receiverAddr.String() {
+				if senderAddr != nil {
+					_, _ = proxy.WriteToUDP(packet, senderAddr)
+				}
+				continue
+			}
+			senderAddr = src
+			if string(packet[:4]) == "sol!" {
+				captured <- packet
+			}
+			_, _ = proxy.WriteToUDP(packet, receiverAddr)
+		}
+	}()
+	sender, e := NewUnicastChannel(
+		"sender", nil, []byte("password"), proxy.LocalAddr().String(), "", 8192, Handlers{}, quit,
+	)
+	if e != nil {
+		t.Fatal(e)
+	}
+	solution := []byte("solution")
+	// the receiver drops messages until it has joined the session of the sender
+	deadline := time.After(time.Second * 5)
+out:
+	for {
+		if e = sender.SendMany([]byte("sol!"), GetShards(solution)); e != nil {
+			t.Fatal(e)
+		}
+		select {
+		case b := <-received:
+			if !bytes.Equal(b, solution) {
+				t.Fatalf("received %x", b)
+			}
+			break out
+		case <-time.After(time.Millisecond * 200):
+		case <-deadline:
+			t.Fatal("message was not received")
+		}
+	}
+	time.Sleep(time.Millisecond * 200)
+	var packets [][]byte
+	for len(captured) > 0 {
+		packets = append(packets, <-captured)
+	}
+	for len(received) > 0 {
+		<-received
+	}
+	// replaying the captured packets does not deliver the message again
+	for i := range packets {
+		if _, e = proxy.WriteToUDP(packets[i], receiverAddr); e != nil {
+			t.Fatal(e)
+		}
+	}
+	select {
+	case <-received:
+		t.Fatal("replayed message was delivered")
+	case <-time.After(time.Second):
+	}
+}
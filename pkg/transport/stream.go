@@ -0,0 +1,484 @@
+package transport
+
+import (
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/cybriq/p9/pkg/fec"
+	"github.com/cybriq/p9/pkg/gcm"
+	"github.com/cybriq/p9/pkg/qu"
+)
+
+// The stream transport carries the same messages as a broadcast Channel over TCP, for miners that cannot receive the
+// multicast of their controller, such as those on another subnet or a VPN.
+//
+// A connection starts with each side sending 32 random bytes. The keys for each direction are derived from these and
+// the key GetCipher derives from the pre shared key, so every connection has its own keys and nothing recorded from one
+// can be replayed into another. Frames are a 4 byte big endian length followed by the sealed magic and message, with
+// the nonce being the count of frames sent before it, so frames cannot be replayed or reordered within a connection
+// either. Both sides first send a hello frame, and a connection whose first frame cannot be opened is dropped, which
+// authenticates both ends as holding the pre shared key.
+const (
+	handshakeSize  = 32
+	maxFrameSize   = 1 << 24
+	streamTimeout  = time.Second * 10
+	heartbeat      = time.Second * 10
+	readTimeout    = heartbeat * 3
+	minReconnect   = time.Second
+	maxReconnect   = time.Second * 30
+	helloMagicText = "helo"
+	pingMagicText  = "ping"
+)
+
+var (
+	helloMagic = []byte(helloMagicText)
+	pingMagic  = []byte(pingMagicText)
+)
+
+// Sender is a connection that messages split into shards by GetShards can be sent on. It is implemented by the
+// broadcast Channel as well as the stream server and client.
+type Sender interface {
+	SendMany(magic []byte, b [][]byte) (e error)
+}
+
+// streamConn is an authenticated, encrypted stream connection
+type streamConn struct {
+	net.Conn
+	wmx       sync.Mutex
+	sendCiph  cipher.AEAD
+	sendCount uint64
+	recvCiph  cipher.AEAD
+	recvCount uint64
+	header    [4]byte
+}
+
+// sessionKey derives the key for one direction of a connection from the pre shared key and both sides' handshakes
+func sessionKey(master []byte, direction string, client, server []byte) []byte {
+	mac := hmac.New(sha256.New, master)
+	mac.Write([]byte(direction))
+	mac.Write(client)
+	mac.Write(server)
+	return mac.Sum(nil)
+}
+
+func counterNonce(ciph cipher.AEAD, count uint64) []byte {
+	nonce := make([]byte, ciph.NonceSize())
+	binary.BigEndian.PutUint64(nonce[len(nonce)-8:], count)
+	return nonce
+}
+
+// newStreamConn performs the handshake on a new connection and returns it once both sides have proven they hold the
+// pre shared key
+func newStreamConn(conn net.Conn, master []byte, isServer bool) (sc *streamConn, e error) {
+	if e = conn.SetDeadline(time.Now().Add(streamTimeout)); E.Chk(e) {
+		return
+	}
+	ours := make([]byte, handshakeSize)
+	if _, e = io.ReadFull(rand.Reader, ours); E.Chk(e) {
+		return
+	}
+	if _, e = conn.Write(ours); e != nil {
+		return
+	}
+	theirs := make([]byte, handshakeSize)
+	if _, e = io.ReadFull(conn, theirs); e != nil {
+		return
+	}
+	client, server := ours, theirs
+	send, recv := "client", "server"
+	if isServer {
+		client, server = theirs, ours
+		send, recv = recv, send
+	}
+	sc = &streamConn{Conn: conn}
+	if sc.sendCiph, e = gcm.NewCipher(sessionKey(master, send, client, server)); E.Chk(e) {
+		return
+	}
+	if sc.recvCiph, e = gcm.NewCipher(sessionKey(master, recv, client, server)); E.Chk(e) {
+		return
+	}
+	if e = sc.send(helloMagic, nil); e != nil {
+		return
+	}
+	var magic []byte
+	if magic, _, e = sc.receive(); e != nil {
+		return nil, fmt.Errorf("handshake with %s failed: %v", conn.RemoteAddr(), e)
+	}
+	if string(magic) != helloMagicText {
+		return nil, fmt.Errorf("handshake with %s failed: expected hello", conn.RemoteAddr())
+	}
+	if e = conn.SetDeadline(time.Time{}); E.Chk(e) {
+	}
+	return
+}
+
+// send a message in one frame
+func (sc *streamConn) send(magic []byte, data []byte) (e error) {
+	sc.wmx.Lock()
+	defer sc.wmx.Unlock()
+	plain := make([]byte, 0, len(magic)+len(data))
+	plain = append(append(plain, magic...), data...)
+	sealed := sc.sendCiph.Seal(nil, counterNonce(sc.sendCiph, sc.sendCount), plain, nil)
+	sc.sendCount++
+	frame := make([]byte, 4, 4+len(sealed))
+	binary.BigEndian.PutUint32(frame, uint32(len(sealed)))
+	frame = append(frame, sealed...)
+	if e = sc.SetWriteDeadline(time.Now().Add(streamTimeout)); e != nil {
+		return
+	}
+	_, e = sc.Write(frame)
+	return
+}
+
+// receive the next frame and return its magic and message
+func (sc *streamConn) receive() (magic, data []byte, e error) {
+	if _, e = io.ReadFull(sc, sc.header[:]); e != nil {
+		return
+	}
+	size := binary.BigEndian.Uint32(sc.header[:])
+	if size > maxFrameSize || size < uint32(sc.recvCiph.Overhead()+4) {
+		e = fmt.Errorf("invalid frame size %d", size)
+		return
+	}
+	sealed := make([]byte, size)
+	if _, e = io.ReadFull(sc, sealed); e != nil {
+		return
+	}
+	var plain []byte
+	if plain, e = sc.recvCiph.Open(nil, counterNonce(sc.recvCiph, sc.recvCount), sealed, nil); e != nil {
+		return
+	}
+	sc.recvCount++
+	return plain[:4], plain[4:], nil
+}
+
+// serve reads frames from the connection and invokes the handler matching their magic until the connection fails
+func (sc *streamConn) serve(ctx interface{}, handlers Handlers, timeout time.Duration) (e error) {
+	var magic, data []byte
+	for {
+		if timeout > 0 {
+			if e = sc.SetReadDeadline(time.Now().Add(timeout)); e != nil {
+				return
+			}
+		}
+		if magic, data, e = sc.receive(); e != nil {
+			return
+		}
+		if handler, ok := handlers[string(magic)]; ok {
+			if e = handler(ctx, sc.RemoteAddr(), sc.LocalAddr().String(), data); E.Chk(e) {
+			}
+		}
+	}
+}
+
+// decodeShards reassembles a message split by GetShards, as a stream carries the message as is
+func decodeShards(b [][]byte) (data []byte, e error) {
+	if data, e = fec.Decode(b); E.Chk(e) {
+	}
+	return
+}
+
+// StreamServer accepts stream connections and sends messages to all of them, as the controller end of the stream
+// transport
+type StreamServer struct {
+	sync.Mutex
+	Creator   string
+	context   interface{}
+	handlers  Handlers
+	master    []byte
+	listeners []net.Listener
+	conns     map[*streamConn]struct{}
+	closed    bool
+	quit      qu.C
+}
+
+// NewStreamServer listens on the given addresses for stream connections using the pre shared key, invoking the
+// handlers on the messages received on them
+func NewStreamServer(
+	creator string, ctx interface{}, key []byte, addresses []string,
+	handlers Handlers, quit qu.C,
+) (s *StreamServer, e error) {
+	s = &StreamServer{
+		Creator:  creator,
+		context:  ctx,
+		handlers: handlers,
+		master:   gcm.DeriveKey(key),
+		conns:    make(map[*streamConn]struct{}),
+		quit:     quit,
+	}
+	for i := range addresses {
+		var l net.Listener
+		if l, e = net.Listen("tcp", addresses[i]); E.Chk(e) {
+			s.close()
+			return nil, e
+		}
+		D.Ln("stream server", creator, "listening on", l.Addr())
+		s.listeners = append(s.listeners, l)
+	}
+	for i := range s.listeners {
+		go s.accept(s.listeners[i])
+	}
+	go s.run()
+	return
+}
+
+// Addrs returns the addresses the server is listening on
+func (s *StreamServer) Addrs() (addrs []net.Addr) {
+	for i := range s.listeners {
+		addrs = append(addrs, s.listeners[i].Addr())
+	}
+	return
+}
+
+// Count returns the number of connected clients
+func (s *StreamServer) Count() int {
+	s.Lock()
+	defer s.Unlock()
+	return len(s.conns)
+}
+
+func (s *StreamServer) accept(l net.Listener) {
+	for {
+		conn, e := l.Accept()
+		if e != nil {
+			select {
+			case <-s.quit.Wait():
+			default:
+				E.Ln("stream server", s.Creator, "stopped accepting on", l.Addr(), e)
+			}
+			return
+		}
+		go s.handle(conn)
+	}
+}
+
+func (s *StreamServer) handle(conn net.Conn) {
+	sc, e := newStreamConn(conn, s.master, true)
+	if e != nil {
+		W.Ln("rejected stream connection from", conn.RemoteAddr(), e)
+		if e = conn.Close(); E.Chk(e) {
+		}
+		return
+	}
+	s.Lock()
+	// the server may have been closed while the handshake was in progress
+	if s.closed {
+		s.Unlock()
+		_ = sc.Close()
+		return
+	}
+	s.conns[sc] = struct{}{}
+	s.Unlock()
+	I.Ln("stream client connected from", conn.RemoteAddr())
+	// the server does not time out reads as clients may have nothing to send, dead connections are found by the
+	// heartbeat failing to be sent
+	e = sc.serve(s.context, s.handlers, 0)
+	D.Ln("stream client", conn.RemoteAddr(), "disconnected", e)
+	s.drop(sc)
+}
+
+func (s *StreamServer) drop(sc *streamConn) {
+	s.Lock()
+	delete(s.conns, sc)
+	s.Unlock()
+	_ = sc.Close()
+}
+
+func (s *StreamServer) run() {
+	ticker := time.NewTicker(heartbeat)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			s.broadcast(pingMagic, nil)
+		case <-s.quit.Wait():
+			s.close()
+			return
+		}
+	}
+}
+
+func (s *StreamServer) close() {
+	for i := range s.listeners {
+		_ = s.listeners[i].Close()
+	}
+	s.Lock()
+	s.closed = true
+	for sc := range s.conns {
+		_ = sc.Close()
+	}
+	s.Unlock()
+}
+
+func (s *StreamServer) broadcast(magic, data []byte) {
+	s.Lock()
+	conns := make([]*streamConn, 0, len(s.conns))
+	for sc := range s.conns {
+		conns = append(conns, sc)
+	}
+	s.Unlock()
+	for _, sc := range conns {
+		if e := sc.send(magic, data); e != nil {
+			D.Ln("dropping stream client", sc.RemoteAddr(), e)
+			s.drop(sc)
+		}
+	}
+}
+
+// Send a message to all connected clients
+func (s *StreamServer) Send(magic []byte, data []byte) (e error) {
+	s.broadcast(magic, data)
+	return
+}
+
+// SendMany sends a message split into shards by GetShards to all connected clients
+func (s *StreamServer) SendMany(magic []byte, b [][]byte) (e error) {
+	var data []byte
+	if data, e = decodeShards(b); e != nil {
+		return
+	}
+	return s.Send(magic, data)
+}
+
+// StreamClient keeps a stream connection to the first of a list of servers that accepts it, moving on to the next when
+// the connection is lost or a server cannot be reached
+type StreamClient struct {
+	sync.Mutex
+	Creator   string
+	context   interface{}
+	handlers  Handlers
+	master    []byte
+	addresses []string
+	conn      *streamConn
+	current   int
+	// Connected is signalled each time a connection to a server is established
+	Connected chan string
+	quit      qu.C
+}
+
+// NewStreamClient starts connecting to the servers at the given addresses in turn, invoking the handlers on messages
+// received from the one it is connected to
+func NewStreamClient(
+	creator string, ctx interface{}, key []byte, addresses []string,
+	handlers Handlers, quit qu.C,
+) (c *StreamClient, e error) {
+	if len(addresses) < 1 {
+		return nil, errors.New("no stream server addresses given")
+	}
+	c = &StreamClient{
+		Creator:   creator,
+		context:   ctx,
+		handlers:  handlers,
+		master:    gcm.DeriveKey(key),
+		addresses: addresses,
+		Connected: make(chan string, 1),
+		quit:      quit,
+	}
+	go c.run()
+	return
+}
+
+// Address returns the address of the server the client is connected to, or an empty string if it is not connected
+func (c *StreamClient) Address() string {
+	c.Lock()
+	defer c.Unlock()
+	if c.conn == nil {
+		return ""
+	}
+	return c.addresses[c.current]
+}
+
+func (c *StreamClient) run() {
+	backoff := minReconnect
+	for {
+		select {
+		case <-c.quit.Wait():
+			return
+		default:
+		}
+		address := c.addresses[c.current]
+		D.Ln("stream client", c.Creator, "connecting to", address)
+		conn, e := net.DialTimeout("tcp", address, streamTimeout)
+		var sc *streamConn
+		if e == nil {
+			if sc, e = newStreamConn(conn, c.master, false); e != nil {
+				_ = conn.Close()
+			}
+		}
+		if e != nil {
+			W.Ln("could not connect to", address, e)
+			// move on to the next server, waiting before trying the list again
+			c.current = (c.current + 1) % len(c.addresses)
+			if c.current == 0 {
+				select {
+				case <-time.After(backoff):
+				case <-c.quit.Wait():
+					return
+				}
+				if backoff *= 2; backoff > maxReconnect {
+					backoff = maxReconnect
+				}
+			}
+			continue
+		}
+		I.Ln("stream client", c.Creator, "connected to", address)
+		backoff = minReconnect
+		c.Lock()
+		c.conn = sc
+		c.Unlock()
+		select {
+		case c.Connected <- address:
+		default:
+		}
+		done := qu.T()
+		go func() {
+			select {
+			case <-c.quit.Wait():
+				_ = sc.Close()
+			case <-done.Wait():
+			}
+		}()
+		e = sc.serve(c.context, c.handlers, readTimeout)
+		done.Q()
+		W.Ln("lost connection to", address, e)
+		c.Lock()
+		c.conn = nil
+		c.Unlock()
+		_ = sc.Close()
+		// the next server is tried first, so a controller that went away is failed over from straight away
+		c.current = (c.current + 1) % len(c.addresses)
+	}
+}
+
+// Send a message to the server the client is connected to
+func (c *StreamClient) Send(magic []byte, data []byte) (e error) {
+	c.Lock()
+	sc := c.conn
+	c.Unlock()
+	if sc == nil {
+		return errors.New("stream client is not connected")
+	}
+	if e = sc.send(magic, data); e != nil {
+		// closing the connection makes the receive loop reconnect
+		_ = sc.Close()
+	}
+	return
+}
+
+// SendMany sends a message split into shards by GetShards to the server the client is connected to
+func (c *StreamClient) SendMany(magic []byte, b [][]byte) (e error) {
+	var data []byte
+	if data, e = decodeShards(b); e != nil {
+		return
+	}
+	return c.Send(magic, data)
+}
@@ -0,0 +1,146 @@
+package transport
+
+import (
+	"bytes"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/cybriq/p9/pkg/qu"
+)
+
+type received struct {
+	magic string
+	data  []byte
+}
+
+func recorder(ch chan received, magics ...string) Handlers {
+	h := make(Handlers)
+	for i := range magics {
+		magic := magics[i]
+		h[magic] = func(ctx interface{}, src net.Addr, dst string, b []byte) (e error) {
+			ch <- received{magic, b}
+			return
+		}
+	}
+	return h
+}
+
+func expect(t *testing.T, ch chan received, magic string, data []byte) {
+	select {
+	case r := <-ch:
+		if r.magic != magic || !bytes.Equal(r.data, data) {
+			t.Fatalf("expected %s %x, got %s %x", magic, data, r.magic, r.data)
+		}
+	case <-time.After(time.Second * 10):
+		t.Fatalf("did not receive %s", magic)
+	}
+}
+
+func connected(t *testing.T, c *StreamClient, address string) {
+	select {
+	case a := <-c.Connected:
+		if a != address {
+			t.Fatalf("expected to connect to %s, connected to %s", address, a)
+		}
+	case <-time.After(time.Second * 20):
+		t.Fatalf("did not connect to %s", address)
+	}
+}
+
+// deadAddress returns an address nothing is listening on
+func deadAddress(t *testing.T) string {
+	l, e := net.Listen("tcp", "127.0.0.1:0")
+	if e != nil {
+		t.Fatal(e)
+	}
+	address := l.Addr().String()
+	_ = l.Close()
+	return address
+}
+
+func TestStream(t *testing.T) {
+	quit := qu.T()
+	defer quit.Q()
+	fromClients := make(chan received, 8)
+	server, e := NewStreamServer(
+		"server", nil, []byte("password"), []string{"127.0.0.1:0"},
+		recorder(fromClients, "sol!"), quit,
+	)
+	if e != nil {
+		t.Fatal(e)
+	}
+	address := server.Addrs()[0].String()
+	fromServer := make(chan received, 8)
+	// the first address cannot be connected to so the client moves on to the next
+	client, e := NewStreamClient(
+		"client", nil, []byte("password"), []string{deadAddress(t), address},
+		recorder(fromServer, "job!"), quit,
+	)
+	if e != nil {
+		t.Fatal(e)
+	}
+	connected(t, client, address)
+	if e = client.Send([]byte("sol!"), []byte("solution")); e != nil {
+		t.Fatal(e)
+	}
+	expect(t, fromClients, "sol!", []byte("solution"))
+	job := bytes.Repeat([]byte("template"), 1000)
+	if e = server.SendMany([]byte("job!"), GetShards(job)); e != nil {
+		t.Fatal(e)
+	}
+	expect(t, fromServer, "job!", job)
+	// messages without a handler are ignored
+	if e = server.Send([]byte("none"), []byte{1}); e != nil {
+		t.Fatal(e)
+	}
+	if e = server.Send([]byte("job!"), []byte{2}); e != nil {
+		t.Fatal(e)
+	}
+	expect(t, fromServer, "job!", []byte{2})
+	// a client with the wrong key is never connected
+	wrong, e := NewStreamClient(
+		"wrong", nil, []byte("wrong password"), []string{address},
+		recorder(fromServer, "job!"), quit,
+	)
+	if e != nil {
+		t.Fatal(e)
+	}
+	time.Sleep(time.Second)
+	if wrong.Address() != "" || server.Count() != 1 {
+		t.Fatalf("client with the wrong key was accepted")
+	}
+}
+
+func TestStreamFailover(t *testing.T) {
+	quit := qu.T()
+	defer quit.Q()
+	var addresses []string
+	var quits []qu.C
+	fromClients := make(chan received, 8)
+	for i := 0; i < 2; i++ {
+		q := qu.T()
+		server, e := NewStreamServer(
+			"server", nil, []byte("password"), []string{"127.0.0.1:0"},
+			recorder(fromClients, "sol!"), q,
+		)
+		if e != nil {
+			t.Fatal(e)
+		}
+		addresses = append(addresses, server.Addrs()[0].String())
+		quits = append(quits, q)
+	}
+	defer quits[1].Q()
+	client, e := NewStreamClient("client", nil, []byte("password"), addresses, Handlers{}, quit)
+	if e != nil {
+		t.Fatal(e)
+	}
+	connected(t, client, addresses[0])
+	// when the first server goes away the client fails over to the second
+	quits[0].Q()
+	connected(t, client, addresses[1])
+	if e = client.Send([]byte("sol!"), []byte("after failover")); e != nil {
+		t.Fatal(e)
+	}
+	expect(t, fromClients, "sol!", []byte("after failover"))
+}
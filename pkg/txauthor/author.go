@@ -172,6 +172,14 @@ func (tx *AuthoredTx) RandomizeChangePosition() {
 	tx.ChangeIndex = RandomizeOutputPosition(tx.Tx.TxOut, tx.ChangeIndex)
 }
 
+// SignalReplacement sets the sequence numbers of an authored transaction's inputs so that it signals it can be replaced
+// by a transaction paying a higher fee, as described by BIP125. This should be done before signing.
+func (tx *AuthoredTx) SignalReplacement() {
+	for _, txIn := range tx.Tx.TxIn {
+		txIn.Sequence = wire.MaxReplaceableSequenceNum
+	}
+}
+
 // AddAllInputScripts modifies transaction a transaction by adding inputs
 // scripts for each input. Previous output scripts being redeemed by each input
 // are passed in prevPkScripts and the slice length must match the number of
@@ -0,0 +1,48 @@
+package hdkeychain
+
+import (
+	"strings"
+
+	"github.com/tyler-smith/go-bip39"
+)
+
+// NewMnemonic returns the BIP39 mnemonic words that encode the passed entropy, which must be 16 to 32 bytes long and a
+// multiple of 4 bytes.
+func NewMnemonic(entropy []byte) (string, error) {
+	return bip39.NewMnemonic(entropy)
+}
+
+// NormalizeMnemonic returns the passed mnemonic in lower case with the words separated by single spaces.
+func NormalizeMnemonic(mnemonic string) string {
+	return strings.Join(strings.Fields(strings.ToLower(mnemonic)), " ")
+}
+
+// ValidateMnemonic returns an error if the words or the checksum of the passed BIP39 mnemonic are not valid.
+func ValidateMnemonic(mnemonic string) (e error) {
+	_, e = bip39.EntropyFromMnemonic(NormalizeMnemonic(mnemonic))
+	return
+}
+
+// SeedFromMnemonic validates the words and checksum of a BIP39 mnemonic and returns the seed for the master node of the
+// wallet it stands for, derived from the words and the passphrase as specified by BIP39. The passphrase may be empty,
+// and the same words with a different passphrase restore a different wallet.
+func SeedFromMnemonic(mnemonic, passphrase string) (seed []byte, e error) {
+	mnemonic = NormalizeMnemonic(mnemonic)
+	if e = ValidateMnemonic(mnemonic); e != nil {
+		return nil, e
+	}
+	return bip39.NewSeed(mnemonic, passphrase), nil
+}
+
+// LegacySeedFromMnemonic validates the words and checksum of a BIP39 mnemonic and returns the entropy they encode as the
+// seed. Wallets created by earlier versions of the GUI used the entropy behind the words they showed directly as the
+// seed, so this is only for restoring those wallets, which have no passphrase.
+func LegacySeedFromMnemonic(mnemonic string) (seed []byte, e error) {
+	if seed, e = bip39.EntropyFromMnemonic(NormalizeMnemonic(mnemonic)); e != nil {
+		return nil, e
+	}
+	if len(seed) < MinSeedBytes || len(seed) > MaxSeedBytes {
+		return nil, ErrInvalidSeedLen
+	}
+	return seed, nil
+}
@@ -0,0 +1,65 @@
+package hdkeychain
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+)
+
+// TestSeedFromMnemonic ensures mnemonics encode the entropy they were created from, that the seed is derived as specified
+// by the BIP39 test vectors with or without a passphrase, that the legacy seed is the entropy, and that invalid
+// mnemonics are rejected.
+func TestSeedFromMnemonic(t *testing.T) {
+	entropy := bytes.Repeat([]byte{0x7f}, 16)
+	words := "legal winner thank year wave sausage worth useful legal winner thank yellow"
+	mnemonic, e := NewMnemonic(entropy)
+	if e != nil {
+		t.Fatalf("NewMnemonic: unexpected error: %v", e)
+	}
+	if mnemonic != words {
+		t.Fatalf("NewMnemonic: got %q, want %q", mnemonic, words)
+	}
+	seed, e := LegacySeedFromMnemonic("  Legal winner thank year wave sausage\nworth useful legal winner thank yellow ")
+	if e != nil {
+		t.Fatalf("LegacySeedFromMnemonic: unexpected error: %v", e)
+	}
+	if !bytes.Equal(seed, entropy) {
+		t.Fatalf("LegacySeedFromMnemonic: got seed %x, want %x", seed, entropy)
+	}
+	for _, vector := range []struct {
+		passphrase string
+		seed       string
+	}{
+		{
+			"",
+			"878386efb78845b3355bd15ea4d39ef97d179cb712b77d5c12b6be415fffeffe5f377ba02bf3f8544ab800b955e51fbff09828f682052a2" +
+				"0faa6addbbddfb096",
+		},
+		{
+			"TREZOR",
+			"2e8905819b8723fe2c1d161860e5ee1830318dbf49a83bd451cfb8440c28bd6fa457fe1296106559a3c80937a1c1069be3a3a5bd381ee62" +
+				"60e8d9739fce1f607",
+		},
+	} {
+		want, _ := hex.DecodeString(vector.seed)
+		if seed, e = SeedFromMnemonic(words, vector.passphrase); e != nil {
+			t.Fatalf("SeedFromMnemonic: unexpected error: %v", e)
+		}
+		if !bytes.Equal(seed, want) {
+			t.Fatalf("SeedFromMnemonic: passphrase %q: got seed %x, want %x", vector.passphrase, seed, want)
+		}
+	}
+	for _, invalid := range []string{
+		"",
+		"legal winner thank year wave sausage worth useful legal winner thank thank",
+		"legal winner thank year wave sausage worth useful legal winner thank notaword",
+		"legal winner thank year wave sausage worth useful legal winner thank",
+	} {
+		if _, e = SeedFromMnemonic(invalid, ""); e == nil {
+			t.Errorf("SeedFromMnemonic: no error for invalid mnemonic %q", invalid)
+		}
+		if _, e = LegacySeedFromMnemonic(invalid); e == nil {
+			t.Errorf("LegacySeedFromMnemonic: no error for invalid mnemonic %q", invalid)
+		}
+	}
+}
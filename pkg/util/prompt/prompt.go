@@ -16,20 +16,54 @@ import (
 
 // ProvideSeed is used to prompt for the wallet seed which maybe required during upgrades.
 func ProvideSeed() ([]byte, error) {
-	reader := bufio.NewReader(os.Stdin)
+	return readSeed(bufio.NewReader(os.Stdin))
+}
+
+// readSeed prompts for an existing wallet seed, entered either as a hexadecimal value or as BIP39 mnemonic words. When
+// mnemonic words are entered the optional passphrase they were created with is asked for as well, and without one
+// whether the words come from a wallet created by an earlier version of the GUI, which used their entropy as the seed.
+func readSeed(reader *bufio.Reader) ([]byte, error) {
 	for {
-		fmt.Print("Enter existing wallet seed: ")
+		fmt.Print("Enter existing wallet seed or mnemonic words: ")
 		seedStr, e := reader.ReadString('\n')
 		if e != nil {
 			return nil, e
 		}
 		seedStr = strings.TrimSpace(strings.ToLower(seedStr))
+		if len(strings.Fields(seedStr)) > 1 {
+			fmt.Print("Enter the mnemonic passphrase (leave empty if there is none): ")
+			passphrase, e := terminal.ReadPassword(int(os.Stdin.Fd()))
+			if e != nil {
+				return nil, e
+			}
+			fmt.Print("\n")
+			passphrase = bytes.TrimSpace(passphrase)
+			var legacy bool
+			if len(passphrase) == 0 {
+				if legacy, e = promptListBool(
+					reader, "Were the words shown by a wallet created with an earlier version of the GUI?", "no",
+				); e != nil {
+					return nil, e
+				}
+			}
+			var seed []byte
+			if legacy {
+				seed, e = hdkeychain.LegacySeedFromMnemonic(seedStr)
+			} else {
+				seed, e = hdkeychain.SeedFromMnemonic(seedStr, string(passphrase))
+			}
+			if e != nil {
+				E.Ln("Invalid mnemonic specified:", e)
+				continue
+			}
+			return seed, nil
+		}
 		seed, e := hex.DecodeString(seedStr)
 		if e != nil || len(seed) < hdkeychain.MinSeedBytes ||
 			len(seed) > hdkeychain.MaxSeedBytes {
 			E.F(
 				"Invalid seed specified.  "+
-					"Must be a hexadecimal value that is at least %d bits and at"+
+					"Must be BIP39 mnemonic words or a hexadecimal value that is at least %d bits and at"+
 					" most %d bits", hdkeychain.MinSeedBytes*8,
 				hdkeychain.MaxSeedBytes*8,
 			)
@@ -257,71 +291,68 @@ func PublicPass(
 
 // Seed prompts the user whether they want to use an existing wallet generation seed.
 //
-// When the user answers no, a seed will be generated and displayed to the user along with prompting them for
-// confirmation.
+// When the user answers no, a seed will be generated and displayed to the user, both as hexadecimal and as BIP39
+// mnemonic words, along with prompting them for confirmation.
 //
-// When the user answers yes, a the user is prompted for it.
+// When the user answers yes, a the user is prompted for it, as hexadecimal or as mnemonic words with an optional
+// passphrase, and existing is returned true so the wallet can be restored from the beginning of the chain.
 //
 // All prompts are repeated until the user enters a valid response.
-func Seed(reader *bufio.Reader) ([]byte, error) {
+func Seed(reader *bufio.Reader) (seed []byte, existing bool, e error) {
 	// Ascertain the wallet generation seed.
-	useUserSeed, e := promptListBool(
+	existing, e = promptListBool(
 		reader, "Do you have an "+
 			"existing wallet seed you want to use?", "no",
 	)
 	if e != nil {
-		return nil, e
+		return nil, false, e
 	}
-	if !useUserSeed {
-		seed, e := hdkeychain.GenerateSeed(hdkeychain.RecommendedSeedLen)
-		if e != nil {
-			return nil, e
-		}
-		fmt.Println("\nYour wallet generation seed is:")
-		fmt.Printf("\n%x\n\n", seed)
-		fmt.Print(
-			"IMPORTANT: Keep the seed in a safe place as you will NOT be" +
-				" able to restore your wallet without it.\n\n",
-		)
-		fmt.Print(
-			"Please keep in mind that anyone who has access to the seed" +
-				" can also restore your wallet thereby giving them access to all your funds, so it is imperative that you keep it in a secure location.\n\n",
-		)
-		for {
-			fmt.Print(
-				`Once you have stored the seed in a safe ` +
-					`and secure location, enter "OK" to continue: `,
-			)
-			confirmSeed, e := reader.ReadString('\n')
-			if e != nil {
-				return nil, e
-			}
-			confirmSeed = strings.TrimSpace(confirmSeed)
-			confirmSeed = strings.Trim(confirmSeed, `"`)
-			if confirmSeed == "OK" {
-				break
-			}
-		}
-		return seed, nil
+	if existing {
+		seed, e = readSeed(reader)
+		return seed, true, e
+	}
+	var entropy []byte
+	if entropy, e = hdkeychain.GenerateSeed(hdkeychain.RecommendedSeedLen); e != nil {
+		return nil, false, e
+	}
+	var mnemonic string
+	if mnemonic, e = hdkeychain.NewMnemonic(entropy); e != nil {
+		return nil, false, e
+	}
+	if seed, e = hdkeychain.SeedFromMnemonic(mnemonic, ""); e != nil {
+		return nil, false, e
 	}
+	fmt.Println("\nYour wallet generation seed is:")
+	fmt.Printf("\n%x\n\n", seed)
+	fmt.Println("which can also be written down as the mnemonic words:")
+	fmt.Printf("\n%s\n\n", mnemonic)
+	fmt.Print(
+		"IMPORTANT: Keep the seed in a safe place as you will NOT be" +
+			" able to restore your wallet without it.\n\n",
+	)
+	fmt.Print(
+		"Please keep in mind that anyone who has access to the seed" +
+			" can also restore your wallet thereby giving them access to all your funds, so it is imperative that you keep it in a secure location.\n\n",
+	)
 	for {
-		fmt.Print("Enter existing wallet seed: ")
-		seedStr, e := reader.ReadString('\n')
+		fmt.Print(
+			`Once you have stored the seed in a safe ` +
+				`and secure location, enter "OK" to continue: `,
+		)
+		confirmSeed, e := reader.ReadString('\n')
 		if e != nil {
-			return nil, e
+			return nil, false, e
 		}
-		seedStr = strings.TrimSpace(strings.ToLower(seedStr))
-		seed, e := hex.DecodeString(seedStr)
-		if e != nil || len(seed) < hdkeychain.MinSeedBytes ||
-			len(seed) > hdkeychain.MaxSeedBytes {
-			E.F(
-				"Invalid seed specified.  Must be a "+
-					"hexadecimal value that is at least %d bits and "+
-					"at most %d bits\n", hdkeychain.MinSeedBytes*8,
-				hdkeychain.MaxSeedBytes*8,
-			)
-			continue
+		confirmSeed = strings.TrimSpace(confirmSeed)
+		confirmSeed = strings.Trim(confirmSeed, `"`)
+		if confirmSeed == "OK" {
+			break
 		}
-		return seed, nil
 	}
+	return seed, false, nil
+}
+
+// Confirm asks the user a yes or no question on the terminal, defaulting to no.
+func Confirm(prefix string) (bool, error) {
+	return promptListBool(bufio.NewReader(os.Stdin), prefix, "no")
 }
@@ -6,6 +6,7 @@ import (
 	"io"
 
 	"github.com/cybriq/p9/pkg/chainhash"
+	"github.com/cybriq/p9/pkg/fork"
 )
 
 // defaultTransactionAlloc is the default size used for the backing array for transactions. The transaction array will
@@ -233,8 +234,8 @@ func (msg *Block) BlockHash() chainhash.Hash {
 }
 
 // BlockHashWithAlgos computes the block identifier hash for this block.
-func (msg *Block) BlockHashWithAlgos(h int32) chainhash.Hash {
-	return msg.Header.BlockHashWithAlgos(h)
+func (msg *Block) BlockHashWithAlgos(forks *fork.Schedule, h int32) chainhash.Hash {
+	return msg.Header.BlockHashWithAlgos(forks, h)
 }
 
 // TxHashes returns a slice of hashes of all of transactions in this block.
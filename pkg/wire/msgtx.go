@@ -14,6 +14,9 @@ const (
 	TxVersion = 1
 	// MaxTxInSequenceNum is the maximum sequence number the sequence field of a transaction input can be.
 	MaxTxInSequenceNum uint32 = 0xffffffff
+	// MaxReplaceableSequenceNum is the highest sequence number an input can have for its transaction to signal that it
+	// can be replaced by one paying a higher fee, as described by BIP125.
+	MaxReplaceableSequenceNum uint32 = 0xfffffffd
 	// MaxPrevOutIndex is the maximum index the index field of a previous outpoint can be.
 	MaxPrevOutIndex uint32 = 0xffffffff
 	// SequenceLockTimeDisabled is a flag that if set on a transaction input's sequence number, the sequence number will
@@ -601,6 +601,10 @@ func (c *Config) processCommandlineArgs(args []string) (
 					log.App = cm.AppText
 				}
 			}
+		} else if len(cmds) > 1 {
+			// the deepest command on the path is the one to run, the last argument searched may not have been a command
+			deepest := commands[cmds[len(cmds)-1]]
+			cm = &deepest
 		}
 	}
 	// if there was no command the commands start and end after all the args
@@ -28,6 +28,7 @@ type Config struct {
 	AddCheckpoints         *list.Opt
 	AddPeers               *list.Opt
 	AddrIndex              *binary.Opt
+	AssumeValid            *text.Opt
 	AutoListen             *binary.Opt
 	AutoPorts              *binary.Opt
 	BanDuration            *duration.Opt
@@ -54,10 +55,13 @@ type Config struct {
 	DisableRPC             *binary.Opt
 	Discovery              *binary.Opt
 	ExternalIPs            *list.Opt
+	ForkSchedule           *text.Opt
 	FreeTxRelayLimit       *float.Opt
 	GenThreads             *integer.Opt
 	Generate               *binary.Opt
 	Hilite                 *list.Opt
+	KopachControllers      *list.Opt
+	KopachMetricsListeners *list.Opt
 	LAN                    *binary.Opt
 	LimitPass              *text.Opt
 	LimitUser              *text.Opt
@@ -65,9 +69,16 @@ type Config struct {
 	LogDir                 *text.Opt
 	LogFilter              *list.Opt
 	LogLevel               *text.Opt
+	MaxMempool             *integer.Opt
 	MaxOrphanTxs           *integer.Opt
 	MaxPeers               *integer.Opt
+	MempoolExpiry          *duration.Opt
+	MempoolReplacement     *binary.Opt
+	MetricsListeners       *list.Opt
 	MinRelayTxFee          *float.Opt
+	MinerListeners         *list.Opt
+	MiningAddrs            *list.Opt
+	MiningXPub             *text.Opt
 	MulticastPass          *text.Opt
 	Network                *text.Opt
 	NoCFilters             *binary.Opt
@@ -83,11 +94,17 @@ type Config struct {
 	P2PConnect             *list.Opt
 	P2PListeners           *list.Opt
 	Password               *text.Opt
+	PayoutAddress          *text.Opt
+	PersistMempool         *binary.Opt
 	PipeLog                *binary.Opt
+	PoolMode               *binary.Opt
+	PoolShareRatio         *integer.Opt
+	PoolWindow             *integer.Opt
 	Profile                *text.Opt
 	ProxyAddress           *text.Opt
 	ProxyPass              *text.Opt
 	ProxyUser              *text.Opt
+	Prune                  *integer.Opt
 	RPCCert                *text.Opt
 	RPCConnect             *text.Opt
 	RPCKey                 *text.Opt
@@ -103,6 +120,10 @@ type Config struct {
 	ServerTLS              *binary.Opt
 	SigCacheMaxSize        *integer.Opt
 	Solo                   *binary.Opt
+	StratumDifficulty      *float.Opt
+	StratumListeners       *list.Opt
+	StratumPass            *text.Opt
+	StratumShareTime       *duration.Opt
 	TLSSkipVerify          *binary.Opt
 	TorIsolation           *binary.Opt
 	TrickleInterval        *duration.Opt
@@ -113,6 +134,7 @@ type Config struct {
 	UserAgentComments      *list.Opt
 	Username               *text.Opt
 	WalletFile             *text.Opt
+	WalletMetricsListeners *list.Opt
 	WalletOff              *binary.Opt
 	WalletPass             *text.Opt
 	WalletRPCListeners     *list.Opt
@@ -0,0 +1,125 @@
+package launchers
+
+import (
+	"fmt"
+	"path/filepath"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+
+	"github.com/cybriq/p9/cmd/node"
+	"github.com/cybriq/p9/cmd/wallet"
+	"github.com/cybriq/p9/pkg/apputil"
+	"github.com/cybriq/p9/pkg/constant"
+	"github.com/cybriq/p9/pkg/util/prompt"
+	"github.com/cybriq/p9/pod/state"
+)
+
+// The maintenance commands change the databases of a node or wallet that is not running. Each asks for confirmation
+// before doing anything unless 'yes' follows the command, as in 'pod node resetchain yes'.
+
+// DropAddrIndex drops the address index from the block database
+func DropAddrIndex(ifc interface{}) (e error) {
+	return dropIndexes(ifc, "the address index", true, false, false)
+}
+
+// DropTxIndex drops the transaction index, and with it the address index, from the block database
+func DropTxIndex(ifc interface{}) (e error) {
+	return dropIndexes(ifc, "the transaction and address indexes", false, true, false)
+}
+
+// DropCfIndex drops the committed filter index from the block database
+func DropCfIndex(ifc interface{}) (e error) {
+	return dropIndexes(ifc, "the cfilter index", false, false, true)
+}
+
+// DropIndexes drops all of the indexes from the block database
+func DropIndexes(ifc interface{}) (e error) {
+	return dropIndexes(ifc, "all of the indexes", true, true, true)
+}
+
+func dropIndexes(ifc interface{}, what string, addr, tx, cf bool) (e error) {
+	var cx *state.State
+	var ok bool
+	if cx, ok = ifc.(*state.State); !ok {
+		return fmt.Errorf("cannot run without a state")
+	}
+	if !confirmed(cx, "drop "+what+" of the "+cx.ActiveNet.Name+" block database?") {
+		return
+	}
+	cx.StateCfg.DropAddrIndex, cx.StateCfg.DropTxIndex, cx.StateCfg.DropCfIndex = addr, tx, cf
+	if e = node.DropIndexes(cx); E.Chk(e) {
+		return
+	}
+	fmt.Println("dropped", what)
+	return
+}
+
+// ResetChain deletes the block database so the chain is downloaded again
+func ResetChain(ifc interface{}) (e error) {
+	var cx *state.State
+	var ok bool
+	if cx, ok = ifc.(*state.State); !ok {
+		return fmt.Errorf("cannot run without a state")
+	}
+	if !confirmed(
+		cx, "delete the "+cx.ActiveNet.Name+" block database? the whole chain will have to be downloaded again",
+	) {
+		return
+	}
+	if e = node.ResetChain(cx); E.Chk(e) {
+		return
+	}
+	fmt.Println("deleted the block database")
+	return
+}
+
+// DropWalletHistory clears the transaction history of the wallet so it is rebuilt by rescanning the chain the next
+// time the wallet starts
+func DropWalletHistory(ifc interface{}) (e error) {
+	var cx *state.State
+	var ok bool
+	if cx, ok = ifc.(*state.State); !ok {
+		return fmt.Errorf("cannot run without a state")
+	}
+	dbPath := filepath.Join(cx.Config.DataDir.V(), cx.ActiveNet.Name, constant.DbName)
+	if !apputil.FileExists(dbPath) {
+		return fmt.Errorf("there is no wallet at '%s'", dbPath)
+	}
+	// the wallet database can only be opened by one process at a time, and opening it would otherwise wait forever
+	// for a running wallet to stop
+	var db *bolt.DB
+	if db, e = bolt.Open(dbPath, 0600, &bolt.Options{Timeout: time.Second}); E.Chk(e) {
+		return fmt.Errorf("unable to open the wallet at '%s', is the wallet still running? %v", dbPath, e)
+	}
+	if e = db.Close(); E.Chk(e) {
+		return
+	}
+	if !confirmed(
+		cx, "delete the transaction history of the "+cx.ActiveNet.Name+
+			" wallet? it will be rebuilt by rescanning the chain when the wallet starts",
+	) {
+		return
+	}
+	// the history is replaced in a single database transaction so an interruption leaves it as it was
+	if e = wallet.DropHistory(dbPath); E.Chk(e) {
+		return
+	}
+	fmt.Println("dropped the wallet transaction history")
+	return
+}
+
+// confirmed returns whether the user has agreed to go ahead with a maintenance command
+func confirmed(cx *state.State, question string) bool {
+	for _, arg := range cx.Config.ExtraArgs {
+		if arg == "yes" {
+			return true
+		}
+	}
+	ok, e := prompt.Confirm(question)
+	if E.Chk(e) || !ok {
+		fmt.Println("cancelled")
+		return false
+	}
+	return true
+}
@@ -15,7 +15,6 @@ import (
 	"github.com/cybriq/p9/pod/state"
 
 	"github.com/cybriq/p9/pkg/chaincfg"
-	"github.com/cybriq/p9/pkg/fork"
 )
 
 // Kopach runs the kopach miner
@@ -30,9 +29,6 @@ func Kopach(ifc interface{}) (e error) {
 	I.Ln("starting up kopach standalone miner for parallelcoin")
 	D.Ln(os.Args)
 	// podconfig.Configure(cx, true)
-	if cx.ActiveNet.Name == chaincfg.TestNet3Params.Name {
-		fork.IsTestnet = true
-	}
 	defer cx.KillAll.Q()
 	e = kopach.Run(cx)
 	<-proc.HandlersDone
@@ -47,9 +43,16 @@ func Worker(ifc interface{}) (e error) {
 		return fmt.Errorf("cannot run without a state")
 	}
 	// I.Ln(cx.Config.ExtraArgs)
+	// the worker mines on the network kopach was started on, which is given in the arguments
+	forks := cx.ActiveNet.Forks
 	if len(cx.Config.ExtraArgs) > 1 {
-		if os.Args[3] == chaincfg.TestNet3Params.Name {
-			fork.IsTestnet = true
+		for _, p := range []*chaincfg.Params{
+			&chaincfg.MainNetParams, &chaincfg.TestNet3Params,
+			&chaincfg.RegressionTestParams, &chaincfg.SimNetParams,
+		} {
+			if cx.Config.ExtraArgs[1] == p.Name {
+				forks = p.Forks
+			}
 		}
 	}
 	if len(os.Args) > 2 {
@@ -58,7 +61,7 @@ func Worker(ifc interface{}) (e error) {
 	D.Ln("miner worker starting")
 	w, conn := worker.New(
 		cx.Config.ExtraArgs[0], cx.KillAll,
-		uint64(cx.Config.UUID.V()),
+		uint64(cx.Config.UUID.V()), forks,
 	)
 	e = rpc.Register(w)
 	if e != nil {
@@ -0,0 +1,67 @@
+package launchers
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/cybriq/p9/pkg/simdiff"
+	"github.com/cybriq/p9/pod/state"
+)
+
+// SimDiff runs the Plan 9 difficulty adjustment simulator and writes the result to stdout. The arguments following
+// the command are the scenario name, the number of blocks, the output format (csv, summary or json) and the random
+// seed, all but the scenario being optional. With no scenario the available scenarios are printed.
+func SimDiff(ifc interface{}) (e error) {
+	var cx *state.State
+	var ok bool
+	if cx, ok = ifc.(*state.State); !ok {
+		return fmt.Errorf("cannot run without a state")
+	}
+	args := cx.Config.ExtraArgs
+	if len(args) < 1 {
+		fmt.Println("usage: pod node simdiff <scenario> [blocks] [csv|summary|json] [seed]")
+		fmt.Println()
+		for _, name := range simdiff.ScenarioNames() {
+			fmt.Printf("  %-10s %s\n", name, simdiff.Scenarios[name].Description)
+		}
+		return
+	}
+	cfg := simdiff.Config{Blocks: simdiff.DefaultBlocks}
+	if cx.Config.ForkSchedule.V() != "" {
+		// a schedule given in the configuration has been loaded into the active network's parameters
+		cfg.Forks = cx.ActiveNet.Forks
+	}
+	if cfg.Scenario, ok = simdiff.Scenarios[args[0]]; !ok {
+		return fmt.Errorf("unknown scenario '%s', run 'pod node simdiff' to see the options", args[0])
+	}
+	if len(args) > 1 {
+		if cfg.Blocks, e = strconv.Atoi(args[1]); E.Chk(e) {
+			return fmt.Errorf("invalid number of blocks '%s'", args[1])
+		}
+	}
+	format := "summary"
+	if len(args) > 2 {
+		format = args[2]
+	}
+	if len(args) > 3 {
+		if cfg.Seed, e = strconv.ParseInt(args[3], 10, 64); E.Chk(e) {
+			return fmt.Errorf("invalid seed '%s'", args[3])
+		}
+	}
+	var r *simdiff.Result
+	if r, e = simdiff.Run(cfg); E.Chk(e) {
+		return
+	}
+	switch format {
+	case "csv":
+		e = r.WriteCSV(os.Stdout)
+	case "summary":
+		e = r.WriteSummaryCSV(os.Stdout)
+	case "json":
+		e = r.WriteJSON(os.Stdout)
+	default:
+		e = fmt.Errorf("unknown output format '%s', must be csv, summary or json", format)
+	}
+	return
+}
@@ -112,6 +112,18 @@ func GetConfigs() (c config.Configs) {
 			},
 			false,
 		),
+		"AssumeValid": text.New(
+			meta.Data{
+				Aliases:       []string{"AV"},
+				Group:         "node",
+				Tags:          tags("node"),
+				Label:         "Assume Valid",
+				Description:   "hash of a block whose ancestors are assumed to have valid scripts, which are not checked during the initial sync (empty uses the network default, 0 checks all scripts)",
+				Documentation: "<placeholder for detailed documentation>",
+				OmitEmpty:     true,
+			},
+			"",
+		),
 		"AutoPorts": binary.New(
 			meta.Data{
 				Group:         "debug",
@@ -251,6 +263,18 @@ func GetConfigs() (c config.Configs) {
 			},
 			filepath.Join(string(datadir.Load().([]byte)), "ca.cert"),
 		),
+		"ClientTLS": binary.New(
+			meta.Data{
+				Aliases:       []string{"CT"},
+				Group:         "tls",
+				Tags:          tags("node", "wallet"),
+				Label:         "TLS",
+				Description:   "enable TLS for RPC client connections",
+				Documentation: "<placeholder for detailed documentation>",
+				OmitEmpty:     true,
+			},
+			true,
+		),
 		"ConfigFile": text.New(
 			meta.Data{
 				Aliases:       []string{"CF"},
@@ -413,8 +437,8 @@ func GetConfigs() (c config.Configs) {
 				Aliases:       []string{"DI"},
 				Group:         "node",
 				Tags:          tags("node"),
-				Label:         "Disovery",
-				Description:   "enable LAN peer discovery in GUI",
+				Label:         "Discovery",
+				Description:   "connect to nodes on the local network found from their multicast advertisments (always on in LAN mode)",
 				Documentation: "<placeholder for detailed documentation>",
 				OmitEmpty:     true,
 			},
@@ -433,6 +457,20 @@ func GetConfigs() (c config.Configs) {
 			},
 			[]string{},
 		),
+		"ForkSchedule": text.New(
+			meta.Data{
+				Aliases: []string{"FS"},
+				Group:   "debug",
+				Tags:    tags("node", "wallet", "kopach", "worker"),
+				Label:   "Hard Fork Schedule",
+				Description: "JSON file replacing the hard fork schedule of the network, " +
+					"for testing alternative activation heights and parameters on test networks",
+				Type:          sanitizers.FilePath,
+				Documentation: "<placeholder for detailed documentation>",
+				OmitEmpty:     true,
+			},
+			"",
+		),
 		"FreeTxRelayLimit": float.New(
 			meta.Data{
 				Aliases:       []string{"LR"},
@@ -490,6 +528,32 @@ func GetConfigs() (c config.Configs) {
 			},
 			[]string{},
 		),
+		"KopachControllers": list.New(
+			meta.Data{
+				Aliases:       []string{"KC"},
+				Group:         "mining",
+				Tags:          tags("kopach"),
+				Label:         "Kopach Controllers",
+				Description:   "addresses of controller miner listeners to connect to instead of using multicast, tried in turn when one is unreachable",
+				Type:          sanitizers.NetAddress,
+				Documentation: "<placeholder for detailed documentation>",
+				OmitEmpty:     true,
+			},
+			[]string{},
+		),
+		"KopachMetricsListeners": list.New(
+			meta.Data{
+				Aliases:       []string{"KML"},
+				Group:         "debug",
+				Tags:          tags("kopach"),
+				Label:         "Kopach Metrics Listeners",
+				Description:   "addresses kopach serves prometheus metrics on at /metrics, including the hashrate of each worker thread",
+				Type:          sanitizers.NetAddress,
+				Documentation: "<placeholder for detailed documentation>",
+				OmitEmpty:     true,
+			},
+			[]string{},
+		),
 		"LAN": binary.New(
 			meta.Data{
 				Group:         "debug",
@@ -603,6 +667,19 @@ func GetConfigs() (c config.Configs) {
 			},
 			"info",
 		),
+		"MaxMempool": integer.New(
+			meta.Data{
+				Aliases:       []string{"MMS"},
+				Group:         "policy",
+				Tags:          tags("node"),
+				Label:         "Max Mempool",
+				Description:   "max size of the mempool in megabytes, beyond which the transactions paying the lowest fee rates are evicted (0 for no limit)",
+				Documentation: "<placeholder for detailed documentation>",
+				OmitEmpty:     true,
+			},
+			constant.DefaultMaxMempoolSize,
+			0, math.MaxInt32,
+		),
 		"MaxOrphanTxs": integer.New(
 			meta.Data{
 				Aliases:       []string{"MO"},
@@ -629,6 +706,44 @@ func GetConfigs() (c config.Configs) {
 			constant.DefaultMaxPeers,
 			1, 256,
 		),
+		"MempoolExpiry": duration.New(
+			meta.Data{
+				Aliases:       []string{"MEX"},
+				Group:         "policy",
+				Tags:          tags("node"),
+				Label:         "Mempool Expiry",
+				Description:   "how long a transaction can stay in the mempool without being mined before it is evicted",
+				Documentation: "<placeholder for detailed documentation>",
+				OmitEmpty:     true,
+			},
+			constant.DefaultMempoolExpiry,
+			time.Hour, time.Hour*24*365,
+		),
+		"MempoolReplacement": binary.New(
+			meta.Data{
+				Aliases:       []string{"RBF"},
+				Group:         "policy",
+				Tags:          tags("node"),
+				Label:         "Mempool Replacement",
+				Description:   "accept transactions that replace mempool transactions signalling replace-by-fee (BIP125) when they pay a higher fee",
+				Documentation: "<placeholder for detailed documentation>",
+				OmitEmpty:     true,
+			},
+			true,
+		),
+		"MetricsListeners": list.New(
+			meta.Data{
+				Aliases:       []string{"ML"},
+				Group:         "debug",
+				Tags:          tags("node"),
+				Label:         "Node Metrics Listeners",
+				Description:   "addresses the node serves prometheus metrics on at /metrics, including those of the controller when it is running",
+				Type:          sanitizers.NetAddress,
+				Documentation: "<placeholder for detailed documentation>",
+				OmitEmpty:     true,
+			},
+			[]string{},
+		),
 		"MulticastPass": text.New(
 			meta.Data{
 				Aliases:       []string{"PM"},
@@ -642,6 +757,43 @@ func GetConfigs() (c config.Configs) {
 			},
 			"pa55word",
 		),
+		"MinerListeners": list.New(
+			meta.Data{
+				Aliases:       []string{"MNL"},
+				Group:         "mining",
+				Tags:          tags("node"),
+				Label:         "Miner Listeners",
+				Description:   "addresses the controller accepts kopach connections on for miners that cannot receive its multicast, they authenticate with the multicast password",
+				Type:          sanitizers.NetAddress,
+				Documentation: "<placeholder for detailed documentation>",
+				OmitEmpty:     true,
+			},
+			[]string{},
+		),
+		"MiningAddrs": list.New(
+			meta.Data{
+				Aliases:       []string{"MAD"},
+				Group:         "mining",
+				Tags:          tags("node"),
+				Label:         "Mining Addresses",
+				Description:   "addresses the controller pays the coinbases of mined blocks to in turn, in place of addresses from a wallet",
+				Documentation: "<placeholder for detailed documentation>",
+				OmitEmpty:     true,
+			},
+			[]string{},
+		),
+		"MiningXPub": text.New(
+			meta.Data{
+				Aliases:       []string{"MXP"},
+				Group:         "mining",
+				Tags:          tags("node"),
+				Label:         "Mining Account Extended Public Key",
+				Description:   "account extended public key the controller derives a fresh receiving address from for each mined block, in place of a wallet",
+				Documentation: "<placeholder for detailed documentation>",
+				OmitEmpty:     true,
+			},
+			"",
+		),
 		"MinRelayTxFee": float.New(
 			meta.Data{
 				Aliases:       []string{"MRTF"},
@@ -839,6 +991,30 @@ func GetConfigs() (c config.Configs) {
 			},
 			genPassword(),
 		),
+		"PayoutAddress": text.New(
+			meta.Data{
+				Aliases:       []string{"PYA"},
+				Group:         "mining",
+				Tags:          tags("kopach"),
+				Label:         "Payout Address",
+				Description:   "address kopach workers submit shares for when mining for a controller in pool mode",
+				Documentation: "<placeholder for detailed documentation>",
+				OmitEmpty:     true,
+			},
+			"",
+		),
+		"PersistMempool": binary.New(
+			meta.Data{
+				Aliases:       []string{"SMP"},
+				Group:         "policy",
+				Tags:          tags("node"),
+				Label:         "Persist Mempool",
+				Description:   "save the mempool when the node shuts down and load it again when it starts",
+				Documentation: "<placeholder for detailed documentation>",
+				OmitEmpty:     true,
+			},
+			true,
+		),
 		"PipeLog": binary.New(
 			meta.Data{
 				Aliases: []string{"PL"},
@@ -856,6 +1032,44 @@ func GetConfigs() (c config.Configs) {
 			},
 			false,
 		),
+		"PoolMode": binary.New(
+			meta.Data{
+				Aliases:       []string{"POM"},
+				Group:         "mining",
+				Tags:          tags("node"),
+				Label:         "Pool Mode",
+				Description:   "accept shares from kopach and stratum workers and split block rewards between them by their recent shares, stratum workers authorizing with their payout address as the worker name",
+				Documentation: "<placeholder for detailed documentation>",
+				OmitEmpty:     true,
+			},
+			false,
+		),
+		"PoolShareRatio": integer.New(
+			meta.Data{
+				Aliases:       []string{"PSR"},
+				Group:         "mining",
+				Tags:          tags("node"),
+				Label:         "Pool Share Ratio",
+				Description:   "how many times easier than the block target the pool share target is",
+				Documentation: "<placeholder for detailed documentation>",
+				OmitEmpty:     true,
+			},
+			64,
+			1, 1<<20,
+		),
+		"PoolWindow": integer.New(
+			meta.Data{
+				Aliases:       []string{"PNW"},
+				Group:         "mining",
+				Tags:          tags("node"),
+				Label:         "Pool Window",
+				Description:   "number of most recent shares block rewards are split between in pool mode",
+				Documentation: "<placeholder for detailed documentation>",
+				OmitEmpty:     true,
+			},
+			1024,
+			1, 1<<20,
+		),
 		"Profile": text.New(
 			meta.Data{
 				Aliases:     []string{"HPR"},
@@ -907,6 +1121,19 @@ func GetConfigs() (c config.Configs) {
 			},
 			"proxyuser",
 		),
+		"Prune": integer.New(
+			meta.Data{
+				Aliases:       []string{"PRN"},
+				Group:         "node",
+				Tags:          tags("node"),
+				Label:         "Prune",
+				Description:   "delete the oldest block files once they take more than this many megabytes, keeping the most recent blocks needed for reorgs (0 keeps all blocks, minimum 550, not compatible with txindex or addrindex)",
+				Documentation: "<placeholder for detailed documentation>",
+				OmitEmpty:     true,
+			},
+			0,
+			0, math.MaxInt32,
+		),
 		"RejectNonStd": binary.New(
 			meta.Data{
 				Aliases:       []string{"REJ"},
@@ -1095,17 +1322,57 @@ func GetConfigs() (c config.Configs) {
 			},
 			false,
 		),
-		"ClientTLS": binary.New(
+		"StratumDifficulty": float.New(
 			meta.Data{
-				Aliases:       []string{"CT"},
-				Group:         "tls",
-				Tags:          tags("node", "wallet"),
-				Label:         "TLS",
-				Description:   "enable TLS for RPC client connections",
+				Aliases:       []string{"SD"},
+				Group:         "mining",
+				Tags:          tags("node"),
+				Label:         "Stratum Difficulty",
+				Description:   "share difficulty stratum miners start at before it is adjusted to their hashrate",
 				Documentation: "<placeholder for detailed documentation>",
 				OmitEmpty:     true,
 			},
-			true,
+			1,
+			0, math.MaxFloat64,
+		),
+		"StratumListeners": list.New(
+			meta.Data{
+				Aliases:       []string{"SL"},
+				Group:         "mining",
+				Tags:          tags("node"),
+				Label:         "Stratum Listeners",
+				Description:   "addresses the controller accepts stratum v1 miners on, workers authorize with the stratum password",
+				Type:          sanitizers.NetAddress,
+				Documentation: "<placeholder for detailed documentation>",
+				OmitEmpty:     true,
+			},
+			[]string{},
+		),
+		"StratumPass": text.New(
+			meta.Data{
+				Aliases:       []string{"SPW"},
+				Group:         "mining",
+				Tags:          tags("node"),
+				Label:         "Stratum Pass",
+				Description:   "password stratum workers authorize with, which is sent unencrypted so it should not be used elsewhere",
+				Type:          sanitizers.Password,
+				Documentation: "<placeholder for detailed documentation>",
+				OmitEmpty:     true,
+			},
+			"",
+		),
+		"StratumShareTime": duration.New(
+			meta.Data{
+				Aliases:       []string{"SST"},
+				Group:         "mining",
+				Tags:          tags("node"),
+				Label:         "Stratum Share Time",
+				Description:   "interval between shares the stratum share difficulty of each miner is adjusted towards",
+				Documentation: "<placeholder for detailed documentation>",
+				OmitEmpty:     true,
+			},
+			time.Second*10,
+			time.Second, time.Hour,
 		),
 		"TLSSkipVerify": binary.New(
 			meta.Data{
@@ -1230,6 +1497,19 @@ func GetConfigs() (c config.Configs) {
 				constant.DbName,
 			),
 		),
+		"WalletMetricsListeners": list.New(
+			meta.Data{
+				Aliases:       []string{"WML"},
+				Group:         "debug",
+				Tags:          tags("wallet"),
+				Label:         "Wallet Metrics Listeners",
+				Description:   "addresses the wallet serves prometheus metrics on at /metrics",
+				Type:          sanitizers.NetAddress,
+				Documentation: "<placeholder for detailed documentation>",
+				OmitEmpty:     true,
+			},
+			[]string{},
+		),
 		"WalletOff": binary.New(
 			meta.Data{
 				Aliases:       []string{"WO"},
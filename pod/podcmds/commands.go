@@ -52,26 +52,31 @@ func GetCommands() (c cmds.Commands) {
 				{
 					Name:       "dropaddrindex",
 					Title:      "drop the address database index",
-					Entrypoint: func(c interface{}) error { return nil },
+					Entrypoint: launchers.DropAddrIndex,
 				},
 				{
 					Name:       "droptxindex",
 					Title:      "drop the transaction database index",
-					Entrypoint: func(c interface{}) error { return nil },
+					Entrypoint: launchers.DropTxIndex,
 				},
 				{
 					Name:       "dropcfindex",
 					Title:      "drop the cfilter database index",
-					Entrypoint: func(c interface{}) error { return nil },
+					Entrypoint: launchers.DropCfIndex,
 				},
 				{
 					Name: "dropindexes", Title: "drop all of the indexes",
-					Entrypoint: func(c interface{}) error { return nil },
+					Entrypoint: launchers.DropIndexes,
 				},
 				{
 					Name:       "resetchain",
 					Title:      "deletes the current blockchain cache to force redownload",
-					Entrypoint: func(c interface{}) error { return nil },
+					Entrypoint: launchers.ResetChain,
+				},
+				{
+					Name:       "simdiff",
+					Title:      "simulate the plan 9 difficulty adjustment against synthetic hashrate",
+					Entrypoint: launchers.SimDiff,
 				},
 			},
 		},
@@ -83,7 +88,7 @@ func GetCommands() (c cmds.Commands) {
 				{
 					Name:       "drophistory",
 					Title:      "reset the wallet transaction history",
-					Entrypoint: func(c interface{}) error { return nil },
+					Entrypoint: launchers.DropWalletHistory,
 				},
 			},
 			Colorizer: color.Bit24(255, 255, 128, false).Sprint,
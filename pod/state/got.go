@@ -22,7 +22,9 @@ import (
 	"github.com/cybriq/p9/cmd/node/active"
 	"github.com/cybriq/p9/pkg/amt"
 	"github.com/cybriq/p9/pkg/apputil"
+	"github.com/cybriq/p9/pkg/btcaddr"
 	"github.com/cybriq/p9/pkg/chaincfg"
+	"github.com/cybriq/p9/pkg/chainhash"
 	"github.com/cybriq/p9/pkg/chainrpc"
 	"github.com/cybriq/p9/pkg/connmgr"
 	"github.com/cybriq/p9/pkg/fork"
@@ -31,6 +33,9 @@ import (
 	"github.com/cybriq/p9/pod/config"
 )
 
+// minPruneTarget is the smallest prune target in megabytes, which leaves room for the blocks kept for reorganizations
+const minPruneTarget = 550
+
 // GetNew returns a fresh new context
 func GetNew(
 	config *config.Config, hf func(ifc interface{}) error,
@@ -60,12 +65,9 @@ func GetNew(
 	switch s.Config.Network.V() {
 	case "testnet", "testnet3", "t":
 		s.ActiveNet = &chaincfg.TestNet3Params
-		fork.IsTestnet = true
 	case "regtestnet", "regressiontest", "r":
-		fork.IsTestnet = true
 		s.ActiveNet = &chaincfg.RegressionTestParams
 	case "simnet", "s":
-		fork.IsTestnet = true
 		s.ActiveNet = &chaincfg.SimNetParams
 	default:
 		if s.Config.Network.V() != "mainnet" &&
@@ -79,6 +81,17 @@ func GetNew(
 			return
 		}
 	}
+	if s.Config.ForkSchedule.V() != "" {
+		if s.ActiveNet.Name == "mainnet" {
+			if e = fmt.Errorf("the hard fork schedule of mainnet cannot be changed"); F.Chk(e) {
+				return
+			}
+		}
+		D.Ln("loading hard fork schedule from", s.Config.ForkSchedule.V())
+		if s.ActiveNet.Forks, e = fork.LoadSchedule(s.Config.ForkSchedule.V()); F.Chk(e) {
+			return
+		}
+	}
 	// if pipe logging is enabled, start it up
 	if s.Config.PipeLog.True() {
 		D.Ln("starting up pipe logger")
@@ -200,6 +213,20 @@ func GetNew(
 		return
 	}
 
+	T.Ln("checking prune target")
+	if prune := s.Config.Prune.V(); prune != 0 {
+		if prune < minPruneTarget {
+			e = fmt.Errorf("prune target of %d MB is below the minimum of %d MB", prune, minPruneTarget)
+			_, _ = fmt.Fprintln(os.Stderr, e)
+			os.Exit(1)
+		}
+		if s.Config.TxIndex.True() || s.Config.AddrIndex.True() {
+			e = fmt.Errorf("prune cannot be used with txindex or addrindex as they need all blocks")
+			_, _ = fmt.Fprintln(os.Stderr, e)
+			os.Exit(1)
+		}
+	}
+
 	// Chk to make sure limited and admin users don't have the same username
 	T.Ln("checking admin and limited username is different")
 	if !s.Config.Username.Empty() &&
@@ -238,6 +265,30 @@ func GetNew(
 		_, _ = fmt.Fprintln(os.Stderr, e)
 		os.Exit(0)
 	}
+	T.Ln("checking mining addresses")
+	s.StateCfg.ActiveMiningAddrs = make([]btcaddr.Address, 0, len(s.Config.MiningAddrs.S()))
+	for _, strAddr := range s.Config.MiningAddrs.S() {
+		var addr btcaddr.Address
+		if addr, e = btcaddr.Decode(strAddr, s.ActiveNet); E.Chk(e) || !addr.IsForNet(s.ActiveNet) {
+			e = fmt.Errorf("mining address %s is invalid for network %s", strAddr, s.ActiveNet.Name)
+			_, _ = fmt.Fprintln(os.Stderr, e)
+			os.Exit(1)
+		}
+		s.StateCfg.ActiveMiningAddrs = append(s.StateCfg.ActiveMiningAddrs, addr)
+	}
+	T.Ln("checking assumed valid block")
+	s.StateCfg.AssumeValid = s.ActiveNet.AssumeValid
+	switch assumeValid := s.Config.AssumeValid.V(); assumeValid {
+	case "":
+	case "0":
+		s.StateCfg.AssumeValid = nil
+	default:
+		if s.StateCfg.AssumeValid, e = chainhash.NewHashFromStr(assumeValid); E.Chk(e) {
+			e = fmt.Errorf("assumevalid %s is not a valid block hash: %v", assumeValid, e)
+			_, _ = fmt.Fprintln(os.Stderr, e)
+			os.Exit(1)
+		}
+	}
 	D.Ln("autolisten", s.Config.AutoListen.True())
 	// if autolisten is set, set default ports on all p2p listeners discovered to be available
 	if s.Config.AutoListen.True() {
@@ -445,10 +496,6 @@ func GetNew(
 		if e = s.Config.WriteToFile(s.Config.ConfigFile.V()); E.Chk(e) {
 		}
 	}
-	if s.ActiveNet.Name == chaincfg.TestNet3Params.Name {
-		fork.IsTestnet = true
-	}
-
 	return
 }
 